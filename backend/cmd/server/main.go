@@ -7,9 +7,11 @@ import (
 	"enfor-data-backend/internal/config"
 	"enfor-data-backend/internal/database"
 	"enfor-data-backend/internal/handlers"
+	"enfor-data-backend/internal/jobs"
 	"enfor-data-backend/internal/middleware"
 	"enfor-data-backend/internal/repository"
 	"enfor-data-backend/internal/services"
+	"enfor-data-backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -38,30 +40,148 @@ func main() {
 	propertyRepo := repository.NewPropertyRepository(db)
 	clientRepo := repository.NewClientRepository(db)
 	appointmentRepo := repository.NewAppointmentRepository(db)
+	matchRepo := repository.NewMatchRepository(db)
+	duplicateRepo := repository.NewDuplicateRepository(db)
+	clientDuplicateRepo := repository.NewClientDuplicateRepository(db)
+	savedSearchRepo := repository.NewSavedSearchRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	shareLinkRepo := repository.NewShareLinkRepository(db)
+	syndicationFeedRepo := repository.NewSyndicationFeedRepository(db)
+	documentRepo := repository.NewDocumentRepository(db)
+	analyticsRepo := repository.NewAnalyticsRepository(db)
+	marketRepo := repository.NewMarketRepository(db)
+	pipelineRepo := repository.NewPipelineRepository(db)
+	activityRepo := repository.NewActivityRepository(db)
+	taskRepo := repository.NewTaskRepository(db)
+	clientImportRepo := repository.NewClientImportRepository(db)
+	leadFormRepo := repository.NewLeadFormRepository(db)
+	clientTransferRepo := repository.NewClientTransferRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	clientSegmentRepo := repository.NewClientSegmentRepository(db)
+	consentRepo := repository.NewConsentRepository(db)
+	dealRepo := repository.NewDealRepository(db)
+	portalRepo := repository.NewPortalRepository(db)
+
+	// Initialize mailer
+	mailer := utils.NewMailer(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
+
+	// Initialize signer for private file download and opt-out URLs
+	urlSigner := utils.NewURLSigner(cfg.JWT.Secret)
 
 	// Initialize services
 	authService := services.NewAuthService(userRepo, cfg)
-	propertyService := services.NewPropertyService(propertyRepo, userRepo)
-	clientService := services.NewClientService(clientRepo, userRepo)
-	appointmentService := services.NewAppointmentService(appointmentRepo, clientRepo, propertyRepo)
+	leadScoreService := services.NewLeadScoreService(clientRepo)
+	tagService := services.NewTagService(tagRepo)
+	clientSegmentService := services.NewClientSegmentService(clientSegmentRepo, tagService)
+	matchService := services.NewMatchService(matchRepo, clientRepo, propertyRepo, leadScoreService)
+	duplicateService := services.NewDuplicateService(duplicateRepo, propertyRepo)
+	notificationService := services.NewNotificationService(notificationRepo, userRepo, mailer)
+	consentService := services.NewConsentService(consentRepo, clientRepo, mailer, urlSigner, cfg.Server.PublicURL)
+	analyticsService := services.NewAnalyticsService(analyticsRepo, propertyRepo)
+	savedSearchService := services.NewSavedSearchService(savedSearchRepo, clientRepo, propertyRepo, notificationService, consentService)
+	propertyService := services.NewPropertyService(
+		propertyRepo, userRepo, matchService, duplicateService, savedSearchService, notificationService, analyticsService, tagService,
+		cfg.Listing.TTL, cfg.Listing.ExpiryNotice,
+	)
+	activityService := services.NewActivityService(activityRepo, clientRepo, leadScoreService)
+	pipelineService := services.NewPipelineService(pipelineRepo, clientRepo, matchService)
+	clientDuplicateService := services.NewClientDuplicateService(clientDuplicateRepo, clientRepo, matchService, activityService)
+	clientService := services.NewClientService(
+		clientRepo, userRepo, matchService, pipelineService, activityService, clientDuplicateService, tagService, clientSegmentService,
+	)
+	appointmentService := services.NewAppointmentService(appointmentRepo, clientRepo, propertyRepo, analyticsService, activityService, leadScoreService)
+	projectService := services.NewProjectService(projectRepo, clientRepo, userRepo, notificationService)
+	shareLinkService := services.NewShareLinkService(shareLinkRepo, propertyRepo, userRepo, analyticsService, cfg.Server.PublicURL)
+	syndicationFeedService := services.NewSyndicationFeedService(syndicationFeedRepo, propertyRepo, cfg.Server.PublicURL, cfg.Feed.Path)
+	documentService := services.NewDocumentService(
+		documentRepo, propertyRepo, clientRepo, userRepo, consentService,
+		urlSigner, cfg.Server.PublicURL, cfg.Upload.DocumentPath, cfg.Upload.MaxDocumentSize,
+	)
+	marketService := services.NewMarketService(marketRepo, cfg.Market.MinListings, cfg.Market.MinBrokers)
+	taskService := services.NewTaskService(
+		taskRepo, clientRepo, propertyRepo, userRepo, notificationService, cfg.Task.ReminderLead, cfg.Task.Timezone,
+	)
+	clientImportService := services.NewClientImportService(
+		clientImportRepo, clientRepo, clientService, notificationService,
+		cfg.Import.MaxFileSize, cfg.Import.MaxRows, cfg.Import.DefaultCountryCode,
+	)
+	leadFormService := services.NewLeadFormService(
+		leadFormRepo, clientRepo, clientService, activityService, notificationService, consentService,
+		cfg.Server.PublicURL, cfg.Import.DefaultCountryCode, cfg.Lead.MinFillTime, cfg.Lead.MaxFormAge,
+	)
+	clientTransferService := services.NewClientTransferService(
+		clientTransferRepo, clientRepo, userRepo, pipelineService, clientDuplicateService, notificationService,
+	)
+	dealService := services.NewDealService(dealRepo, clientRepo, propertyRepo, userRepo, activityService, notificationService)
+	portalService := services.NewPortalService(
+		portalRepo, clientRepo, appointmentRepo, propertyRepo, userRepo, activityService, notificationService, mailer,
+		cfg.JWT.Secret, cfg.Portal.URL, cfg.Server.PublicURL, cfg.Portal.LinkTTL, cfg.Portal.SessionTTL,
+	)
+	trashService := services.NewTrashService(propertyRepo, clientRepo, appointmentRepo, matchService, documentService, cfg.Trash.Retention)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
-	uploadHandler := handlers.NewUploadHandler(authService, cfg)
+	uploadHandler := handlers.NewUploadHandler(authService, projectService, cfg)
 	propertyHandler := handlers.NewPropertyHandler(propertyService)
 	clientHandler := handlers.NewClientHandler(clientService)
 	appointmentHandler := handlers.NewAppointmentHandler(appointmentService)
+	matchHandler := handlers.NewMatchHandler(matchService)
+	duplicateHandler := handlers.NewDuplicateHandler(duplicateService)
+	clientDuplicateHandler := handlers.NewClientDuplicateHandler(clientDuplicateService)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	projectHandler := handlers.NewProjectHandler(projectService)
+	shareLinkHandler := handlers.NewShareLinkHandler(shareLinkService)
+	syndicationFeedHandler := handlers.NewSyndicationFeedHandler(syndicationFeedService)
+	documentHandler := handlers.NewDocumentHandler(documentService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+	marketHandler := handlers.NewMarketHandler(marketService)
+	pipelineHandler := handlers.NewPipelineHandler(pipelineService)
+	activityHandler := handlers.NewActivityHandler(activityService)
+	taskHandler := handlers.NewTaskHandler(taskService)
+	clientImportHandler := handlers.NewClientImportHandler(clientImportService)
+	leadFormHandler := handlers.NewLeadFormHandler(leadFormService)
+	clientTransferHandler := handlers.NewClientTransferHandler(clientTransferService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	clientSegmentHandler := handlers.NewClientSegmentHandler(clientSegmentService)
+	consentHandler := handlers.NewConsentHandler(consentService)
+	dealHandler := handlers.NewDealHandler(dealService)
+	portalHandler := handlers.NewPortalHandler(portalService)
+
+	// Imports run in-process, so any still processing were cut off by the last shutdown
+	clientImportService.FailInterrupted()
+
+	// Score clients created before lead scoring existed without waiting for the first scheduled run
+	leadScoreService.RefreshAll()
+
+	// Initialize background jobs
+	scheduler := jobs.NewScheduler()
+	scheduler.Every("syndication feeds", cfg.Feed.Interval, syndicationFeedService.RunScheduledFeeds)
+	scheduler.Every("listing expiry", cfg.Listing.CheckInterval, propertyService.RunListingExpiry)
+	scheduler.Every("trash purge", cfg.Trash.PurgeInterval, trashService.PurgeExpired)
+	scheduler.Every("task reminders", cfg.Task.ReminderInterval, taskService.RunReminders)
+	scheduler.Every("lead scores", cfg.Lead.ScoreInterval, leadScoreService.RefreshAll)
+	scheduler.Start()
+	defer scheduler.Stop()
 
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(authService)
+	portalAuthMiddleware := middleware.NewPortalAuthMiddleware(portalService)
+	leadRateLimiter := middleware.NewRateLimiter(cfg.Lead.RateLimit, cfg.Lead.RateWindow)
+	portalRateLimiter := middleware.NewRateLimiter(cfg.Portal.RateLimit, cfg.Portal.RateWindow)
 
 	// Initialize Gin router
 	router := gin.New()
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
 
 	// Global middleware
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
-	router.Use(middleware.CORSMiddleware())
+	router.Use(middleware.CORSMiddleware("/api/leads/"))
 
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -97,13 +217,97 @@ func main() {
 			// Property routes (accessible to all authenticated users)
 			protected.GET("/properties", propertyHandler.GetProperties)
 			protected.POST("/properties", propertyHandler.CreateProperty)
+			protected.POST("/properties/bulk-tags", tagHandler.BulkTagProperties)
+			protected.GET("/properties/:id", propertyHandler.GetProperty)
+			protected.PUT("/properties/:id", propertyHandler.UpdateProperty)
+			protected.DELETE("/properties/:id", propertyHandler.DeleteProperty)
+			protected.POST("/properties/:id/renew", propertyHandler.RenewProperty)
+			protected.GET("/properties/:id/matches", matchHandler.GetPropertyMatches)
+			protected.GET("/properties/:id/analytics", analyticsHandler.GetPropertyAnalytics)
+			protected.POST("/properties/:id/inquiries", analyticsHandler.RecordInquiry)
+			protected.GET("/properties/:id/share-links", shareLinkHandler.GetShareLinks)
+			protected.POST("/properties/:id/share-links", shareLinkHandler.CreateShareLink)
+			protected.DELETE("/share-links/:id", shareLinkHandler.RevokeShareLink)
 
 			// Client routes (accessible to all authenticated users)
 			protected.GET("/clients", clientHandler.GetClients)
 			protected.POST("/clients", clientHandler.CreateClient)
+			protected.GET("/clients/export", clientImportHandler.ExportClients)
+			protected.GET("/clients/sources", clientHandler.GetSourceSummary)
+			protected.POST("/clients/bulk-tags", tagHandler.BulkTagClients)
 			protected.GET("/clients/:id", clientHandler.GetClient)
 			protected.PUT("/clients/:id", clientHandler.UpdateClient)
 			protected.DELETE("/clients/:id", clientHandler.DeleteClient)
+			protected.GET("/clients/:id/matches", matchHandler.GetClientMatches)
+			protected.PUT("/clients/:id/stage", pipelineHandler.MoveClient)
+			protected.GET("/clients/:id/stage-history", pipelineHandler.GetStageHistory)
+			protected.GET("/clients/:id/timeline", activityHandler.GetTimeline)
+			protected.POST("/clients/:id/activities", activityHandler.LogActivity)
+			protected.DELETE("/clients/:id/activities/:activityId", activityHandler.DeleteActivity)
+			protected.POST("/clients/:id/merge", clientDuplicateHandler.MergeClients)
+			protected.GET("/clients/:id/ownership", clientTransferHandler.GetOwnershipHistory)
+			protected.GET("/clients/:id/consents", consentHandler.GetClientConsents)
+			protected.POST("/clients/:id/consents", consentHandler.RecordClientConsent)
+			protected.GET("/clients/:id/shared-properties", portalHandler.GetSharedProperties)
+			protected.POST("/clients/:id/shared-properties", portalHandler.ShareProperties)
+			protected.DELETE("/clients/:id/shared-properties/:propertyId", portalHandler.UnshareProperty)
+
+			// Do-not-contact registry: phone numbers and emails that opted out, client or not
+			protected.GET("/do-not-contact", consentHandler.GetRegistry)
+			protected.POST("/do-not-contact", consentHandler.AddToRegistry)
+
+			// Duplicate client review (within the broker's own book)
+			protected.GET("/client-duplicates", clientDuplicateHandler.GetDuplicates)
+			protected.PUT("/client-duplicates/:id", clientDuplicateHandler.ReviewDuplicate)
+
+			// Client transfers between brokers; the receiving broker accepts or declines
+			protected.GET("/client-transfers", clientTransferHandler.GetTransfers)
+			protected.POST("/client-transfers", clientTransferHandler.CreateTransfer)
+			protected.GET("/client-transfers/:id", clientTransferHandler.GetTransfer)
+			protected.POST("/client-transfers/:id/accept", clientTransferHandler.AcceptTransfer)
+			protected.POST("/client-transfers/:id/decline", clientTransferHandler.DeclineTransfer)
+			protected.POST("/client-transfers/:id/cancel", clientTransferHandler.CancelTransfer)
+
+			// Tags on the broker's clients and properties
+			protected.GET("/tags", tagHandler.GetTags)
+			protected.PUT("/tags/:name", tagHandler.RenameTag)
+			protected.DELETE("/tags/:name", tagHandler.DeleteTag)
+
+			// Client segments: saved client list filters, applied with segment_id on the client list and export
+			protected.GET("/client-segments", clientSegmentHandler.GetSegments)
+			protected.POST("/client-segments", clientSegmentHandler.CreateSegment)
+			protected.GET("/client-segments/:id", clientSegmentHandler.GetSegment)
+			protected.PUT("/client-segments/:id", clientSegmentHandler.UpdateSegment)
+			protected.DELETE("/client-segments/:id", clientSegmentHandler.DeleteSegment)
+
+			// Client imports from CSV and vCard files
+			protected.GET("/client-imports", clientImportHandler.GetImports)
+			protected.POST("/client-imports", clientImportHandler.StartImport)
+			protected.GET("/client-imports/:id", clientImportHandler.GetImport)
+
+			// Lead capture form embedded on the broker's own website
+			protected.GET("/lead-form", leadFormHandler.GetLeadForm)
+			protected.PUT("/lead-form", leadFormHandler.UpdateLeadForm)
+			protected.POST("/lead-form/rotate-key", leadFormHandler.RotateLeadFormKey)
+
+			// Deals and brokerage commission; partners on a deal can view it but not change it
+			protected.GET("/deals", dealHandler.GetDeals)
+			protected.POST("/deals", dealHandler.CreateDeal)
+			protected.GET("/deals/commission-report", dealHandler.GetCommissionReport)
+			protected.GET("/deals/:id", dealHandler.GetDeal)
+			protected.PUT("/deals/:id", dealHandler.UpdateDeal)
+			protected.DELETE("/deals/:id", dealHandler.DeleteDeal)
+			protected.POST("/deals/:id/milestones", dealHandler.AddMilestone)
+			protected.PUT("/deals/:id/milestones/:milestoneId", dealHandler.UpdateMilestone)
+			protected.DELETE("/deals/:id/milestones/:milestoneId", dealHandler.DeleteMilestone)
+
+			// Sales pipeline routes
+			protected.GET("/pipeline/stages", pipelineHandler.GetStages)
+			protected.POST("/pipeline/stages", pipelineHandler.CreateStage)
+			protected.POST("/pipeline/stages/reorder", pipelineHandler.ReorderStages)
+			protected.PUT("/pipeline/stages/:id", pipelineHandler.UpdateStage)
+			protected.DELETE("/pipeline/stages/:id", pipelineHandler.DeleteStage)
+			protected.GET("/pipeline/board", pipelineHandler.GetBoard)
 
 			// Appointment routes (accessible to all authenticated users)
 			protected.POST("/appointments", appointmentHandler.CreateAppointment)
@@ -113,6 +317,56 @@ func main() {
 			protected.PUT("/appointments/:id", appointmentHandler.UpdateAppointment)
 			protected.DELETE("/appointments/:id", appointmentHandler.DeleteAppointment)
 
+			// Task routes (visible to the task's creator and assignee)
+			protected.GET("/tasks", taskHandler.GetTasks)
+			protected.POST("/tasks", taskHandler.CreateTask)
+			protected.GET("/tasks/:id", taskHandler.GetTask)
+			protected.PUT("/tasks/:id", taskHandler.UpdateTask)
+			protected.DELETE("/tasks/:id", taskHandler.DeleteTask)
+
+			// Saved search routes (accessible to all authenticated users)
+			protected.GET("/saved-searches", savedSearchHandler.GetSavedSearches)
+			protected.POST("/saved-searches", savedSearchHandler.CreateSavedSearch)
+			protected.GET("/saved-searches/:id", savedSearchHandler.GetSavedSearch)
+			protected.GET("/saved-searches/:id/results", savedSearchHandler.GetSavedSearchResults)
+			protected.PUT("/saved-searches/:id", savedSearchHandler.UpdateSavedSearch)
+			protected.DELETE("/saved-searches/:id", savedSearchHandler.DeleteSavedSearch)
+
+			// Syndication feed routes (portal exports of the broker's listings)
+			protected.GET("/syndication-feeds", syndicationFeedHandler.GetFeeds)
+			protected.POST("/syndication-feeds", syndicationFeedHandler.CreateFeed)
+			protected.GET("/syndication-feeds/:id", syndicationFeedHandler.GetFeed)
+			protected.GET("/syndication-feeds/:id/export", syndicationFeedHandler.ExportFeed)
+			protected.PUT("/syndication-feeds/:id", syndicationFeedHandler.UpdateFeed)
+			protected.DELETE("/syndication-feeds/:id", syndicationFeedHandler.DeleteFeed)
+
+			// Listing analytics routes
+			protected.GET("/analytics/listings", analyticsHandler.GetListingAnalytics)
+
+			// Market analytics routes
+			protected.GET("/market/cities", marketHandler.GetCities)
+			protected.GET("/market/insights", marketHandler.GetMarketInsights)
+
+			// Trash routes
+			protected.GET("/trash", trashHandler.GetTrash)
+			protected.POST("/trash/properties/:id/restore", trashHandler.RestoreProperty)
+			protected.POST("/trash/clients/:id/restore", trashHandler.RestoreClient)
+			protected.POST("/trash/appointments/:id/restore", trashHandler.RestoreAppointment)
+
+			// Documents vault routes
+			protected.GET("/documents", documentHandler.GetDocuments)
+			protected.POST("/documents", documentHandler.UploadDocument)
+			protected.GET("/documents/:id", documentHandler.GetDocument)
+			protected.PUT("/documents/:id", documentHandler.UpdateDocument)
+			protected.DELETE("/documents/:id", documentHandler.DeleteDocument)
+			protected.GET("/documents/:id/download-url", documentHandler.GetDownloadURL)
+			protected.POST("/documents/:id/send", documentHandler.SendToClient)
+
+			// Notification routes
+			protected.GET("/notifications", notificationHandler.GetNotifications)
+			protected.PUT("/notifications/read-all", notificationHandler.MarkAllNotificationsRead)
+			protected.PUT("/notifications/:id/read", notificationHandler.MarkNotificationRead)
+
 			// Role-specific routes
 			broker := protected.Group("/broker")
 			broker.Use(authMiddleware.RequireRole("broker"))
@@ -123,6 +377,12 @@ func main() {
 						"user_id": c.GetString("user_id"),
 					})
 				})
+
+				// Builder projects and unit blocking
+				broker.GET("/projects", projectHandler.BrowseProjects)
+				broker.GET("/projects/:id", projectHandler.GetBrokerProject)
+				broker.POST("/projects/:id/units/:unitId/block", projectHandler.BlockUnit)
+				broker.DELETE("/projects/:id/units/:unitId/block", projectHandler.ReleaseUnit)
 			}
 
 			channelPartner := protected.Group("/channel-partner")
@@ -134,6 +394,17 @@ func main() {
 						"user_id": c.GetString("user_id"),
 					})
 				})
+
+				// Project and unit inventory management
+				channelPartner.GET("/projects", projectHandler.GetProjects)
+				channelPartner.POST("/projects", projectHandler.CreateProject)
+				channelPartner.GET("/projects/:id", projectHandler.GetProject)
+				channelPartner.PUT("/projects/:id", projectHandler.UpdateProject)
+				channelPartner.DELETE("/projects/:id", projectHandler.DeleteProject)
+				channelPartner.POST("/projects/:id/brochure", uploadHandler.UploadProjectBrochure)
+				channelPartner.POST("/projects/:id/units", projectHandler.AddProjectUnits)
+				channelPartner.PUT("/projects/:id/units/:unitId", projectHandler.UpdateProjectUnit)
+				channelPartner.DELETE("/projects/:id/units/:unitId", projectHandler.DeleteProjectUnit)
 			}
 
 			admin := protected.Group("/admin")
@@ -145,17 +416,55 @@ func main() {
 						"user_id": c.GetString("user_id"),
 					})
 				})
+
+				// Duplicate listing review
+				admin.GET("/property-duplicates", duplicateHandler.GetDuplicateClusters)
+				admin.PUT("/property-duplicates/:id", duplicateHandler.ReviewDuplicate)
+				admin.POST("/client-transfers", clientTransferHandler.CreateAdminTransfer)
 			}
 		}
 
 		// File serving routes (public for uploaded files)
 		api.GET("/uploads/:filename", uploadHandler.ServeUploadedFile)
+
+		// Shared listing routes (public, token-protected)
+		api.GET("/share/:token", shareLinkHandler.GetSharedListing)
+
+		// Lead form submissions (public, form key-protected and rate limited)
+		api.POST("/leads/:formKey", leadRateLimiter.Limit(), leadFormHandler.SubmitLead)
+
+		// Document downloads (public, authorized by signed URL)
+		api.GET("/documents/:id/download", documentHandler.DownloadDocument)
+
+		// Client portal sign-in (public, rate limited); clients are emailed a one-time link
+		api.POST("/portal/login", portalRateLimiter.Limit(), portalHandler.RequestLink)
+		api.POST("/portal/session", portalRateLimiter.Limit(), portalHandler.StartSession)
+
+		// Client portal routes (client portal token; scoped to the signed-in client's own record)
+		portal := api.Group("/portal")
+		portal.Use(portalAuthMiddleware.RequireClient())
+		{
+			portal.GET("/me", portalHandler.GetProfile)
+			portal.GET("/appointments", portalHandler.GetAppointments)
+			portal.POST("/appointments/:id/confirm", portalHandler.ConfirmAppointment)
+			portal.POST("/appointments/:id/cancel", portalHandler.CancelAppointment)
+			portal.GET("/properties", portalHandler.GetProperties)
+		}
 	}
 
+	// Public listing pages with Open Graph tags for link previews
+	router.GET("/s/:token", shareLinkHandler.RenderSharedListing)
+
+	// Client opt-out pages linked from client emails (public, authorized by signed URL)
+	router.GET("/opt-out/:clientId", consentHandler.RenderOptOut)
+	router.POST("/opt-out/:clientId", consentHandler.OptOut)
+
 	// Start server
 	log.Printf("Server starting on port %s", cfg.Server.Port)
 	log.Printf("Database connected to %s:%s/%s", cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName)
 	log.Printf("Upload path: %s", cfg.Upload.Path)
+	log.Printf("Feed path: %s", cfg.Feed.Path)
+	log.Printf("Document path: %s", cfg.Upload.DocumentPath)
 
 	if err := router.Run(":" + cfg.Server.Port); err != nil {
 		log.Fatal("Failed to start server:", err)
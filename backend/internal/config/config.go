@@ -4,7 +4,9 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+	_ "time/tzdata" // TASK_TIMEZONE must resolve on hosts without a zoneinfo database
 
 	"github.com/joho/godotenv"
 )
@@ -14,6 +16,15 @@ type Config struct {
 	JWT      JWTConfig
 	Server   ServerConfig
 	Upload   UploadConfig
+	SMTP     SMTPConfig
+	Feed     FeedConfig
+	Listing  ListingConfig
+	Trash    TrashConfig
+	Market   MarketConfig
+	Task     TaskConfig
+	Import   ImportConfig
+	Lead     LeadConfig
+	Portal   PortalConfig
 }
 
 type DatabaseConfig struct {
@@ -31,13 +42,77 @@ type JWTConfig struct {
 }
 
 type ServerConfig struct {
-	Port    string
-	GinMode string
+	Port      string
+	GinMode   string
+	PublicURL string // Externally reachable base URL used in share links
+
+	// Reverse proxies whose X-Forwarded-For header is believed when rate limiting by client IP
+	TrustedProxies []string
 }
 
 type UploadConfig struct {
-	Path        string
-	MaxFileSize int64
+	Path            string
+	MaxFileSize     int64
+	DocumentPath    string // Private storage for the documents vault; must not be publicly served
+	MaxDocumentSize int64
+}
+
+type FeedConfig struct {
+	Path     string        // Storage directory scheduled portal feeds are written to
+	Interval time.Duration // How often scheduled feeds are generated
+}
+
+type ListingConfig struct {
+	TTL           time.Duration // How long a listing stays live before it expires
+	ExpiryNotice  time.Duration // How long before expiry brokers are reminded
+	CheckInterval time.Duration // How often expiring and expired listings are processed
+}
+
+type TrashConfig struct {
+	Retention     time.Duration // How long deleted records can be restored before they are purged
+	PurgeInterval time.Duration // How often expired trash is purged
+}
+
+type MarketConfig struct {
+	MinListings int // Fewest listings a market statistic may be computed from
+	MinBrokers  int // Fewest distinct brokers whose listings a market statistic must include
+}
+
+type TaskConfig struct {
+	ReminderLead     time.Duration  // How long before a task is due its assignee is reminded, unless the task says otherwise
+	ReminderInterval time.Duration  // How often due task reminders are sent
+	Timezone         *time.Location // Decides what "today" is for task lists
+}
+
+type ImportConfig struct {
+	MaxFileSize        int64  // Largest contacts file that can be imported
+	MaxRows            int    // Most rows or contacts a single import may hold
+	DefaultCountryCode string // Country calling code given to imported phone numbers without one
+}
+
+type LeadConfig struct {
+	RateLimit   int           // Most lead form submissions one IP address may make per RateWindow
+	RateWindow  time.Duration // Window the lead form rate limit is counted over
+	MinFillTime time.Duration // Submissions sent sooner than this after the form loaded are treated as spam
+	MaxFormAge  time.Duration // Submissions from forms loaded longer ago than this are treated as spam
+
+	ScoreInterval time.Duration // How often every client's lead score is recalculated
+}
+
+type PortalConfig struct {
+	URL        string        // Frontend page sign-in links open with ?token=, which it exchanges for a session
+	LinkTTL    time.Duration // How long an emailed sign-in link can be used
+	SessionTTL time.Duration // How long a client stays signed in
+	RateLimit  int           // Most sign-in link requests one IP address may make per RateWindow
+	RateWindow time.Duration // Window the sign-in link rate limit is counted over
+}
+
+type SMTPConfig struct {
+	Host     string // Leave empty to log emails instead of sending them
+	Port     string
+	Username string
+	Password string
+	From     string
 }
 
 func Load() *Config {
@@ -62,6 +137,61 @@ func Load() *Config {
 		maxFileSize = 5242880
 	}
 
+	// Parse max document size
+	maxDocumentSizeStr := getEnv("MAX_DOCUMENT_SIZE", "20971520") // 20MB default
+	maxDocumentSize, err := strconv.ParseInt(maxDocumentSizeStr, 10, 64)
+	if err != nil || maxDocumentSize <= 0 {
+		log.Printf("Invalid MAX_DOCUMENT_SIZE format, using default 20MB: %v", err)
+		maxDocumentSize = 20971520
+	}
+
+	// Parse feed generation interval
+	feedIntervalStr := getEnv("FEED_INTERVAL", "1h")
+	feedInterval, err := time.ParseDuration(feedIntervalStr)
+	if err != nil || feedInterval <= 0 {
+		log.Printf("Invalid FEED_INTERVAL format, using default 1h: %v", err)
+		feedInterval = time.Hour
+	}
+
+	// Parse listing expiry settings
+	listingTTL := getDurationEnv("LISTING_TTL", 90*24*time.Hour)
+	listingExpiryNotice := getDurationEnv("LISTING_EXPIRY_NOTICE", 7*24*time.Hour)
+	listingCheckInterval := getDurationEnv("LISTING_EXPIRY_INTERVAL", time.Hour)
+
+	// Parse trash retention settings
+	trashRetention := getDurationEnv("TRASH_RETENTION", 30*24*time.Hour)
+	trashPurgeInterval := getDurationEnv("TRASH_PURGE_INTERVAL", 6*time.Hour)
+
+	// Parse market analytics sample thresholds
+	marketMinListings := getIntEnv("MARKET_MIN_LISTINGS", 10)
+	marketMinBrokers := getIntEnv("MARKET_MIN_BROKERS", 3)
+
+	// Parse task reminder settings
+	taskReminderLead := getDurationEnv("TASK_REMINDER_LEAD", 30*time.Minute)
+	taskReminderInterval := getDurationEnv("TASK_REMINDER_INTERVAL", time.Minute)
+	taskTimezone, err := time.LoadLocation(getEnv("TASK_TIMEZONE", "Asia/Kolkata"))
+	if err != nil {
+		log.Printf("Invalid TASK_TIMEZONE, using default Asia/Kolkata: %v", err)
+		taskTimezone, _ = time.LoadLocation("Asia/Kolkata")
+	}
+
+	// Parse client import limits
+	importMaxFileSize := int64(getIntEnv("IMPORT_MAX_FILE_SIZE", 10485760)) // 10MB default
+	importMaxRows := getIntEnv("IMPORT_MAX_ROWS", 10000)
+
+	// Parse public lead form protection
+	leadRateLimit := getIntEnv("LEAD_RATE_LIMIT", 5)
+	leadRateWindow := getDurationEnv("LEAD_RATE_WINDOW", 10*time.Minute)
+	leadMinFillTime := getDurationEnv("LEAD_MIN_FILL_TIME", 3*time.Second)
+	leadMaxFormAge := getDurationEnv("LEAD_MAX_FORM_AGE", 24*time.Hour)
+	leadScoreInterval := getDurationEnv("LEAD_SCORE_INTERVAL", time.Hour)
+
+	// Parse client portal sign-in settings
+	portalLinkTTL := getDurationEnv("PORTAL_LINK_TTL", 15*time.Minute)
+	portalSessionTTL := getDurationEnv("PORTAL_SESSION_TTL", 7*24*time.Hour)
+	portalRateLimit := getIntEnv("PORTAL_RATE_LIMIT", 5)
+	portalRateWindow := getDurationEnv("PORTAL_RATE_WINDOW", 10*time.Minute)
+
 	return &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -76,19 +206,116 @@ func Load() *Config {
 			ExpiresIn: jwtExpires,
 		},
 		Server: ServerConfig{
-			Port:    getEnv("PORT", "8080"),
-			GinMode: getEnv("GIN_MODE", "debug"),
+			Port:      getEnv("PORT", "8080"),
+			GinMode:   getEnv("GIN_MODE", "debug"),
+			PublicURL: getEnv("PUBLIC_URL", "http://localhost:8080"),
+
+			TrustedProxies: getListEnv("TRUSTED_PROXIES"),
 		},
 		Upload: UploadConfig{
-			Path:        getEnv("UPLOAD_PATH", "./uploads"),
-			MaxFileSize: maxFileSize,
+			Path:            getEnv("UPLOAD_PATH", "./uploads"),
+			MaxFileSize:     maxFileSize,
+			DocumentPath:    getEnv("DOCUMENT_PATH", "./documents"),
+			MaxDocumentSize: maxDocumentSize,
+		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", "ENFOR DATA <no-reply@enfordata.local>"),
+		},
+		Feed: FeedConfig{
+			Path:     getEnv("FEED_PATH", "./feeds"),
+			Interval: feedInterval,
+		},
+		Listing: ListingConfig{
+			TTL:           listingTTL,
+			ExpiryNotice:  listingExpiryNotice,
+			CheckInterval: listingCheckInterval,
+		},
+		Trash: TrashConfig{
+			Retention:     trashRetention,
+			PurgeInterval: trashPurgeInterval,
+		},
+		Market: MarketConfig{
+			MinListings: marketMinListings,
+			MinBrokers:  marketMinBrokers,
+		},
+		Task: TaskConfig{
+			ReminderLead:     taskReminderLead,
+			ReminderInterval: taskReminderInterval,
+			Timezone:         taskTimezone,
+		},
+		Import: ImportConfig{
+			MaxFileSize:        importMaxFileSize,
+			MaxRows:            importMaxRows,
+			DefaultCountryCode: getEnv("IMPORT_COUNTRY_CODE", "91"),
+		},
+		Lead: LeadConfig{
+			RateLimit:   leadRateLimit,
+			RateWindow:  leadRateWindow,
+			MinFillTime: leadMinFillTime,
+			MaxFormAge:  leadMaxFormAge,
+
+			ScoreInterval: leadScoreInterval,
+		},
+		Portal: PortalConfig{
+			URL:        getEnv("PORTAL_URL", "http://localhost:5173/portal"),
+			LinkTTL:    portalLinkTTL,
+			SessionTTL: portalSessionTTL,
+			RateLimit:  portalRateLimit,
+			RateWindow: portalRateWindow,
 		},
 	}
 }
 
+// getListEnv splits a comma-separated list from the environment, skipping blank entries
+func getListEnv(key string) []string {
+	values := []string{}
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+// getDurationEnv parses a positive duration from the environment, falling back to defaultValue
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s format, using default %s: %v", key, defaultValue, err)
+		return defaultValue
+	}
+
+	return duration
+}
+
+// getIntEnv parses a positive integer from the environment, falling back to defaultValue
+func getIntEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		log.Printf("Invalid %s format, using default %d: %v", key, defaultValue, err)
+		return defaultValue
+	}
+
+	return number
+}
@@ -599,6 +599,1395 @@ CREATE TRIGGER sync_broker_info_to_appointments_trigger
 		return fmt.Errorf("failed to run appointments migration: %w", err)
 	}
 
+	// Migration 005: Create property matches table
+	propertyMatchesMigration := `
+-- Create property_matches table linking clients to properties that fit their requirements
+CREATE TABLE IF NOT EXISTS property_matches (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationships
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+
+    -- Match Quality
+    score INTEGER NOT NULL CHECK (score BETWEEN 0 AND 100),
+    reasons TEXT[] DEFAULT '{}',
+
+    -- Timestamps (created_at marks when the match first surfaced)
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (client_id, property_id)
+);
+
+-- Performance Indexes
+
+-- Best matches for a client
+CREATE INDEX IF NOT EXISTS idx_property_matches_client_score
+    ON property_matches(client_id, score DESC);
+
+-- Best matches for a property
+CREATE INDEX IF NOT EXISTS idx_property_matches_property_score
+    ON property_matches(property_id, score DESC);
+
+-- Candidate lookups used by the matching engine
+CREATE INDEX IF NOT EXISTS idx_properties_match_candidates
+    ON properties(LOWER(city), listing_type, status);
+
+CREATE INDEX IF NOT EXISTS idx_clients_match_candidates
+    ON clients(LOWER(city), type, status);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_property_matches_updated_at ON property_matches;
+CREATE TRIGGER update_property_matches_updated_at
+    BEFORE UPDATE ON property_matches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(propertyMatchesMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run property matches migration: %w", err)
+	}
+
+	// Migration 006: Create property duplicates table
+	propertyDuplicatesMigration := `
+-- Trigram index on address for duplicate listing detection (pg_trgm enabled in migration 002)
+CREATE INDEX IF NOT EXISTS idx_properties_address_trgm
+    ON properties USING gin(address gin_trgm_ops);
+
+-- Create property_duplicates table recording likely duplicate listing pairs
+CREATE TABLE IF NOT EXISTS property_duplicates (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Pair of properties, stored in canonical order so each pair appears once
+    property_a_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    property_b_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+
+    -- Detection details
+    address_similarity REAL NOT NULL,
+
+    -- Admin Review
+    status VARCHAR(50) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'dismissed')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (property_a_id < property_b_id),
+    UNIQUE (property_a_id, property_b_id)
+);
+
+-- Performance Indexes
+
+-- Admin review queue
+CREATE INDEX IF NOT EXISTS idx_property_duplicates_status
+    ON property_duplicates(status, created_at DESC);
+
+-- Lookups from either side of a pair
+CREATE INDEX IF NOT EXISTS idx_property_duplicates_property_b
+    ON property_duplicates(property_b_id);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_property_duplicates_updated_at ON property_duplicates;
+CREATE TRIGGER update_property_duplicates_updated_at
+    BEFORE UPDATE ON property_duplicates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(propertyDuplicatesMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run property duplicates migration: %w", err)
+	}
+
+	// Migration 007: Create saved searches and notifications tables
+	savedSearchesMigration := `
+-- Create saved_searches table holding stored property search definitions
+CREATE TABLE IF NOT EXISTS saved_searches (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Ownership (a broker's own search, optionally on behalf of one of their clients)
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id UUID REFERENCES clients(id) ON DELETE CASCADE,
+
+    -- Search Definition (same vocabulary as property search filters)
+    name VARCHAR(100) NOT NULL,
+    filters JSONB NOT NULL DEFAULT '{}',
+
+    -- Delivery Preferences
+    notify_in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_email BOOLEAN NOT NULL DEFAULT TRUE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_notified_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user_created
+    ON saved_searches(user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_client
+    ON saved_searches(client_id) WHERE client_id IS NOT NULL;
+
+-- Evaluator prefilter on the most selective filter keys
+CREATE INDEX IF NOT EXISTS idx_saved_searches_active_city
+    ON saved_searches(LOWER(filters->>'city')) WHERE is_active = TRUE;
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_saved_searches_updated_at ON saved_searches;
+CREATE TRIGGER update_saved_searches_updated_at
+    BEFORE UPDATE ON saved_searches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Create saved_search_alerts table so each listing is alerted at most once per search
+CREATE TABLE IF NOT EXISTS saved_search_alerts (
+    saved_search_id UUID NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (saved_search_id, property_id)
+);
+
+-- Create notifications table for in-app notifications
+CREATE TABLE IF NOT EXISTS notifications (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Recipient
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Content
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+
+    -- Related entity (e.g. the property a saved search matched)
+    entity_type VARCHAR(50),
+    entity_id UUID,
+
+    -- Read State
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    read_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created
+    ON notifications(user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread
+    ON notifications(user_id) WHERE is_read = FALSE;
+`
+
+	_, err = db.Exec(savedSearchesMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run saved searches migration: %w", err)
+	}
+
+	// Migration 008: Create projects and project units tables
+	projectsMigration := `
+-- Create projects table for channel partner (builder) projects
+CREATE TABLE IF NOT EXISTS projects (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Basic Project Information
+    name VARCHAR(255) NOT NULL,
+    builder_name VARCHAR(255) NOT NULL,
+    project_type VARCHAR(50) NOT NULL CHECK (project_type IN ('residential', 'commercial', 'mixed')),
+
+    -- Location Information
+    location VARCHAR(255) NOT NULL,
+    city VARCHAR(100) NOT NULL,
+    state VARCHAR(100) NOT NULL,
+
+    -- Pricing
+    price_range_min DECIMAL(15, 2) NOT NULL,
+    price_range_max DECIMAL(15, 2) NOT NULL,
+
+    -- Description and Media
+    description TEXT NOT NULL,
+    amenities TEXT[] DEFAULT '{}',
+    images TEXT[] DEFAULT '{}',
+    brochure_url VARCHAR(500),
+
+    -- Timeline and Status
+    launch_date DATE,
+    possession_date DATE,
+    status VARCHAR(50) NOT NULL DEFAULT 'upcoming'
+        CHECK (status IN ('upcoming', 'launched', 'under_construction', 'ready')),
+
+    -- Ownership
+    channel_partner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (price_range_min <= price_range_max)
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_projects_partner_created
+    ON projects(channel_partner_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_projects_city_status
+    ON projects(LOWER(city), status);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_projects_updated_at ON projects;
+CREATE TRIGGER update_projects_updated_at
+    BEFORE UPDATE ON projects
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Create project_units table for unit-level inventory
+CREATE TABLE IF NOT EXISTS project_units (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Unit Identification
+    tower VARCHAR(50) NOT NULL,
+    floor INTEGER NOT NULL,
+    unit_number VARCHAR(50) NOT NULL,
+
+    -- Unit Details
+    configuration VARCHAR(50) NOT NULL, -- e.g. 1BHK, 2BHK, Shop, Office
+    carpet_area DECIMAL(10, 2),
+    price DECIMAL(15, 2) NOT NULL,
+
+    -- Inventory Status
+    status VARCHAR(50) NOT NULL DEFAULT 'available'
+        CHECK (status IN ('available', 'blocked', 'booked')),
+
+    -- Block held by a broker for one of their clients
+    blocked_by_broker_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    blocked_for_client_id UUID REFERENCES clients(id) ON DELETE SET NULL,
+    blocked_at TIMESTAMP WITH TIME ZONE,
+    block_expires_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (project_id, tower, unit_number)
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_project_units_project_status
+    ON project_units(project_id, status);
+
+CREATE INDEX IF NOT EXISTS idx_project_units_blocked_by
+    ON project_units(blocked_by_broker_id) WHERE blocked_by_broker_id IS NOT NULL;
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_project_units_updated_at ON project_units;
+CREATE TRIGGER update_project_units_updated_at
+    BEFORE UPDATE ON project_units
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(projectsMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run projects migration: %w", err)
+	}
+
+	// Migration 009: Create property share links table
+	shareLinksMigration := `
+-- Create property_share_links table for public, tokenized listing pages
+CREATE TABLE IF NOT EXISTS property_share_links (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Shared listing and the broker who shared it
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Unguessable token used in the public URL
+    token VARCHAR(64) NOT NULL UNIQUE,
+
+    -- Presentation options
+    show_broker_contact BOOLEAN NOT NULL DEFAULT TRUE,
+    hide_address BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- Lifetime
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+
+    -- View tracking
+    view_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_property_share_links_property
+    ON property_share_links(property_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_property_share_links_broker
+    ON property_share_links(broker_id);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_property_share_links_updated_at ON property_share_links;
+CREATE TRIGGER update_property_share_links_updated_at
+    BEFORE UPDATE ON property_share_links
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(shareLinksMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run share links migration: %w", err)
+	}
+
+	// Migration 010: Add property images and create syndication feeds table
+	syndicationFeedsMigration := `
+-- Add listing images used by share pages and portal feeds
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS images TEXT[] DEFAULT '{}';
+
+-- Create syndication_feeds table for exporting listings to external property portals
+CREATE TABLE IF NOT EXISTS syndication_feeds (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owner
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Feed definition
+    name VARCHAR(255) NOT NULL,
+    portal VARCHAR(100) NOT NULL,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('xml', 'json')),
+    root_element VARCHAR(100) NOT NULL DEFAULT 'listings',
+    item_element VARCHAR(100) NOT NULL DEFAULT 'listing',
+    fields JSONB NOT NULL DEFAULT '[]', -- ordered [{source, target}] field mapping
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- Scheduled generation state
+    last_generated_at TIMESTAMP WITH TIME ZONE,
+    last_file_path VARCHAR(500),
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_syndication_feeds_broker
+    ON syndication_feeds(broker_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_properties_broker_updated
+    ON properties(broker_id, updated_at);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_syndication_feeds_updated_at ON syndication_feeds;
+CREATE TRIGGER update_syndication_feeds_updated_at
+    BEFORE UPDATE ON syndication_feeds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(syndicationFeedsMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run syndication feeds migration: %w", err)
+	}
+
+	// Migration 011: Create documents table
+	documentsMigration := `
+-- Create documents table for the property and client documents vault
+CREATE TABLE IF NOT EXISTS documents (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owning broker and the records the document is filed under
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    property_id UUID REFERENCES properties(id) ON DELETE CASCADE,
+    client_id UUID REFERENCES clients(id) ON DELETE CASCADE,
+
+    -- Classification
+    document_type VARCHAR(30) NOT NULL CHECK (document_type IN (
+        'sale_deed', 'noc', 'rera_certificate', 'rent_agreement',
+        'id_proof', 'floor_plan', 'tax_receipt', 'other'
+    )),
+    title VARCHAR(255) NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private' CHECK (visibility IN ('private', 'shareable')),
+
+    -- Stored file (kept outside the public uploads directory)
+    file_name VARCHAR(255) NOT NULL UNIQUE,
+    original_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    -- Every document is filed under a property, a client, or both
+    CONSTRAINT documents_owner_check CHECK (property_id IS NOT NULL OR client_id IS NOT NULL)
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_documents_broker ON documents(broker_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_documents_property ON documents(property_id) WHERE property_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_documents_client ON documents(client_id) WHERE client_id IS NOT NULL;
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_documents_updated_at ON documents;
+CREATE TRIGGER update_documents_updated_at
+    BEFORE UPDATE ON documents
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(documentsMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run documents migration: %w", err)
+	}
+
+	// Migration 012: Add property expiry and archived status
+	propertyExpiryMigration := `
+-- Add listing expiry, renewal and the archived status to properties
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS expiry_notified_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+
+-- Allow the archived status
+ALTER TABLE properties DROP CONSTRAINT IF EXISTS properties_status_check;
+ALTER TABLE properties ADD CONSTRAINT properties_status_check
+    CHECK (status IN ('available', 'sold', 'rented', 'under_negotiation', 'archived'));
+
+-- Give existing listings an expiry, with at least two weeks' notice before anything is archived
+UPDATE properties
+SET expires_at = GREATEST(updated_at + INTERVAL '90 days', NOW() + INTERVAL '14 days')
+WHERE expires_at IS NULL;
+
+-- Supports the scheduled expiry scan
+CREATE INDEX IF NOT EXISTS idx_properties_status_expires
+    ON properties(status, expires_at);
+`
+
+	_, err = db.Exec(propertyExpiryMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run property expiry migration: %w", err)
+	}
+
+	// Migration 013: Add soft delete to properties, clients and appointments
+	softDeleteMigration := `
+-- Add soft deletion to properties, clients and appointments
+-- Deleted rows stay in the trash until the purge job removes them after the retention window
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Trash listing and purge scans only touch deleted rows
+CREATE INDEX IF NOT EXISTS idx_properties_deleted
+    ON properties(broker_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_clients_deleted
+    ON clients(broker_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_appointments_deleted
+    ON appointments(broker_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Cascade restore finds a client's appointments trashed together with it
+CREATE INDEX IF NOT EXISTS idx_appointments_client_deleted
+    ON appointments(client_id, deleted_at) WHERE deleted_at IS NOT NULL;
+`
+
+	_, err = db.Exec(softDeleteMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run soft delete migration: %w", err)
+	}
+
+	// Migration 014: Create property daily stats table
+	propertyDailyStatsMigration := `
+-- Create property_daily_stats table for listing engagement analytics
+-- One row per property and day; counters are incremented as events happen
+CREATE TABLE IF NOT EXISTS property_daily_stats (
+    -- Listing and the broker who owns it
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+
+    -- Engagement counters
+    share_views INTEGER NOT NULL DEFAULT 0,
+    app_views INTEGER NOT NULL DEFAULT 0,
+    inquiries INTEGER NOT NULL DEFAULT 0,
+    appointments INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (property_id, day)
+);
+
+-- Per-broker reports scan a date range across all of the broker's listings
+CREATE INDEX IF NOT EXISTS idx_property_daily_stats_broker_day
+    ON property_daily_stats(broker_id, day);
+
+-- Backfill appointment counts from existing appointments
+INSERT INTO property_daily_stats (property_id, broker_id, day, appointments)
+SELECT a.property_id, p.broker_id, a.created_at::date, COUNT(*)
+FROM appointments a
+JOIN properties p ON p.id = a.property_id
+WHERE a.property_id IS NOT NULL
+GROUP BY a.property_id, p.broker_id, a.created_at::date
+ON CONFLICT (property_id, day) DO NOTHING;
+`
+
+	_, err = db.Exec(propertyDailyStatsMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run property daily stats migration: %w", err)
+	}
+
+	// Migration 015: Add property closed_at for days-on-market
+	propertyClosedAtMigration := `
+-- Record when a listing was sold or rented so days-on-market can be measured
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP WITH TIME ZONE;
+
+-- Listings closed before this migration use their last update as the closing time
+UPDATE properties
+SET closed_at = updated_at
+WHERE status IN ('sold', 'rented') AND closed_at IS NULL;
+
+-- Keep closed_at in step with the status: set when a listing closes, cleared if it is reopened
+CREATE OR REPLACE FUNCTION set_property_closed_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status IN ('sold', 'rented') THEN
+        IF TG_OP = 'INSERT' OR OLD.status NOT IN ('sold', 'rented') OR NEW.closed_at IS NULL THEN
+            NEW.closed_at = NOW();
+        END IF;
+    ELSE
+        NEW.closed_at = NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS set_properties_closed_at ON properties;
+CREATE TRIGGER set_properties_closed_at
+    BEFORE INSERT OR UPDATE OF status ON properties
+    FOR EACH ROW
+    EXECUTE FUNCTION set_property_closed_at();
+
+-- Market analytics group listings by city regardless of capitalisation
+CREATE INDEX IF NOT EXISTS idx_properties_city_lower
+    ON properties(LOWER(TRIM(city)), listing_type);
+`
+
+	_, err = db.Exec(propertyClosedAtMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run property closed_at migration: %w", err)
+	}
+
+	// Migration 016: Add structured client requirements
+	clientRequirementsMigration := `
+-- Add structured requirement fields to clients; the free-text requirements column is kept for notes
+DO $$
+BEGIN
+    -- Columns are added and backfilled together, once, so later edits are never overwritten
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'clients' AND column_name = 'preferred_localities'
+    ) THEN
+        ALTER TABLE clients
+            ADD COLUMN property_types TEXT[] NOT NULL DEFAULT '{}',
+            ADD COLUMN listing_type VARCHAR(50) CHECK (listing_type IN ('sale', 'rent')),
+            ADD COLUMN bedrooms_min INTEGER,
+            ADD COLUMN bedrooms_max INTEGER,
+            ADD COLUMN area_min DECIMAL(10, 2),
+            ADD COLUMN area_max DECIMAL(10, 2),
+            ADD COLUMN preferred_localities TEXT[] NOT NULL DEFAULT '{}',
+            ADD COLUMN required_amenities TEXT[] NOT NULL DEFAULT '{}';
+
+        -- Seed the structured fields from what existing clients already tell us
+        UPDATE clients SET
+            listing_type = CASE type WHEN 'buyer' THEN 'sale' WHEN 'tenant' THEN 'rent' END,
+            preferred_localities = CASE
+                WHEN TRIM(preferred_location) <> '' THEN ARRAY[TRIM(preferred_location)]
+                ELSE '{}'
+            END,
+            bedrooms_min = substring(requirements FROM '(?i)(\d+)\s*-?\s*bhk')::INTEGER,
+            bedrooms_max = substring(requirements FROM '(?i)(\d+)\s*-?\s*bhk')::INTEGER;
+    END IF;
+END
+$$;
+
+-- Requirement filters on the client list
+CREATE INDEX IF NOT EXISTS idx_clients_property_types
+    ON clients USING gin(property_types);
+
+CREATE INDEX IF NOT EXISTS idx_clients_required_amenities
+    ON clients USING gin(required_amenities);
+`
+
+	_, err = db.Exec(clientRequirementsMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run client requirements migration: %w", err)
+	}
+
+	// Migration 017: Create pipeline stages and stage transitions
+	pipelineMigration := `
+-- Create pipeline_stages table: each broker's configurable sales stages, in board order
+CREATE TABLE IF NOT EXISTS pipeline_stages (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owner
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Stage definition; won and lost stages close the lead
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (kind IN ('open', 'won', 'lost')),
+    position INTEGER NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pipeline_stages_broker_name
+    ON pipeline_stages(broker_id, LOWER(name));
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_stages_broker_position
+    ON pipeline_stages(broker_id, position);
+
+DROP TRIGGER IF EXISTS update_pipeline_stages_updated_at ON pipeline_stages;
+CREATE TRIGGER update_pipeline_stages_updated_at
+    BEFORE UPDATE ON pipeline_stages
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Current stage of each client; stages with clients in them cannot be deleted
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS pipeline_stage_id UUID REFERENCES pipeline_stages(id) ON DELETE RESTRICT;
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS stage_changed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS lost_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_clients_pipeline_stage
+    ON clients(pipeline_stage_id, stage_changed_at DESC);
+
+-- Create client_stage_transitions table: history of every stage change
+CREATE TABLE IF NOT EXISTS client_stage_transitions (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Client and the broker who moved it
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    -- Stages; names are copied so history survives renames and deletions
+    from_stage_id UUID REFERENCES pipeline_stages(id) ON DELETE SET NULL,
+    from_stage_name VARCHAR(100),
+    to_stage_id UUID REFERENCES pipeline_stages(id) ON DELETE SET NULL,
+    to_stage_name VARCHAR(100) NOT NULL,
+
+    -- Why the lead was lost, for moves into a lost stage
+    lost_reason TEXT,
+
+    -- Timestamp
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_client_stage_transitions_client
+    ON client_stage_transitions(client_id, created_at DESC);
+`
+
+	_, err = db.Exec(pipelineMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run pipeline migration: %w", err)
+	}
+
+	// Migration 018: Create client activities table
+	clientActivitiesMigration := `
+-- Create client_activities table: the interaction log behind each client's timeline
+DO $$
+BEGIN
+    -- The table is created and backfilled together, once, so deleted entries never come back
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.tables
+        WHERE table_name = 'client_activities'
+    ) THEN
+        CREATE TABLE client_activities (
+            -- Primary Key
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+            -- Relationships; author is empty when no user made the change
+            client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+            broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+            appointment_id UUID REFERENCES appointments(id) ON DELETE SET NULL,
+
+            -- Entry
+            type VARCHAR(50) NOT NULL CHECK (type IN (
+                'call', 'whatsapp', 'email', 'note', 'site_visit', 'meeting',
+                'status_change', 'appointment_scheduled', 'appointment_rescheduled',
+                'appointment_completed', 'appointment_cancelled'
+            )),
+            body TEXT NOT NULL,
+
+            -- Timestamps; occurred_at is when the interaction happened, which may predate logging it
+            occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+        );
+
+        -- Existing notes become the first entry of each client's timeline
+        INSERT INTO client_activities (client_id, broker_id, author_id, type, body, occurred_at)
+        SELECT id, broker_id, broker_id, 'note', TRIM(notes), updated_at
+        FROM clients
+        WHERE TRIM(COALESCE(notes, '')) <> '';
+
+        -- Existing appointments are listed when they were booked and, once closed, when that happened
+        INSERT INTO client_activities (client_id, broker_id, appointment_id, type, body, occurred_at)
+        SELECT client_id, broker_id, id, 'appointment_scheduled',
+            INITCAP(REPLACE(type, '_', ' ')) || ' scheduled for ' || TO_CHAR(date, 'YYYY-MM-DD')
+                || ' at ' || TO_CHAR(time, 'HH24:MI') || ': ' || title,
+            created_at
+        FROM appointments;
+
+        INSERT INTO client_activities (client_id, broker_id, appointment_id, type, body, occurred_at)
+        SELECT client_id, broker_id, id, 'appointment_' || status,
+            INITCAP(REPLACE(type, '_', ' ')) || ' ' || status || ': ' || title,
+            updated_at
+        FROM appointments
+        WHERE status IN ('completed', 'cancelled');
+    END IF;
+END
+$$;
+
+-- Timeline query pattern: a client's entries, newest first
+CREATE INDEX IF NOT EXISTS idx_client_activities_client_occurred
+    ON client_activities(client_id, occurred_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_client_activities_appointment
+    ON client_activities(appointment_id);
+`
+
+	_, err = db.Exec(clientActivitiesMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run client activities migration: %w", err)
+	}
+
+	// Migration 019: Create tasks table
+	tasksMigration := `
+-- Create tasks table: follow-ups attached to clients or properties, with reminders
+CREATE TABLE IF NOT EXISTS tasks (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owner and the user who has to do the task
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assignee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- What the task is about; both are optional
+    client_id UUID REFERENCES clients(id) ON DELETE CASCADE,
+    property_id UUID REFERENCES properties(id) ON DELETE CASCADE,
+
+    -- Task Details
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    priority VARCHAR(20) NOT NULL DEFAULT 'medium' CHECK (priority IN ('low', 'medium', 'high')),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'done')),
+
+    -- Scheduling; the reminder goes out at remind_at and reminded_at records that it did
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    remind_at TIMESTAMP WITH TIME ZONE,
+    reminded_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Task list query patterns: a user's open tasks by due date
+CREATE INDEX IF NOT EXISTS idx_tasks_assignee_due
+    ON tasks(assignee_id, status, due_at);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_broker_due
+    ON tasks(broker_id, status, due_at);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_client
+    ON tasks(client_id);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_property
+    ON tasks(property_id);
+
+-- Reminder scan: open tasks whose reminder has not gone out yet
+CREATE INDEX IF NOT EXISTS idx_tasks_pending_reminders
+    ON tasks(remind_at)
+    WHERE status = 'open' AND reminded_at IS NULL;
+
+DROP TRIGGER IF EXISTS update_tasks_updated_at ON tasks;
+CREATE TRIGGER update_tasks_updated_at
+    BEFORE UPDATE ON tasks
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(tasksMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run tasks migration: %w", err)
+	}
+
+	// Migration 020: Create client duplicates table
+	clientDuplicatesMigration := `
+-- Normalized contact indexes for duplicate client detection within a broker's book
+-- Phones are compared on their last 10 digits so "+91 98200-12345" matches "9820012345"
+CREATE INDEX IF NOT EXISTS idx_clients_broker_phone_digits
+    ON clients(broker_id, RIGHT(regexp_replace(phone, '\D', '', 'g'), 10))
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_clients_broker_email_lower
+    ON clients(broker_id, LOWER(TRIM(email)))
+    WHERE deleted_at IS NULL;
+
+-- Create client_duplicates table recording likely duplicate client pairs
+CREATE TABLE IF NOT EXISTS client_duplicates (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owner of both clients
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Pair of clients, stored in canonical order so each pair appears once
+    client_a_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    client_b_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+
+    -- Detection details
+    phone_match BOOLEAN NOT NULL DEFAULT FALSE,
+    email_match BOOLEAN NOT NULL DEFAULT FALSE,
+    name_similarity REAL NOT NULL DEFAULT 0,
+
+    -- Broker Review; merging removes the pair along with the merged client
+    status VARCHAR(50) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'dismissed')),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (client_a_id < client_b_id),
+    UNIQUE (client_a_id, client_b_id)
+);
+
+-- Performance Indexes
+
+-- Broker review queue
+CREATE INDEX IF NOT EXISTS idx_client_duplicates_broker_status
+    ON client_duplicates(broker_id, status, created_at DESC);
+
+-- Lookups from either side of a pair
+CREATE INDEX IF NOT EXISTS idx_client_duplicates_client_b
+    ON client_duplicates(client_b_id);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_client_duplicates_updated_at ON client_duplicates;
+CREATE TRIGGER update_client_duplicates_updated_at
+    BEFORE UPDATE ON client_duplicates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(clientDuplicatesMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run client duplicates migration: %w", err)
+	}
+
+	// Migration 021: Create client imports table
+	clientImportsMigration := `
+-- Create client_imports table tracking contact file imports and their per-row report
+CREATE TABLE IF NOT EXISTS client_imports (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Ownership
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Source file and options
+    file_name VARCHAR(255) NOT NULL,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('csv', 'vcard')),
+    duplicate_policy VARCHAR(20) NOT NULL DEFAULT 'skip'
+        CHECK (duplicate_policy IN ('skip', 'merge', 'create')),
+    default_type VARCHAR(50) NOT NULL DEFAULT 'buyer'
+        CHECK (default_type IN ('buyer', 'seller', 'tenant', 'owner')),
+    mapping JSONB,
+
+    -- Progress
+    status VARCHAR(20) NOT NULL DEFAULT 'processing'
+        CHECK (status IN ('processing', 'completed', 'failed')),
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    created_rows INTEGER NOT NULL DEFAULT 0,
+    merged_rows INTEGER NOT NULL DEFAULT 0,
+    skipped_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+
+    -- Rows that were merged, skipped or failed, in file order
+    report JSONB NOT NULL DEFAULT '[]',
+
+    -- Timestamps
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- A broker's imports, newest first
+CREATE INDEX IF NOT EXISTS idx_client_imports_broker
+    ON client_imports(broker_id, created_at DESC);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_client_imports_updated_at ON client_imports;
+CREATE TRIGGER update_client_imports_updated_at
+    BEFORE UPDATE ON client_imports
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(clientImportsMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run client imports migration: %w", err)
+	}
+
+	// Migration 022: Add lead sources and lead capture forms
+	leadSourcesMigration := `
+-- Add lead source and campaign attribution to clients, and per-broker public lead capture forms
+ALTER TABLE clients
+    ADD COLUMN IF NOT EXISTS source VARCHAR(20)
+        CHECK (source IN ('website', 'portal', 'referral', 'walk_in', 'other')),
+    ADD COLUMN IF NOT EXISTS campaign VARCHAR(100);
+
+-- Lead source filters and the per-source summary
+CREATE INDEX IF NOT EXISTS idx_clients_broker_source
+    ON clients(broker_id, source, campaign)
+    WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS lead_forms (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Each broker has one form
+    broker_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Unguessable key used in the public submission URL
+    form_key VARCHAR(64) NOT NULL UNIQUE,
+
+    -- Options
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    default_type VARCHAR(50) NOT NULL DEFAULT 'buyer'
+        CHECK (default_type IN ('buyer', 'seller', 'tenant', 'owner')),
+
+    -- Submission tracking; spam covers submissions rejected by the honeypot or timing checks
+    submission_count INTEGER NOT NULL DEFAULT 0,
+    spam_count INTEGER NOT NULL DEFAULT 0,
+    last_submission_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_lead_forms_updated_at ON lead_forms;
+CREATE TRIGGER update_lead_forms_updated_at
+    BEFORE UPDATE ON lead_forms
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(leadSourcesMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run lead sources migration: %w", err)
+	}
+
+	// Migration 023: Add client lead score
+	clientLeadScoreMigration := `
+-- Add a stored lead score to clients so brokers can work through their book best leads first
+ALTER TABLE clients
+    ADD COLUMN IF NOT EXISTS lead_score SMALLINT NOT NULL DEFAULT 0
+        CHECK (lead_score BETWEEN 0 AND 100),
+    ADD COLUMN IF NOT EXISTS lead_score_factors JSONB,
+    ADD COLUMN IF NOT EXISTS lead_score_updated_at TIMESTAMP WITH TIME ZONE;
+
+-- Client list sorted by lead score
+CREATE INDEX IF NOT EXISTS idx_clients_broker_lead_score
+    ON clients(broker_id, lead_score DESC, id DESC)
+    WHERE deleted_at IS NULL;
+
+-- Refreshing a lead score is bookkeeping rather than an edit, so an update that only changes the
+-- score keeps the client's updated_at (which the score itself reads for recency)
+CREATE OR REPLACE FUNCTION update_clients_updated_at_column()
+RETURNS TRIGGER AS $clients_updated_at$
+BEGIN
+    IF (NEW.lead_score, NEW.lead_score_factors) IS DISTINCT FROM (OLD.lead_score, OLD.lead_score_factors)
+        AND to_jsonb(NEW) - 'lead_score' - 'lead_score_factors' - 'lead_score_updated_at'
+            = to_jsonb(OLD) - 'lead_score' - 'lead_score_factors' - 'lead_score_updated_at' THEN
+        NEW.updated_at = OLD.updated_at;
+    ELSE
+        NEW.updated_at = NOW();
+    END IF;
+    RETURN NEW;
+END;
+$clients_updated_at$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS update_clients_updated_at ON clients;
+CREATE TRIGGER update_clients_updated_at
+    BEFORE UPDATE ON clients
+    FOR EACH ROW
+    EXECUTE FUNCTION update_clients_updated_at_column();
+`
+
+	_, err = db.Exec(clientLeadScoreMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run client lead score migration: %w", err)
+	}
+
+	// Migration 024: Create client transfers and ownership history tables
+	clientTransfersMigration := `
+-- Create client_transfers table recording clients handed from one broker to another
+CREATE TABLE IF NOT EXISTS client_transfers (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Giving and receiving brokers, and who proposed the transfer (the giving broker or an admin)
+    from_broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    -- Clients offered; clients merged or deleted in the meantime are skipped on acceptance
+    client_ids UUID[] NOT NULL,
+    message TEXT,
+
+    -- The receiving broker accepts or declines; the proposer may cancel while it is pending
+    status VARCHAR(50) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    responded_at TIMESTAMP WITH TIME ZONE,
+
+    -- What acceptance moved: client count and related record counts by table
+    transferred_count INTEGER NOT NULL DEFAULT 0,
+    moved JSONB,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (from_broker_id <> to_broker_id),
+    CHECK (cardinality(client_ids) > 0)
+);
+
+-- Incoming and outgoing transfer lists
+CREATE INDEX IF NOT EXISTS idx_client_transfers_to_broker
+    ON client_transfers(to_broker_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_client_transfers_from_broker
+    ON client_transfers(from_broker_id, created_at DESC);
+
+-- Finding pending transfers that already offer a client
+CREATE INDEX IF NOT EXISTS idx_client_transfers_pending_clients
+    ON client_transfers USING gin(client_ids)
+    WHERE status = 'pending';
+
+DROP TRIGGER IF EXISTS update_client_transfers_updated_at ON client_transfers;
+CREATE TRIGGER update_client_transfers_updated_at
+    BEFORE UPDATE ON client_transfers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Create client_ownership_history table recording every change of a client's broker
+-- Broker names are copied so the history still reads after a broker's account is removed
+CREATE TABLE IF NOT EXISTS client_ownership_history (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    transfer_id UUID REFERENCES client_transfers(id) ON DELETE SET NULL,
+
+    from_broker_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    from_broker_name VARCHAR(200),
+    to_broker_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    to_broker_name VARCHAR(200),
+
+    transferred_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_client_ownership_history_client
+    ON client_ownership_history(client_id, transferred_at DESC);
+
+-- Moving a client or appointment to another broker refreshes its denormalized broker_name and
+-- broker_city with the same functions that fill them in on insert
+DROP TRIGGER IF EXISTS populate_client_broker_info_on_transfer ON clients;
+CREATE TRIGGER populate_client_broker_info_on_transfer
+    BEFORE UPDATE OF broker_id ON clients
+    FOR EACH ROW
+    WHEN (OLD.broker_id IS DISTINCT FROM NEW.broker_id)
+    EXECUTE FUNCTION populate_client_broker_info();
+
+DROP TRIGGER IF EXISTS populate_appointment_broker_info_on_transfer ON appointments;
+CREATE TRIGGER populate_appointment_broker_info_on_transfer
+    BEFORE UPDATE OF broker_id ON appointments
+    FOR EACH ROW
+    WHEN (OLD.broker_id IS DISTINCT FROM NEW.broker_id)
+    EXECUTE FUNCTION populate_appointment_broker_info();
+`
+
+	_, err = db.Exec(clientTransfersMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run client transfers migration: %w", err)
+	}
+
+	// Migration 025: Add tags to clients and properties, and client segments
+	tagsAndSegmentsMigration := `
+-- Add per-broker tags to clients and properties
+-- A tag exists for as long as some client or property of the broker carries it
+ALTER TABLE clients
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE properties
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- GIN indexes support tag containment filters
+CREATE INDEX IF NOT EXISTS idx_clients_tags
+    ON clients USING gin(tags)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_properties_tags
+    ON properties USING gin(tags)
+    WHERE deleted_at IS NULL;
+
+-- Create client_segments table holding stored client list filters
+CREATE TABLE IF NOT EXISTS client_segments (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Ownership
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Segment Definition (same vocabulary as the client list filters)
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    filters JSONB NOT NULL DEFAULT '{}',
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Segment names are unique per broker regardless of case
+CREATE UNIQUE INDEX IF NOT EXISTS idx_client_segments_broker_name
+    ON client_segments(broker_id, LOWER(name));
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_client_segments_updated_at ON client_segments;
+CREATE TRIGGER update_client_segments_updated_at
+    BEFORE UPDATE ON client_segments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(tagsAndSegmentsMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run tags and segments migration: %w", err)
+	}
+
+	// Migration 026: Add contact consents
+	contactConsentsMigration := `
+-- Create contact_consents table: an append-only log of clients' consent to be contacted per channel
+-- A contact's current consent on a channel is its latest record; contacts with no record have not consented
+-- Records are keyed by the contact itself so an opt-out keeps applying after the client is deleted or re-added
+CREATE TABLE IF NOT EXISTS contact_consents (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Ownership
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Contact: the last 10 phone digits for whatsapp, sms and call, the lowercased address for email
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('whatsapp', 'sms', 'email', 'call')),
+    contact VARCHAR(255) NOT NULL,
+    client_id UUID REFERENCES clients(id) ON DELETE SET NULL,
+
+    -- Consent
+    status VARCHAR(20) NOT NULL CHECK (status IN ('granted', 'withdrawn')),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('verbal', 'written', 'lead_form', 'opt_out_link', 'client_request', 'transfer', 'other')),
+    note TEXT,
+
+    -- Audit
+    recorded_by UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL when the client recorded it
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Index for looking up a contact's latest consent
+CREATE INDEX IF NOT EXISTS idx_contact_consents_contact
+    ON contact_consents(broker_id, channel, contact, recorded_at DESC);
+
+-- Index for a client's consent history
+CREATE INDEX IF NOT EXISTS idx_contact_consents_client
+    ON contact_consents(client_id, recorded_at DESC);
+`
+
+	_, err = db.Exec(contactConsentsMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run contact consents migration: %w", err)
+	}
+
+	// Migration 027: Create deals and deal milestones tables
+	dealsMigration := `
+-- Create deals table recording the transactions brokers close for their clients
+CREATE TABLE IF NOT EXISTS deals (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Ownership
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Parties; records that are purged from the trash leave the deal in place
+    client_id UUID REFERENCES clients(id) ON DELETE SET NULL,
+    property_id UUID REFERENCES properties(id) ON DELETE SET NULL,
+
+    -- Co-broker or channel partner sharing the brokerage, and their share of it
+    partner_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    partner_share_percent DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (partner_share_percent BETWEEN 0 AND 100),
+
+    -- Terms
+    agreed_price DECIMAL(15,2) NOT NULL CHECK (agreed_price > 0),
+    token_amount DECIMAL(15,2) CHECK (token_amount >= 0),
+    token_date DATE,
+    brokerage_percent DECIMAL(5,2) CHECK (brokerage_percent BETWEEN 0 AND 100),
+    brokerage_amount DECIMAL(15,2) NOT NULL CHECK (brokerage_amount >= 0),
+
+    -- Progress
+    status VARCHAR(20) NOT NULL DEFAULT 'token_paid'
+        CHECK (status IN ('token_paid', 'agreement_signed', 'completed', 'cancelled')),
+    expected_close_date DATE,
+    closed_on DATE,
+    notes TEXT,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Indexes for the deal lists
+CREATE INDEX IF NOT EXISTS idx_deals_broker ON deals(broker_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_deals_partner ON deals(partner_id, created_at DESC) WHERE partner_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_deals_client ON deals(client_id);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_deals_updated_at ON deals;
+CREATE TRIGGER update_deals_updated_at
+    BEFORE UPDATE ON deals
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Create deal_milestones table: the installments the brokerage is paid in
+CREATE TABLE IF NOT EXISTS deal_milestones (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    deal_id UUID NOT NULL REFERENCES deals(id) ON DELETE CASCADE,
+
+    -- Installment
+    name VARCHAR(100) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    due_date DATE,
+
+    -- Payment
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'received')),
+    received_on DATE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Indexes for a deal's milestones and the monthly commission report
+CREATE INDEX IF NOT EXISTS idx_deal_milestones_deal ON deal_milestones(deal_id, due_date);
+CREATE INDEX IF NOT EXISTS idx_deal_milestones_received ON deal_milestones(received_on) WHERE status = 'received';
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_deal_milestones_updated_at ON deal_milestones;
+CREATE TRIGGER update_deal_milestones_updated_at
+    BEFORE UPDATE ON deal_milestones
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+`
+
+	_, err = db.Exec(dealsMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run deals migration: %w", err)
+	}
+
+	// Migration 028: Add client portal sign-in links, shared properties and appointment responses
+	clientPortalMigration := `
+-- Create client_portal_tokens table for the magic links clients sign in to the portal with
+-- Only a hash of each token is stored; a token signs in once and expires soon after it is sent
+CREATE TABLE IF NOT EXISTS client_portal_tokens (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The client row the link signs in to
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+
+    -- SHA-256 of the token in the link
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+
+    -- Lifetime
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Index for clearing a client's spent links
+CREATE INDEX IF NOT EXISTS idx_client_portal_tokens_client ON client_portal_tokens(client_id);
+
+-- Create client_shared_properties table for the listings a broker shares with a client on the portal
+CREATE TABLE IF NOT EXISTS client_shared_properties (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Client and the listing shared with them
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+
+    -- Broker's note to the client about the listing
+    note TEXT,
+
+    -- Audit
+    shared_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    shared_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    UNIQUE (client_id, property_id)
+);
+
+-- Index for a client's shared listings, newest first
+CREATE INDEX IF NOT EXISTS idx_client_shared_properties_client
+    ON client_shared_properties(client_id, shared_at DESC);
+
+-- Client's answer to an appointment from the portal; cleared when the appointment is rescheduled
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS client_response VARCHAR(20)
+    CHECK (client_response IN ('confirmed', 'cancelled'));
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS client_responded_at TIMESTAMP WITH TIME ZONE;
+`
+
+	_, err = db.Exec(clientPortalMigration)
+	if err != nil {
+		return fmt.Errorf("failed to run client portal migration: %w", err)
+	}
+
 	log.Println("Database migrations completed successfully")
 	return nil
 }
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ActivityHandler handles HTTP requests for client interaction logs and timelines
+type ActivityHandler struct {
+	activityService *services.ActivityService
+	validator       *validator.Validate
+}
+
+// NewActivityHandler creates a new ActivityHandler instance
+func NewActivityHandler(activityService *services.ActivityService) *ActivityHandler {
+	return &ActivityHandler{
+		activityService: activityService,
+		validator:       validator.New(),
+	}
+}
+
+// GetTimeline handles GET /api/clients/:id/timeline - the client's interactions, appointment
+// events and stage changes, newest first
+func (h *ActivityHandler) GetTimeline(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.ClientTimelineRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	entries, page, err := h.activityService.GetTimeline(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		h.respondActivityError(c, err, "Client not found", "Failed to retrieve client timeline")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message:    "Client timeline retrieved successfully",
+		Data:       entries,
+		Pagination: page,
+	})
+}
+
+// LogActivity handles POST /api/clients/:id/activities - logs a call, message, note or visit
+func (h *ActivityHandler) LogActivity(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CreateClientActivityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	activity, err := h.activityService.LogActivity(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		h.respondActivityError(c, err, "Client not found", "Failed to log activity")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Activity logged successfully",
+		Data:    activity,
+	})
+}
+
+// DeleteActivity handles DELETE /api/clients/:id/activities/:activityId - removes a logged interaction
+func (h *ActivityHandler) DeleteActivity(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	err := h.activityService.DeleteActivity(c.Param("id"), c.Param("activityId"), brokerID.(string))
+	if err != nil {
+		h.respondActivityError(c, err, "Activity not found", "Failed to delete activity")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Activity deleted successfully",
+	})
+}
+
+// respondActivityError maps activity service errors to HTTP responses
+func (h *ActivityHandler) respondActivityError(c *gin.Context, err error, notFoundMessage, failureMessage string) {
+	message := err.Error()
+
+	switch {
+	case strings.HasPrefix(message, "invalid"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	case strings.Contains(message, "not found") ||
+		strings.Contains(message, "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: notFoundMessage,
+		})
+	case strings.HasPrefix(message, "cannot"):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Conflict",
+			Message: message,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: failureMessage,
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// AnalyticsHandler handles HTTP requests for listing engagement analytics
+type AnalyticsHandler struct {
+	analyticsService *services.AnalyticsService
+	validator        *validator.Validate
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler instance
+func NewAnalyticsHandler(analyticsService *services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+		validator:        validator.New(),
+	}
+}
+
+// GetPropertyAnalytics handles GET /api/properties/:id/analytics - engagement report for a listing
+func (h *AnalyticsHandler) GetPropertyAnalytics(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	req, ok := h.bindRange(c)
+	if !ok {
+		return
+	}
+
+	analytics, err := h.analyticsService.GetPropertyAnalytics(c.Param("id"), brokerID.(string), req)
+	if err != nil {
+		h.respondAnalyticsError(c, err, "Failed to retrieve property analytics")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Property analytics retrieved successfully",
+		Data:    analytics,
+	})
+}
+
+// GetListingAnalytics handles GET /api/analytics/listings - engagement report across the broker's listings
+func (h *AnalyticsHandler) GetListingAnalytics(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	req, ok := h.bindRange(c)
+	if !ok {
+		return
+	}
+
+	analytics, err := h.analyticsService.GetBrokerAnalytics(brokerID.(string), req)
+	if err != nil {
+		h.respondAnalyticsError(c, err, "Failed to retrieve listing analytics")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Listing analytics retrieved successfully",
+		Data:    analytics,
+	})
+}
+
+// RecordInquiry handles POST /api/properties/:id/inquiries - logs an inquiry received for a listing
+func (h *AnalyticsHandler) RecordInquiry(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.analyticsService.RecordInquiry(c.Param("id"), brokerID.(string)); err != nil {
+		h.respondAnalyticsError(c, err, "Failed to record inquiry")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Inquiry recorded successfully",
+	})
+}
+
+// bindRange binds and validates the from/to query parameters, writing a 400 response on failure
+func (h *AnalyticsHandler) bindRange(c *gin.Context) (*models.AnalyticsRangeRequest, bool) {
+	var req models.AnalyticsRangeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid filters",
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return nil, false
+	}
+
+	return &req, true
+}
+
+// respondAnalyticsError maps analytics service errors to HTTP responses
+func (h *AnalyticsHandler) respondAnalyticsError(c *gin.Context, err error, failureMessage string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+	case strings.Contains(err.Error(), "not found") ||
+		strings.Contains(err.Error(), "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Property not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: failureMessage,
+		})
+	}
+}
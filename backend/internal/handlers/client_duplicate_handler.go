@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ClientDuplicateHandler handles HTTP requests for duplicate client review and merging
+type ClientDuplicateHandler struct {
+	clientDuplicateService *services.ClientDuplicateService
+	validator              *validator.Validate
+}
+
+// NewClientDuplicateHandler creates a new ClientDuplicateHandler instance
+func NewClientDuplicateHandler(clientDuplicateService *services.ClientDuplicateService) *ClientDuplicateHandler {
+	return &ClientDuplicateHandler{
+		clientDuplicateService: clientDuplicateService,
+		validator:              validator.New(),
+	}
+}
+
+// GetDuplicates handles GET /api/client-duplicates - retrieves the broker's likely duplicate client pairs
+// Optional query parameter status (pending, dismissed) defaults to pending
+func (h *ClientDuplicateHandler) GetDuplicates(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	status := c.DefaultQuery("status", "pending")
+	if status != "pending" && status != "dismissed" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: "status must be one of: pending dismissed",
+		})
+		return
+	}
+
+	duplicates, err := h.clientDuplicateService.GetDuplicates(brokerID.(string), status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve duplicate clients",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Duplicate clients retrieved successfully",
+		Data:    duplicates,
+	})
+}
+
+// ReviewDuplicate handles PUT /api/client-duplicates/:id - dismisses a duplicate client pair or reopens it
+func (h *ClientDuplicateHandler) ReviewDuplicate(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.ReviewClientDuplicateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	duplicate, err := h.clientDuplicateService.ReviewDuplicate(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Duplicate pair not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to review duplicate pair",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Duplicate pair reviewed successfully",
+		Data:    duplicate,
+	})
+}
+
+// MergeClients handles POST /api/clients/:id/merge - folds a duplicate client into this one
+func (h *ClientDuplicateHandler) MergeClients(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.MergeClientsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	result, err := h.clientDuplicateService.MergeClients(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		message := err.Error()
+
+		// Invalid references are checked first since they wrap "not found" errors
+		switch {
+		case strings.HasPrefix(message, "invalid"):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Validation failed",
+				Message: message,
+			})
+		case strings.Contains(message, "not found") ||
+			strings.Contains(message, "access denied"):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Client not found",
+			})
+		case strings.HasPrefix(message, "cannot"):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "Conflict",
+				Message: message,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "Internal server error",
+				Message: "Failed to merge clients",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Clients merged successfully",
+		Data:    result,
+	})
+}
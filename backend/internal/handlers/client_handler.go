@@ -25,7 +25,7 @@ func NewClientHandler(clientService *services.ClientService) *ClientHandler {
 	}
 }
 
-// GetClients handles GET /api/clients - retrieves all clients for authenticated broker
+// GetClients handles GET /api/clients - retrieves the authenticated broker's clients with optional filters
 func (h *ClientHandler) GetClients(c *gin.Context) {
 	// Extract broker_id from gin context (set by auth middleware)
 	brokerID, exists := c.Get("user_id")
@@ -37,9 +37,36 @@ func (h *ClientHandler) GetClients(c *gin.Context) {
 		return
 	}
 
+	// Parse and validate requirement filters from the query string
+	var filters models.ClientFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
 	// Call clientService.GetBrokerClients
-	clients, err := h.clientService.GetBrokerClients(brokerID.(string))
+	clients, page, err := h.clientService.GetBrokerClients(brokerID.(string), filters)
 	if err != nil {
+		// Return 400 for a bad cursor or date range
+		if strings.HasPrefix(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid query parameters",
+				Message: err.Error(),
+			})
+			return
+		}
+
 		// Return 500 if service call fails
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Internal server error",
@@ -50,8 +77,9 @@ func (h *ClientHandler) GetClients(c *gin.Context) {
 
 	// Return 200 with clients array in SuccessResponse format
 	c.JSON(http.StatusOK, SuccessResponse{
-		Message: "Clients retrieved successfully",
-		Data:    clients,
+		Message:    "Clients retrieved successfully",
+		Data:       clients,
+		Pagination: page,
 	})
 }
 
@@ -90,11 +118,10 @@ func (h *ClientHandler) CreateClient(c *gin.Context) {
 	}
 
 	// Call clientService.CreateClient
-	client, err := h.clientService.CreateClient(&req, brokerID.(string))
+	client, warnings, err := h.clientService.CreateClient(&req, brokerID.(string))
 	if err != nil {
-		// Return 400 for business logic errors (budget validation)
-		if strings.Contains(err.Error(), "budget_min") ||
-			strings.Contains(err.Error(), "budget_max") {
+		// Return 400 for business logic errors (budget and requirement ranges)
+		if isClientValidationError(err) {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Error:   "Validation failed",
 				Message: err.Error(),
@@ -110,10 +137,11 @@ func (h *ClientHandler) CreateClient(c *gin.Context) {
 		return
 	}
 
-	// Return 201 with created client in SuccessResponse format
+	// Return 201 with created client and any likely duplicates in SuccessResponse format
 	c.JSON(http.StatusCreated, SuccessResponse{
-		Message: "Client created successfully",
-		Data:    client,
+		Message:  "Client created successfully",
+		Data:     client,
+		Warnings: clientDuplicateWarnings(warnings),
 	})
 }
 
@@ -200,7 +228,7 @@ func (h *ClientHandler) UpdateClient(c *gin.Context) {
 	}
 
 	// Call clientService.UpdateClient
-	client, err := h.clientService.UpdateClient(clientID, &req, brokerID.(string))
+	client, warnings, err := h.clientService.UpdateClient(clientID, &req, brokerID.(string))
 	if err != nil {
 		// Return 404 if client not found or ownership verification fails
 		if strings.Contains(err.Error(), "not found") ||
@@ -214,8 +242,7 @@ func (h *ClientHandler) UpdateClient(c *gin.Context) {
 		}
 
 		// Return 400 for business logic errors
-		if strings.Contains(err.Error(), "budget_min") ||
-			strings.Contains(err.Error(), "budget_max") {
+		if isClientValidationError(err) {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Error:   "Validation failed",
 				Message: err.Error(),
@@ -231,10 +258,11 @@ func (h *ClientHandler) UpdateClient(c *gin.Context) {
 		return
 	}
 
-	// Return 200 with updated client in SuccessResponse format
+	// Return 200 with updated client and any likely duplicates in SuccessResponse format
 	c.JSON(http.StatusOK, SuccessResponse{
-		Message: "Client updated successfully",
-		Data:    client,
+		Message:  "Client updated successfully",
+		Data:     client,
+		Warnings: clientDuplicateWarnings(warnings),
 	})
 }
 
@@ -280,4 +308,72 @@ func (h *ClientHandler) DeleteClient(c *gin.Context) {
 	c.JSON(http.StatusOK, SuccessResponse{
 		Message: "Client deleted successfully",
 	})
-}
\ No newline at end of file
+}
+
+// GetSourceSummary handles GET /api/clients/sources - counts clients per lead source and campaign
+// Query parameters: created_from, created_to (YYYY-MM-DD, inclusive)
+func (h *ClientHandler) GetSourceSummary(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.ClientSourceSummaryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	summary, err := h.clientService.GetSourceSummary(brokerID.(string), &req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid query parameters",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve lead sources",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Lead sources retrieved successfully",
+		Data:    summary,
+	})
+}
+
+// clientDuplicateWarnings returns warnings for the response, or nil so the field is omitted when empty
+func clientDuplicateWarnings(warnings []models.ClientDuplicateWarning) interface{} {
+	if len(warnings) == 0 {
+		return nil
+	}
+	return warnings
+}
+
+// isClientValidationError reports whether a client service error is a business validation failure
+func isClientValidationError(err error) bool {
+	return strings.HasPrefix(err.Error(), "budget_min") ||
+		strings.HasPrefix(err.Error(), "budget_max") ||
+		strings.HasPrefix(err.Error(), "bedrooms_min") ||
+		strings.HasPrefix(err.Error(), "area_min")
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ClientImportHandler handles HTTP requests for importing and exporting clients
+type ClientImportHandler struct {
+	clientImportService *services.ClientImportService
+	validator           *validator.Validate
+}
+
+// NewClientImportHandler creates a new ClientImportHandler instance
+func NewClientImportHandler(clientImportService *services.ClientImportService) *ClientImportHandler {
+	return &ClientImportHandler{
+		clientImportService: clientImportService,
+		validator:           validator.New(),
+	}
+}
+
+// StartImport handles POST /api/client-imports - uploads a CSV or vCard file to import as clients
+// Form fields: file, format, duplicate_policy, default_type, mapping
+// Rows are imported in the background; poll GET /api/client-imports/:id for progress and the report
+func (h *ClientImportHandler) StartImport(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	// Bound the request body so oversized uploads are rejected before they are buffered
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.clientImportService.MaxFileSize()+multipartOverhead)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "File too large",
+				Message: fmt.Sprintf("Import files must be at most %d MB", h.clientImportService.MaxFileSize()/1024/1024),
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "No file provided",
+			Message: "Please provide a CSV or vCard file",
+		})
+		return
+	}
+	defer file.Close()
+
+	var req models.ClientImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	clientImport, err := h.clientImportService.StartImport(&req, file, header.Filename, brokerID.(string))
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Validation failed",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to start client import",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, SuccessResponse{
+		Message: "Client import started",
+		Data:    clientImport,
+	})
+}
+
+// GetImports handles GET /api/client-imports - lists the broker's recent imports
+func (h *ClientImportHandler) GetImports(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	imports, err := h.clientImportService.GetImports(brokerID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve client imports",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client imports retrieved successfully",
+		Data:    imports,
+	})
+}
+
+// GetImport handles GET /api/client-imports/:id - retrieves an import's progress and per-row report
+func (h *ClientImportHandler) GetImport(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	clientImport, err := h.clientImportService.GetImport(c.Param("id"), brokerID.(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") ||
+			strings.Contains(err.Error(), "access denied") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Client import not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve client import",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client import retrieved successfully",
+		Data:    clientImport,
+	})
+}
+
+// ExportClients handles GET /api/clients/export - downloads the broker's clients as CSV or vCard
+// Accepts the client list filters, search and sort; paging parameters are ignored
+func (h *ClientImportHandler) ExportClients(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.ClientExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	content, extension, err := h.clientImportService.ExportClients(brokerID.(string), &req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid query parameters",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to export clients",
+		})
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if extension == "vcf" {
+		contentType = "text/vcard; charset=utf-8"
+	}
+
+	fileName := fmt.Sprintf("clients-%s.%s", time.Now().Format("2006-01-02"), extension)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Data(http.StatusOK, contentType, content)
+}
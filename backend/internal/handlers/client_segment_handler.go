@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ClientSegmentHandler handles HTTP requests for saved client segments
+type ClientSegmentHandler struct {
+	segmentService *services.ClientSegmentService
+	validator      *validator.Validate
+}
+
+// NewClientSegmentHandler creates a new ClientSegmentHandler instance
+func NewClientSegmentHandler(segmentService *services.ClientSegmentService) *ClientSegmentHandler {
+	return &ClientSegmentHandler{
+		segmentService: segmentService,
+		validator:      validator.New(),
+	}
+}
+
+// GetSegments handles GET /api/client-segments - retrieves the broker's client segments
+func (h *ClientSegmentHandler) GetSegments(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	segments, err := h.segmentService.GetSegments(brokerID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve client segments",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client segments retrieved successfully",
+		Data:    segments,
+	})
+}
+
+// CreateSegment handles POST /api/client-segments - saves a named set of client list filters
+func (h *ClientSegmentHandler) CreateSegment(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CreateClientSegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	segment, err := h.segmentService.CreateSegment(&req, brokerID.(string))
+	if err != nil {
+		writeClientSegmentError(c, err, "Failed to create client segment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Client segment created successfully",
+		Data:    segment,
+	})
+}
+
+// GetSegment handles GET /api/client-segments/:id - retrieves a specific client segment
+func (h *ClientSegmentHandler) GetSegment(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	segment, err := h.segmentService.GetSegment(c.Param("id"), brokerID.(string))
+	if err != nil {
+		writeClientSegmentError(c, err, "Failed to retrieve client segment")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client segment retrieved successfully",
+		Data:    segment,
+	})
+}
+
+// UpdateSegment handles PUT /api/client-segments/:id - updates a client segment's name, description or filters
+func (h *ClientSegmentHandler) UpdateSegment(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdateClientSegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	segment, err := h.segmentService.UpdateSegment(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		writeClientSegmentError(c, err, "Failed to update client segment")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client segment updated successfully",
+		Data:    segment,
+	})
+}
+
+// DeleteSegment handles DELETE /api/client-segments/:id - deletes a client segment
+// The clients in it are not affected
+func (h *ClientSegmentHandler) DeleteSegment(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.segmentService.DeleteSegment(c.Param("id"), brokerID.(string)); err != nil {
+		writeClientSegmentError(c, err, "Failed to delete client segment")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client segment deleted successfully",
+	})
+}
+
+// writeClientSegmentError maps a client segment service error to its response
+func writeClientSegmentError(c *gin.Context, err error, fallback string) {
+	message := err.Error()
+
+	switch {
+	case strings.HasPrefix(message, "invalid"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	case strings.Contains(message, "not found") ||
+		strings.Contains(message, "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Client segment not found",
+		})
+	case strings.Contains(message, "already exists"):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Conflict",
+			Message: message,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: fallback,
+		})
+	}
+}
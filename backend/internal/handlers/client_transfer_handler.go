@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ClientTransferHandler handles HTTP requests for handing clients between brokers
+type ClientTransferHandler struct {
+	clientTransferService *services.ClientTransferService
+	validator             *validator.Validate
+}
+
+// NewClientTransferHandler creates a new ClientTransferHandler instance
+func NewClientTransferHandler(clientTransferService *services.ClientTransferService) *ClientTransferHandler {
+	return &ClientTransferHandler{
+		clientTransferService: clientTransferService,
+		validator:             validator.New(),
+	}
+}
+
+// CreateTransfer handles POST /api/client-transfers - offers some of the broker's clients to another broker
+func (h *ClientTransferHandler) CreateTransfer(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CreateClientTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	transfer, err := h.clientTransferService.CreateTransfer(&req, brokerID.(string))
+	if err != nil {
+		writeClientTransferError(c, err, "Failed to create client transfer")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Client transfer created successfully",
+		Data:    transfer,
+	})
+}
+
+// CreateAdminTransfer handles POST /api/admin/client-transfers - offers a broker's clients to another broker
+// on their behalf, for brokers who have left
+func (h *ClientTransferHandler) CreateAdminTransfer(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.AdminClientTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	transfer, err := h.clientTransferService.CreateAdminTransfer(&req, adminID.(string))
+	if err != nil {
+		writeClientTransferError(c, err, "Failed to create client transfer")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Client transfer created successfully",
+		Data:    transfer,
+	})
+}
+
+// GetTransfers handles GET /api/client-transfers - retrieves transfers the user gives, receives or proposed
+// Optional query parameters: direction (incoming, outgoing), status
+func (h *ClientTransferHandler) GetTransfers(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var filters models.ClientTransferFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	transfers, err := h.clientTransferService.GetTransfers(userID.(string), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve client transfers",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client transfers retrieved successfully",
+		Data:    transfers,
+	})
+}
+
+// GetTransfer handles GET /api/client-transfers/:id - retrieves a transfer with its offered clients
+func (h *ClientTransferHandler) GetTransfer(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	transfer, err := h.clientTransferService.GetTransfer(c.Param("id"), userID.(string))
+	if err != nil {
+		writeClientTransferError(c, err, "Failed to retrieve client transfer")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client transfer retrieved successfully",
+		Data:    transfer,
+	})
+}
+
+// AcceptTransfer handles POST /api/client-transfers/:id/accept - moves the offered clients into the broker's book
+func (h *ClientTransferHandler) AcceptTransfer(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	transfer, err := h.clientTransferService.AcceptTransfer(c.Param("id"), brokerID.(string))
+	if err != nil {
+		writeClientTransferError(c, err, "Failed to accept client transfer")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client transfer accepted successfully",
+		Data:    transfer,
+	})
+}
+
+// DeclineTransfer handles POST /api/client-transfers/:id/decline - turns down a transfer offered to the broker
+func (h *ClientTransferHandler) DeclineTransfer(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	transfer, err := h.clientTransferService.DeclineTransfer(c.Param("id"), brokerID.(string))
+	if err != nil {
+		writeClientTransferError(c, err, "Failed to decline client transfer")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client transfer declined successfully",
+		Data:    transfer,
+	})
+}
+
+// CancelTransfer handles POST /api/client-transfers/:id/cancel - withdraws a pending transfer
+func (h *ClientTransferHandler) CancelTransfer(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	transfer, err := h.clientTransferService.CancelTransfer(c.Param("id"), userID.(string))
+	if err != nil {
+		writeClientTransferError(c, err, "Failed to cancel client transfer")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client transfer cancelled successfully",
+		Data:    transfer,
+	})
+}
+
+// GetOwnershipHistory handles GET /api/clients/:id/ownership - retrieves a client's changes of broker
+func (h *ClientTransferHandler) GetOwnershipHistory(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	history, err := h.clientTransferService.GetOwnershipHistory(c.Param("id"), brokerID.(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") ||
+			strings.Contains(err.Error(), "access denied") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Client not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve client ownership history",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client ownership history retrieved successfully",
+		Data:    history,
+	})
+}
+
+// writeClientTransferError maps a client transfer service error to its response
+func writeClientTransferError(c *gin.Context, err error, fallback string) {
+	message := err.Error()
+
+	// Invalid references are checked first since they may mention clients that were not found
+	switch {
+	case strings.HasPrefix(message, "invalid"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	case strings.Contains(message, "not found") ||
+		strings.Contains(message, "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Client transfer not found",
+		})
+	case strings.HasPrefix(message, "cannot"):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Conflict",
+			Message: message,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: fallback,
+		})
+	}
+}
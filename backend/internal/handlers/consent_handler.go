@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ConsentHandler handles HTTP requests for client contact consent and the do-not-contact registry
+type ConsentHandler struct {
+	consentService *services.ConsentService
+	validator      *validator.Validate
+}
+
+// NewConsentHandler creates a new ConsentHandler instance
+func NewConsentHandler(consentService *services.ConsentService) *ConsentHandler {
+	return &ConsentHandler{
+		consentService: consentService,
+		validator:      validator.New(),
+	}
+}
+
+// GetClientConsents handles GET /api/clients/:id/consents - retrieves a client's consent per channel and its history
+func (h *ConsentHandler) GetClientConsents(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	consents, err := h.consentService.GetClientConsents(c.Param("id"), brokerID.(string))
+	if err != nil {
+		writeConsentError(c, err, "Failed to retrieve client consent")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client consent retrieved successfully",
+		Data:    consents,
+	})
+}
+
+// RecordClientConsent handles POST /api/clients/:id/consents - records a client granting or withdrawing consent
+func (h *ConsentHandler) RecordClientConsent(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.RecordConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	consents, err := h.consentService.RecordClientConsent(c.Param("id"), brokerID.(string), &req)
+	if err != nil {
+		writeConsentError(c, err, "Failed to record client consent")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Client consent recorded successfully",
+		Data:    consents,
+	})
+}
+
+// GetRegistry handles GET /api/do-not-contact - lists the contacts that have opted out, optionally on one channel
+func (h *ConsentHandler) GetRegistry(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var filters models.DoNotContactFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	consents, err := h.consentService.GetRegistry(brokerID.(string), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve do-not-contact registry",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Do-not-contact registry retrieved successfully",
+		Data:    consents,
+	})
+}
+
+// AddToRegistry handles POST /api/do-not-contact - opts a phone number and/or email out of contact
+func (h *ConsentHandler) AddToRegistry(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.DoNotContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	consents, err := h.consentService.AddToRegistry(brokerID.(string), &req)
+	if err != nil {
+		writeConsentError(c, err, "Failed to add to do-not-contact registry")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Added to do-not-contact registry successfully",
+		Data:    consents,
+	})
+}
+
+// RenderOptOut handles GET /opt-out/:clientId - public page asking a client to confirm opting out
+func (h *ConsentHandler) RenderOptOut(c *gin.Context) {
+	page := optOutPage{
+		Expires:   c.Query("expires"),
+		Signature: c.Query("signature"),
+	}
+
+	client, err := h.consentService.OpenOptOut(c.Param("clientId"), page.Expires, page.Signature)
+	if err != nil {
+		page.Invalid = true
+	} else if client.BrokerName != nil {
+		page.BrokerName = *client.BrokerName
+	}
+
+	h.renderOptOutPage(c, page, err)
+}
+
+// OptOut handles POST /opt-out/:clientId - records a client's opt-out from every channel
+func (h *ConsentHandler) OptOut(c *gin.Context) {
+	page := optOutPage{Done: true}
+
+	client, err := h.consentService.OptOut(c.Param("clientId"), c.PostForm("expires"), c.PostForm("signature"))
+	if err != nil {
+		page.Done = false
+		page.Invalid = true
+	} else if client.BrokerName != nil {
+		page.BrokerName = *client.BrokerName
+	}
+
+	h.renderOptOutPage(c, page, err)
+}
+
+// renderOptOutPage writes the opt-out page with a status matching err
+func (h *ConsentHandler) renderOptOutPage(c *gin.Context, page optOutPage, err error) {
+	status := http.StatusOK
+	switch {
+	case err == nil:
+	case strings.Contains(err.Error(), "signed url"):
+		status = http.StatusForbidden
+	case strings.Contains(err.Error(), "not found"):
+		status = http.StatusNotFound
+	default:
+		log.Printf("Failed to process opt-out link: %v", err)
+		status = http.StatusInternalServerError
+	}
+
+	var buf bytes.Buffer
+	if err := optOutPageTemplate.Execute(&buf, page); err != nil {
+		log.Printf("Failed to render opt-out page: %v", err)
+		c.String(http.StatusInternalServerError, "Failed to render page")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(status, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// writeConsentError maps a consent service error to its response
+func writeConsentError(c *gin.Context, err error, fallback string) {
+	message := err.Error()
+
+	switch {
+	case strings.HasPrefix(message, "invalid"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	case strings.Contains(message, "not found") ||
+		strings.Contains(message, "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Client not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: fallback,
+		})
+	}
+}
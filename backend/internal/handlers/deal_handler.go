@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// DealHandler handles HTTP requests for deals, their brokerage milestones and commission reports
+type DealHandler struct {
+	dealService *services.DealService
+	validator   *validator.Validate
+}
+
+// NewDealHandler creates a new DealHandler instance
+func NewDealHandler(dealService *services.DealService) *DealHandler {
+	return &DealHandler{
+		dealService: dealService,
+		validator:   validator.New(),
+	}
+}
+
+// GetDeals handles GET /api/deals - retrieves the deals the user owns or is the partner on
+func (h *DealHandler) GetDeals(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var filters models.DealFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	deals, err := h.dealService.GetDeals(userID.(string), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve deals",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Deals retrieved successfully",
+		Data:    deals,
+	})
+}
+
+// CreateDeal handles POST /api/deals - records a deal for one of the broker's clients
+func (h *DealHandler) CreateDeal(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CreateDealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	deal, err := h.dealService.CreateDeal(&req, brokerID.(string))
+	if err != nil {
+		writeDealError(c, err, "Failed to create deal")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Deal created successfully",
+		Data:    deal,
+	})
+}
+
+// GetCommissionReport handles GET /api/deals/commission-report - the user's brokerage earned and due per month
+func (h *DealHandler) GetCommissionReport(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CommissionReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	report, err := h.dealService.GetCommissionReport(userID.(string), &req)
+	if err != nil {
+		writeDealError(c, err, "Failed to build commission report")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Commission report retrieved successfully",
+		Data:    report,
+	})
+}
+
+// GetDeal handles GET /api/deals/:id - retrieves a deal the user owns or is the partner on
+func (h *DealHandler) GetDeal(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	deal, err := h.dealService.GetDeal(c.Param("id"), userID.(string))
+	if err != nil {
+		writeDealError(c, err, "Failed to retrieve deal")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Deal retrieved successfully",
+		Data:    deal,
+	})
+}
+
+// UpdateDeal handles PUT /api/deals/:id - updates a deal's terms or progress
+func (h *DealHandler) UpdateDeal(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdateDealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	deal, err := h.dealService.UpdateDeal(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		writeDealError(c, err, "Failed to update deal")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Deal updated successfully",
+		Data:    deal,
+	})
+}
+
+// DeleteDeal handles DELETE /api/deals/:id - deletes a deal and its milestones
+func (h *DealHandler) DeleteDeal(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.dealService.DeleteDeal(c.Param("id"), brokerID.(string)); err != nil {
+		writeDealError(c, err, "Failed to delete deal")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Deal deleted successfully",
+	})
+}
+
+// AddMilestone handles POST /api/deals/:id/milestones - adds a brokerage installment to a deal
+func (h *DealHandler) AddMilestone(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.DealMilestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	deal, err := h.dealService.AddMilestone(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		writeDealError(c, err, "Failed to add deal milestone")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Deal milestone added successfully",
+		Data:    deal,
+	})
+}
+
+// UpdateMilestone handles PUT /api/deals/:id/milestones/:milestoneId - changes an installment or marks it received
+func (h *DealHandler) UpdateMilestone(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdateDealMilestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	deal, err := h.dealService.UpdateMilestone(c.Param("id"), c.Param("milestoneId"), &req, brokerID.(string))
+	if err != nil {
+		writeDealError(c, err, "Failed to update deal milestone")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Deal milestone updated successfully",
+		Data:    deal,
+	})
+}
+
+// DeleteMilestone handles DELETE /api/deals/:id/milestones/:milestoneId - removes an installment from a deal
+func (h *DealHandler) DeleteMilestone(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	deal, err := h.dealService.DeleteMilestone(c.Param("id"), c.Param("milestoneId"), brokerID.(string))
+	if err != nil {
+		writeDealError(c, err, "Failed to delete deal milestone")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Deal milestone deleted successfully",
+		Data:    deal,
+	})
+}
+
+// writeDealError maps a deal service error to its response
+func writeDealError(c *gin.Context, err error, fallback string) {
+	message := err.Error()
+
+	switch {
+	case strings.HasPrefix(message, "invalid"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	case strings.HasPrefix(message, "forbidden"):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "Forbidden",
+			Message: "Only the deal's broker can change it",
+		})
+	case message == "deal milestone not found":
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Deal milestone not found",
+		})
+	case strings.Contains(message, "not found") ||
+		strings.Contains(message, "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Deal not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: fallback,
+		})
+	}
+}
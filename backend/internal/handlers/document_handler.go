@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// multipartOverhead is allowance for form fields and multipart framing on top of the file itself
+const multipartOverhead = 1 << 20
+
+// DocumentHandler handles HTTP requests for the documents vault
+type DocumentHandler struct {
+	documentService *services.DocumentService
+	validator       *validator.Validate
+}
+
+// NewDocumentHandler creates a new DocumentHandler instance
+func NewDocumentHandler(documentService *services.DocumentService) *DocumentHandler {
+	return &DocumentHandler{
+		documentService: documentService,
+		validator:       validator.New(),
+	}
+}
+
+// GetDocuments handles GET /api/documents - retrieves the broker's documents
+// Supports filtering by property_id, client_id, document_type, and visibility
+func (h *DocumentHandler) GetDocuments(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var filters models.DocumentFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid filters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	documents, err := h.documentService.GetBrokerDocuments(brokerID.(string), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve documents",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Documents retrieved successfully",
+		Data:    documents,
+	})
+}
+
+// UploadDocument handles POST /api/documents - uploads a document as multipart form data
+// Form fields: file, document_type, property_id and/or client_id, title, visibility
+func (h *DocumentHandler) UploadDocument(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	// Bound the request body so oversized uploads are rejected before they are buffered
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.documentService.MaxFileSize()+multipartOverhead)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "File too large",
+				Message: fmt.Sprintf("Documents must be at most %d MB", h.documentService.MaxFileSize()/1024/1024),
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "No file provided",
+			Message: "Please provide a document file",
+		})
+		return
+	}
+	defer file.Close()
+
+	var req models.UploadDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	document, err := h.documentService.UploadDocument(&req, file, header.Filename, brokerID.(string))
+	if err != nil {
+		h.respondDocumentError(c, err, "Failed to upload document")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Document uploaded successfully",
+		Data:    document,
+	})
+}
+
+// GetDocument handles GET /api/documents/:id - retrieves a specific document's metadata
+func (h *DocumentHandler) GetDocument(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	document, err := h.documentService.GetDocumentByID(c.Param("id"), brokerID.(string))
+	if err != nil {
+		h.respondDocumentError(c, err, "Failed to retrieve document")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Document retrieved successfully",
+		Data:    document,
+	})
+}
+
+// UpdateDocument handles PUT /api/documents/:id - updates a document's type, title, or visibility
+func (h *DocumentHandler) UpdateDocument(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdateDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	document, err := h.documentService.UpdateDocument(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		h.respondDocumentError(c, err, "Failed to update document")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Document updated successfully",
+		Data:    document,
+	})
+}
+
+// DeleteDocument handles DELETE /api/documents/:id - deletes a document and its file
+func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.documentService.DeleteDocument(c.Param("id"), brokerID.(string)); err != nil {
+		h.respondDocumentError(c, err, "Failed to delete document")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Document deleted successfully",
+	})
+}
+
+// GetDownloadURL handles GET /api/documents/:id/download-url - issues a short-lived signed download URL
+func (h *DocumentHandler) GetDownloadURL(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	download, err := h.documentService.CreateDownloadURL(c.Param("id"), brokerID.(string))
+	if err != nil {
+		h.respondDocumentError(c, err, "Failed to create download URL")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Download URL created successfully",
+		Data:    download,
+	})
+}
+
+// SendToClient handles POST /api/documents/:id/send - emails a shareable document to its client
+func (h *DocumentHandler) SendToClient(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	download, err := h.documentService.SendToClient(c.Param("id"), brokerID.(string))
+	if err != nil {
+		h.respondDocumentError(c, err, "Failed to send document")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Document sent to client successfully",
+		Data:    download,
+	})
+}
+
+// DownloadDocument handles GET /api/documents/:id/download - serves a document behind a signed URL
+// This route is public; the expires and signature query parameters authorize the request
+func (h *DocumentHandler) DownloadDocument(c *gin.Context) {
+	document, filePath, err := h.documentService.OpenSignedDownload(
+		c.Param("id"),
+		c.Query("scope"),
+		c.Query("expires"),
+		c.Query("signature"),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "signed url") {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "Forbidden",
+				Message: "Download link is invalid or has expired",
+			})
+			return
+		}
+
+		h.respondDocumentError(c, err, "Failed to download document")
+		return
+	}
+
+	if _, err := os.Stat(filePath); err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "File not found",
+		})
+		return
+	}
+
+	c.Header("Content-Type", document.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, no-store")
+	c.FileAttachment(filePath, document.OriginalName)
+}
+
+// respondDocumentError maps document service errors to HTTP responses
+func (h *DocumentHandler) respondDocumentError(c *gin.Context, err error, failureMessage string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid") ||
+		strings.Contains(err.Error(), "unsupported file type") ||
+		strings.Contains(err.Error(), "not shareable") ||
+		strings.Contains(err.Error(), "not filed under"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+	case strings.Contains(err.Error(), "file too large"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "File too large",
+			Message: err.Error(),
+		})
+	case strings.Contains(err.Error(), "not found") ||
+		strings.Contains(err.Error(), "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Document not found",
+		})
+	case strings.HasPrefix(err.Error(), "cannot"):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Conflict",
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: failureMessage,
+		})
+	}
+}
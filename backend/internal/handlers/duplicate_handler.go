@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// DuplicateHandler handles HTTP requests for duplicate listing review
+type DuplicateHandler struct {
+	duplicateService *services.DuplicateService
+	validator        *validator.Validate
+}
+
+// NewDuplicateHandler creates a new DuplicateHandler instance
+func NewDuplicateHandler(duplicateService *services.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{
+		duplicateService: duplicateService,
+		validator:        validator.New(),
+	}
+}
+
+// GetDuplicateClusters handles GET /api/admin/property-duplicates - retrieves duplicate listing clusters
+// Optional query parameter status (pending, confirmed, dismissed) defaults to pending
+func (h *DuplicateHandler) GetDuplicateClusters(c *gin.Context) {
+	status := c.DefaultQuery("status", "pending")
+	if status != "pending" && status != "confirmed" && status != "dismissed" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: "status must be one of: pending confirmed dismissed",
+		})
+		return
+	}
+
+	clusters, err := h.duplicateService.GetDuplicateClusters(status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve duplicate listings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Duplicate listings retrieved successfully",
+		Data:    clusters,
+	})
+}
+
+// ReviewDuplicate handles PUT /api/admin/property-duplicates/:id - confirms or dismisses a duplicate pair
+func (h *DuplicateHandler) ReviewDuplicate(c *gin.Context) {
+	// Extract admin id from gin context (set by auth middleware)
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.ReviewDuplicateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	duplicate, err := h.duplicateService.ReviewDuplicate(c.Param("id"), &req, adminID.(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Duplicate pair not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to review duplicate pair",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Duplicate pair reviewed successfully",
+		Data:    duplicate,
+	})
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// LeadFormHandler handles HTTP requests for brokers' lead capture forms and public lead submissions
+type LeadFormHandler struct {
+	leadFormService *services.LeadFormService
+	validator       *validator.Validate
+}
+
+// NewLeadFormHandler creates a new LeadFormHandler instance
+func NewLeadFormHandler(leadFormService *services.LeadFormService) *LeadFormHandler {
+	return &LeadFormHandler{
+		leadFormService: leadFormService,
+		validator:       validator.New(),
+	}
+}
+
+// GetLeadForm handles GET /api/lead-form - retrieves the broker's lead form and its submission URL
+func (h *LeadFormHandler) GetLeadForm(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	form, err := h.leadFormService.GetLeadForm(brokerID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve lead form",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Lead form retrieved successfully",
+		Data:    form,
+	})
+}
+
+// UpdateLeadForm handles PUT /api/lead-form - enables or disables the form and sets the default client type
+func (h *LeadFormHandler) UpdateLeadForm(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdateLeadFormRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	form, err := h.leadFormService.UpdateLeadForm(&req, brokerID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to update lead form",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Lead form updated successfully",
+		Data:    form,
+	})
+}
+
+// RotateLeadFormKey handles POST /api/lead-form/rotate-key - replaces the form key, retiring the old submission URL
+func (h *LeadFormHandler) RotateLeadFormKey(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	form, err := h.leadFormService.RotateLeadFormKey(brokerID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to rotate lead form key",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Lead form key rotated successfully",
+		Data:    form,
+	})
+}
+
+// SubmitLead handles POST /api/leads/:formKey - public lead submission from a broker's website
+// Accepts JSON or form-encoded fields; rate limited per IP address
+func (h *LeadFormHandler) SubmitLead(c *gin.Context) {
+	var req models.LeadSubmission
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.leadFormService.SubmitLead(c.Param("formKey"), &req); err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Validation failed",
+				Message: strings.TrimPrefix(err.Error(), "invalid lead: "),
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "This form is no longer accepting enquiries",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to submit enquiry",
+		})
+		return
+	}
+
+	// Dropped spam gets the same response as an accepted lead
+	c.JSON(http.StatusAccepted, SuccessResponse{
+		Message: "Thank you, we'll be in touch soon",
+	})
+}
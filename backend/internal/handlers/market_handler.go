@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// MarketHandler handles HTTP requests for city-level market analytics
+type MarketHandler struct {
+	marketService *services.MarketService
+	validator     *validator.Validate
+}
+
+// NewMarketHandler creates a new MarketHandler instance
+func NewMarketHandler(marketService *services.MarketService) *MarketHandler {
+	return &MarketHandler{
+		marketService: marketService,
+		validator:     validator.New(),
+	}
+}
+
+// GetCities handles GET /api/market/cities - cities with enough data for market analytics
+func (h *MarketHandler) GetCities(c *gin.Context) {
+	cities, err := h.marketService.GetCities()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve market cities",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Market cities retrieved successfully",
+		Data:    cities,
+	})
+}
+
+// GetMarketInsights handles GET /api/market/insights - aggregated market analytics for a city
+func (h *MarketHandler) GetMarketInsights(c *gin.Context) {
+	var req models.MarketInsightsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid filters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	insights, err := h.marketService.GetMarketInsights(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve market insights",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Market insights retrieved successfully",
+		Data:    insights,
+	})
+}
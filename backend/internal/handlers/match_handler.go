@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MatchHandler handles HTTP requests for property/client matches
+type MatchHandler struct {
+	matchService *services.MatchService
+}
+
+// NewMatchHandler creates a new MatchHandler instance
+func NewMatchHandler(matchService *services.MatchService) *MatchHandler {
+	return &MatchHandler{
+		matchService: matchService,
+	}
+}
+
+// GetClientMatches handles GET /api/clients/:id/matches - retrieves properties matching a client
+func (h *MatchHandler) GetClientMatches(c *gin.Context) {
+	// Extract broker_id from gin context (set by auth middleware)
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	matches, err := h.matchService.GetClientMatches(c.Param("id"), brokerID.(string))
+	if err != nil {
+		// Return 404 if client not found or ownership verification fails
+		if strings.Contains(err.Error(), "not found") ||
+			strings.Contains(err.Error(), "access denied") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Client not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve client matches",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client matches retrieved successfully",
+		Data:    matches,
+	})
+}
+
+// GetPropertyMatches handles GET /api/properties/:id/matches - retrieves clients matching a property
+func (h *MatchHandler) GetPropertyMatches(c *gin.Context) {
+	// Extract broker_id from gin context (set by auth middleware)
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	matches, err := h.matchService.GetPropertyMatches(c.Param("id"), brokerID.(string))
+	if err != nil {
+		// Return 404 if property not found or ownership verification fails
+		if strings.Contains(err.Error(), "not found") ||
+			strings.Contains(err.Error(), "access denied") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Property not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve property matches",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Property matches retrieved successfully",
+		Data:    matches,
+	})
+}
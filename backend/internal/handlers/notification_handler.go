@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler handles HTTP requests for in-app notifications
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetNotifications handles GET /api/notifications - retrieves the user's notifications
+// Optional query parameters: unread=true, limit
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	filters := models.NotificationFilters{
+		UnreadOnly: c.Query("unread") == "true",
+	}
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value <= 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid query parameters",
+				Message: "limit must be a positive whole number",
+			})
+			return
+		}
+		filters.Limit = value
+	}
+
+	notifications, unread, err := h.notificationService.GetUserNotifications(userID.(string), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve notifications",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Notifications retrieved successfully",
+		Data: gin.H{
+			"notifications": notifications,
+			"unread_count":  unread,
+		},
+	})
+}
+
+// MarkNotificationRead handles PUT /api/notifications/:id/read - marks a notification as read
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.notificationService.MarkNotificationRead(c.Param("id"), userID.(string)); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Notification not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to update notification",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Notification marked as read",
+	})
+}
+
+// MarkAllNotificationsRead handles PUT /api/notifications/read-all - marks all notifications as read
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.notificationService.MarkAllNotificationsRead(userID.(string)); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to update notifications",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "All notifications marked as read",
+	})
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"html/template"
+)
+
+// optOutPage is the data rendered into the public opt-out page
+type optOutPage struct {
+	BrokerName string
+	Expires    string
+	Signature  string
+	Invalid    bool // the link is malformed, expired or for a client who no longer exists
+	Done       bool // the opt-out has been recorded
+}
+
+// optOutPageTemplate asks the client to confirm an opt-out before recording it, so link scanners
+// that follow URLs in emails don't opt clients out on their own
+var optOutPageTemplate = template.Must(template.New("opt-out").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Stop messages</title>
+<style>
+body{font-family:-apple-system,Segoe UI,Roboto,sans-serif;margin:0;background:#f5f6f8;color:#1f2933}
+main{max-width:480px;margin:0 auto;padding:48px 16px}
+.card{background:#fff;border-radius:12px;padding:24px;box-shadow:0 1px 3px rgba(0,0,0,.08)}
+h1{font-size:22px;margin:0 0 8px}
+.meta{color:#52606d}
+button{background:#9a3412;color:#fff;border:0;padding:10px 16px;border-radius:8px;font-size:16px;cursor:pointer}
+</style>
+</head>
+<body>
+<main>
+<div class="card">
+{{- if .Invalid}}
+<h1>Link unavailable</h1>
+<p class="meta">This link has expired or is no longer valid. Please contact your broker directly to stop messages.</p>
+{{- else if .Done}}
+<h1>You have been opted out</h1>
+<p class="meta">{{with .BrokerName}}{{.}}{{else}}Your broker{{end}} will no longer contact you by WhatsApp, SMS, email or phone.</p>
+{{- else}}
+<h1>Stop messages</h1>
+<p class="meta">Stop all WhatsApp, SMS, email and call contact from {{with .BrokerName}}{{.}}{{else}}your broker{{end}}?</p>
+<form method="post">
+<input type="hidden" name="expires" value="{{.Expires}}">
+<input type="hidden" name="signature" value="{{.Signature}}">
+<button type="submit">Stop all messages</button>
+</form>
+{{- end}}
+</div>
+</main>
+</body>
+</html>
+`))
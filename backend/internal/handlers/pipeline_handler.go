@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// PipelineHandler handles HTTP requests for the sales pipeline
+type PipelineHandler struct {
+	pipelineService *services.PipelineService
+	validator       *validator.Validate
+}
+
+// NewPipelineHandler creates a new PipelineHandler instance
+func NewPipelineHandler(pipelineService *services.PipelineService) *PipelineHandler {
+	return &PipelineHandler{
+		pipelineService: pipelineService,
+		validator:       validator.New(),
+	}
+}
+
+// GetStages handles GET /api/pipeline/stages - lists the broker's pipeline stages in board order
+func (h *PipelineHandler) GetStages(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	stages, err := h.pipelineService.GetStages(brokerID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve pipeline stages",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Pipeline stages retrieved successfully",
+		Data:    stages,
+	})
+}
+
+// CreateStage handles POST /api/pipeline/stages - adds a stage to the end of the pipeline
+func (h *PipelineHandler) CreateStage(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CreatePipelineStageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	stage, err := h.pipelineService.CreateStage(&req, brokerID.(string))
+	if err != nil {
+		h.respondPipelineError(c, err, "Pipeline stage not found", "Failed to create pipeline stage")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Pipeline stage created successfully",
+		Data:    stage,
+	})
+}
+
+// UpdateStage handles PUT /api/pipeline/stages/:id - renames a stage or changes its kind
+func (h *PipelineHandler) UpdateStage(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdatePipelineStageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	stage, err := h.pipelineService.UpdateStage(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		h.respondPipelineError(c, err, "Pipeline stage not found", "Failed to update pipeline stage")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Pipeline stage updated successfully",
+		Data:    stage,
+	})
+}
+
+// ReorderStages handles POST /api/pipeline/stages/reorder - sets the board order of every stage
+func (h *PipelineHandler) ReorderStages(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.ReorderPipelineStagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	stages, err := h.pipelineService.ReorderStages(&req, brokerID.(string))
+	if err != nil {
+		h.respondPipelineError(c, err, "Pipeline stage not found", "Failed to reorder pipeline stages")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Pipeline stages reordered successfully",
+		Data:    stages,
+	})
+}
+
+// DeleteStage handles DELETE /api/pipeline/stages/:id - removes an empty stage
+func (h *PipelineHandler) DeleteStage(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.pipelineService.DeleteStage(c.Param("id"), brokerID.(string)); err != nil {
+		h.respondPipelineError(c, err, "Pipeline stage not found", "Failed to delete pipeline stage")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Pipeline stage deleted successfully",
+	})
+}
+
+// GetBoard handles GET /api/pipeline/board - clients grouped by stage with counts and total budget
+func (h *PipelineHandler) GetBoard(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.PipelineBoardRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	board, err := h.pipelineService.GetBoard(&req, brokerID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve pipeline board",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Pipeline board retrieved successfully",
+		Data:    board,
+	})
+}
+
+// MoveClient handles PUT /api/clients/:id/stage - moves a client to another pipeline stage
+func (h *PipelineHandler) MoveClient(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.MoveClientStageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	client, transition, err := h.pipelineService.MoveClient(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		h.respondPipelineError(c, err, "Client not found", "Failed to move client")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client moved successfully",
+		Data: gin.H{
+			"client":     client,
+			"transition": transition,
+		},
+	})
+}
+
+// GetStageHistory handles GET /api/clients/:id/stage-history - the client's stage transitions, newest first
+func (h *PipelineHandler) GetStageHistory(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	transitions, err := h.pipelineService.GetStageHistory(c.Param("id"), brokerID.(string))
+	if err != nil {
+		h.respondPipelineError(c, err, "Client not found", "Failed to retrieve stage history")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Stage history retrieved successfully",
+		Data:    transitions,
+	})
+}
+
+// respondPipelineError maps pipeline service errors to HTTP responses
+// Invalid references are checked first since they wrap "not found" errors
+func (h *PipelineHandler) respondPipelineError(c *gin.Context, err error, notFoundMessage, failureMessage string) {
+	message := err.Error()
+
+	switch {
+	case strings.Contains(message, "invalid") ||
+		strings.Contains(message, "is required"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	case strings.Contains(message, "not found") ||
+		strings.Contains(message, "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: notFoundMessage,
+		})
+	case strings.HasPrefix(message, "cannot") ||
+		strings.Contains(message, "already exists"):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Conflict",
+			Message: message,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: failureMessage,
+		})
+	}
+}
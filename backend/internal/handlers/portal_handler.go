@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// PortalHandler handles HTTP requests for the client portal and the listings brokers share on it
+type PortalHandler struct {
+	portalService *services.PortalService
+	validator     *validator.Validate
+}
+
+// NewPortalHandler creates a new PortalHandler instance
+func NewPortalHandler(portalService *services.PortalService) *PortalHandler {
+	return &PortalHandler{
+		portalService: portalService,
+		validator:     validator.New(),
+	}
+}
+
+// RequestLink handles POST /api/portal/login - emails a client a sign-in link
+// The response is the same whether or not the address belongs to a client
+func (h *PortalHandler) RequestLink(c *gin.Context) {
+	var req models.PortalLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	if err := h.portalService.RequestLink(&req); err != nil {
+		log.Printf("Failed to send portal sign-in link: %v", err)
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "If this email belongs to a client, a sign-in link has been sent to it",
+	})
+}
+
+// StartSession handles POST /api/portal/session - exchanges a sign-in link's token for a portal session
+func (h *PortalHandler) StartSession(c *gin.Context) {
+	var req models.PortalSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	session, err := h.portalService.StartSession(&req)
+	if err != nil {
+		writePortalError(c, err, "Failed to sign in")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Signed in successfully",
+		Data:    session,
+	})
+}
+
+// GetProfile handles GET /api/portal/me - retrieves the signed-in client and their broker's contact details
+func (h *PortalHandler) GetProfile(c *gin.Context) {
+	clientID, exists := c.Get("client_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	profile, err := h.portalService.GetProfile(clientID.(string))
+	if err != nil {
+		writePortalError(c, err, "Failed to retrieve profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Profile retrieved successfully",
+		Data:    profile,
+	})
+}
+
+// GetAppointments handles GET /api/portal/appointments - retrieves the signed-in client's upcoming appointments
+func (h *PortalHandler) GetAppointments(c *gin.Context) {
+	clientID, exists := c.Get("client_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	appointments, err := h.portalService.GetAppointments(clientID.(string))
+	if err != nil {
+		writePortalError(c, err, "Failed to retrieve appointments")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Appointments retrieved successfully",
+		Data:    appointments,
+	})
+}
+
+// ConfirmAppointment handles POST /api/portal/appointments/:id/confirm - the client confirms they will attend
+func (h *PortalHandler) ConfirmAppointment(c *gin.Context) {
+	clientID, exists := c.Get("client_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	appointment, err := h.portalService.ConfirmAppointment(c.Param("id"), clientID.(string))
+	if err != nil {
+		writePortalError(c, err, "Failed to confirm appointment")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Appointment confirmed successfully",
+		Data:    appointment,
+	})
+}
+
+// CancelAppointment handles POST /api/portal/appointments/:id/cancel - the client cancels an appointment
+func (h *PortalHandler) CancelAppointment(c *gin.Context) {
+	clientID, exists := c.Get("client_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	// The reason is optional, so an empty body is accepted
+	var req models.PortalCancelRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request body",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	appointment, err := h.portalService.CancelAppointment(c.Param("id"), clientID.(string), &req)
+	if err != nil {
+		writePortalError(c, err, "Failed to cancel appointment")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Appointment cancelled successfully",
+		Data:    appointment,
+	})
+}
+
+// GetProperties handles GET /api/portal/properties - retrieves the listings shared with the signed-in client
+func (h *PortalHandler) GetProperties(c *gin.Context) {
+	clientID, exists := c.Get("client_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	properties, err := h.portalService.GetProperties(clientID.(string))
+	if err != nil {
+		writePortalError(c, err, "Failed to retrieve shared properties")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Shared properties retrieved successfully",
+		Data:    properties,
+	})
+}
+
+// GetSharedProperties handles GET /api/clients/:id/shared-properties - the listings shared with a client on the portal
+func (h *PortalHandler) GetSharedProperties(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	shared, err := h.portalService.GetSharedProperties(c.Param("id"), brokerID.(string))
+	if err != nil {
+		writePortalError(c, err, "Failed to retrieve shared properties")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Shared properties retrieved successfully",
+		Data:    shared,
+	})
+}
+
+// ShareProperties handles POST /api/clients/:id/shared-properties - shares the broker's listings with a client
+func (h *PortalHandler) ShareProperties(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.SharePropertiesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	shared, err := h.portalService.ShareProperties(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		writePortalError(c, err, "Failed to share properties")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Properties shared successfully",
+		Data:    shared,
+	})
+}
+
+// UnshareProperty handles DELETE /api/clients/:id/shared-properties/:propertyId - stops sharing a listing with a client
+func (h *PortalHandler) UnshareProperty(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.portalService.UnshareProperty(c.Param("id"), c.Param("propertyId"), brokerID.(string)); err != nil {
+		writePortalError(c, err, "Failed to unshare property")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Property unshared successfully",
+	})
+}
+
+// writePortalError maps a portal service error to its response
+func writePortalError(c *gin.Context, err error, fallback string) {
+	message := err.Error()
+
+	switch {
+	case message == "portal token not found":
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Invalid sign-in link",
+			Message: "This sign-in link is invalid, has already been used or has expired",
+		})
+	case strings.HasPrefix(message, "unauthorized"):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Portal access has ended",
+		})
+	case strings.HasPrefix(message, "invalid"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	case strings.HasPrefix(message, "cannot"):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Conflict",
+			Message: message,
+		})
+	case strings.HasPrefix(message, "appointment not found") ||
+		strings.Contains(message, "appointment belongs to another client"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Appointment not found",
+		})
+	case message == "shared property not found":
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Shared property not found",
+		})
+	case strings.Contains(message, "not found") ||
+		strings.Contains(message, "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Client not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: fallback,
+		})
+	}
+}
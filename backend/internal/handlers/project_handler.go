@@ -0,0 +1,478 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ProjectHandler handles HTTP requests for channel partner projects and unit inventory
+type ProjectHandler struct {
+	projectService *services.ProjectService
+	validator      *validator.Validate
+}
+
+// NewProjectHandler creates a new ProjectHandler instance
+func NewProjectHandler(projectService *services.ProjectService) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+		validator:      validator.New(),
+	}
+}
+
+// GetProjects handles GET /api/channel-partner/projects - retrieves the channel partner's projects
+// Optional query parameters: city, status, project_type
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	filters := parseProjectFilters(c)
+	if err := h.validator.Struct(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid filters",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	projects, err := h.projectService.GetChannelPartnerProjects(userID.(string), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve projects",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Projects retrieved successfully",
+		Data:    projects,
+	})
+}
+
+// CreateProject handles POST /api/channel-partner/projects - creates a new project
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CreateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	project, err := h.projectService.CreateProject(&req, userID.(string))
+	if err != nil {
+		h.respondProjectError(c, err, "Failed to create project")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Project created successfully",
+		Data:    project,
+	})
+}
+
+// GetProject handles GET /api/channel-partner/projects/:id - retrieves a project with its units
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	project, err := h.projectService.GetOwnedProjectDetail(c.Param("id"), userID.(string))
+	if err != nil {
+		h.respondProjectError(c, err, "Failed to retrieve project")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Project retrieved successfully",
+		Data:    project,
+	})
+}
+
+// UpdateProject handles PUT /api/channel-partner/projects/:id - updates a project
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	project, err := h.projectService.UpdateProject(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		h.respondProjectError(c, err, "Failed to update project")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Project updated successfully",
+		Data:    project,
+	})
+}
+
+// DeleteProject handles DELETE /api/channel-partner/projects/:id - deletes a project and its units
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.projectService.DeleteProject(c.Param("id"), userID.(string)); err != nil {
+		h.respondProjectError(c, err, "Failed to delete project")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Project deleted successfully",
+	})
+}
+
+// AddProjectUnits handles POST /api/channel-partner/projects/:id/units - adds a batch of units
+func (h *ProjectHandler) AddProjectUnits(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CreateProjectUnitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	units, err := h.projectService.AddProjectUnits(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		h.respondProjectError(c, err, "Failed to add project units")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Project units added successfully",
+		Data:    units,
+	})
+}
+
+// UpdateProjectUnit handles PUT /api/channel-partner/projects/:id/units/:unitId - updates a unit
+func (h *ProjectHandler) UpdateProjectUnit(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdateProjectUnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	unit, err := h.projectService.UpdateProjectUnit(c.Param("id"), c.Param("unitId"), &req, userID.(string))
+	if err != nil {
+		h.respondProjectError(c, err, "Failed to update project unit")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Project unit updated successfully",
+		Data:    unit,
+	})
+}
+
+// DeleteProjectUnit handles DELETE /api/channel-partner/projects/:id/units/:unitId - removes a unit
+func (h *ProjectHandler) DeleteProjectUnit(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.projectService.DeleteProjectUnit(c.Param("id"), c.Param("unitId"), userID.(string)); err != nil {
+		h.respondProjectError(c, err, "Failed to delete project unit")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Project unit deleted successfully",
+	})
+}
+
+// BrowseProjects handles GET /api/broker/projects - lists projects from all channel partners
+// Optional query parameters: city, status, project_type
+func (h *ProjectHandler) BrowseProjects(c *gin.Context) {
+	filters := parseProjectFilters(c)
+	if err := h.validator.Struct(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid filters",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	projects, err := h.projectService.BrowseProjects(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve projects",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Projects retrieved successfully",
+		Data:    projects,
+	})
+}
+
+// GetBrokerProject handles GET /api/broker/projects/:id - retrieves a project with its unit inventory
+// Optional query parameters: status, configuration, tower
+func (h *ProjectHandler) GetBrokerProject(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	filters := models.ProjectUnitFilters{}
+	if status := c.Query("status"); status != "" {
+		filters.Status = &status
+	}
+	if configuration := c.Query("configuration"); configuration != "" {
+		filters.Configuration = &configuration
+	}
+	if tower := c.Query("tower"); tower != "" {
+		filters.Tower = &tower
+	}
+	if err := h.validator.Struct(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid filters",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	project, err := h.projectService.GetProjectForBroker(c.Param("id"), userID.(string), filters)
+	if err != nil {
+		h.respondProjectError(c, err, "Failed to retrieve project")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Project retrieved successfully",
+		Data:    project,
+	})
+}
+
+// BlockUnit handles POST /api/broker/projects/:id/units/:unitId/block - blocks a unit for a client
+func (h *ProjectHandler) BlockUnit(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.BlockUnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	unit, err := h.projectService.BlockUnit(c.Param("id"), c.Param("unitId"), &req, userID.(string))
+	if err != nil {
+		h.respondProjectError(c, err, "Failed to block unit")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Unit blocked successfully",
+		Data:    unit,
+	})
+}
+
+// ReleaseUnit handles DELETE /api/broker/projects/:id/units/:unitId/block - releases the broker's block
+func (h *ProjectHandler) ReleaseUnit(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	unit, err := h.projectService.ReleaseUnit(c.Param("id"), c.Param("unitId"), userID.(string))
+	if err != nil {
+		h.respondProjectError(c, err, "Failed to release unit")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Unit released successfully",
+		Data:    unit,
+	})
+}
+
+// respondProjectError maps project service errors to HTTP responses
+func (h *ProjectHandler) respondProjectError(c *gin.Context, err error, failureMessage string) {
+	message := err.Error()
+
+	// Check invalid client_id first since it wraps a "not found" error
+	switch {
+	case strings.Contains(message, "invalid client_id"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	case strings.Contains(message, "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "project not found",
+		})
+	case strings.Contains(message, "not found"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: message,
+		})
+	case strings.Contains(message, "not available") ||
+		strings.Contains(message, "not blocked") ||
+		strings.Contains(message, "already exists"):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Conflict",
+			Message: message,
+		})
+	case strings.Contains(message, "does not belong") ||
+		strings.Contains(message, "cannot be greater than"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: failureMessage,
+		})
+	}
+}
+
+// parseProjectFilters reads project filters from query parameters
+func parseProjectFilters(c *gin.Context) models.ProjectFilters {
+	filters := models.ProjectFilters{}
+
+	if city := c.Query("city"); city != "" {
+		filters.City = &city
+	}
+	if status := c.Query("status"); status != "" {
+		filters.Status = &status
+	}
+	if projectType := c.Query("project_type"); projectType != "" {
+		filters.ProjectType = &projectType
+	}
+
+	return filters
+}
@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"enfor-data-backend/internal/models"
 	"enfor-data-backend/internal/services"
@@ -25,6 +28,7 @@ func NewPropertyHandler(propertyService *services.PropertyService) *PropertyHand
 }
 
 // GetProperties handles GET /api/properties - retrieves all properties for authenticated broker
+// Supports optional filters: type, listing_type, status, city, location, min/max_price, min/max_area, min/max_bedrooms
 func (h *PropertyHandler) GetProperties(c *gin.Context) {
 	// Extract broker_id from context (set by auth middleware)
 	brokerID, exists := c.Get("user_id")
@@ -36,8 +40,26 @@ func (h *PropertyHandler) GetProperties(c *gin.Context) {
 		return
 	}
 
+	// Parse and validate query parameters for filters
+	filters, err := parsePropertyFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
 	// Get properties from service
-	properties, err := h.propertyService.GetBrokerProperties(brokerID.(string))
+	properties, err := h.propertyService.GetBrokerProperties(brokerID.(string), filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Internal server error",
@@ -84,15 +106,10 @@ func (h *PropertyHandler) CreateProperty(c *gin.Context) {
 	}
 
 	// Create property through service
-	property, err := h.propertyService.CreateProperty(&req, brokerID.(string))
+	property, warnings, err := h.propertyService.CreateProperty(&req, brokerID.(string))
 	if err != nil {
 		// Check for specific business logic errors
-		if err.Error() == "bedrooms are required for property type 'apartment'" ||
-			err.Error() == "bedrooms are required for property type 'house'" ||
-			err.Error() == "bathrooms are required for property type 'apartment'" ||
-			err.Error() == "bathrooms are required for property type 'house'" ||
-			err.Error() == "bedrooms must be a positive number" ||
-			err.Error() == "bathrooms must be a positive number" {
+		if isPropertyValidationError(err) {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Error:   "Validation failed",
 				Message: err.Error(),
@@ -109,7 +126,275 @@ func (h *PropertyHandler) CreateProperty(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusCreated, SuccessResponse{
-		Message: "Property created successfully",
+		Message:  "Property created successfully",
+		Data:     property,
+		Warnings: duplicateWarnings(warnings),
+	})
+}
+
+// GetProperty handles GET /api/properties/:id - retrieves a specific property
+func (h *PropertyHandler) GetProperty(c *gin.Context) {
+	// Extract broker_id from context (set by auth middleware)
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	// Get property through service (verifies ownership and counts the detail view)
+	property, err := h.propertyService.ViewProperty(c.Param("id"), brokerID.(string))
+	if err != nil {
+		// Return 404 if property not found or ownership verification fails
+		if strings.Contains(err.Error(), "not found") ||
+			strings.Contains(err.Error(), "access denied") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Property not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve property",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Property retrieved successfully",
 		Data:    property,
 	})
-}
\ No newline at end of file
+}
+
+// UpdateProperty handles PUT /api/properties/:id - updates a specific property
+func (h *PropertyHandler) UpdateProperty(c *gin.Context) {
+	// Extract broker_id from context (set by auth middleware)
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	// Parse request body
+	var req models.UpdatePropertyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Validate request using go-playground/validator
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	// Update property through service
+	property, warnings, err := h.propertyService.UpdateProperty(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		// Return 404 if property not found or ownership verification fails
+		if strings.Contains(err.Error(), "not found") ||
+			strings.Contains(err.Error(), "access denied") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Property not found",
+			})
+			return
+		}
+
+		// Check for specific business logic errors
+		if isPropertyValidationError(err) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Validation failed",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to update property",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message:  "Property updated successfully",
+		Data:     property,
+		Warnings: duplicateWarnings(warnings),
+	})
+}
+
+// DeleteProperty handles DELETE /api/properties/:id - moves a property to the trash
+func (h *PropertyHandler) DeleteProperty(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.propertyService.DeleteProperty(c.Param("id"), brokerID.(string)); err != nil {
+		if strings.Contains(err.Error(), "not found") ||
+			strings.Contains(err.Error(), "access denied") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Property not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to delete property",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Property deleted successfully",
+	})
+}
+
+// RenewProperty handles POST /api/properties/:id/renew - extends a listing's expiry
+// Archived listings are put back on the market
+func (h *PropertyHandler) RenewProperty(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	// An empty body renews for the default listing lifetime
+	var req models.RenewPropertyRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request body",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	property, err := h.propertyService.RenewProperty(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") ||
+			strings.Contains(err.Error(), "access denied") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Not found",
+				Message: "Property not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to renew property",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Property renewed successfully",
+		Data:    property,
+	})
+}
+
+// isPropertyValidationError reports whether err is a property business rule violation
+func isPropertyValidationError(err error) bool {
+	return strings.HasPrefix(err.Error(), "bedrooms are required") ||
+		strings.HasPrefix(err.Error(), "bathrooms are required") ||
+		err.Error() == "bedrooms must be a positive number" ||
+		err.Error() == "bathrooms must be a positive number" ||
+		err.Error() == "expires_at must be in the future"
+}
+
+// duplicateWarnings returns warnings for the response, or nil so the field is omitted when empty
+func duplicateWarnings(warnings []models.DuplicateWarning) interface{} {
+	if len(warnings) == 0 {
+		return nil
+	}
+	return warnings
+}
+
+// parsePropertyFilters reads property search filters from query parameters
+func parsePropertyFilters(c *gin.Context) (models.PropertyFilters, error) {
+	filters := models.PropertyFilters{}
+
+	if propertyType := c.Query("type"); propertyType != "" {
+		filters.Type = &propertyType
+	}
+	if listingType := c.Query("listing_type"); listingType != "" {
+		filters.ListingType = &listingType
+	}
+	if status := c.Query("status"); status != "" {
+		filters.Status = &status
+	}
+	if city := c.Query("city"); city != "" {
+		filters.City = &city
+	}
+	if location := c.Query("location"); location != "" {
+		filters.Location = &location
+	}
+	if tags := c.QueryArray("tag"); len(tags) > 0 {
+		filters.Tags = tags // listings carrying every given tag
+	}
+
+	floatParams := map[string]**float64{
+		"min_price": &filters.MinPrice,
+		"max_price": &filters.MaxPrice,
+		"min_area":  &filters.MinArea,
+		"max_area":  &filters.MaxArea,
+	}
+	for name, target := range floatParams {
+		if raw := c.Query(name); raw != "" {
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return filters, fmt.Errorf("%s must be a number", name)
+			}
+			*target = &value
+		}
+	}
+
+	intParams := map[string]**int{
+		"min_bedrooms": &filters.MinBedrooms,
+		"max_bedrooms": &filters.MaxBedrooms,
+	}
+	for name, target := range intParams {
+		if raw := c.Query(name); raw != "" {
+			value, err := strconv.Atoi(raw)
+			if err != nil {
+				return filters, fmt.Errorf("%s must be a whole number", name)
+			}
+			*target = &value
+		}
+	}
+
+	return filters, nil
+}
@@ -10,8 +10,10 @@ type ErrorResponse struct {
 
 // SuccessResponse represents a success response
 type SuccessResponse struct {
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	Warnings   interface{} `json:"warnings,omitempty"`
+	Pagination interface{} `json:"pagination,omitempty"` // set for cursor-paginated lists
 }
 
 // formatValidationErrors formats validator errors into a readable message
@@ -38,6 +40,8 @@ func formatValidationErrors(err error) string {
 				return field + " must be one of: " + validationErrors[0].Param()
 			case "email":
 				return field + " must be a valid email address"
+			case "datetime":
+				return field + " must be a date in the format " + validationErrors[0].Param()
 			default:
 				return field + " validation failed"
 			}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// SavedSearchHandler handles HTTP requests for saved searches
+type SavedSearchHandler struct {
+	savedSearchService *services.SavedSearchService
+	validator          *validator.Validate
+}
+
+// NewSavedSearchHandler creates a new SavedSearchHandler instance
+func NewSavedSearchHandler(savedSearchService *services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		savedSearchService: savedSearchService,
+		validator:          validator.New(),
+	}
+}
+
+// GetSavedSearches handles GET /api/saved-searches - retrieves the user's saved searches
+// Optional query parameter client_id limits results to searches kept for that client
+func (h *SavedSearchHandler) GetSavedSearches(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var clientID *string
+	if id := c.Query("client_id"); id != "" {
+		clientID = &id
+	}
+
+	searches, err := h.savedSearchService.GetUserSavedSearches(userID.(string), clientID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve saved searches",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Saved searches retrieved successfully",
+		Data:    searches,
+	})
+}
+
+// CreateSavedSearch handles POST /api/saved-searches - creates a new saved search
+func (h *SavedSearchHandler) CreateSavedSearch(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	search, err := h.savedSearchService.CreateSavedSearch(&req, userID.(string))
+	if err != nil {
+		// Return 400 for business logic errors (invalid client, inverted ranges)
+		if strings.Contains(err.Error(), "invalid client_id") ||
+			strings.Contains(err.Error(), "does not belong") ||
+			strings.Contains(err.Error(), "cannot be greater than") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Validation failed",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to create saved search",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Saved search created successfully",
+		Data:    search,
+	})
+}
+
+// GetSavedSearch handles GET /api/saved-searches/:id - retrieves a specific saved search
+func (h *SavedSearchHandler) GetSavedSearch(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	search, err := h.savedSearchService.GetSavedSearchByID(c.Param("id"), userID.(string))
+	if err != nil {
+		h.respondLookupError(c, err, "Failed to retrieve saved search")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Saved search retrieved successfully",
+		Data:    search,
+	})
+}
+
+// GetSavedSearchResults handles GET /api/saved-searches/:id/results - runs a saved search now
+func (h *SavedSearchHandler) GetSavedSearchResults(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	properties, err := h.savedSearchService.RunSavedSearch(c.Param("id"), userID.(string))
+	if err != nil {
+		h.respondLookupError(c, err, "Failed to run saved search")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Saved search results retrieved successfully",
+		Data:    properties,
+	})
+}
+
+// UpdateSavedSearch handles PUT /api/saved-searches/:id - updates a specific saved search
+func (h *SavedSearchHandler) UpdateSavedSearch(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	search, err := h.savedSearchService.UpdateSavedSearch(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "cannot be greater than") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Validation failed",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		h.respondLookupError(c, err, "Failed to update saved search")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Saved search updated successfully",
+		Data:    search,
+	})
+}
+
+// DeleteSavedSearch handles DELETE /api/saved-searches/:id - deletes a specific saved search
+func (h *SavedSearchHandler) DeleteSavedSearch(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.savedSearchService.DeleteSavedSearch(c.Param("id"), userID.(string)); err != nil {
+		h.respondLookupError(c, err, "Failed to delete saved search")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Saved search deleted successfully",
+	})
+}
+
+// respondLookupError returns 404 for missing or foreign saved searches and 500 otherwise
+func (h *SavedSearchHandler) respondLookupError(c *gin.Context, err error, failureMessage string) {
+	if strings.Contains(err.Error(), "not found") ||
+		strings.Contains(err.Error(), "access denied") {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Saved search not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "Internal server error",
+		Message: failureMessage,
+	})
+}
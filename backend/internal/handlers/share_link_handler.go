@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// previewCrawlers are user agent fragments of link-preview bots that should not count as views
+var previewCrawlers = []string{
+	"whatsapp", "facebookexternalhit", "facebot", "twitterbot", "slackbot",
+	"telegrambot", "linkedinbot", "discordbot", "skypeuripreview", "googlebot",
+}
+
+// ShareLinkHandler handles HTTP requests for property share links and public listing pages
+type ShareLinkHandler struct {
+	shareLinkService *services.ShareLinkService
+	validator        *validator.Validate
+}
+
+// NewShareLinkHandler creates a new ShareLinkHandler instance
+func NewShareLinkHandler(shareLinkService *services.ShareLinkService) *ShareLinkHandler {
+	return &ShareLinkHandler{
+		shareLinkService: shareLinkService,
+		validator:        validator.New(),
+	}
+}
+
+// CreateShareLink handles POST /api/properties/:id/share-links - creates a public share link
+func (h *ShareLinkHandler) CreateShareLink(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	// All options are optional, so an empty body is allowed
+	var req models.CreateShareLinkRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request body",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	link, err := h.shareLinkService.CreateShareLink(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		h.respondLookupError(c, err, "Property not found", "Failed to create share link")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Share link created successfully",
+		Data:    link,
+	})
+}
+
+// GetShareLinks handles GET /api/properties/:id/share-links - lists a property's share links
+func (h *ShareLinkHandler) GetShareLinks(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	links, err := h.shareLinkService.GetPropertyShareLinks(c.Param("id"), brokerID.(string))
+	if err != nil {
+		h.respondLookupError(c, err, "Property not found", "Failed to retrieve share links")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Share links retrieved successfully",
+		Data:    links,
+	})
+}
+
+// RevokeShareLink handles DELETE /api/share-links/:id - revokes a share link
+func (h *ShareLinkHandler) RevokeShareLink(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	link, err := h.shareLinkService.RevokeShareLink(c.Param("id"), brokerID.(string))
+	if err != nil {
+		h.respondLookupError(c, err, "Share link not found", "Failed to revoke share link")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Share link revoked successfully",
+		Data:    link,
+	})
+}
+
+// GetSharedListing handles GET /api/share/:token - public JSON view of a shared listing
+func (h *ShareLinkHandler) GetSharedListing(c *gin.Context) {
+	listing, err := h.shareLinkService.GetSharedListing(c.Param("token"), !isPreviewCrawler(c.Request.UserAgent()))
+	if err != nil {
+		h.respondLookupError(c, err, "This link has expired or is no longer available", "Failed to retrieve listing")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Listing retrieved successfully",
+		Data:    listing,
+	})
+}
+
+// RenderSharedListing handles GET /s/:token - public HTML listing page with Open Graph tags
+func (h *ShareLinkHandler) RenderSharedListing(c *gin.Context) {
+	token := c.Param("token")
+
+	status := http.StatusOK
+	page := sharePage{URL: h.shareLinkService.ShareURL(token)}
+
+	listing, err := h.shareLinkService.GetSharedListing(token, !isPreviewCrawler(c.Request.UserAgent()))
+	if err != nil {
+		status = http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			status = http.StatusNotFound
+		}
+	} else {
+		page.Listing = listing
+	}
+
+	var buf bytes.Buffer
+	if err := sharePageTemplate.Execute(&buf, page); err != nil {
+		log.Printf("Failed to render shared listing page: %v", err)
+		c.String(http.StatusInternalServerError, "Failed to render listing")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(status, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// respondLookupError returns 404 for missing or foreign resources and 500 otherwise
+func (h *ShareLinkHandler) respondLookupError(c *gin.Context, err error, notFoundMessage, failureMessage string) {
+	if strings.Contains(err.Error(), "not found") ||
+		strings.Contains(err.Error(), "access denied") {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: notFoundMessage,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "Internal server error",
+		Message: failureMessage,
+	})
+}
+
+// isPreviewCrawler reports whether a request comes from a link-preview bot
+func isPreviewCrawler(userAgent string) bool {
+	userAgent = strings.ToLower(userAgent)
+	for _, crawler := range previewCrawlers {
+		if strings.Contains(userAgent, crawler) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+)
+
+// sharePage is the data rendered into the public listing page
+type sharePage struct {
+	URL     string
+	Listing *models.SharedListing // nil when the link is missing, revoked or expired
+}
+
+// Description summarises the listing for the page and Open Graph previews
+func (p sharePage) Description() string {
+	listing := p.Listing
+	if listing == nil {
+		return "This link has expired or is no longer available."
+	}
+
+	parts := []string{}
+	if listing.Bedrooms != nil && *listing.Bedrooms > 0 {
+		parts = append(parts, fmt.Sprintf("%d BHK %s", *listing.Bedrooms, listing.Type))
+	} else {
+		parts = append(parts, titleCase(listing.Type))
+	}
+	parts = append(parts, fmt.Sprintf("%.0f sq ft", listing.Area))
+	parts = append(parts, fmt.Sprintf("%s, %s", listing.Location, listing.City))
+	parts = append(parts, formatListingPrice(listing.Price, listing.ListingType))
+
+	return strings.Join(parts, " · ")
+}
+
+// formatListingPrice formats a price in lakhs/crores, with a monthly suffix for rentals
+func formatListingPrice(price float64, listingType string) string {
+	var formatted string
+	switch {
+	case price >= 1e7:
+		formatted = fmt.Sprintf("₹%.2f Cr", price/1e7)
+	case price >= 1e5:
+		formatted = fmt.Sprintf("₹%.2f L", price/1e5)
+	default:
+		formatted = fmt.Sprintf("₹%.0f", price)
+	}
+
+	if listingType == "rent" {
+		formatted += " / month"
+	}
+	return formatted
+}
+
+// titleCase turns stored values like "under_negotiation" into "Under Negotiation"
+func titleCase(value string) string {
+	words := strings.Fields(strings.ReplaceAll(value, "_", " "))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// digitsOnly strips everything but digits, as required by wa.me links
+func digitsOnly(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+// sharePageTemplate renders a shared listing; html/template escapes all listing fields
+var sharePageTemplate = template.Must(template.New("share").Funcs(template.FuncMap{
+	"price":  formatListingPrice,
+	"title":  titleCase,
+	"digits": digitsOnly,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+{{- if .Listing}}
+<title>{{.Listing.Title}}</title>
+<meta property="og:title" content="{{.Listing.Title}}">
+{{- else}}
+<title>Listing unavailable</title>
+<meta property="og:title" content="Listing unavailable">
+{{- end}}
+<meta name="description" content="{{.Description}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:type" content="website">
+<meta property="og:url" content="{{.URL}}">
+<meta property="og:site_name" content="ENFOR DATA">
+{{- if and .Listing .Listing.Images}}
+<meta property="og:image" content="{{index .Listing.Images 0}}">
+{{- end}}
+<style>
+body{font-family:-apple-system,Segoe UI,Roboto,sans-serif;margin:0;background:#f5f6f8;color:#1f2933}
+main{max-width:640px;margin:0 auto;padding:24px 16px}
+.card{background:#fff;border-radius:12px;padding:20px;margin-bottom:16px;box-shadow:0 1px 3px rgba(0,0,0,.08)}
+h1{font-size:22px;margin:0 0 8px}
+.price{font-size:24px;font-weight:700;color:#0b6e4f;margin:8px 0}
+.meta{color:#52606d;margin:4px 0}
+.facts{display:flex;flex-wrap:wrap;gap:8px;margin:12px 0;padding:0;list-style:none}
+.facts li{background:#eef2f6;border-radius:16px;padding:4px 12px;font-size:14px}
+.cover{width:100%;border-radius:12px;margin-bottom:16px;object-fit:cover;max-height:360px}
+.status{display:inline-block;font-size:12px;text-transform:uppercase;color:#9a3412}
+a.button{display:inline-block;background:#25d366;color:#fff;text-decoration:none;padding:10px 16px;border-radius:8px;margin-top:8px}
+</style>
+</head>
+<body>
+<main>
+{{- with .Listing}}
+{{- range .Images}}
+<img class="cover" src="{{.}}" alt="">
+{{- end}}
+<div class="card">
+<h1>{{.Title}}</h1>
+{{- if ne .Status "available"}}
+<div class="status">{{title .Status}}</div>
+{{- end}}
+<div class="price">{{price .Price .ListingType}}</div>
+<p class="meta">{{.Location}}, {{.City}}, {{.State}}</p>
+{{- if .Address}}
+<p class="meta">{{.Address}}</p>
+{{- end}}
+<ul class="facts">
+<li>{{title .Type}} for {{.ListingType}}</li>
+<li>{{printf "%.0f" .Area}} sq ft</li>
+{{- if .Bedrooms}}<li>{{.Bedrooms}} bedrooms</li>{{end}}
+{{- if .Bathrooms}}<li>{{.Bathrooms}} bathrooms</li>{{end}}
+{{- range .Amenities}}<li>{{.}}</li>{{end}}
+</ul>
+<p>{{.Description}}</p>
+</div>
+{{- with .Broker}}
+<div class="card">
+<strong>{{.Name}}</strong>
+<p class="meta">{{.FirmName}}</p>
+<p class="meta"><a href="mailto:{{.Email}}">{{.Email}}</a></p>
+{{- if .WhatsappNumber}}
+<a class="button" href="https://wa.me/{{digits .WhatsappNumber}}">Chat on WhatsApp</a>
+{{- end}}
+</div>
+{{- end}}
+{{- else}}
+<div class="card">
+<h1>Listing unavailable</h1>
+<p class="meta">This link has expired or is no longer available.</p>
+</div>
+{{- end}}
+</main>
+</body>
+</html>
+`))
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// SyndicationFeedHandler handles HTTP requests for portal syndication feeds
+type SyndicationFeedHandler struct {
+	feedService *services.SyndicationFeedService
+	validator   *validator.Validate
+}
+
+// NewSyndicationFeedHandler creates a new SyndicationFeedHandler instance
+func NewSyndicationFeedHandler(feedService *services.SyndicationFeedService) *SyndicationFeedHandler {
+	return &SyndicationFeedHandler{
+		feedService: feedService,
+		validator:   validator.New(),
+	}
+}
+
+// GetFeeds handles GET /api/syndication-feeds - retrieves the broker's feeds
+func (h *SyndicationFeedHandler) GetFeeds(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	feeds, err := h.feedService.GetBrokerFeeds(brokerID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve syndication feeds",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Syndication feeds retrieved successfully",
+		Data:    feeds,
+	})
+}
+
+// CreateFeed handles POST /api/syndication-feeds - creates a new feed
+func (h *SyndicationFeedHandler) CreateFeed(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CreateSyndicationFeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	feed, err := h.feedService.CreateFeed(&req, brokerID.(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Validation failed",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to create syndication feed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Syndication feed created successfully",
+		Data:    feed,
+	})
+}
+
+// GetFeed handles GET /api/syndication-feeds/:id - retrieves a specific feed
+func (h *SyndicationFeedHandler) GetFeed(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	feed, err := h.feedService.GetFeedByID(c.Param("id"), brokerID.(string))
+	if err != nil {
+		h.respondLookupError(c, err, "Failed to retrieve syndication feed")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Syndication feed retrieved successfully",
+		Data:    feed,
+	})
+}
+
+// UpdateFeed handles PUT /api/syndication-feeds/:id - updates a specific feed
+func (h *SyndicationFeedHandler) UpdateFeed(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdateSyndicationFeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	feed, err := h.feedService.UpdateFeed(c.Param("id"), &req, brokerID.(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Validation failed",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		h.respondLookupError(c, err, "Failed to update syndication feed")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Syndication feed updated successfully",
+		Data:    feed,
+	})
+}
+
+// DeleteFeed handles DELETE /api/syndication-feeds/:id - deletes a specific feed
+func (h *SyndicationFeedHandler) DeleteFeed(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.feedService.DeleteFeed(c.Param("id"), brokerID.(string)); err != nil {
+		h.respondLookupError(c, err, "Failed to delete syndication feed")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Syndication feed deleted successfully",
+	})
+}
+
+// ExportFeed handles GET /api/syndication-feeds/:id/export - renders the feed document
+// Optional query parameter since (RFC 3339) returns an incremental feed of listings changed after it
+func (h *SyndicationFeedHandler) ExportFeed(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var since *time.Time
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid filters",
+				Message: "since must be an RFC 3339 timestamp",
+			})
+			return
+		}
+		since = &parsed
+	}
+
+	feed, content, err := h.feedService.ExportFeed(c.Param("id"), brokerID.(string), since)
+	if err != nil {
+		h.respondLookupError(c, err, "Failed to export syndication feed")
+		return
+	}
+
+	contentType := "application/json; charset=utf-8"
+	if feed.Format == "xml" {
+		contentType = "application/xml; charset=utf-8"
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", feed.ID+"."+feed.Format))
+	c.Data(http.StatusOK, contentType, content)
+}
+
+// respondLookupError returns 404 for missing or foreign feeds and 500 otherwise
+func (h *SyndicationFeedHandler) respondLookupError(c *gin.Context, err error, failureMessage string) {
+	if strings.Contains(err.Error(), "not found") ||
+		strings.Contains(err.Error(), "access denied") {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Syndication feed not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "Internal server error",
+		Message: failureMessage,
+	})
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// TagHandler handles HTTP requests for the tags on a broker's clients and properties
+type TagHandler struct {
+	tagService *services.TagService
+	validator  *validator.Validate
+}
+
+// NewTagHandler creates a new TagHandler instance
+func NewTagHandler(tagService *services.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+		validator:  validator.New(),
+	}
+}
+
+// GetTags handles GET /api/tags - retrieves the broker's tags with client and property counts
+func (h *TagHandler) GetTags(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	tags, err := h.tagService.GetTags(brokerID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve tags",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Tags retrieved successfully",
+		Data:    tags,
+	})
+}
+
+// RenameTag handles PUT /api/tags/:name - renames a tag on all the broker's clients and properties
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	tags, err := h.tagService.RenameTag(brokerID.(string), c.Param("name"), &req)
+	if err != nil {
+		writeTagError(c, err, "Failed to rename tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Tag renamed successfully",
+		Data:    tags,
+	})
+}
+
+// DeleteTag handles DELETE /api/tags/:name - removes a tag from all the broker's clients and properties
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.tagService.DeleteTag(brokerID.(string), c.Param("name")); err != nil {
+		writeTagError(c, err, "Failed to delete tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Tag deleted successfully",
+	})
+}
+
+// BulkTagClients handles POST /api/clients/bulk-tags - adds and removes tags on several clients
+func (h *TagHandler) BulkTagClients(c *gin.Context) {
+	h.bulkTag(c, h.tagService.BulkTagClients, "Client tags updated successfully", "Failed to update client tags")
+}
+
+// BulkTagProperties handles POST /api/properties/bulk-tags - adds and removes tags on several properties
+func (h *TagHandler) BulkTagProperties(c *gin.Context) {
+	h.bulkTag(c, h.tagService.BulkTagProperties, "Property tags updated successfully", "Failed to update property tags")
+}
+
+// bulkTag binds a bulk tag request and applies it with the given service method
+func (h *TagHandler) bulkTag(
+	c *gin.Context,
+	apply func(string, *models.BulkTagRequest) (*models.BulkTagResult, error),
+	successMessage, failureMessage string,
+) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.BulkTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	result, err := apply(brokerID.(string), &req)
+	if err != nil {
+		writeTagError(c, err, failureMessage)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: successMessage,
+		Data:    result,
+	})
+}
+
+// writeTagError maps a tag service error to its response
+func writeTagError(c *gin.Context, err error, fallback string) {
+	message := err.Error()
+
+	switch {
+	case strings.HasPrefix(message, "invalid"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	case message == "tag not found":
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Tag not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: fallback,
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// TaskHandler handles HTTP requests for follow-up tasks
+type TaskHandler struct {
+	taskService *services.TaskService
+	validator   *validator.Validate
+}
+
+// NewTaskHandler creates a new TaskHandler instance
+func NewTaskHandler(taskService *services.TaskService) *TaskHandler {
+	return &TaskHandler{
+		taskService: taskService,
+		validator:   validator.New(),
+	}
+}
+
+// GetTasks handles GET /api/tasks - tasks the user created or is assigned, soonest due first
+func (h *TaskHandler) GetTasks(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var filters models.TaskFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	tasks, err := h.taskService.GetTasks(userID.(string), filters)
+	if err != nil {
+		h.respondTaskError(c, err, "Failed to retrieve tasks")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Tasks retrieved successfully",
+		Data:    tasks,
+	})
+}
+
+// CreateTask handles POST /api/tasks - creates a follow-up task
+func (h *TaskHandler) CreateTask(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.CreateTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	task, err := h.taskService.CreateTask(&req, userID.(string))
+	if err != nil {
+		h.respondTaskError(c, err, "Failed to create task")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Task created successfully",
+		Data:    task,
+	})
+}
+
+// GetTask handles GET /api/tasks/:id - retrieves a single task
+func (h *TaskHandler) GetTask(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	task, err := h.taskService.GetTask(c.Param("id"), userID.(string))
+	if err != nil {
+		h.respondTaskError(c, err, "Failed to retrieve task")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Task retrieved successfully",
+		Data:    task,
+	})
+}
+
+// UpdateTask handles PUT /api/tasks/:id - updates a task, including marking it done
+func (h *TaskHandler) UpdateTask(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	var req models.UpdateTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: formatValidationErrors(err),
+		})
+		return
+	}
+
+	task, err := h.taskService.UpdateTask(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		h.respondTaskError(c, err, "Failed to update task")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Task updated successfully",
+		Data:    task,
+	})
+}
+
+// DeleteTask handles DELETE /api/tasks/:id - deletes a task the user created
+func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	if err := h.taskService.DeleteTask(c.Param("id"), userID.(string)); err != nil {
+		h.respondTaskError(c, err, "Failed to delete task")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Task deleted successfully",
+	})
+}
+
+// respondTaskError maps task service errors to HTTP responses
+// Invalid references are checked first since they wrap "not found" errors
+func (h *TaskHandler) respondTaskError(c *gin.Context, err error, failureMessage string) {
+	message := err.Error()
+
+	switch {
+	case strings.HasPrefix(message, "invalid"):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: message,
+		})
+	case strings.Contains(message, "not found") ||
+		strings.Contains(message, "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Task not found",
+		})
+	case strings.HasPrefix(message, "cannot"):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Conflict",
+			Message: message,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: failureMessage,
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrashHandler handles HTTP requests for deleted records awaiting restore or purge
+type TrashHandler struct {
+	trashService *services.TrashService
+}
+
+// NewTrashHandler creates a new TrashHandler instance
+func NewTrashHandler(trashService *services.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// GetTrash handles GET /api/trash - lists the broker's deleted properties, clients and appointments
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	trash, err := h.trashService.GetTrash(brokerID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: "Failed to retrieve trash",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Trash retrieved successfully",
+		Data:    trash,
+	})
+}
+
+// RestoreProperty handles POST /api/trash/properties/:id/restore - restores a deleted property
+func (h *TrashHandler) RestoreProperty(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	property, err := h.trashService.RestoreProperty(c.Param("id"), brokerID.(string))
+	if err != nil {
+		h.respondRestoreError(c, err, "Property", "Failed to restore property")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Property restored successfully",
+		Data:    property,
+	})
+}
+
+// RestoreClient handles POST /api/trash/clients/:id/restore - restores a deleted client
+// Appointments deleted together with the client are restored as well
+func (h *TrashHandler) RestoreClient(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	client, restoredAppointments, err := h.trashService.RestoreClient(c.Param("id"), brokerID.(string))
+	if err != nil {
+		h.respondRestoreError(c, err, "Client", "Failed to restore client")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Client restored successfully",
+		Data: gin.H{
+			"client":                client,
+			"restored_appointments": restoredAppointments,
+		},
+	})
+}
+
+// RestoreAppointment handles POST /api/trash/appointments/:id/restore - restores a deleted appointment
+func (h *TrashHandler) RestoreAppointment(c *gin.Context) {
+	brokerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Unauthorized",
+			Message: "Authentication required",
+		})
+		return
+	}
+
+	appointment, err := h.trashService.RestoreAppointment(c.Param("id"), brokerID.(string))
+	if err != nil {
+		h.respondRestoreError(c, err, "Appointment", "Failed to restore appointment")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Appointment restored successfully",
+		Data:    appointment,
+	})
+}
+
+// respondRestoreError returns 409 when a dependency blocks the restore, 404 for missing
+// or foreign records and 500 otherwise
+func (h *TrashHandler) respondRestoreError(c *gin.Context, err error, entity, failureMessage string) {
+	switch {
+	case strings.Contains(err.Error(), "cannot restore"):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Conflict",
+			Message: err.Error(),
+		})
+	case strings.Contains(err.Error(), "not found") ||
+		strings.Contains(err.Error(), "access denied"):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: entity + " not found in trash",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal server error",
+			Message: failureMessage,
+		})
+	}
+}
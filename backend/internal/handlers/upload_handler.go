@@ -17,14 +17,16 @@ import (
 )
 
 type UploadHandler struct {
-	authService *services.AuthService
-	config      *config.Config
+	authService    *services.AuthService
+	projectService *services.ProjectService
+	config         *config.Config
 }
 
-func NewUploadHandler(authService *services.AuthService, cfg *config.Config) *UploadHandler {
+func NewUploadHandler(authService *services.AuthService, projectService *services.ProjectService, cfg *config.Config) *UploadHandler {
 	return &UploadHandler{
-		authService: authService,
-		config:      cfg,
+		authService:    authService,
+		projectService: projectService,
+		config:         cfg,
 	}
 }
 
@@ -126,6 +128,109 @@ func (h *UploadHandler) UploadProfilePhoto(c *gin.Context) {
 	})
 }
 
+// UploadProjectBrochure handles POST /api/channel-partner/projects/:id/brochure - uploads a PDF brochure
+func (h *UploadHandler) UploadProjectBrochure(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	// Verify ownership before accepting the file
+	projectID := c.Param("id")
+	if _, err := h.projectService.GetOwnedProject(projectID, userID.(string)); err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Not found",
+			Message: "Project not found",
+		})
+		return
+	}
+
+	// Parse multipart form
+	file, header, err := c.Request.FormFile("brochure")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "No file provided",
+			Message: "Please provide a brochure",
+		})
+		return
+	}
+	defer file.Close()
+
+	// Validate file size
+	if header.Size > h.config.Upload.MaxFileSize {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "File too large",
+			Message: fmt.Sprintf("File size must be less than %d MB", h.config.Upload.MaxFileSize/1024/1024),
+		})
+		return
+	}
+
+	// Validate file type
+	if strings.ToLower(filepath.Ext(header.Filename)) != ".pdf" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid file type",
+			Message: "Only PDF brochures are allowed",
+		})
+		return
+	}
+
+	// Create uploads directory if it doesn't exist
+	uploadDir := h.config.Upload.Path
+	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to create upload directory",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Generate unique filename
+	fileName := fmt.Sprintf("%s_%d.pdf", uuid.New().String(), time.Now().Unix())
+	filePath := filepath.Join(uploadDir, fileName)
+
+	// Create destination file
+	dst, err := os.Create(filePath)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to create file",
+			Message: err.Error(),
+		})
+		return
+	}
+	defer dst.Close()
+
+	// Copy uploaded file to destination
+	if _, err := io.Copy(dst, file); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to save file",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	fileURL := fmt.Sprintf("/uploads/%s", fileName)
+
+	project, err := h.projectService.UpdateProjectBrochure(projectID, fileURL, userID.(string))
+	if err != nil {
+		// Clean up uploaded file if database update fails
+		os.Remove(filePath)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to update project",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Brochure uploaded successfully",
+		Data:    project,
+	})
+}
+
 // ServeUploadedFile serves uploaded files
 func (h *UploadHandler) ServeUploadedFile(c *gin.Context) {
 	filename := c.Param("filename")
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// job is a named task run on a fixed interval
+type job struct {
+	name     string
+	interval time.Duration
+	run      func()
+}
+
+// Scheduler runs background jobs on fixed intervals until stopped
+// Each job runs in its own goroutine and never overlaps with itself
+type Scheduler struct {
+	jobs []job
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler instance
+func NewScheduler() *Scheduler {
+	return &Scheduler{stop: make(chan struct{})}
+}
+
+// Every registers a job to run once per interval, starting one interval after Start
+// Jobs must be registered before Start is called
+func (s *Scheduler) Every(name string, interval time.Duration, run func()) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start launches every registered job
+func (s *Scheduler) Start() {
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(j)
+		log.Printf("Scheduled job %q every %s", j.name, j.interval)
+	}
+}
+
+// Stop signals every job to exit and waits for in-flight runs to finish
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// loop runs a job on its interval until the scheduler is stopped
+func (s *Scheduler) loop(j job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.runOnce(j)
+		}
+	}
+}
+
+// runOnce runs a job, recovering from panics so one bad run cannot kill the server
+func (s *Scheduler) runOnce(j job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %q panicked: %v", j.name, r)
+		}
+	}()
+
+	started := time.Now()
+	j.run()
+	log.Printf("Job %q finished in %s", j.name, time.Since(started).Round(time.Millisecond))
+}
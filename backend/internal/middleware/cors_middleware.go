@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -8,8 +9,10 @@ import (
 )
 
 // CORSMiddleware returns a CORS middleware configured for the application
-func CORSMiddleware() gin.HandlerFunc {
-	return cors.New(cors.Config{
+// Requests under publicPrefixes (such as lead forms embedded on brokers' own websites) are
+// accepted from any origin, without credentials
+func CORSMiddleware(publicPrefixes ...string) gin.HandlerFunc {
+	private := cors.New(cors.Config{
 		AllowOrigins: []string{
 			"http://localhost:3000", // React dev server
 			"http://localhost:5173", // Vite dev server
@@ -40,4 +43,21 @@ func CORSMiddleware() gin.HandlerFunc {
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	})
+
+	public := cors.New(cors.Config{
+		AllowAllOrigins: true,
+		AllowMethods:    []string{"POST", "OPTIONS"},
+		AllowHeaders:    []string{"Origin", "Content-Type", "Accept"},
+		MaxAge:          12 * time.Hour,
+	})
+
+	return func(c *gin.Context) {
+		for _, prefix := range publicPrefixes {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				public(c)
+				return
+			}
+		}
+		private(c)
+	}
 }
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"enfor-data-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PortalAuthMiddleware authenticates clients signed in to the client portal
+type PortalAuthMiddleware struct {
+	portalService *services.PortalService
+}
+
+// NewPortalAuthMiddleware creates a new PortalAuthMiddleware instance
+func NewPortalAuthMiddleware(portalService *services.PortalService) *PortalAuthMiddleware {
+	return &PortalAuthMiddleware{
+		portalService: portalService,
+	}
+}
+
+// RequireClient middleware validates a client portal token and sets the client's ID in context
+// User tokens are rejected, so portal routes only ever act on the signed-in client's own record
+func (m *PortalAuthMiddleware) RequireClient() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "Authorization header missing",
+				Message: "Please provide a valid authorization token",
+			})
+			c.Abort()
+			return
+		}
+
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		if tokenString == authHeader {
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "Invalid token format",
+				Message: "Authorization header must be in format: Bearer <token>",
+			})
+			c.Abort()
+			return
+		}
+
+		clientID, err := m.portalService.ValidateSession(tokenString)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "Invalid token",
+				Message: err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("client_id", clientID)
+
+		c.Next()
+	}
+}
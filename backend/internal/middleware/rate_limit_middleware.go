@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimiter limits how many requests each client IP can make within a fixed window
+// Counters are kept in memory, so limits apply per server process
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu        sync.Mutex
+	windows   map[string]*rateWindow
+	lastSweep time.Time
+}
+
+// rateWindow counts a client's requests since the window started
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// NewRateLimiter creates a RateLimiter allowing limit requests per window for each client IP
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:     limit,
+		window:    window,
+		windows:   make(map[string]*rateWindow),
+		lastSweep: time.Now(),
+	}
+}
+
+// Limit middleware rejects requests over the limit with 429 Too Many Requests
+func (l *RateLimiter) Limit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, retryAfter := l.allow(c.ClientIP(), time.Now())
+		if !allowed {
+			c.Header("Retry-After", fmt.Sprintf("%d", int(math.Ceil(retryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, ErrorResponse{
+				Error:   "Too many requests",
+				Message: "Please wait a while before trying again",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// allow counts a request from key and reports whether it is within the limit
+// When it is not, the time until the window resets is returned
+func (l *RateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop expired windows now and then so the map doesn't grow with every IP ever seen
+	if now.Sub(l.lastSweep) >= l.window {
+		for k, w := range l.windows {
+			if now.Sub(w.start) >= l.window {
+				delete(l.windows, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	w, exists := l.windows[key]
+	if !exists || now.Sub(w.start) >= l.window {
+		l.windows[key] = &rateWindow{start: now, count: 1}
+		return true, 0
+	}
+
+	if w.count >= l.limit {
+		return false, w.start.Add(l.window).Sub(now)
+	}
+
+	w.count++
+	return true, 0
+}
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	start := time.Date(2026, time.October, 18, 10, 0, 0, 0, time.UTC)
+
+	type request struct {
+		key            string
+		at             time.Duration // since start
+		wantAllowed    bool
+		wantRetryAfter time.Duration
+	}
+
+	tests := []struct {
+		name     string
+		requests []request
+	}{
+		{
+			name: "requests up to the limit are allowed",
+			requests: []request{
+				{"203.0.113.1", 0, true, 0},
+				{"203.0.113.1", 10 * time.Second, true, 0},
+				{"203.0.113.1", 20 * time.Second, true, 0},
+			},
+		},
+		{
+			name: "over the limit waits until the window resets",
+			requests: []request{
+				{"203.0.113.1", 0, true, 0},
+				{"203.0.113.1", 1 * time.Second, true, 0},
+				{"203.0.113.1", 2 * time.Second, true, 0},
+				{"203.0.113.1", 15 * time.Second, false, 45 * time.Second},
+				{"203.0.113.1", 59 * time.Second, false, 1 * time.Second},
+			},
+		},
+		{
+			name: "a new window starts once the old one has passed",
+			requests: []request{
+				{"203.0.113.1", 0, true, 0},
+				{"203.0.113.1", 1 * time.Second, true, 0},
+				{"203.0.113.1", 2 * time.Second, true, 0},
+				{"203.0.113.1", 60 * time.Second, true, 0},
+				{"203.0.113.1", 61 * time.Second, true, 0},
+				{"203.0.113.1", 62 * time.Second, true, 0},
+				{"203.0.113.1", 63 * time.Second, false, 57 * time.Second},
+			},
+		},
+		{
+			name: "rejected requests do not extend the window",
+			requests: []request{
+				{"203.0.113.1", 0, true, 0},
+				{"203.0.113.1", 0, true, 0},
+				{"203.0.113.1", 0, true, 0},
+				{"203.0.113.1", 30 * time.Second, false, 30 * time.Second},
+				{"203.0.113.1", 50 * time.Second, false, 10 * time.Second},
+				{"203.0.113.1", 60 * time.Second, true, 0},
+			},
+		},
+		{
+			name: "each client has its own window",
+			requests: []request{
+				{"203.0.113.1", 0, true, 0},
+				{"203.0.113.1", 0, true, 0},
+				{"203.0.113.1", 0, true, 0},
+				{"203.0.113.1", 5 * time.Second, false, 55 * time.Second},
+				{"198.51.100.7", 5 * time.Second, true, 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewRateLimiter(3, time.Minute)
+
+			for i, req := range tt.requests {
+				allowed, retryAfter := limiter.allow(req.key, start.Add(req.at))
+				if allowed != req.wantAllowed || retryAfter != req.wantRetryAfter {
+					t.Fatalf("request %d at +%s: allow() = %v, %s; want %v, %s",
+						i+1, req.at, allowed, retryAfter, req.wantAllowed, req.wantRetryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiterSweepsExpiredWindows(t *testing.T) {
+	limiter := NewRateLimiter(3, time.Minute)
+	start := limiter.lastSweep
+
+	limiter.allow("203.0.113.1", start)
+	limiter.allow("198.51.100.7", start.Add(30*time.Second))
+	limiter.allow("192.0.2.10", start.Add(70*time.Second))
+
+	if _, exists := limiter.windows["203.0.113.1"]; exists {
+		t.Error("expired window was not swept")
+	}
+	if len(limiter.windows) != 2 {
+		t.Errorf("limiter holds %d windows, want 2", len(limiter.windows))
+	}
+}
+
+func TestRateLimiterLimitSetsRetryAfter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/leads", NewRateLimiter(1, time.Minute).Limit(), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	send := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/leads", nil)
+		req.RemoteAddr = "203.0.113.1:4321"
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	if first := send(); first.Code != http.StatusNoContent {
+		t.Fatalf("first request status = %d, want %d", first.Code, http.StatusNoContent)
+	}
+
+	second := send()
+	if second.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request status = %d, want %d", second.Code, http.StatusTooManyRequests)
+	}
+	if retryAfter := second.Header().Get("Retry-After"); retryAfter != "60" && retryAfter != "59" {
+		t.Errorf("Retry-After = %q, want about 60 seconds", retryAfter)
+	}
+}
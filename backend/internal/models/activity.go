@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// Interaction types brokers log by hand
+const (
+	ActivityCall      = "call"
+	ActivityWhatsApp  = "whatsapp"
+	ActivityEmail     = "email"
+	ActivityNote      = "note"
+	ActivitySiteVisit = "site_visit"
+	ActivityMeeting   = "meeting"
+)
+
+// Entry types recorded automatically when a client or their appointments change
+const (
+	ActivityStatusChange           = "status_change"
+	ActivityAppointmentScheduled   = "appointment_scheduled"
+	ActivityAppointmentRescheduled = "appointment_rescheduled"
+	ActivityAppointmentCompleted   = "appointment_completed"
+	ActivityAppointmentCancelled   = "appointment_cancelled"
+
+	// ActivityStageChange entries come from the pipeline's stage history
+	ActivityStageChange = "stage_change"
+)
+
+// Timeline entry sources
+const (
+	TimelineSourceActivity = "activity" // the client_activities log
+	TimelineSourcePipeline = "pipeline" // pipeline stage transitions
+)
+
+// ClientActivity is one entry of a client's timeline
+type ClientActivity struct {
+	ID            string  `json:"id" db:"id"`
+	ClientID      string  `json:"client_id" db:"client_id"`
+	BrokerID      string  `json:"broker_id" db:"broker_id"`
+	AuthorID      *string `json:"author_id,omitempty" db:"author_id"` // empty when no user made the change
+	AuthorName    *string `json:"author_name,omitempty" db:"author_name"`
+	AppointmentID *string `json:"appointment_id,omitempty" db:"appointment_id"`
+	Source        string  `json:"source" db:"source"`
+	Type          string  `json:"type" db:"type"`
+	Body          string  `json:"body" db:"body"`
+
+	// Timestamps
+	OccurredAt time.Time `json:"occurred_at" db:"occurred_at"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateClientActivityRequest represents an interaction logged against a client
+type CreateClientActivityRequest struct {
+	Type       string  `json:"type" validate:"required,oneof=call whatsapp email note site_visit meeting"`
+	Body       string  `json:"body" validate:"required,min=1,max=5000"`
+	OccurredAt *string `json:"occurred_at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // defaults to now
+}
+
+// ClientTimelineRequest represents the query for a client's timeline
+type ClientTimelineRequest struct {
+	Types  []string `form:"type" validate:"omitempty,dive,oneof=call whatsapp email note site_visit meeting status_change appointment_scheduled appointment_rescheduled appointment_completed appointment_cancelled stage_change"`
+	Limit  int      `form:"limit" validate:"omitempty,min=1,max=100"` // defaults to 30
+	Cursor string   `form:"cursor" validate:"omitempty,max=500"`      // next_cursor of the previous page
+}
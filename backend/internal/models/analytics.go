@@ -0,0 +1,59 @@
+package models
+
+// Listing engagement events tracked in the daily aggregates
+const (
+	ListingEventShareView   = "share_view"  // public share link opened
+	ListingEventAppView     = "app_view"    // property detail opened in the app
+	ListingEventInquiry     = "inquiry"     // inquiry logged against the listing
+	ListingEventAppointment = "appointment" // appointment booked for the listing
+)
+
+// AnalyticsRangeRequest represents the date range query for analytics endpoints
+// Dates are inclusive calendar days (YYYY-MM-DD); the range defaults to the last 30 days
+type AnalyticsRangeRequest struct {
+	From string `form:"from" validate:"omitempty,datetime=2006-01-02"`
+	To   string `form:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// ListingEngagement holds engagement counters for a listing or a group of listings
+type ListingEngagement struct {
+	Views        int `json:"views"` // share_views + app_views
+	ShareViews   int `json:"share_views"`
+	AppViews     int `json:"app_views"`
+	Inquiries    int `json:"inquiries"`
+	Appointments int `json:"appointments"`
+}
+
+// DailyEngagement is one day of engagement counters
+type DailyEngagement struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	ListingEngagement
+}
+
+// PropertyAnalytics is the engagement report for a single listing
+type PropertyAnalytics struct {
+	PropertyID string            `json:"property_id"`
+	Title      string            `json:"title"`
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Totals     ListingEngagement `json:"totals"`
+	Daily      []DailyEngagement `json:"daily"` // one entry per day in the range, zero-filled
+}
+
+// PropertyEngagement is a listing's engagement totals within a broker report
+type PropertyEngagement struct {
+	PropertyID string `json:"property_id"`
+	Title      string `json:"title"`
+	City       string `json:"city"`
+	Status     string `json:"status"`
+	ListingEngagement
+}
+
+// BrokerListingAnalytics is the engagement report across all of a broker's listings
+type BrokerListingAnalytics struct {
+	From       string               `json:"from"`
+	To         string               `json:"to"`
+	Totals     ListingEngagement    `json:"totals"`
+	Daily      []DailyEngagement    `json:"daily"`      // one entry per day in the range, zero-filled
+	Properties []PropertyEngagement `json:"properties"` // listings with activity in the range, most viewed first
+}
@@ -28,9 +28,14 @@ type Appointment struct {
 	BrokerName      *string `json:"broker_name,omitempty" db:"broker_name"`
 	BrokerCity      *string `json:"broker_city,omitempty" db:"broker_city"`
 
+	// Client's answer from the portal; cleared when the appointment is rescheduled
+	ClientResponse    *string    `json:"client_response,omitempty" db:"client_response"` // confirmed, cancelled
+	ClientRespondedAt *time.Time `json:"client_responded_at,omitempty" db:"client_responded_at"`
+
 	// Timestamps
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // set while in the trash
 }
 
 // CreateAppointmentRequest represents the data required for creating an appointment
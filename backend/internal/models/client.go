@@ -29,10 +29,37 @@ type Client struct {
 	State             string `json:"state" db:"state"`
 	PostalCode        string `json:"postal_code" db:"postal_code"`
 
-	// Requirements/Enquiry
+	// Structured Requirements
+	PropertyTypes       []string `json:"property_types" db:"property_types"`
+	ListingType         *string  `json:"listing_type,omitempty" db:"listing_type"`
+	BedroomsMin         *int     `json:"bedrooms_min,omitempty" db:"bedrooms_min"`
+	BedroomsMax         *int     `json:"bedrooms_max,omitempty" db:"bedrooms_max"`
+	AreaMin             *float64 `json:"area_min,omitempty" db:"area_min"`
+	AreaMax             *float64 `json:"area_max,omitempty" db:"area_max"`
+	PreferredLocalities []string `json:"preferred_localities" db:"preferred_localities"`
+	RequiredAmenities   []string `json:"required_amenities" db:"required_amenities"` // must-have amenities
+
+	// Requirements/Enquiry (free-text notes alongside the structured fields)
 	Requirements string  `json:"requirements" db:"requirements"`
 	Notes        *string `json:"notes,omitempty" db:"notes"`
 
+	// Sales Pipeline (changed through the pipeline endpoints)
+	PipelineStageID *string    `json:"pipeline_stage_id,omitempty" db:"pipeline_stage_id"`
+	StageChangedAt  *time.Time `json:"stage_changed_at,omitempty" db:"stage_changed_at"`
+	LostReason      *string    `json:"lost_reason,omitempty" db:"lost_reason"`
+
+	// Lead Attribution: the channel and campaign that brought the client in
+	Source   *string `json:"source,omitempty" db:"source"` // website, portal, referral, walk_in, other
+	Campaign *string `json:"campaign,omitempty" db:"campaign"`
+
+	// Tags: the broker's own labels for grouping clients ("NRI", "hot")
+	Tags []string `json:"tags" db:"tags"`
+
+	// Lead Score (0-100, recalculated by the system; higher means call sooner)
+	LeadScore          int               `json:"lead_score" db:"lead_score"`
+	LeadScoreFactors   *LeadScoreFactors `json:"lead_score_factors,omitempty" db:"lead_score_factors"`
+	LeadScoreUpdatedAt *time.Time        `json:"lead_score_updated_at,omitempty" db:"lead_score_updated_at"`
+
 	// Ownership
 	BrokerID string `json:"broker_id" db:"broker_id"`
 
@@ -41,8 +68,9 @@ type Client struct {
 	BrokerCity *string `json:"broker_city,omitempty" db:"broker_city"`
 
 	// Timestamps
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // set while in the trash
 }
 
 // CreateClientRequest represents the data required for creating a new client
@@ -66,10 +94,27 @@ type CreateClientRequest struct {
 	// Requirements/Enquiry
 	Requirements string `json:"requirements" validate:"required,min=5"`
 
+	// Structured Requirements (optional)
+	PropertyTypes       []string `json:"property_types,omitempty" validate:"omitempty,max=4,dive,oneof=apartment house commercial plot"`
+	ListingType         *string  `json:"listing_type,omitempty" validate:"omitempty,oneof=sale rent"` // defaults from type for buyers and tenants
+	BedroomsMin         *int     `json:"bedrooms_min,omitempty" validate:"omitempty,gte=0,lte=20"`
+	BedroomsMax         *int     `json:"bedrooms_max,omitempty" validate:"omitempty,gte=0,lte=20"`
+	AreaMin             *float64 `json:"area_min,omitempty" validate:"omitempty,gt=0"`
+	AreaMax             *float64 `json:"area_max,omitempty" validate:"omitempty,gt=0"`
+	PreferredLocalities []string `json:"preferred_localities,omitempty" validate:"omitempty,max=20,dive,min=2,max=255"`
+	RequiredAmenities   []string `json:"required_amenities,omitempty" validate:"omitempty,max=30,dive,min=2,max=100"`
+
 	// Optional Fields
 	BudgetMin *float64 `json:"budget_min,omitempty" validate:"omitempty,gt=0"`
 	BudgetMax *float64 `json:"budget_max,omitempty" validate:"omitempty,gt=0"`
 	Notes     *string  `json:"notes,omitempty"`
+
+	// Lead Attribution (optional)
+	Source   *string `json:"source,omitempty" validate:"omitempty,oneof=website portal referral walk_in other"`
+	Campaign *string `json:"campaign,omitempty" validate:"omitempty,max=100"`
+
+	// Tags (optional; matched to the broker's existing tags regardless of case)
+	Tags []string `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
 }
 
 // UpdateClientRequest represents the data that can be updated
@@ -94,8 +139,91 @@ type UpdateClientRequest struct {
 	// Requirements/Enquiry
 	Requirements *string `json:"requirements,omitempty" validate:"omitempty,min=5"`
 
+	// Structured Requirements (a list sent as [] clears it)
+	PropertyTypes       []string `json:"property_types,omitempty" validate:"omitempty,max=4,dive,oneof=apartment house commercial plot"`
+	ListingType         *string  `json:"listing_type,omitempty" validate:"omitempty,oneof=sale rent"`
+	BedroomsMin         *int     `json:"bedrooms_min,omitempty" validate:"omitempty,gte=0,lte=20"`
+	BedroomsMax         *int     `json:"bedrooms_max,omitempty" validate:"omitempty,gte=0,lte=20"`
+	AreaMin             *float64 `json:"area_min,omitempty" validate:"omitempty,gt=0"`
+	AreaMax             *float64 `json:"area_max,omitempty" validate:"omitempty,gt=0"`
+	PreferredLocalities []string `json:"preferred_localities,omitempty" validate:"omitempty,max=20,dive,min=2,max=255"`
+	RequiredAmenities   []string `json:"required_amenities,omitempty" validate:"omitempty,max=30,dive,min=2,max=100"`
+
 	// Optional Fields
 	BudgetMin *float64 `json:"budget_min,omitempty" validate:"omitempty,gt=0"`
 	BudgetMax *float64 `json:"budget_max,omitempty" validate:"omitempty,gt=0"`
 	Notes     *string  `json:"notes,omitempty"`
+
+	// Lead Attribution (optional)
+	Source   *string `json:"source,omitempty" validate:"omitempty,oneof=website portal referral walk_in other"`
+	Campaign *string `json:"campaign,omitempty" validate:"omitempty,max=100"`
+
+	// Tags (a list sent as [] clears it)
+	Tags []string `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// ClientFilters represents query filters for the client list
+type ClientFilters struct {
+	ClientSegmentFilters
+
+	// Saved segment whose filters apply to any filter not given in the request
+	SegmentID string `form:"segment_id" validate:"omitempty,uuid"`
+
+	// Sorting and cursor pagination
+	Sort   string `form:"sort" validate:"omitempty,oneof=created_at updated_at name budget_max lead_score"` // defaults to created_at
+	Order  string `form:"order" validate:"omitempty,oneof=asc desc"`                                        // defaults to asc for name, desc otherwise
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=200"`                                         // defaults to 50
+	Cursor string `form:"cursor" validate:"omitempty,max=500"`                                              // next_cursor of the previous page
+}
+
+// ClientSegmentFilters are the client list filters that select clients
+// The same filters are stored as JSON in client segment definitions
+// Requirement filters match clients whose stated requirements cover the given value
+type ClientSegmentFilters struct {
+	Type         *string  `form:"type" json:"type,omitempty" validate:"omitempty,oneof=buyer seller tenant owner"`
+	Status       *string  `form:"status" json:"status,omitempty" validate:"omitempty,oneof=active converted inactive"`
+	City         *string  `form:"city" json:"city,omitempty" validate:"omitempty,max=100"`
+	PropertyType *string  `form:"property_type" json:"property_type,omitempty" validate:"omitempty,oneof=apartment house commercial plot"`
+	ListingType  *string  `form:"listing_type" json:"listing_type,omitempty" validate:"omitempty,oneof=sale rent"`
+	Bedrooms     *int     `form:"bedrooms" json:"bedrooms,omitempty" validate:"omitempty,gte=0"`     // within the client's bedroom range
+	Area         *float64 `form:"area" json:"area,omitempty" validate:"omitempty,gt=0"`              // within the client's area range
+	Locality     *string  `form:"locality" json:"locality,omitempty" validate:"omitempty,max=255"`   // partial match on any preferred locality
+	Amenity      *string  `form:"amenity" json:"amenity,omitempty" validate:"omitempty,max=100"`     // listed among the must-have amenities
+	MinBudget    *float64 `form:"min_budget" json:"min_budget,omitempty" validate:"omitempty,gte=0"` // budget range reaches at least this
+	MaxBudget    *float64 `form:"max_budget" json:"max_budget,omitempty" validate:"omitempty,gte=0"` // budget range starts at most at this
+	Source       *string  `form:"source" json:"source,omitempty" validate:"omitempty,oneof=website portal referral walk_in other"`
+	Campaign     *string  `form:"campaign" json:"campaign,omitempty" validate:"omitempty,max=100"`
+	MinLeadScore *int     `form:"min_lead_score" json:"min_lead_score,omitempty" validate:"omitempty,min=0,max=100"`
+	Tags         []string `form:"tag" json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`                     // carries every given tag, case-insensitive
+	Contactable  *string  `form:"contactable" json:"contactable,omitempty" validate:"omitempty,oneof=whatsapp sms email call"` // consented to this channel
+
+	// Search and created date range (YYYY-MM-DD, inclusive)
+	Search      string `form:"q" json:"q,omitempty" validate:"omitempty,max=100"` // fuzzy name, partial email or phone digits
+	CreatedFrom string `form:"created_from" json:"created_from,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	CreatedTo   string `form:"created_to" json:"created_to,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// LeadScoreFactors breaks a lead score down into the points each signal contributed
+// Clients who are not active score zero on every factor
+type LeadScoreFactors struct {
+	Budget       int `json:"budget"`       // budget and structured requirements filled in, up to 20
+	Appointments int `json:"appointments"` // an upcoming appointment or one booked in the last 30 days, up to 25
+	Completions  int `json:"completions"`  // appointments completed in the last 90 days, up to 20
+	Matches      int `json:"matches"`      // available properties matching the requirements, up to 15
+	Recency      int `json:"recency"`      // how recently the client was edited or had a timeline entry, up to 20
+}
+
+// ClientSourceSummary counts a broker's clients brought in by one source and campaign
+type ClientSourceSummary struct {
+	Source    *string `json:"source"`   // null for clients with no recorded source
+	Campaign  *string `json:"campaign"` // null for clients with no campaign
+	Clients   int     `json:"clients"`
+	Active    int     `json:"active"`
+	Converted int     `json:"converted"`
+}
+
+// ClientSourceSummaryRequest represents the created date range of the lead source summary
+type ClientSourceSummaryRequest struct {
+	CreatedFrom string `form:"created_from" validate:"omitempty,datetime=2006-01-02"`
+	CreatedTo   string `form:"created_to" validate:"omitempty,datetime=2006-01-02"`
 }
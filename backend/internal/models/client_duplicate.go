@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+)
+
+// ClientDuplicate represents a likely duplicate client pair in a broker's book
+type ClientDuplicate struct {
+	ID       string `json:"id" db:"id"`
+	BrokerID string `json:"broker_id" db:"broker_id"`
+
+	// Pair of clients (stored in canonical order)
+	ClientAID string `json:"client_a_id" db:"client_a_id"`
+	ClientBID string `json:"client_b_id" db:"client_b_id"`
+
+	// Detection details
+	PhoneMatch     bool    `json:"phone_match" db:"phone_match"`
+	EmailMatch     bool    `json:"email_match" db:"email_match"`
+	NameSimilarity float64 `json:"name_similarity" db:"name_similarity"`
+
+	// Broker Review
+	Status     string     `json:"status" db:"status"` // pending, dismissed
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+
+	// Both clients, filled in for the review list
+	Clients []Client `json:"clients,omitempty"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ClientDuplicateWarning describes an existing client that looks like a duplicate of a created or updated one
+type ClientDuplicateWarning struct {
+	ClientID       string  `json:"client_id"`
+	FirstName      string  `json:"first_name"`
+	LastName       string  `json:"last_name"`
+	Email          string  `json:"email"`
+	Phone          string  `json:"phone"`
+	Status         string  `json:"status"`
+	PhoneMatch     bool    `json:"phone_match"`
+	EmailMatch     bool    `json:"email_match"`
+	NameSimilarity float64 `json:"name_similarity"`
+	Message        string  `json:"message"`
+}
+
+// ReviewClientDuplicateRequest represents a broker decision on a duplicate client pair
+type ReviewClientDuplicateRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending dismissed"`
+}
+
+// MergeClientsRequest names the duplicate client to fold into the client in the URL
+type MergeClientsRequest struct {
+	DuplicateClientID string `json:"duplicate_client_id" validate:"required,uuid"`
+}
+
+// ClientMergeResult reports the surviving client and how many records were moved onto it
+type ClientMergeResult struct {
+	Client         *Client          `json:"client"`
+	MergedClientID string           `json:"merged_client_id"`
+	Reassigned     map[string]int64 `json:"reassigned"` // moved record counts by table
+}
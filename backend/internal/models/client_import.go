@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+)
+
+// ClientImportFields lists the client fields file columns can be mapped to
+// name is a full name that is split into first_name and last_name
+var ClientImportFields = []string{
+	"name", "first_name", "last_name", "email", "phone", "type",
+	"preferred_location", "address", "city", "state", "postal_code",
+	"budget_min", "budget_max", "requirements", "notes", "source", "campaign",
+}
+
+// Duplicate policies for rows whose phone or email matches an existing client
+const (
+	ImportDuplicateSkip   = "skip"   // leave the existing client alone
+	ImportDuplicateMerge  = "merge"  // fill the existing client's blank fields from the row
+	ImportDuplicateCreate = "create" // create another client; it shows up for duplicate review
+)
+
+// Outcomes of an imported row
+const (
+	ImportRowCreated = "created"
+	ImportRowMerged  = "merged"
+	ImportRowSkipped = "skipped"
+	ImportRowFailed  = "failed"
+)
+
+// ClientImport represents a contacts file being imported into a broker's clients
+type ClientImport struct {
+	ID       string `json:"id" db:"id"`
+	BrokerID string `json:"broker_id" db:"broker_id"`
+
+	// Source file and options
+	FileName        string            `json:"file_name" db:"file_name"`
+	Format          string            `json:"format" db:"format"` // csv or vcard
+	DuplicatePolicy string            `json:"duplicate_policy" db:"duplicate_policy"`
+	DefaultType     string            `json:"default_type" db:"default_type"`
+	Mapping         map[string]string `json:"mapping,omitempty" db:"mapping"` // CSV column -> client field
+
+	// Progress
+	Status        string  `json:"status" db:"status"` // processing, completed, failed
+	TotalRows     int     `json:"total_rows" db:"total_rows"`
+	ProcessedRows int     `json:"processed_rows" db:"processed_rows"`
+	CreatedRows   int     `json:"created_rows" db:"created_rows"`
+	MergedRows    int     `json:"merged_rows" db:"merged_rows"`
+	SkippedRows   int     `json:"skipped_rows" db:"skipped_rows"`
+	FailedRows    int     `json:"failed_rows" db:"failed_rows"`
+	Error         *string `json:"error,omitempty" db:"error"` // why the whole import failed
+
+	// Every row that was not simply created, in file order
+	Report []ClientImportRowResult `json:"report,omitempty" db:"report"` // left out of import lists
+
+	// Timestamps
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// ClientImportRowResult reports what happened to one row of an import
+type ClientImportRowResult struct {
+	Row      int     `json:"row"` // line number in a CSV file, card number in a vCard file
+	Name     string  `json:"name,omitempty"`
+	Status   string  `json:"status"`
+	ClientID *string `json:"client_id,omitempty"` // the created client, or the existing one a duplicate matched
+	Message  string  `json:"message,omitempty"`
+}
+
+// ClientImportRequest represents the form fields sent alongside an uploaded contacts file
+type ClientImportRequest struct {
+	Format          string `form:"format" validate:"omitempty,oneof=csv vcard"`                   // inferred from the file name when omitted
+	DuplicatePolicy string `form:"duplicate_policy" validate:"omitempty,oneof=skip merge create"` // defaults to skip
+	DefaultType     string `form:"default_type" validate:"omitempty,oneof=buyer seller tenant owner"`
+	Mapping         string `form:"mapping" validate:"omitempty,max=5000"` // JSON object of CSV column -> client field
+}
+
+// ClientExportRequest selects the clients to export and the file format
+type ClientExportRequest struct {
+	ClientFilters
+	Format string `form:"format" validate:"omitempty,oneof=csv vcard"` // defaults to csv
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// ClientSegment represents a named, stored set of client list filters
+// Segments are resolved when used, so their clients change as the broker's book does
+type ClientSegment struct {
+	ID       string `json:"id" db:"id"`
+	BrokerID string `json:"broker_id" db:"broker_id"`
+
+	// Segment Definition
+	Name        string               `json:"name" db:"name"`
+	Description *string              `json:"description,omitempty" db:"description"`
+	Filters     ClientSegmentFilters `json:"filters" db:"filters"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateClientSegmentRequest represents the data required for creating a client segment
+type CreateClientSegmentRequest struct {
+	Name        string               `json:"name" validate:"required,min=2,max=100"`
+	Description *string              `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Filters     ClientSegmentFilters `json:"filters"`
+}
+
+// UpdateClientSegmentRequest represents the data that can be updated on a client segment
+type UpdateClientSegmentRequest struct {
+	Name        *string               `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Description *string               `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Filters     *ClientSegmentFilters `json:"filters,omitempty"`
+}
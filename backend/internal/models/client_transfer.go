@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+)
+
+// ClientTransfer represents clients offered by one broker to another
+// Nothing moves until the receiving broker accepts
+type ClientTransfer struct {
+	ID string `json:"id" db:"id"`
+
+	// Giving and receiving brokers, and the broker or admin who proposed the transfer
+	FromBrokerID   string  `json:"from_broker_id" db:"from_broker_id"`
+	FromBrokerName string  `json:"from_broker_name" db:"from_broker_name"`
+	ToBrokerID     string  `json:"to_broker_id" db:"to_broker_id"`
+	ToBrokerName   string  `json:"to_broker_name" db:"to_broker_name"`
+	RequestedBy    *string `json:"requested_by,omitempty" db:"requested_by"`
+
+	// Clients offered
+	ClientIDs []string               `json:"client_ids" db:"client_ids"`
+	Clients   []ClientTransferClient `json:"clients,omitempty"` // filled in for the transfer lists
+	Message   *string                `json:"message,omitempty" db:"message"`
+
+	// Response
+	Status      string     `json:"status" db:"status"` // pending, accepted, declined, cancelled
+	RespondedAt *time.Time `json:"responded_at,omitempty" db:"responded_at"`
+
+	// What acceptance moved: the clients, and their related records by table
+	TransferredCount int              `json:"transferred_count" db:"transferred_count"`
+	Moved            map[string]int64 `json:"moved,omitempty" db:"moved"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ClientTransferClient summarizes an offered client for a broker who does not own it yet
+type ClientTransferClient struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+	City      string `json:"city"`
+}
+
+// CreateClientTransferRequest offers some of the broker's clients to another broker
+type CreateClientTransferRequest struct {
+	ClientIDs     []string `json:"client_ids" validate:"required,min=1,max=500,dive,uuid"`
+	ToBrokerEmail string   `json:"to_broker_email" validate:"required,email"`
+	Message       *string  `json:"message,omitempty" validate:"omitempty,max=1000"`
+}
+
+// AdminClientTransferRequest offers a broker's clients to another broker on the giving broker's behalf,
+// for brokers who have left; leaving client_ids empty offers every client in the giving broker's book
+type AdminClientTransferRequest struct {
+	FromBrokerEmail string   `json:"from_broker_email" validate:"required,email"`
+	ToBrokerEmail   string   `json:"to_broker_email" validate:"required,email"`
+	ClientIDs       []string `json:"client_ids,omitempty" validate:"omitempty,max=5000,dive,uuid"`
+	Message         *string  `json:"message,omitempty" validate:"omitempty,max=1000"`
+}
+
+// ClientTransferFilters represents query parameters for listing transfers
+type ClientTransferFilters struct {
+	Direction string `form:"direction" validate:"omitempty,oneof=incoming outgoing"` // both when empty
+	Status    string `form:"status" validate:"omitempty,oneof=pending accepted declined cancelled"`
+}
+
+// ClientOwnershipChange records a client moving from one broker to another
+type ClientOwnershipChange struct {
+	ID             string    `json:"id" db:"id"`
+	ClientID       string    `json:"client_id" db:"client_id"`
+	TransferID     *string   `json:"transfer_id,omitempty" db:"transfer_id"`
+	FromBrokerID   *string   `json:"from_broker_id,omitempty" db:"from_broker_id"`
+	FromBrokerName *string   `json:"from_broker_name,omitempty" db:"from_broker_name"`
+	ToBrokerID     *string   `json:"to_broker_id,omitempty" db:"to_broker_id"`
+	ToBrokerName   *string   `json:"to_broker_name,omitempty" db:"to_broker_name"`
+	TransferredAt  time.Time `json:"transferred_at" db:"transferred_at"`
+}
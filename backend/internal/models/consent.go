@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+)
+
+// Channels a client can consent to be contacted on
+const (
+	ConsentChannelWhatsApp = "whatsapp"
+	ConsentChannelSMS      = "sms"
+	ConsentChannelEmail    = "email"
+	ConsentChannelCall     = "call"
+)
+
+// ConsentChannels lists every consent channel; the phone channels come first
+var ConsentChannels = []string{ConsentChannelWhatsApp, ConsentChannelSMS, ConsentChannelCall, ConsentChannelEmail}
+
+// ContactConsent is one entry in a broker's consent log for a phone number or email address
+// The latest entry for a channel and contact is the consent in force
+type ContactConsent struct {
+	ID       string `json:"id" db:"id"`
+	BrokerID string `json:"broker_id" db:"broker_id"`
+
+	// Contact: the last 10 phone digits for whatsapp, sms and call, the lowercased address for email
+	Channel  string  `json:"channel" db:"channel"`
+	Contact  string  `json:"contact" db:"contact"`
+	ClientID *string `json:"client_id,omitempty" db:"client_id"`
+
+	// Consent
+	Status string  `json:"status" db:"status"` // granted, withdrawn
+	Source string  `json:"source" db:"source"` // verbal, written, lead_form, opt_out_link, client_request, transfer, other
+	Note   *string `json:"note,omitempty" db:"note"`
+
+	// Audit; recorded_by is empty when the client recorded it themselves
+	RecordedBy *string   `json:"recorded_by,omitempty" db:"recorded_by"`
+	RecordedAt time.Time `json:"recorded_at" db:"recorded_at"`
+}
+
+// ChannelConsent is a client's current consent on one channel
+type ChannelConsent struct {
+	Channel    string     `json:"channel"`
+	Contact    string     `json:"contact,omitempty"` // empty when the client has no phone number or email for the channel
+	Status     string     `json:"status"`            // granted, withdrawn, none
+	Source     *string    `json:"source,omitempty"`
+	RecordedAt *time.Time `json:"recorded_at,omitempty"`
+}
+
+// ClientConsents is a client's current consent per channel and the log entries behind it, newest first
+type ClientConsents struct {
+	ClientID string           `json:"client_id"`
+	Channels []ChannelConsent `json:"channels"`
+	History  []ContactConsent `json:"history"`
+}
+
+// RecordConsentRequest records a client granting or withdrawing consent
+// Leaving channels empty applies it to every channel the client has a phone number or email for
+type RecordConsentRequest struct {
+	Channels []string `json:"channels,omitempty" validate:"omitempty,max=4,dive,oneof=whatsapp sms email call"`
+	Status   string   `json:"status" validate:"required,oneof=granted withdrawn"`
+	Source   string   `json:"source" validate:"required,oneof=verbal written client_request other"`
+	Note     *string  `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+
+// DoNotContactRequest adds a phone number and/or email address to the broker's do-not-contact registry,
+// whether or not it belongs to a client; leaving channels empty blocks every channel the contact applies to
+type DoNotContactRequest struct {
+	Phone    string   `json:"phone,omitempty" validate:"omitempty,max=30"`
+	Email    string   `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Channels []string `json:"channels,omitempty" validate:"omitempty,max=4,dive,oneof=whatsapp sms email call"`
+	Source   string   `json:"source,omitempty" validate:"omitempty,oneof=verbal written client_request other"`
+	Note     *string  `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+
+// DoNotContactFilters represents query parameters for the do-not-contact registry
+type DoNotContactFilters struct {
+	Channel string `form:"channel" validate:"omitempty,oneof=whatsapp sms email call"`
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"time"
+)
+
+// Deal records a transaction a broker closed for a client, and the brokerage it earns
+type Deal struct {
+	ID         string `json:"id" db:"id"`
+	BrokerID   string `json:"broker_id" db:"broker_id"`
+	BrokerName string `json:"broker_name" db:"broker_name"`
+
+	// Parties; client and property are empty once purged from the trash
+	ClientID      *string `json:"client_id,omitempty" db:"client_id"`
+	ClientName    *string `json:"client_name,omitempty" db:"client_name"`
+	PropertyID    *string `json:"property_id,omitempty" db:"property_id"`
+	PropertyTitle *string `json:"property_title,omitempty" db:"property_title"`
+
+	// Co-broker or channel partner and their percentage of the brokerage
+	PartnerID           *string `json:"partner_id,omitempty" db:"partner_id"`
+	PartnerName         *string `json:"partner_name,omitempty" db:"partner_name"`
+	PartnerSharePercent float64 `json:"partner_share_percent" db:"partner_share_percent"`
+
+	// Terms; dates are YYYY-MM-DD
+	AgreedPrice      float64  `json:"agreed_price" db:"agreed_price"`
+	TokenAmount      *float64 `json:"token_amount,omitempty" db:"token_amount"`
+	TokenDate        *string  `json:"token_date,omitempty" db:"token_date"`
+	BrokeragePercent *float64 `json:"brokerage_percent,omitempty" db:"brokerage_percent"`
+	BrokerageAmount  float64  `json:"brokerage_amount" db:"brokerage_amount"`
+
+	// Progress
+	Status            string  `json:"status" db:"status"` // token_paid, agreement_signed, completed, cancelled
+	ExpectedCloseDate *string `json:"expected_close_date,omitempty" db:"expected_close_date"`
+	ClosedOn          *string `json:"closed_on,omitempty" db:"closed_on"`
+	Notes             *string `json:"notes,omitempty" db:"notes"`
+
+	// Brokerage installments, soonest due first, and how much of the brokerage is in or still due
+	Milestones         []DealMilestone `json:"milestones"`
+	CommissionReceived float64         `json:"commission_received"`
+	CommissionPending  float64         `json:"commission_pending"` // zero once the deal is cancelled
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DealMilestone is one installment of a deal's brokerage
+type DealMilestone struct {
+	ID         string    `json:"id" db:"id"`
+	DealID     string    `json:"deal_id" db:"deal_id"`
+	Name       string    `json:"name" db:"name"`
+	Amount     float64   `json:"amount" db:"amount"`
+	DueDate    *string   `json:"due_date,omitempty" db:"due_date"`
+	Status     string    `json:"status" db:"status"` // pending, received
+	ReceivedOn *string   `json:"received_on,omitempty" db:"received_on"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateDealRequest represents the data required for recording a deal
+// The brokerage is given as brokerage_amount, or as brokerage_percent of the agreed price
+type CreateDealRequest struct {
+	ClientID   string  `json:"client_id" validate:"required,uuid"`
+	PropertyID *string `json:"property_id,omitempty" validate:"omitempty,uuid"`
+
+	PartnerEmail        *string  `json:"partner_email,omitempty" validate:"omitempty,email"`
+	PartnerSharePercent *float64 `json:"partner_share_percent,omitempty" validate:"omitempty,gte=0,lte=100"`
+
+	AgreedPrice      float64  `json:"agreed_price" validate:"required,gt=0"`
+	TokenAmount      *float64 `json:"token_amount,omitempty" validate:"omitempty,gte=0"`
+	TokenDate        *string  `json:"token_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	BrokeragePercent *float64 `json:"brokerage_percent,omitempty" validate:"omitempty,gte=0,lte=100"`
+	BrokerageAmount  *float64 `json:"brokerage_amount,omitempty" validate:"omitempty,gte=0"`
+
+	Status            *string `json:"status,omitempty" validate:"omitempty,oneof=token_paid agreement_signed completed cancelled"`
+	ExpectedCloseDate *string `json:"expected_close_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Notes             *string `json:"notes,omitempty" validate:"omitempty,max=5000"`
+
+	// Without milestones the whole brokerage is one installment due on the expected close date
+	Milestones []DealMilestoneRequest `json:"milestones,omitempty" validate:"omitempty,max=20,dive"`
+}
+
+// UpdateDealRequest represents the deal fields that can be changed
+// An empty partner_email removes the partner; changing the price or brokerage_percent recalculates
+// a percentage brokerage unless brokerage_amount is also given
+type UpdateDealRequest struct {
+	PropertyID *string `json:"property_id,omitempty" validate:"omitempty,uuid"`
+
+	PartnerEmail        *string  `json:"partner_email,omitempty" validate:"omitempty,max=255"`
+	PartnerSharePercent *float64 `json:"partner_share_percent,omitempty" validate:"omitempty,gte=0,lte=100"`
+
+	AgreedPrice      *float64 `json:"agreed_price,omitempty" validate:"omitempty,gt=0"`
+	TokenAmount      *float64 `json:"token_amount,omitempty" validate:"omitempty,gte=0"`
+	TokenDate        *string  `json:"token_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	BrokeragePercent *float64 `json:"brokerage_percent,omitempty" validate:"omitempty,gte=0,lte=100"`
+	BrokerageAmount  *float64 `json:"brokerage_amount,omitempty" validate:"omitempty,gte=0"`
+
+	Status            *string `json:"status,omitempty" validate:"omitempty,oneof=token_paid agreement_signed completed cancelled"`
+	ExpectedCloseDate *string `json:"expected_close_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	ClosedOn          *string `json:"closed_on,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Notes             *string `json:"notes,omitempty" validate:"omitempty,max=5000"`
+}
+
+// DealMilestoneRequest represents a brokerage installment added to a deal
+type DealMilestoneRequest struct {
+	Name    string  `json:"name" validate:"required,min=1,max=100"`
+	Amount  float64 `json:"amount" validate:"required,gt=0"`
+	DueDate *string `json:"due_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// UpdateDealMilestoneRequest represents the milestone fields that can be changed
+// Setting status to received records the payment, on received_on or today
+type UpdateDealMilestoneRequest struct {
+	Name       *string  `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Amount     *float64 `json:"amount,omitempty" validate:"omitempty,gt=0"`
+	DueDate    *string  `json:"due_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Status     *string  `json:"status,omitempty" validate:"omitempty,oneof=pending received"`
+	ReceivedOn *string  `json:"received_on,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// DealFilters represents query parameters for the deal list
+type DealFilters struct {
+	Role     string `form:"role" validate:"omitempty,oneof=broker partner"` // deals the user owns or shares; both by default
+	Status   string `form:"status" validate:"omitempty,oneof=token_paid agreement_signed completed cancelled"`
+	ClientID string `form:"client_id" validate:"omitempty,uuid"`
+}
+
+// CommissionReportRequest represents the months a commission report covers (YYYY-MM, inclusive)
+type CommissionReportRequest struct {
+	From string `form:"from" validate:"omitempty,datetime=2006-01"` // defaults to five months before to
+	To   string `form:"to" validate:"omitempty,datetime=2006-01"`   // defaults to the current month
+}
+
+// CommissionReport totals the user's share of brokerage earned and still due, month by month
+type CommissionReport struct {
+	From        string            `json:"from"`
+	To          string            `json:"to"`
+	Months      []CommissionMonth `json:"months"`
+	Earned      float64           `json:"earned"`      // received within the report's months
+	Pending     float64           `json:"pending"`     // due within the report's months and not yet received
+	Unscheduled float64           `json:"unscheduled"` // due on open deals with no due or expected close date
+}
+
+// CommissionMonth is the user's share of brokerage received and still due in one month
+type CommissionMonth struct {
+	Month   string  `json:"month"` // YYYY-MM
+	Earned  float64 `json:"earned"`
+	Pending float64 `json:"pending"`
+}
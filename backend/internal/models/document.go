@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+)
+
+// Document represents a file in the broker's documents vault
+// A document is filed under a property, a client, or both (e.g. a rent agreement)
+type Document struct {
+	ID         string  `json:"id" db:"id"`
+	BrokerID   string  `json:"broker_id" db:"broker_id"`
+	PropertyID *string `json:"property_id,omitempty" db:"property_id"`
+	ClientID   *string `json:"client_id,omitempty" db:"client_id"`
+
+	// Classification
+	DocumentType string `json:"document_type" db:"document_type"`
+	Title        string `json:"title" db:"title"`
+	Visibility   string `json:"visibility" db:"visibility"` // private, shareable
+
+	// Stored file; FileName is internal and never exposed
+	FileName     string `json:"-" db:"file_name"`
+	OriginalName string `json:"original_name" db:"original_name"`
+	ContentType  string `json:"content_type" db:"content_type"`
+	SizeBytes    int64  `json:"size_bytes" db:"size_bytes"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UploadDocumentRequest represents the form fields sent alongside an uploaded document
+type UploadDocumentRequest struct {
+	PropertyID   string `form:"property_id" validate:"omitempty,uuid"`
+	ClientID     string `form:"client_id" validate:"omitempty,uuid"`
+	DocumentType string `form:"document_type" validate:"required,oneof=sale_deed noc rera_certificate rent_agreement id_proof floor_plan tax_receipt other"`
+	Title        string `form:"title" validate:"omitempty,max=255"`                      // defaults to the uploaded file name
+	Visibility   string `form:"visibility" validate:"omitempty,oneof=private shareable"` // defaults to private
+}
+
+// UpdateDocumentRequest represents the request payload for updating document metadata
+type UpdateDocumentRequest struct {
+	DocumentType *string `json:"document_type,omitempty" validate:"omitempty,oneof=sale_deed noc rera_certificate rent_agreement id_proof floor_plan tax_receipt other"`
+	Title        *string `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
+	Visibility   *string `json:"visibility,omitempty" validate:"omitempty,oneof=private shareable"`
+}
+
+// DocumentFilters represents the filters for listing documents
+type DocumentFilters struct {
+	PropertyID   string `form:"property_id" validate:"omitempty,uuid"`
+	ClientID     string `form:"client_id" validate:"omitempty,uuid"`
+	DocumentType string `form:"document_type" validate:"omitempty,oneof=sale_deed noc rera_certificate rent_agreement id_proof floor_plan tax_receipt other"`
+	Visibility   string `form:"visibility" validate:"omitempty,oneof=private shareable"`
+}
+
+// DocumentDownload is a short-lived, signed URL for downloading a document
+type DocumentDownload struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
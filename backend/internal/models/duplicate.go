@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// PropertyDuplicate represents a likely duplicate listing pair awaiting admin review
+type PropertyDuplicate struct {
+	ID string `json:"id" db:"id"`
+
+	// Pair of properties (stored in canonical order)
+	PropertyAID string `json:"property_a_id" db:"property_a_id"`
+	PropertyBID string `json:"property_b_id" db:"property_b_id"`
+
+	// Detection details
+	AddressSimilarity float64 `json:"address_similarity" db:"address_similarity"`
+
+	// Admin Review
+	Status     string     `json:"status" db:"status"` // pending, confirmed, dismissed
+	ReviewedBy *string    `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DuplicateWarning describes an existing listing that looks like a duplicate of a created or updated one
+type DuplicateWarning struct {
+	PropertyID        string  `json:"property_id"`
+	Title             string  `json:"title"`
+	Address           string  `json:"address"`
+	Price             float64 `json:"price"`
+	Area              float64 `json:"area"`
+	BrokerID          string  `json:"broker_id"`
+	BrokerName        *string `json:"broker_name,omitempty"`
+	AddressSimilarity float64 `json:"address_similarity"`
+	SameBroker        bool    `json:"same_broker"`
+	Message           string  `json:"message"`
+}
+
+// DuplicateCluster groups listings connected by duplicate pairs for admin review
+type DuplicateCluster struct {
+	Properties []Property          `json:"properties"`
+	Pairs      []PropertyDuplicate `json:"pairs"`
+}
+
+// ReviewDuplicateRequest represents an admin decision on a duplicate pair
+type ReviewDuplicateRequest struct {
+	Status string `json:"status" validate:"required,oneof=confirmed dismissed"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// LeadForm is a broker's public lead capture form
+// Submissions to its key create clients in the broker's book
+type LeadForm struct {
+	ID       string `json:"id" db:"id"`
+	BrokerID string `json:"broker_id" db:"broker_id"`
+	FormKey  string `json:"form_key" db:"form_key"`
+
+	// Public URL submissions are posted to (not stored)
+	SubmitURL string `json:"submit_url" db:"-"`
+
+	// Options
+	Enabled     bool   `json:"enabled" db:"enabled"`
+	DefaultType string `json:"default_type" db:"default_type"` // client type given to leads that don't say
+
+	// Submission tracking
+	SubmissionCount  int        `json:"submission_count" db:"submission_count"`
+	SpamCount        int        `json:"spam_count" db:"spam_count"` // dropped by the honeypot or timing checks
+	LastSubmissionAt *time.Time `json:"last_submission_at,omitempty" db:"last_submission_at"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateLeadFormRequest represents the lead form options that can be changed
+type UpdateLeadFormRequest struct {
+	Enabled     *bool   `json:"enabled,omitempty"`
+	DefaultType *string `json:"default_type,omitempty" validate:"omitempty,oneof=buyer seller tenant owner"`
+}
+
+// LeadSubmission represents an enquiry posted to a public lead form, as JSON or form fields
+type LeadSubmission struct {
+	// Contact; a full name or first and last name, plus a phone number or email
+	Name      string `json:"name" form:"name" validate:"omitempty,max=200"`
+	FirstName string `json:"first_name" form:"first_name" validate:"omitempty,max=100"`
+	LastName  string `json:"last_name" form:"last_name" validate:"omitempty,max=100"`
+	Email     string `json:"email" form:"email" validate:"omitempty,max=255"`
+	Phone     string `json:"phone" form:"phone" validate:"omitempty,max=30"`
+
+	// Enquiry
+	Type              string   `json:"type" form:"type" validate:"omitempty,oneof=buyer seller tenant owner"`
+	PreferredLocation string   `json:"preferred_location" form:"preferred_location" validate:"omitempty,max=255"`
+	BudgetMax         *float64 `json:"budget_max,omitempty" form:"budget_max" validate:"omitempty,gt=0"`
+	Message           string   `json:"message" form:"message" validate:"omitempty,max=5000"`
+
+	// Attribution; source defaults to website and utm_campaign is accepted for campaign
+	Source      string `json:"source" form:"source" validate:"omitempty,oneof=website portal referral walk_in other"`
+	Campaign    string `json:"campaign" form:"campaign" validate:"omitempty,max=100"`
+	UTMCampaign string `json:"utm_campaign" form:"utm_campaign" validate:"omitempty,max=100"`
+
+	// Channels the lead agreed to be contacted on, e.g. from consent checkboxes on the form
+	Consent []string `json:"consent" form:"consent" validate:"omitempty,max=4,dive,oneof=whatsapp sms email call"`
+
+	// Spam protection: the website field is hidden from people and must stay empty, and
+	// form_loaded_at is when the form was shown (Unix time in seconds or milliseconds)
+	Website      string `json:"website" form:"website"`
+	FormLoadedAt int64  `json:"form_loaded_at" form:"form_loaded_at"`
+}
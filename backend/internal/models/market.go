@@ -0,0 +1,50 @@
+package models
+
+// MarketInsightsRequest represents the query for city-level market analytics
+type MarketInsightsRequest struct {
+	City   string `form:"city" validate:"required,min=2,max=100"`
+	Type   string `form:"type" validate:"omitempty,oneof=apartment house commercial plot"`
+	Months int    `form:"months" validate:"omitempty,min=1,max=36"` // lookback window, defaults to 12
+}
+
+// MarketInsights is the aggregated, anonymized market picture for a city
+// Groups covering too few listings or brokers are left out rather than reported
+type MarketInsights struct {
+	City        string `json:"city"`
+	Type        string `json:"type,omitempty"`
+	Months      int    `json:"months"`
+	MinListings int    `json:"min_listings"` // smallest sample any statistic is computed from
+	MinBrokers  int    `json:"min_brokers"`  // fewest distinct brokers any statistic covers
+
+	Inventory    []MarketInventory    `json:"inventory"`
+	Localities   []LocalityPrice      `json:"localities"`
+	DaysOnMarket []MarketDaysOnMarket `json:"days_on_market"`
+}
+
+// MarketInventory counts listings currently available for sale or rent
+type MarketInventory struct {
+	ListingType string `json:"listing_type"`
+	Listings    int    `json:"listings"`
+}
+
+// LocalityPrice is the median asking price per square foot in a locality
+// For rentals the price is the monthly rent
+type LocalityPrice struct {
+	Locality           string  `json:"locality"`
+	ListingType        string  `json:"listing_type"`
+	Listings           int     `json:"listings"`
+	MedianPricePerSqft float64 `json:"median_price_per_sqft"`
+}
+
+// MarketDaysOnMarket is how long listings took to sell or rent within the lookback window
+type MarketDaysOnMarket struct {
+	ListingType string  `json:"listing_type"`
+	Closed      int     `json:"closed"`
+	MedianDays  float64 `json:"median_days"`
+}
+
+// MarketCity is a city with enough listing data for market analytics
+type MarketCity struct {
+	City     string `json:"city"`
+	Listings int    `json:"listings"`
+}
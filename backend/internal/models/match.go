@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// PropertyMatch represents a scored pairing between a client and a property
+type PropertyMatch struct {
+	ID         string   `json:"id" db:"id"`
+	ClientID   string   `json:"client_id" db:"client_id"`
+	PropertyID string   `json:"property_id" db:"property_id"`
+	Score      int      `json:"score" db:"score"`
+	Reasons    []string `json:"reasons" db:"reasons"`
+
+	// Timestamps (CreatedAt marks when the match first surfaced)
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ClientPropertyMatch represents a matched property returned for a client
+type ClientPropertyMatch struct {
+	Score     int       `json:"score"`
+	Reasons   []string  `json:"reasons"`
+	MatchedAt time.Time `json:"matched_at"`
+	Property  Property  `json:"property"`
+}
+
+// PropertyClientMatch represents a matched client returned for a property
+type PropertyClientMatch struct {
+	Score     int       `json:"score"`
+	Reasons   []string  `json:"reasons"`
+	MatchedAt time.Time `json:"matched_at"`
+	Client    Client    `json:"client"`
+}
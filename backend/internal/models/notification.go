@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// Notification represents an in-app notification for a user
+type Notification struct {
+	ID     string `json:"id" db:"id"`
+	UserID string `json:"user_id" db:"user_id"`
+
+	// Content
+	Type    string `json:"type" db:"type"`
+	Title   string `json:"title" db:"title"`
+	Message string `json:"message" db:"message"`
+
+	// Related entity (e.g. the property a saved search matched)
+	EntityType *string `json:"entity_type,omitempty" db:"entity_type"`
+	EntityID   *string `json:"entity_id,omitempty" db:"entity_id"`
+
+	// Read State
+	IsRead bool       `json:"is_read" db:"is_read"`
+	ReadAt *time.Time `json:"read_at,omitempty" db:"read_at"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// NotificationFilters represents query filters for notifications
+type NotificationFilters struct {
+	UnreadOnly bool
+	Limit      int
+}
@@ -0,0 +1,8 @@
+package models
+
+// PageInfo describes a page of cursor-paginated results
+type PageInfo struct {
+	Limit      int    `json:"limit"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"` // pass as cursor to fetch the next page
+}
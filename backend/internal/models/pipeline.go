@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// PipelineStage represents one stage of a broker's sales pipeline
+// Kind marks closing stages: leads in won stages are converted, leads in lost stages need a reason
+type PipelineStage struct {
+	ID       string `json:"id" db:"id"`
+	BrokerID string `json:"broker_id" db:"broker_id"`
+	Name     string `json:"name" db:"name"`
+	Kind     string `json:"kind" db:"kind"`         // open, won or lost
+	Position int    `json:"position" db:"position"` // board order, starting at 1
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreatePipelineStageRequest represents the data required for adding a stage to the end of the pipeline
+type CreatePipelineStageRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+	Kind string `json:"kind,omitempty" validate:"omitempty,oneof=open won lost"` // defaults to open
+}
+
+// UpdatePipelineStageRequest represents the stage fields that can be updated
+type UpdatePipelineStageRequest struct {
+	Name *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Kind *string `json:"kind,omitempty" validate:"omitempty,oneof=open won lost"`
+}
+
+// ReorderPipelineStagesRequest lists every stage ID of the pipeline in the new board order
+type ReorderPipelineStagesRequest struct {
+	StageIDs []string `json:"stage_ids" validate:"required,min=1,dive,uuid"`
+}
+
+// MoveClientStageRequest represents moving a client to another pipeline stage
+type MoveClientStageRequest struct {
+	StageID    string  `json:"stage_id" validate:"required,uuid"`
+	LostReason *string `json:"lost_reason,omitempty" validate:"omitempty,min=3,max=500"` // required for lost stages
+}
+
+// StageTransition records a client moving between pipeline stages
+type StageTransition struct {
+	ID            string  `json:"id" db:"id"`
+	ClientID      string  `json:"client_id" db:"client_id"`
+	ChangedBy     *string `json:"changed_by,omitempty" db:"changed_by"` // empty when the client was placed automatically
+	FromStageID   *string `json:"from_stage_id,omitempty" db:"from_stage_id"`
+	FromStageName *string `json:"from_stage_name,omitempty" db:"from_stage_name"` // empty for the first stage a client entered
+	ToStageID     *string `json:"to_stage_id,omitempty" db:"to_stage_id"`         // empty once the stage is deleted
+	ToStageName   string  `json:"to_stage_name" db:"to_stage_name"`
+	LostReason    *string `json:"lost_reason,omitempty" db:"lost_reason"`
+
+	// Timestamp
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// PipelineBoardRequest represents the query for the pipeline board
+type PipelineBoardRequest struct {
+	Limit int `form:"limit" validate:"omitempty,min=1,max=100"` // clients shown per stage, defaults to 20
+}
+
+// PipelineBoardColumn is one stage of the pipeline board with its clients
+type PipelineBoardColumn struct {
+	Stage       PipelineStage `json:"stage"`
+	ClientCount int           `json:"client_count"`
+	TotalBudget float64       `json:"total_budget"` // sum of budget_max across every client in the stage
+	Clients     []Client      `json:"clients"`      // most recently moved first, up to the requested limit
+}
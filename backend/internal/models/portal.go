@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+)
+
+// PortalRole is the token role of a client signed in to the portal; staff routes reject it
+const PortalRole = "client"
+
+// PortalClientTypes are the client types that can sign in to the portal
+var PortalClientTypes = []string{"buyer", "tenant"}
+
+// PortalLoginRequest asks for a sign-in link to be emailed to a client
+type PortalLoginRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+// PortalSessionRequest exchanges the token from a sign-in link for a portal session
+type PortalSessionRequest struct {
+	Token string `json:"token" validate:"required,max=100"`
+}
+
+// PortalSession is the bearer token a signed-in client uses on the portal routes
+type PortalSession struct {
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	Client    PortalClient `json:"client"`
+}
+
+// PortalClient is the client's own record as shown on the portal, with their broker's contact details
+type PortalClient struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+
+	Broker *SharedListingBroker `json:"broker,omitempty"`
+}
+
+// PortalAppointment is an upcoming appointment as shown to the client; the broker's own notes are left out
+type PortalAppointment struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Date  string `json:"date"` // YYYY-MM-DD
+	Time  string `json:"time"` // HH:MM
+	Type  string `json:"type"`
+
+	Status            string     `json:"status"`
+	ClientResponse    *string    `json:"client_response,omitempty"` // confirmed, cancelled
+	ClientRespondedAt *time.Time `json:"client_responded_at,omitempty"`
+
+	PropertyID      *string `json:"property_id,omitempty"`
+	PropertyAddress *string `json:"property_address,omitempty"`
+}
+
+// PortalCancelRequest is the client's optional reason for cancelling an appointment
+type PortalCancelRequest struct {
+	Reason *string `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+// PortalProperty is a listing shared with the client, with the broker's note about it
+type PortalProperty struct {
+	PropertyID string        `json:"property_id"`
+	Note       *string       `json:"note,omitempty"`
+	SharedAt   time.Time     `json:"shared_at"`
+	Listing    SharedListing `json:"listing"`
+}
+
+// ClientSharedProperty is a listing a broker shared with a client on the portal
+type ClientSharedProperty struct {
+	ID             string    `json:"id" db:"id"`
+	ClientID       string    `json:"client_id" db:"client_id"`
+	PropertyID     string    `json:"property_id" db:"property_id"`
+	PropertyTitle  string    `json:"property_title" db:"property_title"`
+	PropertyStatus string    `json:"property_status" db:"property_status"`
+	Note           *string   `json:"note,omitempty" db:"note"`
+	SharedBy       *string   `json:"shared_by,omitempty" db:"shared_by"`
+	SharedAt       time.Time `json:"shared_at" db:"shared_at"`
+}
+
+// SharePropertiesRequest shares the broker's listings with a client on the portal
+// Sharing a listing again replaces its note
+type SharePropertiesRequest struct {
+	PropertyIDs []string `json:"property_ids" validate:"required,min=1,max=50,dive,uuid"`
+	Note        *string  `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"time"
+)
+
+// Project represents a builder project marketed by a channel partner
+type Project struct {
+	ID string `json:"id" db:"id"`
+
+	// Basic Project Information
+	Name        string `json:"name" db:"name"`
+	BuilderName string `json:"builder_name" db:"builder_name"`
+	ProjectType string `json:"project_type" db:"project_type"` // residential, commercial, mixed
+
+	// Location Information
+	Location string `json:"location" db:"location"`
+	City     string `json:"city" db:"city"`
+	State    string `json:"state" db:"state"`
+
+	// Pricing
+	PriceRangeMin float64 `json:"price_range_min" db:"price_range_min"`
+	PriceRangeMax float64 `json:"price_range_max" db:"price_range_max"`
+
+	// Inventory counts (computed from project_units)
+	TotalUnits     int `json:"total_units" db:"total_units"`
+	AvailableUnits int `json:"available_units" db:"available_units"`
+
+	// Description and Media
+	Description string   `json:"description" db:"description"`
+	Amenities   []string `json:"amenities" db:"amenities"`
+	Images      []string `json:"images" db:"images"`
+	BrochureURL *string  `json:"brochure_url,omitempty" db:"brochure_url"`
+
+	// Timeline and Status
+	LaunchDate     *string `json:"launch_date,omitempty" db:"launch_date"`
+	PossessionDate *string `json:"possession_date,omitempty" db:"possession_date"`
+	Status         string  `json:"status" db:"status"` // upcoming, launched, under_construction, ready
+
+	// Ownership
+	ChannelPartnerID string `json:"channel_partner_id" db:"channel_partner_id"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ProjectUnit represents a single unit in a project's inventory
+type ProjectUnit struct {
+	ID        string `json:"id" db:"id"`
+	ProjectID string `json:"project_id" db:"project_id"`
+
+	// Unit Identification
+	Tower      string `json:"tower" db:"tower"`
+	Floor      int    `json:"floor" db:"floor"`
+	UnitNumber string `json:"unit_number" db:"unit_number"`
+
+	// Unit Details
+	Configuration string   `json:"configuration" db:"configuration"` // e.g. 1BHK, 2BHK, Shop
+	CarpetArea    *float64 `json:"carpet_area,omitempty" db:"carpet_area"`
+	Price         float64  `json:"price" db:"price"`
+
+	// Inventory Status (expired blocks are reported as available)
+	Status string `json:"status" db:"status"` // available, blocked, booked
+
+	// Block held by a broker for one of their clients
+	BlockedByBrokerID  *string    `json:"blocked_by_broker_id,omitempty" db:"blocked_by_broker_id"`
+	BlockedForClientID *string    `json:"blocked_for_client_id,omitempty" db:"blocked_for_client_id"`
+	BlockedAt          *time.Time `json:"blocked_at,omitempty" db:"blocked_at"`
+	BlockExpiresAt     *time.Time `json:"block_expires_at,omitempty" db:"block_expires_at"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ProjectDetail represents a project together with its unit inventory
+type ProjectDetail struct {
+	Project
+	Units []ProjectUnit `json:"units"`
+}
+
+// CreateProjectRequest represents the data required for creating a project
+type CreateProjectRequest struct {
+	// Basic Project Information
+	Name        string `json:"name" validate:"required,min=2,max=255"`
+	BuilderName string `json:"builder_name" validate:"required,min=2,max=255"`
+	ProjectType string `json:"project_type" validate:"required,oneof=residential commercial mixed"`
+
+	// Location Information
+	Location string `json:"location" validate:"required,min=2,max=255"`
+	City     string `json:"city" validate:"required,min=2,max=100"`
+	State    string `json:"state" validate:"required,min=2,max=100"`
+
+	// Pricing
+	PriceRangeMin float64 `json:"price_range_min" validate:"required,gt=0"`
+	PriceRangeMax float64 `json:"price_range_max" validate:"required,gt=0"`
+
+	// Description and Media
+	Description string   `json:"description" validate:"required,min=20"`
+	Amenities   []string `json:"amenities"`
+	Images      []string `json:"images"`
+
+	// Timeline and Status
+	LaunchDate     *string `json:"launch_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	PossessionDate *string `json:"possession_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Status         string  `json:"status" validate:"omitempty,oneof=upcoming launched under_construction ready"`
+}
+
+// UpdateProjectRequest represents the data that can be updated for a project
+type UpdateProjectRequest struct {
+	// Basic Project Information
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	BuilderName *string `json:"builder_name,omitempty" validate:"omitempty,min=2,max=255"`
+	ProjectType *string `json:"project_type,omitempty" validate:"omitempty,oneof=residential commercial mixed"`
+
+	// Location Information
+	Location *string `json:"location,omitempty" validate:"omitempty,min=2,max=255"`
+	City     *string `json:"city,omitempty" validate:"omitempty,min=2,max=100"`
+	State    *string `json:"state,omitempty" validate:"omitempty,min=2,max=100"`
+
+	// Pricing
+	PriceRangeMin *float64 `json:"price_range_min,omitempty" validate:"omitempty,gt=0"`
+	PriceRangeMax *float64 `json:"price_range_max,omitempty" validate:"omitempty,gt=0"`
+
+	// Description and Media
+	Description *string  `json:"description,omitempty" validate:"omitempty,min=20"`
+	Amenities   []string `json:"amenities,omitempty"`
+	Images      []string `json:"images,omitempty"`
+
+	// Timeline and Status
+	LaunchDate     *string `json:"launch_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	PossessionDate *string `json:"possession_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Status         *string `json:"status,omitempty" validate:"omitempty,oneof=upcoming launched under_construction ready"`
+}
+
+// CreateProjectUnitRequest represents a single unit to add to a project
+type CreateProjectUnitRequest struct {
+	Tower         string   `json:"tower" validate:"required,max=50"`
+	Floor         int      `json:"floor" validate:"gte=-5"`
+	UnitNumber    string   `json:"unit_number" validate:"required,max=50"`
+	Configuration string   `json:"configuration" validate:"required,max=50"`
+	CarpetArea    *float64 `json:"carpet_area,omitempty" validate:"omitempty,gt=0"`
+	Price         float64  `json:"price" validate:"required,gt=0"`
+}
+
+// CreateProjectUnitsRequest represents a batch of units to add to a project
+type CreateProjectUnitsRequest struct {
+	Units []CreateProjectUnitRequest `json:"units" validate:"required,min=1,max=500,dive"`
+}
+
+// UpdateProjectUnitRequest represents the data a channel partner can update on a unit
+// Setting status to available releases any block; booked finalises the sale
+// Units can only be blocked by brokers through the block endpoint
+type UpdateProjectUnitRequest struct {
+	Configuration *string  `json:"configuration,omitempty" validate:"omitempty,max=50"`
+	CarpetArea    *float64 `json:"carpet_area,omitempty" validate:"omitempty,gt=0"`
+	Price         *float64 `json:"price,omitempty" validate:"omitempty,gt=0"`
+	Status        *string  `json:"status,omitempty" validate:"omitempty,oneof=available booked"`
+}
+
+// BlockUnitRequest represents a broker blocking a unit for a client
+type BlockUnitRequest struct {
+	ClientID string `json:"client_id" validate:"required,uuid"`
+}
+
+// ProjectFilters represents query filters for browsing projects
+type ProjectFilters struct {
+	City        *string `json:"city,omitempty" validate:"omitempty,min=2,max=100"`
+	Status      *string `json:"status,omitempty" validate:"omitempty,oneof=upcoming launched under_construction ready"`
+	ProjectType *string `json:"project_type,omitempty" validate:"omitempty,oneof=residential commercial mixed"`
+}
+
+// ProjectUnitFilters represents query filters for a project's units
+type ProjectUnitFilters struct {
+	Status        *string `json:"status,omitempty" validate:"omitempty,oneof=available blocked booked"`
+	Configuration *string `json:"configuration,omitempty" validate:"omitempty,max=50"`
+	Tower         *string `json:"tower,omitempty" validate:"omitempty,max=50"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
@@ -10,8 +11,8 @@ type Property struct {
 
 	// Basic Property Information
 	Title       string `json:"title" db:"title"`
-	Type        string `json:"type" db:"type"`                   // apartment, house, commercial, plot
-	ListingType string `json:"listing_type" db:"listing_type"`   // sale, rent
+	Type        string `json:"type" db:"type"`                 // apartment, house, commercial, plot
+	ListingType string `json:"listing_type" db:"listing_type"` // sale, rent
 
 	// Pricing and Size
 	Price float64 `json:"price" db:"price"`
@@ -31,17 +32,28 @@ type Property struct {
 	Description string   `json:"description" db:"description"`
 	Amenities   []string `json:"amenities" db:"amenities"`
 
+	// Media
+	Images []string `json:"images" db:"images"` // image URLs, first is the cover image
+
+	// Tags: the broker's own labels for grouping listings, never shown to other brokers
+	Tags []string `json:"tags" db:"tags"`
+
 	// Status and Ownership
-	Status   string `json:"status" db:"status"`       // available, sold, rented, under_negotiation
+	Status   string `json:"status" db:"status"` // available, sold, rented, under_negotiation, archived
 	BrokerID string `json:"broker_id" db:"broker_id"`
 
+	// Listing lifetime; available listings are archived once they expire
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+
 	// Denormalized broker info for admin queries
 	BrokerName *string `json:"broker_name,omitempty" db:"broker_name"`
 	BrokerCity *string `json:"broker_city,omitempty" db:"broker_city"`
 
 	// Timestamps
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // set while in the trash
 }
 
 // CreatePropertyRequest represents the data required for creating a new property
@@ -68,6 +80,15 @@ type CreatePropertyRequest struct {
 	// Description and Features
 	Description string   `json:"description" validate:"required,min=20"`
 	Amenities   []string `json:"amenities"`
+
+	// Media
+	Images []string `json:"images" validate:"omitempty,max=20,dive,max=500"`
+
+	// Tags (optional; matched to the broker's existing tags regardless of case)
+	Tags []string `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+
+	// Listing lifetime (defaults to the configured listing TTL)
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 // UpdatePropertyRequest represents the data that can be updated for an existing property
@@ -95,6 +116,88 @@ type UpdatePropertyRequest struct {
 	Description *string  `json:"description,omitempty" validate:"omitempty,min=20"`
 	Amenities   []string `json:"amenities,omitempty"`
 
+	// Media
+	Images []string `json:"images,omitempty" validate:"omitempty,max=20,dive,max=500"`
+
+	// Tags (a list sent as [] clears it)
+	Tags []string `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+
 	// Status
-	Status *string `json:"status,omitempty" validate:"omitempty,oneof=available sold rented under_negotiation"`
+	Status *string `json:"status,omitempty" validate:"omitempty,oneof=available sold rented under_negotiation archived"`
+
+	// Listing lifetime
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// RenewPropertyRequest represents the request payload for renewing a listing
+type RenewPropertyRequest struct {
+	ExpiresInDays *int `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=365"` // defaults to the configured listing TTL
+}
+
+// PropertyFilters represents query filters for property search
+// The same filters are stored as JSON in saved search definitions
+type PropertyFilters struct {
+	Type        *string  `json:"type,omitempty" validate:"omitempty,oneof=apartment house commercial plot"`
+	ListingType *string  `json:"listing_type,omitempty" validate:"omitempty,oneof=sale rent"`
+	Status      *string  `json:"status,omitempty" validate:"omitempty,oneof=available sold rented under_negotiation archived"` // archived listings are excluded unless requested
+	City        *string  `json:"city,omitempty" validate:"omitempty,max=100"`
+	Location    *string  `json:"location,omitempty" validate:"omitempty,max=255"` // partial match on location or address
+	MinPrice    *float64 `json:"min_price,omitempty" validate:"omitempty,gte=0"`
+	MaxPrice    *float64 `json:"max_price,omitempty" validate:"omitempty,gte=0"`
+	MinArea     *float64 `json:"min_area,omitempty" validate:"omitempty,gte=0"`
+	MaxArea     *float64 `json:"max_area,omitempty" validate:"omitempty,gte=0"`
+	MinBedrooms *int     `json:"min_bedrooms,omitempty" validate:"omitempty,gte=0"`
+	MaxBedrooms *int     `json:"max_bedrooms,omitempty" validate:"omitempty,gte=0"`
+
+	// Tags only filter the broker's own listings, so they are not part of saved searches
+	Tags []string `json:"-" validate:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// Matches reports whether a property satisfies every filter that is set
+// Mirrors the SQL applied by the property repository so saved searches can be evaluated in memory
+func (f PropertyFilters) Matches(p *Property) bool {
+	if f.Type != nil && p.Type != *f.Type {
+		return false
+	}
+	if f.ListingType != nil && p.ListingType != *f.ListingType {
+		return false
+	}
+	if f.Status != nil && p.Status != *f.Status {
+		return false
+	}
+	if f.Status == nil && p.Status == "archived" {
+		return false
+	}
+	if p.DeletedAt != nil {
+		return false
+	}
+	if f.City != nil && !strings.EqualFold(p.City, *f.City) {
+		return false
+	}
+	if f.Location != nil {
+		needle := strings.ToLower(*f.Location)
+		if !strings.Contains(strings.ToLower(p.Location), needle) &&
+			!strings.Contains(strings.ToLower(p.Address), needle) {
+			return false
+		}
+	}
+	if f.MinPrice != nil && p.Price < *f.MinPrice {
+		return false
+	}
+	if f.MaxPrice != nil && p.Price > *f.MaxPrice {
+		return false
+	}
+	if f.MinArea != nil && p.Area < *f.MinArea {
+		return false
+	}
+	if f.MaxArea != nil && p.Area > *f.MaxArea {
+		return false
+	}
+	if f.MinBedrooms != nil && (p.Bedrooms == nil || *p.Bedrooms < *f.MinBedrooms) {
+		return false
+	}
+	if f.MaxBedrooms != nil && (p.Bedrooms == nil || *p.Bedrooms > *f.MaxBedrooms) {
+		return false
+	}
+	return true
 }
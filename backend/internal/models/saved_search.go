@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// SavedSearch represents a stored property search that alerts its owner about new matching listings
+type SavedSearch struct {
+	ID string `json:"id" db:"id"`
+
+	// Ownership (ClientID is set when the search is kept on behalf of a client)
+	UserID   string  `json:"user_id" db:"user_id"`
+	ClientID *string `json:"client_id,omitempty" db:"client_id"`
+
+	// Search Definition
+	Name    string          `json:"name" db:"name"`
+	Filters PropertyFilters `json:"filters" db:"filters"`
+
+	// Delivery Preferences
+	NotifyInApp    bool       `json:"notify_in_app" db:"notify_in_app"`
+	NotifyEmail    bool       `json:"notify_email" db:"notify_email"`
+	IsActive       bool       `json:"is_active" db:"is_active"`
+	LastNotifiedAt *time.Time `json:"last_notified_at,omitempty" db:"last_notified_at"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateSavedSearchRequest represents the data required for creating a saved search
+type CreateSavedSearchRequest struct {
+	Name     string          `json:"name" validate:"required,min=2,max=100"`
+	ClientID *string         `json:"client_id,omitempty" validate:"omitempty,uuid"`
+	Filters  PropertyFilters `json:"filters"`
+
+	// Delivery Preferences (both default to true)
+	NotifyInApp *bool `json:"notify_in_app,omitempty"`
+	NotifyEmail *bool `json:"notify_email,omitempty"`
+}
+
+// UpdateSavedSearchRequest represents the data that can be updated on a saved search
+type UpdateSavedSearchRequest struct {
+	Name        *string          `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Filters     *PropertyFilters `json:"filters,omitempty"`
+	NotifyInApp *bool            `json:"notify_in_app,omitempty"`
+	NotifyEmail *bool            `json:"notify_email,omitempty"`
+	IsActive    *bool            `json:"is_active,omitempty"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+)
+
+// ShareLink represents a public, tokenized link to a property listing
+type ShareLink struct {
+	ID         string `json:"id" db:"id"`
+	PropertyID string `json:"property_id" db:"property_id"`
+	BrokerID   string `json:"broker_id" db:"broker_id"`
+	Token      string `json:"token" db:"token"`
+
+	// Public URL of the listing page (not stored)
+	URL string `json:"url" db:"-"`
+
+	// Presentation options
+	ShowBrokerContact bool `json:"show_broker_contact" db:"show_broker_contact"`
+	HideAddress       bool `json:"hide_address" db:"hide_address"`
+
+	// Lifetime
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+
+	// View tracking
+	ViewCount    int        `json:"view_count" db:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty" db:"last_viewed_at"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateShareLinkRequest represents the options for sharing a property
+type CreateShareLinkRequest struct {
+	ShowBrokerContact *bool `json:"show_broker_contact,omitempty"` // defaults to true
+	HideAddress       *bool `json:"hide_address,omitempty"`        // defaults to false
+	ExpiresInDays     *int  `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=365"`
+}
+
+// SharedListing is the public view of a property behind a share link
+// Only fields safe for anyone holding the link are included
+type SharedListing struct {
+	Title       string   `json:"title"`
+	Type        string   `json:"type"`
+	ListingType string   `json:"listing_type"`
+	Price       float64  `json:"price"`
+	Area        float64  `json:"area"`
+	Bedrooms    *int     `json:"bedrooms,omitempty"`
+	Bathrooms   *int     `json:"bathrooms,omitempty"`
+	Location    string   `json:"location"`
+	Address     string   `json:"address,omitempty"` // empty when the link hides the exact address
+	City        string   `json:"city"`
+	State       string   `json:"state"`
+	Description string   `json:"description"`
+	Amenities   []string `json:"amenities"`
+	Images      []string `json:"images"` // absolute URLs
+	Status      string   `json:"status"`
+
+	// Broker branding, present when the link shows contact details
+	Broker *SharedListingBroker `json:"broker,omitempty"`
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// SharedListingBroker represents the broker contact shown on a shared listing
+type SharedListingBroker struct {
+	Name           string  `json:"name"`
+	FirmName       string  `json:"firm_name"`
+	Email          string  `json:"email"`
+	WhatsappNumber string  `json:"whatsapp_number"`
+	ProfileImage   *string `json:"profile_image,omitempty"`
+}
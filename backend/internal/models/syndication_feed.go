@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+)
+
+// FeedSourceFields lists the property fields that can be mapped into a syndication feed
+// Keep in sync with the oneof list on FeedFieldMapping.Source
+var FeedSourceFields = []string{
+	"id", "title", "type", "listing_type", "price", "area", "bedrooms", "bathrooms",
+	"location", "address", "city", "state", "description", "amenities", "images",
+	"status", "broker_name", "created_at", "updated_at",
+}
+
+// SyndicationFeed represents a broker's export of listings to an external property portal
+type SyndicationFeed struct {
+	ID       string `json:"id" db:"id"`
+	BrokerID string `json:"broker_id" db:"broker_id"`
+
+	// Feed definition
+	Name        string             `json:"name" db:"name"`
+	Portal      string             `json:"portal" db:"portal"`
+	Format      string             `json:"format" db:"format"` // xml, json
+	RootElement string             `json:"root_element" db:"root_element"`
+	ItemElement string             `json:"item_element" db:"item_element"`
+	Fields      []FeedFieldMapping `json:"fields" db:"fields"`
+	IsActive    bool               `json:"is_active" db:"is_active"`
+
+	// Scheduled generation state
+	LastGeneratedAt *time.Time `json:"last_generated_at,omitempty" db:"last_generated_at"`
+	LastFilePath    *string    `json:"last_file_path,omitempty" db:"last_file_path"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// FeedFieldMapping maps a property field to the element/key name a portal expects
+type FeedFieldMapping struct {
+	Source string `json:"source" validate:"required,oneof=id title type listing_type price area bedrooms bathrooms location address city state description amenities images status broker_name created_at updated_at"`
+	Target string `json:"target" validate:"required,max=100"`
+}
+
+// CreateSyndicationFeedRequest represents the data required for creating a feed
+// An empty field mapping exports every source field under its own name
+type CreateSyndicationFeedRequest struct {
+	Name        string             `json:"name" validate:"required,min=2,max=255"`
+	Portal      string             `json:"portal" validate:"required,min=2,max=100"`
+	Format      string             `json:"format" validate:"required,oneof=xml json"`
+	RootElement string             `json:"root_element" validate:"omitempty,max=100"`
+	ItemElement string             `json:"item_element" validate:"omitempty,max=100"`
+	Fields      []FeedFieldMapping `json:"fields" validate:"omitempty,dive"`
+	IsActive    *bool              `json:"is_active,omitempty"` // defaults to true
+}
+
+// UpdateSyndicationFeedRequest represents the data that can be updated for a feed
+type UpdateSyndicationFeedRequest struct {
+	Name        *string            `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	Portal      *string            `json:"portal,omitempty" validate:"omitempty,min=2,max=100"`
+	Format      *string            `json:"format,omitempty" validate:"omitempty,oneof=xml json"`
+	RootElement *string            `json:"root_element,omitempty" validate:"omitempty,max=100"`
+	ItemElement *string            `json:"item_element,omitempty" validate:"omitempty,max=100"`
+	Fields      []FeedFieldMapping `json:"fields,omitempty" validate:"omitempty,dive"`
+	IsActive    *bool              `json:"is_active,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// Tag represents one of a broker's tags with how many of their clients and properties carry it
+type Tag struct {
+	Name          string `json:"name"`
+	ClientCount   int    `json:"client_count"`
+	PropertyCount int    `json:"property_count"`
+}
+
+// RenameTagRequest represents the request payload for renaming a tag across a broker's records
+// Renaming onto an existing tag merges the two
+type RenameTagRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+// BulkTagRequest adds and removes tags on several of the broker's clients or properties at once
+type BulkTagRequest struct {
+	IDs    []string `json:"ids" validate:"required,min=1,max=1000,dive,uuid"`
+	Add    []string `json:"add,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+	Remove []string `json:"remove,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// BulkTagResult reports how many records a bulk tag change updated
+type BulkTagResult struct {
+	Updated int64 `json:"updated"`
+}
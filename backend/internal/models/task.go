@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+)
+
+// Task represents a follow-up a broker has to do, optionally about a client or property
+type Task struct {
+	ID         string `json:"id" db:"id"`
+	BrokerID   string `json:"broker_id" db:"broker_id"`     // who created the task
+	AssigneeID string `json:"assignee_id" db:"assignee_id"` // who has to do it
+
+	// Related client and property
+	ClientID      *string `json:"client_id,omitempty" db:"client_id"`
+	PropertyID    *string `json:"property_id,omitempty" db:"property_id"`
+	ClientName    *string `json:"client_name,omitempty" db:"client_name"`
+	PropertyTitle *string `json:"property_title,omitempty" db:"property_title"`
+	AssigneeName  *string `json:"assignee_name,omitempty" db:"assignee_name"`
+
+	// Task Details
+	Title       string  `json:"title" db:"title"`
+	Description *string `json:"description,omitempty" db:"description"`
+	Priority    string  `json:"priority" db:"priority"` // low, medium or high
+	Status      string  `json:"status" db:"status"`     // open or done
+
+	// Scheduling
+	DueAt       time.Time  `json:"due_at" db:"due_at"`
+	RemindAt    *time.Time `json:"remind_at,omitempty" db:"remind_at"`
+	RemindedAt  *time.Time `json:"reminded_at,omitempty" db:"reminded_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateTaskRequest represents the data required for creating a task
+type CreateTaskRequest struct {
+	Title           string  `json:"title" validate:"required,min=2,max=255"`
+	Description     *string `json:"description,omitempty" validate:"omitempty,max=2000"`
+	ClientID        *string `json:"client_id,omitempty" validate:"omitempty,uuid"`
+	PropertyID      *string `json:"property_id,omitempty" validate:"omitempty,uuid"`
+	AssigneeID      *string `json:"assignee_id,omitempty" validate:"omitempty,uuid"` // defaults to the creator
+	DueAt           string  `json:"due_at" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Priority        string  `json:"priority,omitempty" validate:"omitempty,oneof=low medium high"`   // defaults to medium
+	ReminderMinutes *int    `json:"reminder_minutes,omitempty" validate:"omitempty,min=0,max=10080"` // minutes before due_at, defaults to TASK_REMINDER_LEAD
+}
+
+// UpdateTaskRequest represents the task fields that can be updated
+type UpdateTaskRequest struct {
+	Title           *string `json:"title,omitempty" validate:"omitempty,min=2,max=255"`
+	Description     *string `json:"description,omitempty" validate:"omitempty,max=2000"`
+	ClientID        *string `json:"client_id,omitempty" validate:"omitempty,uuid"`
+	PropertyID      *string `json:"property_id,omitempty" validate:"omitempty,uuid"`
+	AssigneeID      *string `json:"assignee_id,omitempty" validate:"omitempty,uuid"`
+	DueAt           *string `json:"due_at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Priority        *string `json:"priority,omitempty" validate:"omitempty,oneof=low medium high"`
+	Status          *string `json:"status,omitempty" validate:"omitempty,oneof=open done"`
+	ReminderMinutes *int    `json:"reminder_minutes,omitempty" validate:"omitempty,min=0,max=10080"`
+}
+
+// TaskFilters represents query filters for task lists
+type TaskFilters struct {
+	View       string `form:"view" validate:"omitempty,oneof=overdue today upcoming"` // overdue: open and past due; today: open and due today; upcoming: open and due after today
+	Status     string `form:"status" validate:"omitempty,oneof=open done"`            // ignored by views, which only list open tasks
+	Scope      string `form:"scope" validate:"omitempty,oneof=assigned created all"`  // assigned to me, created by me, or both (default)
+	Priority   string `form:"priority" validate:"omitempty,oneof=low medium high"`
+	ClientID   string `form:"client_id" validate:"omitempty,uuid"`
+	PropertyID string `form:"property_id" validate:"omitempty,uuid"`
+	Timezone   string `form:"tz" validate:"omitempty,max=64"` // IANA zone that decides what "today" is, defaults to TASK_TIMEZONE
+}
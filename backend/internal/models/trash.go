@@ -0,0 +1,9 @@
+package models
+
+// Trash lists a broker's soft-deleted records awaiting restore or purge
+type Trash struct {
+	Properties    []Property    `json:"properties"`
+	Clients       []Client      `json:"clients"`
+	Appointments  []Appointment `json:"appointments"`
+	RetentionDays int           `json:"retention_days"` // deleted records are purged after this many days
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ActivityRepository handles database operations for client activities and the merged client timeline
+type ActivityRepository struct {
+	db *database.DB
+}
+
+// timelineColumns lists the timeline columns in the order expected by scanClientActivity
+const timelineColumns = `
+	id, client_id, broker_id, author_id, author_name, appointment_id,
+	source, type, body, occurred_at, created_at`
+
+// activitySelect selects logged activities with timelineColumns
+const activitySelect = `
+	SELECT a.id, a.client_id, a.broker_id, a.author_id,
+		NULLIF(TRIM(u.first_name || ' ' || u.last_name), '') AS author_name, a.appointment_id,
+		'` + models.TimelineSourceActivity + `' AS source, a.type, a.body, a.occurred_at, a.created_at
+	FROM client_activities a
+	LEFT JOIN users u ON u.id = a.author_id`
+
+// stageChangeSelect selects pipeline stage transitions with timelineColumns
+const stageChangeSelect = `
+	SELECT t.id, t.client_id, c.broker_id, t.changed_by,
+		NULLIF(TRIM(u.first_name || ' ' || u.last_name), '') AS author_name, NULL::UUID,
+		'` + models.TimelineSourcePipeline + `' AS source, '` + models.ActivityStageChange + `' AS type,
+		CASE
+			WHEN t.from_stage_name IS NULL THEN 'Entered ' || t.to_stage_name
+			ELSE 'Moved from ' || t.from_stage_name || ' to ' || t.to_stage_name
+		END || COALESCE(': ' || t.lost_reason, ''),
+		t.created_at, t.created_at
+	FROM client_stage_transitions t
+	JOIN clients c ON c.id = t.client_id
+	LEFT JOIN users u ON u.id = t.changed_by`
+
+// timelineCursor is the position of the last entry on a timeline page
+type timelineCursor struct {
+	OccurredAt time.Time `json:"t"`
+	ID         string    `json:"id"`
+}
+
+// NewActivityRepository creates a new ActivityRepository instance
+func NewActivityRepository(db *database.DB) *ActivityRepository {
+	return &ActivityRepository{db: db}
+}
+
+// Create inserts a new activity; a zero OccurredAt is recorded as now
+func (r *ActivityRepository) Create(activity *models.ClientActivity) error {
+	query := `
+		INSERT INTO client_activities (client_id, broker_id, author_id, appointment_id, type, body, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, NOW()))
+		RETURNING id, occurred_at, created_at
+	`
+
+	var occurredAt *time.Time
+	if !activity.OccurredAt.IsZero() {
+		occurredAt = &activity.OccurredAt
+	}
+
+	err := r.db.QueryRow(
+		query,
+		activity.ClientID,
+		activity.BrokerID,
+		activity.AuthorID,
+		activity.AppointmentID,
+		activity.Type,
+		activity.Body,
+		occurredAt,
+	).Scan(
+		&activity.ID,
+		&activity.OccurredAt,
+		&activity.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create client activity: %w", err)
+	}
+
+	activity.Source = models.TimelineSourceActivity
+
+	return nil
+}
+
+// GetByID retrieves a single logged activity by ID
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *ActivityRepository) GetByID(id string) (*models.ClientActivity, error) {
+	query := activitySelect + `
+		WHERE a.id = $1
+	`
+
+	var activity models.ClientActivity
+
+	err := scanClientActivity(r.db.QueryRow(query, id), &activity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("activity not found")
+		}
+		return nil, fmt.Errorf("failed to get activity by ID: %w", err)
+	}
+
+	return &activity, nil
+}
+
+// Delete permanently removes a logged activity
+func (r *ActivityRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM client_activities WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete activity: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("activity not found")
+	}
+
+	return nil
+}
+
+// GetTimeline retrieves one page of a client's logged activities merged with their pipeline
+// stage changes, newest first
+// Pages are keyset-paginated on (occurred_at, id) so entries logged meanwhile don't shift pages
+func (r *ActivityRepository) GetTimeline(clientID string, types []string, limit int, cursorToken string) ([]models.ClientActivity, *models.PageInfo, error) {
+	args := []interface{}{clientID}
+	conditions := []string{}
+
+	if len(types) > 0 {
+		args = append(args, pq.Array(types))
+		conditions = append(conditions, fmt.Sprintf("type = ANY($%d)", len(args)))
+	}
+
+	if cursorToken != "" {
+		cursor, err := decodeTimelineCursor(cursorToken)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cursor")
+		}
+		args = append(args, cursor.OccurredAt, cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(occurred_at, id) < ($%d, $%d::UUID)", len(args)-1, len(args)))
+	}
+
+	query := `
+		SELECT ` + timelineColumns + `
+		FROM (
+			` + activitySelect + `
+			WHERE a.client_id = $1
+			UNION ALL
+			` + stageChangeSelect + `
+			WHERE t.client_id = $1
+		) timeline
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	args = append(args, limit+1)
+	query += fmt.Sprintf(" ORDER BY occurred_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query client timeline: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.ClientActivity{}
+
+	for rows.Next() {
+		var entry models.ClientActivity
+		if err := scanClientActivity(rows, &entry); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan timeline row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating timeline rows: %w", err)
+	}
+
+	page := &models.PageInfo{Limit: limit}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		page.HasMore = true
+		page.NextCursor = encodeTimelineCursor(timelineCursor{
+			OccurredAt: entries[limit-1].OccurredAt,
+			ID:         entries[limit-1].ID,
+		})
+	}
+
+	return entries, page, nil
+}
+
+// scanClientActivity scans a row selected with timelineColumns into an activity
+func scanClientActivity(scanner rowScanner, activity *models.ClientActivity) error {
+	return scanner.Scan(
+		&activity.ID,
+		&activity.ClientID,
+		&activity.BrokerID,
+		&activity.AuthorID,
+		&activity.AuthorName,
+		&activity.AppointmentID,
+		&activity.Source,
+		&activity.Type,
+		&activity.Body,
+		&activity.OccurredAt,
+		&activity.CreatedAt,
+	)
+}
+
+// encodeTimelineCursor encodes a timeline position as an opaque URL-safe token
+func encodeTimelineCursor(cursor timelineCursor) string {
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// decodeTimelineCursor decodes a token produced by encodeTimelineCursor
+func decodeTimelineCursor(token string) (timelineCursor, error) {
+	var cursor timelineCursor
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, err
+	}
+
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return cursor, err
+	}
+
+	if _, err := uuid.Parse(cursor.ID); err != nil || cursor.OccurredAt.IsZero() {
+		return cursor, fmt.Errorf("cursor is incomplete")
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+)
+
+// AnalyticsRepository handles database operations for listing engagement aggregates
+type AnalyticsRepository struct {
+	db *database.DB
+}
+
+// listingEventColumns maps each tracked event to its counter column in property_daily_stats
+var listingEventColumns = map[string]string{
+	models.ListingEventShareView:   "share_views",
+	models.ListingEventAppView:     "app_views",
+	models.ListingEventInquiry:     "inquiries",
+	models.ListingEventAppointment: "appointments",
+}
+
+// engagementSums aggregates the counters of property_daily_stats rows aliased s
+// in the order expected by scanEngagement
+const engagementSums = `
+	COALESCE(SUM(s.share_views), 0), COALESCE(SUM(s.app_views), 0),
+	COALESCE(SUM(s.inquiries), 0), COALESCE(SUM(s.appointments), 0)`
+
+// NewAnalyticsRepository creates a new AnalyticsRepository instance
+func NewAnalyticsRepository(db *database.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// RecordEvent adds one event to today's counters for a property
+func (r *AnalyticsRepository) RecordEvent(propertyID, brokerID, event string) error {
+	column, ok := listingEventColumns[event]
+	if !ok {
+		return fmt.Errorf("unknown listing event: %s", event)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO property_daily_stats (property_id, broker_id, day, %[1]s)
+		VALUES ($1, $2, CURRENT_DATE, 1)
+		ON CONFLICT (property_id, day) DO UPDATE
+		SET %[1]s = property_daily_stats.%[1]s + 1
+	`, column)
+
+	if _, err := r.db.Exec(query, propertyID, brokerID); err != nil {
+		return fmt.Errorf("failed to record listing event: %w", err)
+	}
+
+	return nil
+}
+
+// GetPropertyDaily retrieves a property's counters for every day from..to (YYYY-MM-DD, inclusive)
+// Days without activity are returned with zero counters
+func (r *AnalyticsRepository) GetPropertyDaily(propertyID, from, to string) ([]models.DailyEngagement, error) {
+	query := `
+		SELECT to_char(d.day, 'YYYY-MM-DD'),` + engagementSums + `
+		FROM generate_series($2::date, $3::date, INTERVAL '1 day') AS d(day)
+		LEFT JOIN property_daily_stats s ON s.day = d.day::date AND s.property_id = $1
+		GROUP BY d.day
+		ORDER BY d.day
+	`
+
+	daily, err := r.queryDaily(query, propertyID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query property engagement: %w", err)
+	}
+
+	return daily, nil
+}
+
+// GetBrokerDaily retrieves the summed counters of a broker's listings for every day from..to
+// Listings in the trash are left out; days without activity are returned with zero counters
+func (r *AnalyticsRepository) GetBrokerDaily(brokerID, from, to string) ([]models.DailyEngagement, error) {
+	query := `
+		SELECT to_char(d.day, 'YYYY-MM-DD'),` + engagementSums + `
+		FROM generate_series($2::date, $3::date, INTERVAL '1 day') AS d(day)
+		LEFT JOIN (
+			SELECT ps.*
+			FROM property_daily_stats ps
+			JOIN properties p ON p.id = ps.property_id
+			WHERE ps.broker_id = $1 AND p.deleted_at IS NULL
+		) s ON s.day = d.day::date
+		GROUP BY d.day
+		ORDER BY d.day
+	`
+
+	daily, err := r.queryDaily(query, brokerID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query broker engagement: %w", err)
+	}
+
+	return daily, nil
+}
+
+// GetBrokerPropertyTotals retrieves per-listing totals for a broker's listings with activity
+// between from and to, most viewed first
+func (r *AnalyticsRepository) GetBrokerPropertyTotals(brokerID, from, to string) ([]models.PropertyEngagement, error) {
+	query := `
+		SELECT p.id, p.title, p.city, p.status,` + engagementSums + `
+		FROM property_daily_stats s
+		JOIN properties p ON p.id = s.property_id
+		WHERE s.broker_id = $1 AND s.day BETWEEN $2::date AND $3::date
+			AND p.deleted_at IS NULL
+		GROUP BY p.id
+		ORDER BY SUM(s.share_views + s.app_views) DESC, SUM(s.inquiries) DESC, p.title
+	`
+
+	rows, err := r.db.Query(query, brokerID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query listing engagement: %w", err)
+	}
+	defer rows.Close()
+
+	properties := []models.PropertyEngagement{}
+
+	for rows.Next() {
+		var property models.PropertyEngagement
+		scanner := withLeadingColumns(rows, &property.PropertyID, &property.Title, &property.City, &property.Status)
+		if err := scanEngagement(scanner, &property.ListingEngagement); err != nil {
+			return nil, fmt.Errorf("failed to scan listing engagement row: %w", err)
+		}
+		properties = append(properties, property)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating listing engagement rows: %w", err)
+	}
+
+	return properties, nil
+}
+
+// queryDaily runs a query selecting a day followed by engagementSums and scans every row
+func (r *AnalyticsRepository) queryDaily(query string, args ...interface{}) ([]models.DailyEngagement, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	daily := []models.DailyEngagement{}
+
+	for rows.Next() {
+		var day models.DailyEngagement
+		if err := scanEngagement(withLeadingColumns(rows, &day.Date), &day.ListingEngagement); err != nil {
+			return nil, fmt.Errorf("failed to scan daily engagement row: %w", err)
+		}
+		daily = append(daily, day)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating daily engagement rows: %w", err)
+	}
+
+	return daily, nil
+}
+
+// scanEngagement scans the engagementSums columns and fills in the combined view count
+func scanEngagement(scanner rowScanner, engagement *models.ListingEngagement) error {
+	err := scanner.Scan(
+		&engagement.ShareViews,
+		&engagement.AppViews,
+		&engagement.Inquiries,
+		&engagement.Appointments,
+	)
+	if err != nil {
+		return err
+	}
+
+	engagement.Views = engagement.ShareViews + engagement.AppViews
+	return nil
+}
@@ -14,6 +14,12 @@ type AppointmentRepository struct {
 	db *database.DB
 }
 
+// appointmentColumns lists the appointment columns in the order expected by scanAppointment
+const appointmentColumns = `
+	id, title, description, date, time, client_id, property_id, broker_id,
+	type, status, client_name, client_phone, property_address, broker_name, broker_city,
+	client_response, client_responded_at, created_at, updated_at, deleted_at`
+
 // NewAppointmentRepository creates a new AppointmentRepository instance
 func NewAppointmentRepository(db *database.DB) *AppointmentRepository {
 	return &AppointmentRepository{db: db}
@@ -63,12 +69,9 @@ func (r *AppointmentRepository) Create(appointment *models.Appointment) error {
 func (r *AppointmentRepository) GetByBrokerID(brokerID string, filters models.AppointmentFilters) ([]models.Appointment, error) {
 	// Build dynamic query with filters
 	query := `
-		SELECT 
-			id, title, description, date, time, client_id, property_id, broker_id,
-			type, status, client_name, client_phone, property_address, broker_name, broker_city,
-			created_at, updated_at
+		SELECT ` + appointmentColumns + `
 		FROM appointments
-		WHERE broker_id = $1
+		WHERE broker_id = $1 AND deleted_at IS NULL
 	`
 
 	args := []interface{}{brokerID}
@@ -125,27 +128,7 @@ func (r *AppointmentRepository) GetByBrokerID(brokerID string, filters models.Ap
 	for rows.Next() {
 		var appointment models.Appointment
 
-		err := rows.Scan(
-			&appointment.ID,
-			&appointment.Title,
-			&appointment.Description,
-			&appointment.Date,
-			&appointment.Time,
-			&appointment.ClientID,
-			&appointment.PropertyID,
-			&appointment.BrokerID,
-			&appointment.Type,
-			&appointment.Status,
-			&appointment.ClientName,
-			&appointment.ClientPhone,
-			&appointment.PropertyAddress,
-			&appointment.BrokerName,
-			&appointment.BrokerCity,
-			&appointment.CreatedAt,
-			&appointment.UpdatedAt,
-		)
-
-		if err != nil {
+		if err := scanAppointment(rows, &appointment); err != nil {
 			return nil, fmt.Errorf("failed to scan appointment row: %w", err)
 		}
 
@@ -164,39 +147,18 @@ func (r *AppointmentRepository) GetByBrokerID(brokerID string, filters models.Ap
 	return appointments, nil
 }
 
-// GetByID retrieves a single appointment by ID
+// GetByID retrieves a single appointment by ID; appointments in the trash are not found
 // This method does NOT validate broker ownership - that should be done at the service layer
 func (r *AppointmentRepository) GetByID(id string) (*models.Appointment, error) {
 	query := `
-		SELECT 
-			id, title, description, date, time, client_id, property_id, broker_id,
-			type, status, client_name, client_phone, property_address, broker_name, broker_city,
-			created_at, updated_at
+		SELECT ` + appointmentColumns + `
 		FROM appointments
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var appointment models.Appointment
 
-	err := r.db.QueryRow(query, id).Scan(
-		&appointment.ID,
-		&appointment.Title,
-		&appointment.Description,
-		&appointment.Date,
-		&appointment.Time,
-		&appointment.ClientID,
-		&appointment.PropertyID,
-		&appointment.BrokerID,
-		&appointment.Type,
-		&appointment.Status,
-		&appointment.ClientName,
-		&appointment.ClientPhone,
-		&appointment.PropertyAddress,
-		&appointment.BrokerName,
-		&appointment.BrokerCity,
-		&appointment.CreatedAt,
-		&appointment.UpdatedAt,
-	)
+	err := scanAppointment(r.db.QueryRow(query, id), &appointment)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -208,14 +170,50 @@ func (r *AppointmentRepository) GetByID(id string) (*models.Appointment, error)
 	return &appointment, nil
 }
 
+// GetUpcomingByClientID retrieves a client's scheduled appointments from today on, soonest first
+func (r *AppointmentRepository) GetUpcomingByClientID(clientID string) ([]models.Appointment, error) {
+	query := `
+		SELECT ` + appointmentColumns + `
+		FROM appointments
+		WHERE client_id = $1 AND deleted_at IS NULL
+			AND status = 'scheduled' AND date >= CURRENT_DATE
+		ORDER BY date ASC, time ASC
+	`
+
+	rows, err := r.db.Query(query, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query upcoming client appointments: %w", err)
+	}
+	defer rows.Close()
+
+	appointments := []models.Appointment{}
+
+	for rows.Next() {
+		var appointment models.Appointment
+
+		if err := scanAppointment(rows, &appointment); err != nil {
+			return nil, fmt.Errorf("failed to scan appointment row: %w", err)
+		}
+
+		appointments = append(appointments, appointment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating appointment rows: %w", err)
+	}
+
+	return appointments, nil
+}
+
 // Update modifies an existing appointment in the database
 // The updated_at timestamp is automatically updated by database trigger
 func (r *AppointmentRepository) Update(appointment *models.Appointment) error {
 	query := `
 		UPDATE appointments SET
 			title = $1, description = $2, date = $3, time = $4,
-			client_id = $5, property_id = $6, type = $7, status = $8
-		WHERE id = $9
+			client_id = $5, property_id = $6, type = $7, status = $8,
+			client_response = $9, client_responded_at = $10
+		WHERE id = $11 AND deleted_at IS NULL
 		RETURNING client_name, client_phone, property_address, broker_name, broker_city, created_at, updated_at
 	`
 
@@ -229,6 +227,8 @@ func (r *AppointmentRepository) Update(appointment *models.Appointment) error {
 		appointment.PropertyID,
 		appointment.Type,
 		appointment.Status,
+		appointment.ClientResponse,
+		appointment.ClientRespondedAt,
 		appointment.ID,
 	).Scan(
 		&appointment.ClientName,
@@ -250,9 +250,9 @@ func (r *AppointmentRepository) Update(appointment *models.Appointment) error {
 	return nil
 }
 
-// Delete removes an appointment from the database
-func (r *AppointmentRepository) Delete(id string) error {
-	query := `DELETE FROM appointments WHERE id = $1`
+// SoftDelete moves an appointment to the trash
+func (r *AppointmentRepository) SoftDelete(id string) error {
+	query := `UPDATE appointments SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.Exec(query, id)
 	if err != nil {
@@ -271,6 +271,95 @@ func (r *AppointmentRepository) Delete(id string) error {
 	return nil
 }
 
+// GetDeletedByBrokerID retrieves a broker's appointments in the trash, most recently deleted first
+func (r *AppointmentRepository) GetDeletedByBrokerID(brokerID string) ([]models.Appointment, error) {
+	query := `
+		SELECT ` + appointmentColumns + `
+		FROM appointments
+		WHERE broker_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
+
+	rows, err := r.db.Query(query, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deleted appointments: %w", err)
+	}
+	defer rows.Close()
+
+	var appointments []models.Appointment
+
+	for rows.Next() {
+		var appointment models.Appointment
+		if err := scanAppointment(rows, &appointment); err != nil {
+			return nil, fmt.Errorf("failed to scan appointment row: %w", err)
+		}
+		appointments = append(appointments, appointment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating appointment rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no appointments found
+	if appointments == nil {
+		appointments = []models.Appointment{}
+	}
+
+	return appointments, nil
+}
+
+// GetDeletedByID retrieves a single appointment from the trash
+// This method does NOT validate broker ownership - that should be done at the service layer
+func (r *AppointmentRepository) GetDeletedByID(id string) (*models.Appointment, error) {
+	query := `
+		SELECT ` + appointmentColumns + `
+		FROM appointments
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	var appointment models.Appointment
+
+	err := scanAppointment(r.db.QueryRow(query, id), &appointment)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("appointment not found")
+		}
+		return nil, fmt.Errorf("failed to get deleted appointment by ID: %w", err)
+	}
+
+	return &appointment, nil
+}
+
+// Restore takes an appointment out of the trash
+func (r *AppointmentRepository) Restore(id string) (*models.Appointment, error) {
+	query := `
+		UPDATE appointments SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING ` + appointmentColumns
+
+	var appointment models.Appointment
+
+	err := scanAppointment(r.db.QueryRow(query, id), &appointment)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("appointment not found")
+		}
+		return nil, fmt.Errorf("failed to restore appointment: %w", err)
+	}
+
+	return &appointment, nil
+}
+
+// PurgeDeleted permanently removes appointments that have been in the trash since before the cutoff
+func (r *AppointmentRepository) PurgeDeleted(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM appointments WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted appointments: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
 // GetStats calculates appointment statistics for a broker
 func (r *AppointmentRepository) GetStats(brokerID string) (*models.AppointmentStats, error) {
 	now := time.Now()
@@ -285,7 +374,7 @@ func (r *AppointmentRepository) GetStats(brokerID string) (*models.AppointmentSt
 	query := `
 		SELECT COUNT(*) 
 		FROM appointments 
-		WHERE broker_id = $1 AND date >= $2
+		WHERE broker_id = $1 AND date >= $2 AND deleted_at IS NULL
 	`
 	err := r.db.QueryRow(query, brokerID, firstDayOfMonth.Format("2006-01-02")).Scan(&stats.TotalThisMonth)
 	if err != nil {
@@ -296,7 +385,7 @@ func (r *AppointmentRepository) GetStats(brokerID string) (*models.AppointmentSt
 	query = `
 		SELECT COUNT(*) 
 		FROM appointments 
-		WHERE broker_id = $1 AND date = $2
+		WHERE broker_id = $1 AND date = $2 AND deleted_at IS NULL
 	`
 	err = r.db.QueryRow(query, brokerID, today).Scan(&stats.TodayAppointments)
 	if err != nil {
@@ -307,7 +396,7 @@ func (r *AppointmentRepository) GetStats(brokerID string) (*models.AppointmentSt
 	query = `
 		SELECT COUNT(*) 
 		FROM appointments 
-		WHERE broker_id = $1 AND status = 'scheduled'
+		WHERE broker_id = $1 AND status = 'scheduled' AND deleted_at IS NULL
 	`
 	err = r.db.QueryRow(query, brokerID).Scan(&stats.ScheduledAppointments)
 	if err != nil {
@@ -318,7 +407,7 @@ func (r *AppointmentRepository) GetStats(brokerID string) (*models.AppointmentSt
 	query = `
 		SELECT COUNT(*) 
 		FROM appointments 
-		WHERE broker_id = $1 AND status = 'completed'
+		WHERE broker_id = $1 AND status = 'completed' AND deleted_at IS NULL
 	`
 	err = r.db.QueryRow(query, brokerID).Scan(&stats.CompletedAppointments)
 	if err != nil {
@@ -329,7 +418,7 @@ func (r *AppointmentRepository) GetStats(brokerID string) (*models.AppointmentSt
 	query = `
 		SELECT COUNT(*) 
 		FROM appointments 
-		WHERE broker_id = $1 AND status = 'cancelled'
+		WHERE broker_id = $1 AND status = 'cancelled' AND deleted_at IS NULL
 	`
 	err = r.db.QueryRow(query, brokerID).Scan(&stats.CancelledAppointments)
 	if err != nil {
@@ -340,7 +429,7 @@ func (r *AppointmentRepository) GetStats(brokerID string) (*models.AppointmentSt
 	query = `
 		SELECT type, COUNT(*) 
 		FROM appointments 
-		WHERE broker_id = $1 
+		WHERE broker_id = $1 AND deleted_at IS NULL
 		GROUP BY type
 	`
 	rows, err := r.db.Query(query, brokerID)
@@ -365,3 +454,29 @@ func (r *AppointmentRepository) GetStats(brokerID string) (*models.AppointmentSt
 
 	return stats, nil
 }
+
+// scanAppointment scans a row selected with appointmentColumns into an appointment
+func scanAppointment(scanner rowScanner, appointment *models.Appointment) error {
+	return scanner.Scan(
+		&appointment.ID,
+		&appointment.Title,
+		&appointment.Description,
+		&appointment.Date,
+		&appointment.Time,
+		&appointment.ClientID,
+		&appointment.PropertyID,
+		&appointment.BrokerID,
+		&appointment.Type,
+		&appointment.Status,
+		&appointment.ClientName,
+		&appointment.ClientPhone,
+		&appointment.PropertyAddress,
+		&appointment.BrokerName,
+		&appointment.BrokerCity,
+		&appointment.ClientResponse,
+		&appointment.ClientRespondedAt,
+		&appointment.CreatedAt,
+		&appointment.UpdatedAt,
+		&appointment.DeletedAt,
+	)
+}
@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ClientDuplicateRepository handles database operations for duplicate client detection and merging
+type ClientDuplicateRepository struct {
+	db *database.DB
+}
+
+// clientDuplicateColumns lists the client duplicate columns in the order expected by scanClientDuplicate
+const clientDuplicateColumns = `
+	id, broker_id, client_a_id, client_b_id, phone_match, email_match, name_similarity,
+	status, reviewed_at, created_at, updated_at`
+
+// clientReference is a column pointing at a client that a merge moves onto the surviving client
+type clientReference struct {
+	name  string
+	query string
+}
+
+// clientReferences lists every table a merge re-points; property matches are recomputed instead
+var clientReferences = []clientReference{
+	{name: "appointments", query: `UPDATE appointments SET client_id = $1 WHERE client_id = $2`},
+	{name: "documents", query: `UPDATE documents SET client_id = $1 WHERE client_id = $2`},
+	{name: "tasks", query: `UPDATE tasks SET client_id = $1 WHERE client_id = $2`},
+	{name: "saved_searches", query: `UPDATE saved_searches SET client_id = $1 WHERE client_id = $2`},
+	{name: "activities", query: `UPDATE client_activities SET client_id = $1 WHERE client_id = $2`},
+	{name: "stage_transitions", query: `UPDATE client_stage_transitions SET client_id = $1 WHERE client_id = $2`},
+	{name: "blocked_units", query: `UPDATE project_units SET blocked_for_client_id = $1 WHERE blocked_for_client_id = $2`},
+	{name: "ownership_history", query: `UPDATE client_ownership_history SET client_id = $1 WHERE client_id = $2`},
+	{name: "consents", query: `UPDATE contact_consents SET client_id = $1 WHERE client_id = $2`},
+	{name: "deals", query: `UPDATE deals SET client_id = $1 WHERE client_id = $2`},
+	// A listing shared with both clients keeps the survivor's share; the other goes with the merged client
+	{name: "shared_properties", query: `
+		UPDATE client_shared_properties SET client_id = $1
+		WHERE client_id = $2
+			AND property_id NOT IN (SELECT property_id FROM client_shared_properties WHERE client_id = $1)`},
+}
+
+// NewClientDuplicateRepository creates a new ClientDuplicateRepository instance
+func NewClientDuplicateRepository(db *database.DB) *ClientDuplicateRepository {
+	return &ClientDuplicateRepository{db: db}
+}
+
+// FindCandidates finds clients in the same broker's book that are likely duplicates of the given client
+// Matches on the last 10 digits of the phone, the case-insensitive email, or trigram name similarity
+// Pairs the broker has dismissed are excluded
+func (r *ClientDuplicateRepository) FindCandidates(client *models.Client, minNameSimilarity float64) ([]models.ClientDuplicateWarning, error) {
+	// Short numbers (extensions, partial entries) would match too much to mean anything
+	phone := phoneDigits(client.Phone)
+	if len(phone) < 7 {
+		phone = ""
+	} else if len(phone) > 10 {
+		phone = phone[len(phone)-10:]
+	}
+	email := strings.ToLower(strings.TrimSpace(client.Email))
+
+	query := `
+		SELECT id, first_name, last_name, email, phone, status, phone_match, email_match, name_similarity
+		FROM (
+			SELECT
+				c.id, c.first_name, c.last_name, c.email, c.phone, c.status,
+				($3 <> '' AND RIGHT(regexp_replace(c.phone, '\D', '', 'g'), 10) = $3) AS phone_match,
+				($4 <> '' AND LOWER(TRIM(c.email)) = $4) AS email_match,
+				similarity(c.first_name || ' ' || c.last_name, $5) AS name_similarity
+			FROM clients c
+			WHERE c.broker_id = $1
+				AND c.id <> $2
+				AND c.deleted_at IS NULL
+				AND NOT EXISTS (
+					SELECT 1 FROM client_duplicates d
+					WHERE d.status = 'dismissed'
+						AND d.client_a_id = LEAST(c.id, $2::uuid)
+						AND d.client_b_id = GREATEST(c.id, $2::uuid)
+				)
+		) candidates
+		WHERE phone_match OR email_match OR name_similarity >= $6
+		ORDER BY phone_match DESC, email_match DESC, name_similarity DESC
+		LIMIT 10
+	`
+
+	rows, err := r.db.Query(
+		query,
+		client.BrokerID,
+		client.ID,
+		phone,
+		email,
+		client.FirstName+" "+client.LastName,
+		minNameSimilarity,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query duplicate client candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []models.ClientDuplicateWarning{}
+
+	for rows.Next() {
+		var candidate models.ClientDuplicateWarning
+
+		err := rows.Scan(
+			&candidate.ClientID,
+			&candidate.FirstName,
+			&candidate.LastName,
+			&candidate.Email,
+			&candidate.Phone,
+			&candidate.Status,
+			&candidate.PhoneMatch,
+			&candidate.EmailMatch,
+			&candidate.NameSimilarity,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate client candidate row: %w", err)
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duplicate client candidate rows: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// SyncForClient records the current duplicate pairs for a client
+// Pending pairs that no longer qualify are removed; dismissed pairs keep their status
+func (r *ClientDuplicateRepository) SyncForClient(client *models.Client, candidates []models.ClientDuplicateWarning) error {
+	candidateIDs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidateIDs = append(candidateIDs, candidate.ClientID)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleteQuery := `
+		DELETE FROM client_duplicates
+		WHERE status = 'pending'
+			AND (client_a_id = $1 OR client_b_id = $1)
+			AND NOT (client_a_id::text = ANY($2) OR client_b_id::text = ANY($2))
+	`
+	if _, err := tx.Exec(deleteQuery, client.ID, pq.Array(candidateIDs)); err != nil {
+		return fmt.Errorf("failed to remove stale duplicate client pairs: %w", err)
+	}
+
+	upsertQuery := `
+		INSERT INTO client_duplicates (broker_id, client_a_id, client_b_id, phone_match, email_match, name_similarity)
+		VALUES ($1, LEAST($2::uuid, $3::uuid), GREATEST($2::uuid, $3::uuid), $4, $5, $6)
+		ON CONFLICT (client_a_id, client_b_id)
+		DO UPDATE SET
+			phone_match = EXCLUDED.phone_match,
+			email_match = EXCLUDED.email_match,
+			name_similarity = EXCLUDED.name_similarity
+	`
+	for _, candidate := range candidates {
+		_, err := tx.Exec(
+			upsertQuery,
+			client.BrokerID,
+			client.ID,
+			candidate.ClientID,
+			candidate.PhoneMatch,
+			candidate.EmailMatch,
+			candidate.NameSimilarity,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record duplicate client pair: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit duplicate client pairs: %w", err)
+	}
+
+	return nil
+}
+
+// GetByStatus retrieves a broker's duplicate client pairs with the given status, most recent first
+// Pairs involving a client in the trash are left out until it is restored
+func (r *ClientDuplicateRepository) GetByStatus(brokerID, status string) ([]models.ClientDuplicate, error) {
+	query := `
+		SELECT ` + qualifyColumns("d", clientDuplicateColumns) + `
+		FROM client_duplicates d
+		JOIN clients a ON a.id = d.client_a_id AND a.deleted_at IS NULL
+		JOIN clients b ON b.id = d.client_b_id AND b.deleted_at IS NULL
+		WHERE d.broker_id = $1 AND d.status = $2
+		ORDER BY d.created_at DESC
+	`
+
+	rows, err := r.db.Query(query, brokerID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query duplicate client pairs: %w", err)
+	}
+	defer rows.Close()
+
+	duplicates := []models.ClientDuplicate{}
+
+	for rows.Next() {
+		var duplicate models.ClientDuplicate
+		if err := scanClientDuplicate(rows, &duplicate); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate client pair row: %w", err)
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duplicate client pair rows: %w", err)
+	}
+
+	return duplicates, nil
+}
+
+// UpdateStatus records a broker's review decision on one of their duplicate client pairs
+func (r *ClientDuplicateRepository) UpdateStatus(id, brokerID, status string) (*models.ClientDuplicate, error) {
+	query := `
+		UPDATE client_duplicates
+		SET status = $1, reviewed_at = CASE WHEN $1 = 'pending' THEN NULL ELSE NOW() END
+		WHERE id = $2 AND broker_id = $3
+		RETURNING ` + clientDuplicateColumns
+
+	var duplicate models.ClientDuplicate
+
+	err := scanClientDuplicate(r.db.QueryRow(query, status, id, brokerID), &duplicate)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("duplicate client pair not found")
+		}
+		return nil, fmt.Errorf("failed to update duplicate client pair: %w", err)
+	}
+
+	return &duplicate, nil
+}
+
+// Merge folds one client into another in a single transaction
+// The survivor is saved with its combined fields, every reference to the merged client is moved onto it,
+// and the merged client is deleted along with its stored matches and duplicate pairs.
+// Returns how many rows were moved per referencing table.
+func (r *ClientDuplicateRepository) Merge(survivor *models.Client, mergedID string) (map[string]int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock both clients so concurrent edits or a second merge wait for this one
+	rows, err := tx.Query(`SELECT id FROM clients WHERE id IN ($1, $2) AND deleted_at IS NULL FOR UPDATE`, survivor.ID, mergedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock clients: %w", err)
+	}
+	locked := 0
+	for rows.Next() {
+		locked++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to lock clients: %w", err)
+	}
+	if locked != 2 {
+		return nil, fmt.Errorf("client not found")
+	}
+
+	reassigned := make(map[string]int64, len(clientReferences))
+	for _, reference := range clientReferences {
+		result, err := tx.Exec(reference.query, survivor.ID, mergedID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to move %s to the surviving client: %w", reference.name, err)
+		}
+
+		count, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		reassigned[reference.name] = count
+	}
+
+	// Saving the survivor after the moves lets the sync trigger refresh the client name and phone
+	// on every appointment it now has, including the ones that came from the merged client
+	if err := updateClient(tx, survivor); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM clients WHERE id = $1`, mergedID); err != nil {
+		return nil, fmt.Errorf("failed to delete merged client: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit client merge: %w", err)
+	}
+
+	return reassigned, nil
+}
+
+// scanClientDuplicate scans a row selected with clientDuplicateColumns into a duplicate client pair
+func scanClientDuplicate(scanner rowScanner, duplicate *models.ClientDuplicate) error {
+	return scanner.Scan(
+		&duplicate.ID,
+		&duplicate.BrokerID,
+		&duplicate.ClientAID,
+		&duplicate.ClientBID,
+		&duplicate.PhoneMatch,
+		&duplicate.EmailMatch,
+		&duplicate.NameSimilarity,
+		&duplicate.Status,
+		&duplicate.ReviewedAt,
+		&duplicate.CreatedAt,
+		&duplicate.UpdatedAt,
+	)
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+)
+
+// ClientImportRepository handles database operations for contact file imports
+type ClientImportRepository struct {
+	db *database.DB
+}
+
+// clientImportColumns lists the client import columns in the order expected by scanClientImport
+// The report is selected separately since import lists leave it out
+const clientImportColumns = `
+	id, broker_id, file_name, format, duplicate_policy, default_type, mapping,
+	status, total_rows, processed_rows, created_rows, merged_rows, skipped_rows, failed_rows, error,
+	completed_at, created_at, updated_at`
+
+// NewClientImportRepository creates a new ClientImportRepository instance
+func NewClientImportRepository(db *database.DB) *ClientImportRepository {
+	return &ClientImportRepository{db: db}
+}
+
+// Create inserts a new import in the processing state
+func (r *ClientImportRepository) Create(clientImport *models.ClientImport) error {
+	var mapping []byte
+	if clientImport.Mapping != nil {
+		encoded, err := json.Marshal(clientImport.Mapping)
+		if err != nil {
+			return fmt.Errorf("failed to encode import mapping: %w", err)
+		}
+		mapping = encoded
+	}
+
+	query := `
+		INSERT INTO client_imports (broker_id, file_name, format, duplicate_policy, default_type, mapping, total_rows)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		clientImport.BrokerID,
+		clientImport.FileName,
+		clientImport.Format,
+		clientImport.DuplicatePolicy,
+		clientImport.DefaultType,
+		mapping,
+		clientImport.TotalRows,
+	).Scan(
+		&clientImport.ID,
+		&clientImport.Status,
+		&clientImport.CreatedAt,
+		&clientImport.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create client import: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a single import with its row report
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *ClientImportRepository) GetByID(id string) (*models.ClientImport, error) {
+	query := `
+		SELECT ` + clientImportColumns + `, report
+		FROM client_imports
+		WHERE id = $1
+	`
+
+	var clientImport models.ClientImport
+	var report []byte
+
+	err := scanClientImport(withTrailingColumns(r.db.QueryRow(query, id), &report), &clientImport)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("client import not found")
+		}
+		return nil, fmt.Errorf("failed to get client import by ID: %w", err)
+	}
+
+	if err := json.Unmarshal(report, &clientImport.Report); err != nil {
+		return nil, fmt.Errorf("failed to decode client import report: %w", err)
+	}
+
+	return &clientImport, nil
+}
+
+// GetByBrokerID retrieves a broker's imports, newest first, without their row reports
+func (r *ClientImportRepository) GetByBrokerID(brokerID string) ([]models.ClientImport, error) {
+	query := `
+		SELECT ` + clientImportColumns + `
+		FROM client_imports
+		WHERE broker_id = $1
+		ORDER BY created_at DESC
+		LIMIT 100
+	`
+
+	rows, err := r.db.Query(query, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client imports: %w", err)
+	}
+	defer rows.Close()
+
+	imports := []models.ClientImport{}
+
+	for rows.Next() {
+		var clientImport models.ClientImport
+		if err := scanClientImport(rows, &clientImport); err != nil {
+			return nil, fmt.Errorf("failed to scan client import row: %w", err)
+		}
+		imports = append(imports, clientImport)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating client import rows: %w", err)
+	}
+
+	return imports, nil
+}
+
+// SaveProgress stores an import's counters, report and status
+// A completed or failed import gets its completion time
+func (r *ClientImportRepository) SaveProgress(clientImport *models.ClientImport) error {
+	report, err := json.Marshal(clientImport.Report)
+	if err != nil {
+		return fmt.Errorf("failed to encode client import report: %w", err)
+	}
+
+	query := `
+		UPDATE client_imports SET
+			status = $1, processed_rows = $2, created_rows = $3, merged_rows = $4,
+			skipped_rows = $5, failed_rows = $6, error = $7, report = $8,
+			completed_at = CASE WHEN $1 = 'processing' THEN NULL ELSE NOW() END
+		WHERE id = $9
+		RETURNING completed_at, updated_at
+	`
+
+	err = r.db.QueryRow(
+		query,
+		clientImport.Status,
+		clientImport.ProcessedRows,
+		clientImport.CreatedRows,
+		clientImport.MergedRows,
+		clientImport.SkippedRows,
+		clientImport.FailedRows,
+		clientImport.Error,
+		report,
+		clientImport.ID,
+	).Scan(&clientImport.CompletedAt, &clientImport.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("client import not found")
+		}
+		return fmt.Errorf("failed to save client import progress: %w", err)
+	}
+
+	return nil
+}
+
+// FailInterrupted marks imports that were still processing as failed
+// Imports run in the server process, so any left processing at startup were cut off by a restart
+func (r *ClientImportRepository) FailInterrupted(message string) (int64, error) {
+	query := `
+		UPDATE client_imports
+		SET status = 'failed', error = $1, completed_at = NOW()
+		WHERE status = 'processing'
+	`
+
+	result, err := r.db.Exec(query, message)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail interrupted client imports: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// scanClientImport scans a row selected with clientImportColumns into an import
+func scanClientImport(scanner rowScanner, clientImport *models.ClientImport) error {
+	var mapping []byte
+
+	err := scanner.Scan(
+		&clientImport.ID,
+		&clientImport.BrokerID,
+		&clientImport.FileName,
+		&clientImport.Format,
+		&clientImport.DuplicatePolicy,
+		&clientImport.DefaultType,
+		&mapping,
+		&clientImport.Status,
+		&clientImport.TotalRows,
+		&clientImport.ProcessedRows,
+		&clientImport.CreatedRows,
+		&clientImport.MergedRows,
+		&clientImport.SkippedRows,
+		&clientImport.FailedRows,
+		&clientImport.Error,
+		&clientImport.CompletedAt,
+		&clientImport.CreatedAt,
+		&clientImport.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if mapping != nil {
+		if err := json.Unmarshal(mapping, &clientImport.Mapping); err != nil {
+			return fmt.Errorf("failed to decode import mapping: %w", err)
+		}
+	}
+
+	return nil
+}
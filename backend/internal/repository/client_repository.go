@@ -2,10 +2,20 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"enfor-data-backend/internal/database"
 	"enfor-data-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // ClientRepository handles database operations for clients
@@ -13,6 +23,72 @@ type ClientRepository struct {
 	db *database.DB
 }
 
+// clientColumns lists the client columns in the order expected by scanClient
+const clientColumns = `
+	id, first_name, last_name, email, phone, type, status,
+	budget_min, budget_max, preferred_location, address, city, state, postal_code,
+	property_types, listing_type, bedrooms_min, bedrooms_max, area_min, area_max,
+	preferred_localities, required_amenities,
+	requirements, notes, pipeline_stage_id, stage_changed_at, lost_reason, source, campaign, tags,
+	lead_score, lead_score_factors, lead_score_updated_at,
+	broker_id, broker_name, broker_city, created_at, updated_at, deleted_at`
+
+// defaultClientPageSize is the page size used when the client list is requested without a limit
+const defaultClientPageSize = 50
+
+// clientSortColumn is an SQL expression clients can be ordered by and the type its cursor value is cast to
+type clientSortColumn struct {
+	expression string
+	sqlType    string
+}
+
+// cursorExpression renders the sort value as the text stored in a cursor
+// Timestamps are written as UTC RFC 3339 so the value does not depend on the session's DateStyle and TimeZone
+func (c clientSortColumn) cursorExpression() string {
+	if c.sqlType == "TIMESTAMPTZ" {
+		return `TO_CHAR(` + c.expression + ` AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')`
+	}
+	return c.expression + `::TEXT`
+}
+
+// validCursorValue reports whether a cursor value parses as the column's SQL type,
+// so a tampered cursor is rejected before Postgres fails to cast it
+func (c clientSortColumn) validCursorValue(value string) bool {
+	switch c.sqlType {
+	case "TIMESTAMPTZ":
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	case "NUMERIC":
+		return numericCursorValue.MatchString(value)
+	case "INTEGER":
+		_, err := strconv.ParseInt(value, 10, 32)
+		return err == nil
+	default:
+		return utf8.ValidString(value) && !strings.ContainsRune(value, 0)
+	}
+}
+
+// numericCursorValue matches the NUMERIC sort values Postgres writes as text
+var numericCursorValue = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// clientSortColumns maps the client list sort options to their SQL expressions
+var clientSortColumns = map[string]clientSortColumn{
+	"created_at": {expression: "created_at", sqlType: "TIMESTAMPTZ"},
+	"updated_at": {expression: "updated_at", sqlType: "TIMESTAMPTZ"},
+	"name":       {expression: "LOWER(first_name || ' ' || last_name)", sqlType: "TEXT"},
+	"budget_max": {expression: "COALESCE(budget_max, 0)", sqlType: "NUMERIC"},
+	"lead_score": {expression: "lead_score", sqlType: "INTEGER"},
+}
+
+// clientCursor is the position of the last client on a page
+// Sort and order are kept so a cursor cannot be replayed against a different ordering
+type clientCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
 // NewClientRepository creates a new ClientRepository instance
 func NewClientRepository(db *database.DB) *ClientRepository {
 	return &ClientRepository{db: db}
@@ -25,8 +101,13 @@ func (r *ClientRepository) Create(client *models.Client) error {
 		INSERT INTO clients (
 			first_name, last_name, email, phone, type, status,
 			budget_min, budget_max, preferred_location, address, city, state, postal_code,
-			requirements, notes, broker_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			property_types, listing_type, bedrooms_min, bedrooms_max, area_min, area_max,
+			preferred_localities, required_amenities,
+			requirements, notes, source, campaign, tags, broker_id
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
+			$14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27
+		)
 		RETURNING id, broker_name, broker_city, created_at, updated_at
 	`
 
@@ -45,8 +126,19 @@ func (r *ClientRepository) Create(client *models.Client) error {
 		client.City,
 		client.State,
 		client.PostalCode,
+		pq.Array(client.PropertyTypes),
+		client.ListingType,
+		client.BedroomsMin,
+		client.BedroomsMax,
+		client.AreaMin,
+		client.AreaMax,
+		pq.Array(client.PreferredLocalities),
+		pq.Array(client.RequiredAmenities),
 		client.Requirements,
 		client.Notes,
+		client.Source,
+		client.Campaign,
+		pq.Array(client.Tags),
 		client.BrokerID,
 	).Scan(
 		&client.ID,
@@ -63,110 +155,263 @@ func (r *ClientRepository) Create(client *models.Client) error {
 	return nil
 }
 
-// GetByBrokerID retrieves all clients for a specific broker
-// Uses optimized composite index (broker_id, created_at DESC) for fast retrieval
-func (r *ClientRepository) GetByBrokerID(brokerID string) ([]models.Client, error) {
+// GetByBrokerID retrieves one page of a broker's clients matching the filters
+// Pages are keyset-paginated on (sort value, id) so results stay stable while clients are added
+// Uses optimized composite index (broker_id, created_at DESC) for the default sort
+func (r *ClientRepository) GetByBrokerID(brokerID string, filters models.ClientFilters) ([]models.Client, *models.PageInfo, error) {
+	sort, order := clientSortOptions(filters)
+	sortColumn := clientSortColumns[sort]
+
 	query := `
-		SELECT 
-			id, first_name, last_name, email, phone, type, status,
-			budget_min, budget_max, preferred_location, address, city, state, postal_code,
-			requirements, notes, broker_id, broker_name, broker_city, created_at, updated_at
+		SELECT ` + sortColumn.cursorExpression() + `, ` + clientColumns + `
 		FROM clients
-		WHERE broker_id = $1
-		ORDER BY created_at DESC
+		WHERE broker_id = $1 AND deleted_at IS NULL
 	`
+	query, args := appendClientFilters(query, []interface{}{brokerID}, filters)
 
-	rows, err := r.db.Query(query, brokerID)
+	comparison := "<"
+	if order == "asc" {
+		comparison = ">"
+	}
+
+	if filters.Cursor != "" {
+		cursor, err := decodeClientCursor(filters.Cursor, sort, order)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cursor")
+		}
+		args = append(args, cursor.Value, cursor.ID)
+		query += fmt.Sprintf(" AND (%s, id) %s ($%d::%s, $%d)",
+			sortColumn.expression, comparison, len(args)-1, sortColumn.sqlType, len(args))
+	}
+
+	limit := filters.Limit
+	if limit == 0 {
+		limit = defaultClientPageSize
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	args = append(args, limit+1)
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT $%d", sortColumn.expression, order, order, len(args))
+
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query clients by broker ID: %w", err)
+		return nil, nil, fmt.Errorf("failed to query clients by broker ID: %w", err)
 	}
 	defer rows.Close()
 
-	var clients []models.Client
+	clients := []models.Client{}
+	sortValues := []string{}
 
 	for rows.Next() {
 		var client models.Client
+		var sortValue string
+		if err := scanClient(withLeadingColumns(rows, &sortValue), &client); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan client row: %w", err)
+		}
+		clients = append(clients, client)
+		sortValues = append(sortValues, sortValue)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating client rows: %w", err)
+	}
+
+	page := &models.PageInfo{Limit: limit}
+	if len(clients) > limit {
+		clients = clients[:limit]
+		page.HasMore = true
+		page.NextCursor = encodeClientCursor(clientCursor{
+			Sort:  sort,
+			Order: order,
+			Value: sortValues[limit-1],
+			ID:    clients[limit-1].ID,
+		})
+	}
+
+	return clients, page, nil
+}
+
+// appendClientFilters adds a WHERE condition for every filter that is set
+func appendClientFilters(query string, args []interface{}, filters models.ClientFilters) (string, []interface{}) {
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += fmt.Sprintf(" AND "+condition, len(args))
+	}
 
-		err := rows.Scan(
-			&client.ID,
-			&client.FirstName,
-			&client.LastName,
-			&client.Email,
-			&client.Phone,
-			&client.Type,
-			&client.Status,
-			&client.BudgetMin,
-			&client.BudgetMax,
-			&client.PreferredLocation,
-			&client.Address,
-			&client.City,
-			&client.State,
-			&client.PostalCode,
-			&client.Requirements,
-			&client.Notes,
-			&client.BrokerID,
-			&client.BrokerName,
-			&client.BrokerCity,
-			&client.CreatedAt,
-			&client.UpdatedAt,
+	if filters.Type != nil {
+		addCondition("type = $%d", *filters.Type)
+	}
+	if filters.Status != nil {
+		addCondition("status = $%d", *filters.Status)
+	}
+	if filters.City != nil {
+		addCondition("LOWER(city) = LOWER($%d)", *filters.City)
+	}
+	if filters.PropertyType != nil {
+		// GIN index on property_types supports array containment
+		addCondition("property_types @> ARRAY[$%d]::TEXT[]", *filters.PropertyType)
+	}
+	if filters.ListingType != nil {
+		addCondition("listing_type = $%d", *filters.ListingType)
+	}
+	if filters.Bedrooms != nil {
+		// Only clients who stated a bedroom range; an open end accepts any value on that side
+		addCondition(`(bedrooms_min IS NOT NULL OR bedrooms_max IS NOT NULL)
+			AND COALESCE(bedrooms_min, 0) <= $%[1]d AND COALESCE(bedrooms_max, $%[1]d) >= $%[1]d`, *filters.Bedrooms)
+	}
+	if filters.Area != nil {
+		addCondition(`(area_min IS NOT NULL OR area_max IS NOT NULL)
+			AND COALESCE(area_min, 0) <= $%[1]d AND COALESCE(area_max, $%[1]d) >= $%[1]d`, *filters.Area)
+	}
+	if filters.Locality != nil {
+		addCondition(`EXISTS (SELECT 1 FROM unnest(preferred_localities) AS l WHERE l ILIKE $%d ESCAPE '\')`, containsPattern(*filters.Locality))
+	}
+	if filters.Amenity != nil {
+		addCondition("EXISTS (SELECT 1 FROM unnest(required_amenities) AS a WHERE LOWER(a) = LOWER($%d))", *filters.Amenity)
+	}
+	// The budget filters keep clients whose budget range overlaps the requested one; a range with
+	// one open end is read as the single stated amount, and clients with no budget never match
+	if filters.MinBudget != nil {
+		addCondition("COALESCE(budget_max, budget_min) >= $%d", *filters.MinBudget)
+	}
+	if filters.MaxBudget != nil {
+		addCondition("COALESCE(budget_min, budget_max) <= $%d", *filters.MaxBudget)
+	}
+	if filters.Source != nil {
+		addCondition("source = $%d", *filters.Source)
+	}
+	if filters.Campaign != nil {
+		addCondition("LOWER(campaign) = LOWER($%d)", *filters.Campaign)
+	}
+	if filters.MinLeadScore != nil {
+		addCondition("lead_score >= $%d", *filters.MinLeadScore)
+	}
+	if len(filters.Tags) > 0 {
+		// GIN index on tags supports array containment; tags are matched to the broker's spelling beforehand
+		addCondition("tags @> $%d::TEXT[]", pq.Array(filters.Tags))
+	}
+	if filters.Contactable != nil {
+		// The contact's latest consent entry on the channel is in force; no entry means no consent
+		addCondition(`(
+			SELECT cc.status FROM contact_consents cc
+			WHERE cc.broker_id = clients.broker_id AND cc.channel = $%[1]d
+				AND cc.contact = CASE WHEN $%[1]d = 'email' THEN LOWER(TRIM(clients.email))
+					ELSE RIGHT(regexp_replace(clients.phone, '\D', '', 'g'), 10) END
+			ORDER BY cc.recorded_at DESC LIMIT 1
+		) = 'granted'`, *filters.Contactable)
+	}
+	if filters.CreatedFrom != "" {
+		addCondition("created_at >= $%d::DATE", filters.CreatedFrom)
+	}
+	if filters.CreatedTo != "" {
+		addCondition("created_at < $%d::DATE + 1", filters.CreatedTo)
+	}
+	if search := strings.TrimSpace(filters.Search); search != "" {
+		// Trigram index on the full name supports both the substring and the similarity match
+		args = append(args, containsPattern(search), search)
+		condition := fmt.Sprintf(
+			`(first_name || ' ' || last_name) ILIKE $%[1]d ESCAPE '\' OR (first_name || ' ' || last_name) %% $%[2]d`+
+				` OR email ILIKE $%[1]d ESCAPE '\'`,
+			len(args)-1, len(args),
 		)
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan client row: %w", err)
+		// Phone numbers are compared on digits so "98200 12345" finds "+91-9820012345"
+		if digits := phoneDigits(search); len(digits) >= 3 {
+			args = append(args, "%"+digits+"%")
+			condition += fmt.Sprintf(" OR regexp_replace(phone, '\\D', '', 'g') LIKE $%d", len(args))
 		}
 
-		clients = append(clients, client)
+		query += " AND (" + condition + ")"
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating client rows: %w", err)
+	return query, args
+}
+
+// clientSortOptions returns the sort column and direction, applying the defaults
+func clientSortOptions(filters models.ClientFilters) (string, string) {
+	sort := filters.Sort
+	if sort == "" {
+		sort = "created_at"
 	}
 
-	// Return empty slice instead of nil if no clients found
-	if clients == nil {
-		clients = []models.Client{}
+	order := filters.Order
+	if order == "" {
+		order = "desc"
+		if sort == "name" {
+			order = "asc"
+		}
 	}
 
-	return clients, nil
+	return sort, order
+}
+
+// encodeClientCursor encodes a page position as an opaque URL-safe token
+func encodeClientCursor(cursor clientCursor) string {
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
 }
 
-// GetByID retrieves a single client by ID
+// decodeClientCursor decodes a token produced by encodeClientCursor for the given sort and order,
+// rejecting cursors from another ordering and values that do not parse as the sort column's type
+func decodeClientCursor(token, sort, order string) (clientCursor, error) {
+	var cursor clientCursor
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, err
+	}
+
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return cursor, err
+	}
+
+	if cursor.Sort != sort || cursor.Order != order {
+		return cursor, fmt.Errorf("cursor is for another ordering")
+	}
+
+	sortColumn, ok := clientSortColumns[cursor.Sort]
+	if !ok || !sortColumn.validCursorValue(cursor.Value) {
+		return cursor, fmt.Errorf("cursor value does not match the sort column")
+	}
+
+	id, err := uuid.Parse(cursor.ID)
+	if err != nil {
+		return cursor, fmt.Errorf("cursor id is not a valid UUID")
+	}
+	cursor.ID = id.String()
+
+	return cursor, nil
+}
+
+// containsPattern builds a LIKE pattern matching text that contains value
+// The LIKE wildcards % and _ and the escape character \ in value are matched literally
+func containsPattern(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+	return "%" + escaped + "%"
+}
+
+// phoneDigits strips everything but digits from a phone number or search term
+func phoneDigits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+// GetByID retrieves a single client by ID; clients in the trash are not found
 // This method does NOT validate broker ownership - that should be done at the service layer
 func (r *ClientRepository) GetByID(id string) (*models.Client, error) {
 	query := `
-		SELECT 
-			id, first_name, last_name, email, phone, type, status,
-			budget_min, budget_max, preferred_location, address, city, state, postal_code,
-			requirements, notes, broker_id, broker_name, broker_city, created_at, updated_at
+		SELECT ` + clientColumns + `
 		FROM clients
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var client models.Client
 
-	err := r.db.QueryRow(query, id).Scan(
-		&client.ID,
-		&client.FirstName,
-		&client.LastName,
-		&client.Email,
-		&client.Phone,
-		&client.Type,
-		&client.Status,
-		&client.BudgetMin,
-		&client.BudgetMax,
-		&client.PreferredLocation,
-		&client.Address,
-		&client.City,
-		&client.State,
-		&client.PostalCode,
-		&client.Requirements,
-		&client.Notes,
-		&client.BrokerID,
-		&client.BrokerName,
-		&client.BrokerCity,
-		&client.CreatedAt,
-		&client.UpdatedAt,
-	)
+	err := scanClient(r.db.QueryRow(query, id), &client)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -178,19 +423,232 @@ func (r *ClientRepository) GetByID(id string) (*models.Client, error) {
 	return &client, nil
 }
 
+// GetByIDs retrieves the clients with the given IDs; clients in the trash are left out
+func (r *ClientRepository) GetByIDs(ids []string) ([]models.Client, error) {
+	query := `
+		SELECT ` + clientColumns + `
+		FROM clients
+		WHERE id::text = ANY($1) AND deleted_at IS NULL
+		ORDER BY created_at
+	`
+
+	return r.queryClients(query, pq.Array(ids))
+}
+
+// FindByContact retrieves a broker's clients with the same phone number (last 10 digits) or email
+// (ignoring case), phone matches first; empty values match nothing
+func (r *ClientRepository) FindByContact(brokerID, phone, email string) ([]models.Client, error) {
+	phone = phoneDigits(phone)
+	if len(phone) > 10 {
+		phone = phone[len(phone)-10:]
+	}
+
+	query := `
+		SELECT ` + clientColumns + `
+		FROM clients
+		WHERE broker_id = $1 AND deleted_at IS NULL
+			AND (
+				($2 <> '' AND RIGHT(regexp_replace(phone, '\D', '', 'g'), 10) = $2)
+				OR ($3 <> '' AND LOWER(TRIM(email)) = LOWER(TRIM($3)))
+			)
+		ORDER BY (RIGHT(regexp_replace(phone, '\D', '', 'g'), 10) = $2) DESC, created_at
+		LIMIT 5
+	`
+
+	return r.queryClients(query, brokerID, phone, strings.TrimSpace(email))
+}
+
+// FindByEmail retrieves the clients of every broker with the given email and one of the given types
+func (r *ClientRepository) FindByEmail(email string, types []string) ([]models.Client, error) {
+	query := `
+		SELECT ` + clientColumns + `
+		FROM clients
+		WHERE LOWER(TRIM(email)) = LOWER(TRIM($1)) AND type = ANY($2) AND deleted_at IS NULL
+		ORDER BY created_at
+		LIMIT 10
+	`
+
+	return r.queryClients(query, strings.TrimSpace(email), pq.Array(types))
+}
+
+// GetSourceSummary counts a broker's clients per lead source and campaign, created within the
+// optional date range (YYYY-MM-DD, inclusive), largest groups first
+func (r *ClientRepository) GetSourceSummary(brokerID, createdFrom, createdTo string) ([]models.ClientSourceSummary, error) {
+	query := `
+		SELECT
+			source, campaign, COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'active'),
+			COUNT(*) FILTER (WHERE status = 'converted')
+		FROM clients
+		WHERE broker_id = $1 AND deleted_at IS NULL
+			AND ($2 = '' OR created_at >= NULLIF($2, '')::DATE)
+			AND ($3 = '' OR created_at < NULLIF($3, '')::DATE + 1)
+		GROUP BY source, campaign
+		ORDER BY COUNT(*) DESC, source NULLS LAST, campaign NULLS LAST
+	`
+
+	rows, err := r.db.Query(query, brokerID, createdFrom, createdTo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client source summary: %w", err)
+	}
+	defer rows.Close()
+
+	summary := []models.ClientSourceSummary{}
+
+	for rows.Next() {
+		var group models.ClientSourceSummary
+		if err := rows.Scan(&group.Source, &group.Campaign, &group.Clients, &group.Active, &group.Converted); err != nil {
+			return nil, fmt.Errorf("failed to scan client source summary row: %w", err)
+		}
+		summary = append(summary, group)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating client source summary rows: %w", err)
+	}
+
+	return summary, nil
+}
+
+// leadScoreQuery recalculates the lead scores of the clients matched by %s, a condition on c
+// Clients whose score and breakdown are unchanged are not rewritten. Points per signal:
+//   - budget: budget_max 10, budget_min 5, any structured requirement 5
+//   - appointments: an upcoming scheduled appointment 15, one booked in the last 30 days 10
+//   - completions: 10 per appointment completed in the last 90 days, up to 20
+//   - matches: 3 per available matched property, up to 15
+//   - recency: last edit or timeline entry within 7 days 20, 30 days 10, 90 days 5
+//
+// Clients who are not active score zero
+const leadScoreQuery = `
+	WITH signals AS (
+		SELECT
+			c.id,
+			c.status = 'active' AS active,
+			(CASE WHEN c.budget_max IS NOT NULL THEN 10 ELSE 0 END)
+				+ (CASE WHEN c.budget_min IS NOT NULL THEN 5 ELSE 0 END)
+				+ (CASE WHEN cardinality(c.property_types) > 0
+					OR c.bedrooms_min IS NOT NULL OR c.bedrooms_max IS NOT NULL
+					OR c.area_min IS NOT NULL OR c.area_max IS NOT NULL THEN 5 ELSE 0 END) AS budget,
+			(CASE WHEN a.upcoming > 0 THEN 15 ELSE 0 END)
+				+ (CASE WHEN a.recent > 0 THEN 10 ELSE 0 END) AS appointments,
+			LEAST(a.completed * 10, 20) AS completions,
+			LEAST(m.matched * 3, 15) AS matches,
+			CASE
+				WHEN t.last_touch >= NOW() - INTERVAL '7 days' THEN 20
+				WHEN t.last_touch >= NOW() - INTERVAL '30 days' THEN 10
+				WHEN t.last_touch >= NOW() - INTERVAL '90 days' THEN 5
+				ELSE 0
+			END AS recency
+		FROM clients c
+		CROSS JOIN LATERAL (
+			SELECT
+				COUNT(*) FILTER (WHERE status = 'scheduled' AND date >= CURRENT_DATE) AS upcoming,
+				COUNT(*) FILTER (WHERE status <> 'cancelled' AND created_at >= NOW() - INTERVAL '30 days') AS recent,
+				COUNT(*) FILTER (WHERE status = 'completed' AND date >= CURRENT_DATE - 90) AS completed
+			FROM appointments
+			WHERE client_id = c.id AND deleted_at IS NULL
+		) a
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS matched
+			FROM property_matches pm
+			JOIN properties p ON p.id = pm.property_id
+			WHERE pm.client_id = c.id AND p.status = 'available' AND p.deleted_at IS NULL
+		) m
+		CROSS JOIN LATERAL (
+			SELECT GREATEST(c.updated_at, MAX(occurred_at)) AS last_touch
+			FROM client_activities
+			WHERE client_id = c.id AND occurred_at <= NOW()
+		) t
+		WHERE c.deleted_at IS NULL AND %s
+	),
+	scores AS (
+		SELECT id, jsonb_build_object(
+			'budget', CASE WHEN active THEN budget ELSE 0 END,
+			'appointments', CASE WHEN active THEN appointments ELSE 0 END,
+			'completions', CASE WHEN active THEN completions ELSE 0 END,
+			'matches', CASE WHEN active THEN matches ELSE 0 END,
+			'recency', CASE WHEN active THEN recency ELSE 0 END
+		) AS factors,
+		CASE WHEN active THEN budget + appointments + completions + matches + recency ELSE 0 END AS score
+		FROM signals
+	)
+	UPDATE clients c
+	SET lead_score = s.score, lead_score_factors = s.factors, lead_score_updated_at = NOW()
+	FROM scores s
+	WHERE c.id = s.id
+		AND (c.lead_score, c.lead_score_factors) IS DISTINCT FROM (s.score, s.factors)
+`
+
+// RefreshLeadScores recalculates the lead scores of the given clients
+// Only clients whose score changed are returned, with their new score and breakdown
+func (r *ClientRepository) RefreshLeadScores(clientIDs []string) ([]models.Client, error) {
+	query := fmt.Sprintf(leadScoreQuery, "c.id::text = ANY($1)") +
+		" RETURNING c.id, c.lead_score, c.lead_score_factors, c.lead_score_updated_at"
+
+	rows, err := r.db.Query(query, pq.Array(clientIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh lead scores: %w", err)
+	}
+	defer rows.Close()
+
+	clients := []models.Client{}
+
+	for rows.Next() {
+		var client models.Client
+		var factors []byte
+		if err := rows.Scan(&client.ID, &client.LeadScore, &factors, &client.LeadScoreUpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan lead score row: %w", err)
+		}
+		if err := decodeLeadScoreFactors(factors, &client); err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating lead score rows: %w", err)
+	}
+
+	return clients, nil
+}
+
+// RefreshAllLeadScores recalculates every client's lead score, returning how many changed
+// Recency and appointment windows move with time, so scores drift even without new events
+func (r *ClientRepository) RefreshAllLeadScores() (int64, error) {
+	result, err := r.db.Exec(fmt.Sprintf(leadScoreQuery, "TRUE"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to refresh all lead scores: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
 // Update modifies an existing client in the database
 // The updated_at timestamp is automatically updated by database trigger
 func (r *ClientRepository) Update(client *models.Client) error {
+	return updateClient(r.db, client)
+}
+
+// clientQueryer is satisfied by both *database.DB and *sql.Tx
+type clientQueryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// updateClient writes a client's editable fields, so merges can update the survivor inside their transaction
+func updateClient(queryer clientQueryer, client *models.Client) error {
 	query := `
 		UPDATE clients SET
 			first_name = $1, last_name = $2, email = $3, phone = $4, type = $5, status = $6,
 			budget_min = $7, budget_max = $8, preferred_location = $9, address = $10,
-			city = $11, state = $12, postal_code = $13, requirements = $14, notes = $15
-		WHERE id = $16
+			city = $11, state = $12, postal_code = $13, requirements = $14, notes = $15,
+			property_types = $16, listing_type = $17, bedrooms_min = $18, bedrooms_max = $19,
+			area_min = $20, area_max = $21, preferred_localities = $22, required_amenities = $23,
+			source = $24, campaign = $25, tags = $26
+		WHERE id = $27 AND deleted_at IS NULL
 		RETURNING broker_name, broker_city, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(
+	err := queryer.QueryRow(
 		query,
 		client.FirstName,
 		client.LastName,
@@ -207,6 +665,17 @@ func (r *ClientRepository) Update(client *models.Client) error {
 		client.PostalCode,
 		client.Requirements,
 		client.Notes,
+		pq.Array(client.PropertyTypes),
+		client.ListingType,
+		client.BedroomsMin,
+		client.BedroomsMax,
+		client.AreaMin,
+		client.AreaMax,
+		pq.Array(client.PreferredLocalities),
+		pq.Array(client.RequiredAmenities),
+		client.Source,
+		client.Campaign,
+		pq.Array(client.Tags),
 		client.ID,
 	).Scan(
 		&client.BrokerName,
@@ -225,11 +694,16 @@ func (r *ClientRepository) Update(client *models.Client) error {
 	return nil
 }
 
-// Delete removes a client from the database
-func (r *ClientRepository) Delete(id string) error {
-	query := `DELETE FROM clients WHERE id = $1`
+// SoftDelete moves a client and its appointments to the trash in a single transaction
+// Both share the transaction's NOW() timestamp, which is how Restore finds the cascaded appointments
+func (r *ClientRepository) SoftDelete(id string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	result, err := r.db.Exec(query, id)
+	result, err := tx.Exec(`UPDATE clients SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete client: %w", err)
 	}
@@ -243,5 +717,219 @@ func (r *ClientRepository) Delete(id string) error {
 		return fmt.Errorf("client not found")
 	}
 
+	_, err = tx.Exec(`UPDATE appointments SET deleted_at = NOW() WHERE client_id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete client appointments: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit client deletion: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeletedByBrokerID retrieves a broker's clients in the trash, most recently deleted first
+func (r *ClientRepository) GetDeletedByBrokerID(brokerID string) ([]models.Client, error) {
+	query := `
+		SELECT ` + clientColumns + `
+		FROM clients
+		WHERE broker_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
+
+	return r.queryClients(query, brokerID)
+}
+
+// GetDeletedByID retrieves a single client from the trash
+// This method does NOT validate broker ownership - that should be done at the service layer
+func (r *ClientRepository) GetDeletedByID(id string) (*models.Client, error) {
+	query := `
+		SELECT ` + clientColumns + `
+		FROM clients
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	var client models.Client
+
+	err := scanClient(r.db.QueryRow(query, id), &client)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("client not found")
+		}
+		return nil, fmt.Errorf("failed to get deleted client by ID: %w", err)
+	}
+
+	return &client, nil
+}
+
+// Restore takes a client out of the trash along with the appointments deleted with it
+// Appointments deleted individually before the client stay in the trash
+// Returns the restored client and the number of appointments restored
+func (r *ClientRepository) Restore(id string) (*models.Client, int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var deletedAt time.Time
+	err = tx.QueryRow(`SELECT deleted_at FROM clients WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`, id).Scan(&deletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, fmt.Errorf("client not found")
+		}
+		return nil, 0, fmt.Errorf("failed to lock deleted client: %w", err)
+	}
+
+	query := `
+		UPDATE clients SET deleted_at = NULL
+		WHERE id = $1
+		RETURNING ` + clientColumns
+
+	var client models.Client
+	if err := scanClient(tx.QueryRow(query, id), &client); err != nil {
+		return nil, 0, fmt.Errorf("failed to restore client: %w", err)
+	}
+
+	result, err := tx.Exec(`UPDATE appointments SET deleted_at = NULL WHERE client_id = $1 AND deleted_at = $2`, id, deletedAt)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to restore client appointments: %w", err)
+	}
+
+	restored, err := result.RowsAffected()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, fmt.Errorf("failed to commit client restore: %w", err)
+	}
+
+	return &client, restored, nil
+}
+
+// PurgeDeleted permanently removes clients that have been in the trash since before the cutoff
+// Their appointments are removed by ON DELETE CASCADE
+func (r *ClientRepository) PurgeDeleted(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM clients WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted clients: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// GetMatchCandidates retrieves active buyers and tenants in a city looking for a listing type
+// A client's listing_type wins over the one implied by its type (buyer: sale, tenant: rent)
+// Uses index (LOWER(city), type, status) for fast candidate lookup
+func (r *ClientRepository) GetMatchCandidates(city, listingType string) ([]models.Client, error) {
+	query := `
+		SELECT ` + clientColumns + `
+		FROM clients
+		WHERE LOWER(city) = LOWER($1) AND type IN ('buyer', 'tenant') AND status = 'active'
+			AND COALESCE(listing_type, CASE type WHEN 'buyer' THEN 'sale' ELSE 'rent' END) = $2
+			AND deleted_at IS NULL
+	`
+
+	return r.queryClients(query, city, listingType)
+}
+
+// queryClients runs a client query and scans every returned row
+func (r *ClientRepository) queryClients(query string, args ...interface{}) ([]models.Client, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query clients: %w", err)
+	}
+	defer rows.Close()
+
+	var clients []models.Client
+
+	for rows.Next() {
+		var client models.Client
+		if err := scanClient(rows, &client); err != nil {
+			return nil, fmt.Errorf("failed to scan client row: %w", err)
+		}
+		clients = append(clients, client)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating client rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no clients found
+	if clients == nil {
+		clients = []models.Client{}
+	}
+
+	return clients, nil
+}
+
+// scanClient scans a row selected with clientColumns into a client
+func scanClient(scanner rowScanner, client *models.Client) error {
+	var leadScoreFactors []byte
+
+	err := scanner.Scan(
+		&client.ID,
+		&client.FirstName,
+		&client.LastName,
+		&client.Email,
+		&client.Phone,
+		&client.Type,
+		&client.Status,
+		&client.BudgetMin,
+		&client.BudgetMax,
+		&client.PreferredLocation,
+		&client.Address,
+		&client.City,
+		&client.State,
+		&client.PostalCode,
+		pq.Array(&client.PropertyTypes),
+		&client.ListingType,
+		&client.BedroomsMin,
+		&client.BedroomsMax,
+		&client.AreaMin,
+		&client.AreaMax,
+		pq.Array(&client.PreferredLocalities),
+		pq.Array(&client.RequiredAmenities),
+		&client.Requirements,
+		&client.Notes,
+		&client.PipelineStageID,
+		&client.StageChangedAt,
+		&client.LostReason,
+		&client.Source,
+		&client.Campaign,
+		pq.Array(&client.Tags),
+		&client.LeadScore,
+		&leadScoreFactors,
+		&client.LeadScoreUpdatedAt,
+		&client.BrokerID,
+		&client.BrokerName,
+		&client.BrokerCity,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+		&client.DeletedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return decodeLeadScoreFactors(leadScoreFactors, client)
+}
+
+// decodeLeadScoreFactors sets a client's lead score breakdown from its JSONB column
+// Clients that have not been scored yet have no breakdown
+func decodeLeadScoreFactors(data []byte, client *models.Client) error {
+	client.LeadScoreFactors = nil
+	if data == nil {
+		return nil
+	}
+
+	var factors models.LeadScoreFactors
+	if err := json.Unmarshal(data, &factors); err != nil {
+		return fmt.Errorf("failed to decode lead score factors: %w", err)
+	}
+	client.LeadScoreFactors = &factors
+
 	return nil
 }
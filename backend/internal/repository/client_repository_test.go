@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestDecodeClientCursor(t *testing.T) {
+	const id = "6f1c2a9e-3b7d-4e2a-9c41-8d5e0f7a1b23"
+
+	tests := []struct {
+		name   string
+		cursor clientCursor
+		sort   string
+		order  string
+		wantOK bool
+	}{
+		{"timestamp", clientCursor{"created_at", "desc", "2026-10-18T21:35:04.123456Z", id}, "created_at", "desc", true},
+		{"name", clientCursor{"name", "asc", "priya sharma", id}, "name", "asc", true},
+		{"budget", clientCursor{"budget_max", "desc", "15000000.00", id}, "budget_max", "desc", true},
+		{"lead score", clientCursor{"lead_score", "desc", "72", id}, "lead_score", "desc", true},
+		{"other sort", clientCursor{"created_at", "desc", "2026-10-18T21:35:04.123456Z", id}, "budget_max", "desc", false},
+		{"other order", clientCursor{"created_at", "desc", "2026-10-18T21:35:04.123456Z", id}, "created_at", "asc", false},
+		{"tampered timestamp", clientCursor{"created_at", "desc", "yesterday", id}, "created_at", "desc", false},
+		{"Postgres timestamp text", clientCursor{"updated_at", "desc", "2026-10-18 21:35:04.123456+00", id}, "updated_at", "desc", false},
+		{"tampered budget", clientCursor{"budget_max", "desc", "1e9", id}, "budget_max", "desc", false},
+		{"budget not a number", clientCursor{"budget_max", "desc", "NaN", id}, "budget_max", "desc", false},
+		{"fractional lead score", clientCursor{"lead_score", "desc", "7.5", id}, "lead_score", "desc", false},
+		{"lead score out of range", clientCursor{"lead_score", "desc", "99999999999", id}, "lead_score", "desc", false},
+		{"NUL in name", clientCursor{"name", "asc", "priya\x00", id}, "name", "asc", false},
+		{"id not a UUID", clientCursor{"name", "asc", "priya sharma", "42"}, "name", "asc", false},
+		{"id as a URN", clientCursor{"name", "asc", "priya sharma", "urn:uuid:" + id}, "name", "asc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := decodeClientCursor(encodeClientCursor(tt.cursor), tt.sort, tt.order)
+			if (err == nil) != tt.wantOK {
+				t.Fatalf("decodeClientCursor() error = %v, want ok = %v", err, tt.wantOK)
+			}
+			if err == nil && cursor.ID != id {
+				t.Errorf("decodeClientCursor() id = %q, want %q", cursor.ID, id)
+			}
+		})
+	}
+}
+
+func TestDecodeClientCursorMalformed(t *testing.T) {
+	for _, token := range []string{"", "not base64!", base64.RawURLEncoding.EncodeToString([]byte("not json"))} {
+		if _, err := decodeClientCursor(token, "created_at", "desc"); err == nil {
+			t.Errorf("decodeClientCursor(%q) succeeded, want an error", token)
+		}
+	}
+}
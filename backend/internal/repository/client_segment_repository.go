@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ClientSegmentRepository handles database operations for client segments
+type ClientSegmentRepository struct {
+	db *database.DB
+}
+
+// clientSegmentColumns lists the client segment columns in the order expected by scanClientSegment
+const clientSegmentColumns = `
+	id, broker_id, name, description, filters, created_at, updated_at`
+
+// NewClientSegmentRepository creates a new ClientSegmentRepository instance
+func NewClientSegmentRepository(db *database.DB) *ClientSegmentRepository {
+	return &ClientSegmentRepository{db: db}
+}
+
+// Create inserts a new client segment into the database
+func (r *ClientSegmentRepository) Create(segment *models.ClientSegment) error {
+	filters, err := json.Marshal(segment.Filters)
+	if err != nil {
+		return fmt.Errorf("failed to encode client segment filters: %w", err)
+	}
+
+	query := `
+		INSERT INTO client_segments (broker_id, name, description, filters)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = r.db.QueryRow(
+		query,
+		segment.BrokerID,
+		segment.Name,
+		segment.Description,
+		filters,
+	).Scan(
+		&segment.ID,
+		&segment.CreatedAt,
+		&segment.UpdatedAt,
+	)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("client segment %s already exists", segment.Name)
+		}
+		return fmt.Errorf("failed to create client segment: %w", err)
+	}
+
+	return nil
+}
+
+// GetByBrokerID retrieves a broker's client segments ordered by name
+func (r *ClientSegmentRepository) GetByBrokerID(brokerID string) ([]models.ClientSegment, error) {
+	query := `
+		SELECT ` + clientSegmentColumns + `
+		FROM client_segments
+		WHERE broker_id = $1
+		ORDER BY LOWER(name)
+	`
+
+	rows, err := r.db.Query(query, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client segments by broker ID: %w", err)
+	}
+	defer rows.Close()
+
+	segments := []models.ClientSegment{}
+
+	for rows.Next() {
+		var segment models.ClientSegment
+		if err := scanClientSegment(rows, &segment); err != nil {
+			return nil, fmt.Errorf("failed to scan client segment row: %w", err)
+		}
+		segments = append(segments, segment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating client segment rows: %w", err)
+	}
+
+	return segments, nil
+}
+
+// GetByID retrieves a single client segment by ID
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *ClientSegmentRepository) GetByID(id string) (*models.ClientSegment, error) {
+	query := `
+		SELECT ` + clientSegmentColumns + `
+		FROM client_segments
+		WHERE id = $1
+	`
+
+	var segment models.ClientSegment
+
+	err := scanClientSegment(r.db.QueryRow(query, id), &segment)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("client segment not found")
+		}
+		return nil, fmt.Errorf("failed to get client segment by ID: %w", err)
+	}
+
+	return &segment, nil
+}
+
+// Update modifies an existing client segment in the database
+func (r *ClientSegmentRepository) Update(segment *models.ClientSegment) error {
+	filters, err := json.Marshal(segment.Filters)
+	if err != nil {
+		return fmt.Errorf("failed to encode client segment filters: %w", err)
+	}
+
+	query := `
+		UPDATE client_segments SET name = $1, description = $2, filters = $3
+		WHERE id = $4
+		RETURNING created_at, updated_at
+	`
+
+	err = r.db.QueryRow(
+		query,
+		segment.Name,
+		segment.Description,
+		filters,
+		segment.ID,
+	).Scan(
+		&segment.CreatedAt,
+		&segment.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("client segment not found")
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("client segment %s already exists", segment.Name)
+		}
+		return fmt.Errorf("failed to update client segment: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a client segment from the database
+func (r *ClientSegmentRepository) Delete(id string) error {
+	query := `DELETE FROM client_segments WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete client segment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("client segment not found")
+	}
+
+	return nil
+}
+
+// scanClientSegment scans a row selected with clientSegmentColumns into a client segment
+func scanClientSegment(scanner rowScanner, segment *models.ClientSegment) error {
+	var filters []byte
+
+	err := scanner.Scan(
+		&segment.ID,
+		&segment.BrokerID,
+		&segment.Name,
+		&segment.Description,
+		&filters,
+		&segment.CreatedAt,
+		&segment.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(filters, &segment.Filters); err != nil {
+		return fmt.Errorf("failed to decode client segment filters: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,453 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ClientTransferRepository handles database operations for client transfers between brokers
+type ClientTransferRepository struct {
+	db *database.DB
+}
+
+// clientTransferColumns lists the transfer columns in the order expected by scanClientTransfer
+// Selected from client_transfers t joined to the giving (f) and receiving (r) brokers
+const clientTransferColumns = `
+	t.id, t.from_broker_id, f.first_name || ' ' || f.last_name,
+	t.to_broker_id, r.first_name || ' ' || r.last_name, t.requested_by,
+	t.client_ids, t.message, t.status, t.responded_at, t.transferred_count, t.moved,
+	t.created_at, t.updated_at`
+
+// clientTransferFrom is the FROM clause clientTransferColumns are selected from
+const clientTransferFrom = `
+	FROM client_transfers t
+	JOIN users f ON f.id = t.from_broker_id
+	JOIN users r ON r.id = t.to_broker_id`
+
+// clientTransferMoves lists the records that follow a client to its new broker
+// $1 is the receiving broker, $2 the giving broker and $3 the transferred client IDs.
+// Past appointments, completed tasks and documents filed under a property stay with the giving broker.
+// Consent is copied rather than moved, since the giving broker's log is their record of what they were
+// allowed; an opt-out always carries over, a grant only if the receiving broker has nothing on file
+var clientTransferMoves = []clientReference{
+	{name: "appointments", query: `
+		UPDATE appointments SET broker_id = $1
+		WHERE client_id::text = ANY($3) AND broker_id = $2
+			AND status = 'scheduled' AND date >= CURRENT_DATE AND deleted_at IS NULL`},
+	{name: "tasks", query: `
+		UPDATE tasks SET
+			broker_id = $1::uuid,
+			assignee_id = CASE WHEN assignee_id = $2::uuid THEN $1::uuid ELSE assignee_id END
+		WHERE client_id::text = ANY($3) AND broker_id = $2 AND status = 'open'`},
+	{name: "documents", query: `
+		UPDATE documents SET broker_id = $1
+		WHERE client_id::text = ANY($3) AND broker_id = $2 AND property_id IS NULL`},
+	{name: "saved_searches", query: `
+		UPDATE saved_searches SET user_id = $1
+		WHERE client_id::text = ANY($3) AND user_id = $2`},
+	{name: "consents", query: `
+		INSERT INTO contact_consents (broker_id, channel, contact, client_id, status, source, note)
+		SELECT $1::uuid, latest.channel, latest.contact, latest.client_id, latest.status, 'transfer', latest.note
+		FROM (
+			SELECT DISTINCT ON (cc.channel, cc.contact) cc.channel, cc.contact, c.id AS client_id, cc.status, cc.note
+			FROM clients c
+			JOIN contact_consents cc ON cc.broker_id = $2::uuid
+				AND cc.contact = CASE WHEN cc.channel = 'email' THEN LOWER(TRIM(c.email))
+					ELSE RIGHT(regexp_replace(c.phone, '\D', '', 'g'), 10) END
+			WHERE c.id::text = ANY($3)
+			ORDER BY cc.channel, cc.contact, cc.recorded_at DESC
+		) latest
+		WHERE latest.status = 'withdrawn' OR NOT EXISTS (
+			SELECT 1 FROM contact_consents r
+			WHERE r.broker_id = $1::uuid AND r.channel = latest.channel AND r.contact = latest.contact
+		)`},
+}
+
+// NewClientTransferRepository creates a new ClientTransferRepository instance
+func NewClientTransferRepository(db *database.DB) *ClientTransferRepository {
+	return &ClientTransferRepository{db: db}
+}
+
+// Create inserts a new pending transfer
+func (r *ClientTransferRepository) Create(transfer *models.ClientTransfer) error {
+	query := `
+		INSERT INTO client_transfers (from_broker_id, to_broker_id, requested_by, client_ids, message)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, transferred_count, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		transfer.FromBrokerID,
+		transfer.ToBrokerID,
+		transfer.RequestedBy,
+		pq.Array(transfer.ClientIDs),
+		transfer.Message,
+	).Scan(
+		&transfer.ID,
+		&transfer.Status,
+		&transfer.TransferredCount,
+		&transfer.CreatedAt,
+		&transfer.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create client transfer: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a transfer by ID
+// This method does NOT validate access - that should be done at the service layer
+func (r *ClientTransferRepository) GetByID(id string) (*models.ClientTransfer, error) {
+	query := `SELECT ` + clientTransferColumns + clientTransferFrom + ` WHERE t.id = $1`
+
+	var transfer models.ClientTransfer
+
+	err := scanClientTransfer(r.db.QueryRow(query, id), &transfer)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("client transfer not found")
+		}
+		return nil, fmt.Errorf("failed to get client transfer by ID: %w", err)
+	}
+
+	return &transfer, nil
+}
+
+// GetForUser retrieves the most recent 100 transfers a user gives, receives or proposed, newest first
+func (r *ClientTransferRepository) GetForUser(userID string, filters models.ClientTransferFilters) ([]models.ClientTransfer, error) {
+	var condition string
+	switch filters.Direction {
+	case "incoming":
+		condition = "t.to_broker_id = $1"
+	case "outgoing":
+		condition = "(t.from_broker_id = $1 OR t.requested_by = $1)"
+	default:
+		condition = "(t.to_broker_id = $1 OR t.from_broker_id = $1 OR t.requested_by = $1)"
+	}
+
+	query := `SELECT ` + clientTransferColumns + clientTransferFrom + ` WHERE ` + condition
+	args := []interface{}{userID}
+
+	if filters.Status != "" {
+		args = append(args, filters.Status)
+		query += " AND t.status = $2"
+	}
+
+	query += " ORDER BY t.created_at DESC LIMIT 100"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client transfers: %w", err)
+	}
+	defer rows.Close()
+
+	transfers := []models.ClientTransfer{}
+
+	for rows.Next() {
+		var transfer models.ClientTransfer
+		if err := scanClientTransfer(rows, &transfer); err != nil {
+			return nil, fmt.Errorf("failed to scan client transfer row: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating client transfer rows: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// GetPendingClientIDs returns which of the given clients are already offered in a pending transfer
+func (r *ClientTransferRepository) GetPendingClientIDs(clientIDs []string) ([]string, error) {
+	query := `
+		SELECT DISTINCT offered::text
+		FROM client_transfers t, unnest(t.client_ids) AS offered
+		WHERE t.status = 'pending'
+			AND t.client_ids && $1::uuid[]
+			AND offered = ANY($1::uuid[])
+	`
+
+	rows, err := r.db.Query(query, pq.Array(clientIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending client transfers: %w", err)
+	}
+	defer rows.Close()
+
+	pending := []string{}
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan pending client transfer row: %w", err)
+		}
+		pending = append(pending, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending client transfer rows: %w", err)
+	}
+
+	return pending, nil
+}
+
+// GetClientIDsByBroker returns the IDs of every client in a broker's book, leaving out the trash
+func (r *ClientTransferRepository) GetClientIDsByBroker(brokerID string) ([]string, error) {
+	rows, err := r.db.Query(`SELECT id FROM clients WHERE broker_id = $1 AND deleted_at IS NULL`, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query broker clients: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan broker client row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating broker client rows: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Respond closes a pending transfer without moving anything (declined or cancelled)
+func (r *ClientTransferRepository) Respond(id, status string) error {
+	query := `
+		UPDATE client_transfers
+		SET status = $1, responded_at = NOW()
+		WHERE id = $2 AND status = 'pending'
+	`
+
+	result, err := r.db.Exec(query, status, id)
+	if err != nil {
+		return fmt.Errorf("failed to update client transfer: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("cannot respond to a transfer that is no longer pending")
+	}
+
+	return nil
+}
+
+// Accept hands the transfer's clients to the receiving broker in a single transaction
+// Only clients the giving broker still owns are moved, along with their future appointments,
+// open tasks, client documents and saved searches. Each move is recorded in the ownership history
+// and on the client's timeline, and the clients leave the pipeline and duplicate review of the
+// giving broker. Returns the IDs of the clients that moved.
+func (r *ClientTransferRepository) Accept(transfer *models.ClientTransfer, acceptedBy string) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the transfer so a concurrent accept, decline or cancel waits for this one
+	var status string
+	err = tx.QueryRow(`SELECT status FROM client_transfers WHERE id = $1 FOR UPDATE`, transfer.ID).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("client transfer not found")
+		}
+		return nil, fmt.Errorf("failed to lock client transfer: %w", err)
+	}
+	if status != "pending" {
+		return nil, fmt.Errorf("cannot accept a transfer that is %s", status)
+	}
+
+	// Pipeline stages belong to a broker, so moved clients are placed again in the receiving pipeline
+	moveQuery := `
+		UPDATE clients
+		SET broker_id = $1, pipeline_stage_id = NULL
+		WHERE id::text = ANY($3) AND broker_id = $2 AND deleted_at IS NULL
+		RETURNING id
+	`
+	rows, err := tx.Query(moveQuery, transfer.ToBrokerID, transfer.FromBrokerID, pq.Array(transfer.ClientIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to move clients: %w", err)
+	}
+
+	movedIDs := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan moved client: %w", err)
+		}
+		movedIDs = append(movedIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating moved clients: %w", err)
+	}
+
+	moved := make(map[string]int64, len(clientTransferMoves))
+	for _, reference := range clientTransferMoves {
+		result, err := tx.Exec(reference.query, transfer.ToBrokerID, transfer.FromBrokerID, pq.Array(movedIDs))
+		if err != nil {
+			return nil, fmt.Errorf("failed to move %s to the receiving broker: %w", reference.name, err)
+		}
+
+		count, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		moved[reference.name] = count
+	}
+
+	// Duplicate pairs are found within one broker's book; the receiving broker's are detected afresh
+	if _, err := tx.Exec(
+		`DELETE FROM client_duplicates WHERE client_a_id::text = ANY($1) OR client_b_id::text = ANY($1)`,
+		pq.Array(movedIDs),
+	); err != nil {
+		return nil, fmt.Errorf("failed to remove duplicate client pairs: %w", err)
+	}
+
+	historyQuery := `
+		INSERT INTO client_ownership_history (
+			client_id, transfer_id, from_broker_id, from_broker_name, to_broker_id, to_broker_name
+		)
+		SELECT moved::uuid, $1::uuid, f.id, f.first_name || ' ' || f.last_name, r.id, r.first_name || ' ' || r.last_name
+		FROM unnest($4::text[]) AS moved, users f, users r
+		WHERE f.id = $2 AND r.id = $3
+	`
+	if _, err := tx.Exec(historyQuery, transfer.ID, transfer.FromBrokerID, transfer.ToBrokerID, pq.Array(movedIDs)); err != nil {
+		return nil, fmt.Errorf("failed to record client ownership history: %w", err)
+	}
+
+	timelineQuery := `
+		INSERT INTO client_activities (client_id, broker_id, author_id, type, body)
+		SELECT moved::uuid, $1::uuid, $2::uuid, 'note', 'Transferred from ' || $3::text || ' to ' || $4::text
+		FROM unnest($5::text[]) AS moved
+	`
+	if _, err := tx.Exec(
+		timelineQuery,
+		transfer.ToBrokerID, acceptedBy, transfer.FromBrokerName, transfer.ToBrokerName, pq.Array(movedIDs),
+	); err != nil {
+		return nil, fmt.Errorf("failed to record client transfer on the timeline: %w", err)
+	}
+
+	movedJSON, err := json.Marshal(moved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode moved records: %w", err)
+	}
+
+	acceptQuery := `
+		UPDATE client_transfers
+		SET status = 'accepted', responded_at = NOW(), transferred_count = $1, moved = $2
+		WHERE id = $3
+		RETURNING status, responded_at, updated_at
+	`
+	err = tx.QueryRow(acceptQuery, len(movedIDs), movedJSON, transfer.ID).Scan(
+		&transfer.Status,
+		&transfer.RespondedAt,
+		&transfer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept client transfer: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit client transfer: %w", err)
+	}
+
+	transfer.TransferredCount = len(movedIDs)
+	transfer.Moved = moved
+
+	return movedIDs, nil
+}
+
+// GetOwnershipHistory retrieves a client's changes of broker, most recent first
+func (r *ClientTransferRepository) GetOwnershipHistory(clientID string) ([]models.ClientOwnershipChange, error) {
+	query := `
+		SELECT id, client_id, transfer_id, from_broker_id, from_broker_name, to_broker_id, to_broker_name, transferred_at
+		FROM client_ownership_history
+		WHERE client_id = $1
+		ORDER BY transferred_at DESC
+	`
+
+	rows, err := r.db.Query(query, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client ownership history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.ClientOwnershipChange{}
+
+	for rows.Next() {
+		var change models.ClientOwnershipChange
+		err := rows.Scan(
+			&change.ID,
+			&change.ClientID,
+			&change.TransferID,
+			&change.FromBrokerID,
+			&change.FromBrokerName,
+			&change.ToBrokerID,
+			&change.ToBrokerName,
+			&change.TransferredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan client ownership row: %w", err)
+		}
+		history = append(history, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating client ownership rows: %w", err)
+	}
+
+	return history, nil
+}
+
+// scanClientTransfer scans a row selected with clientTransferColumns into a transfer
+func scanClientTransfer(scanner rowScanner, transfer *models.ClientTransfer) error {
+	var moved []byte
+
+	err := scanner.Scan(
+		&transfer.ID,
+		&transfer.FromBrokerID,
+		&transfer.FromBrokerName,
+		&transfer.ToBrokerID,
+		&transfer.ToBrokerName,
+		&transfer.RequestedBy,
+		pq.Array(&transfer.ClientIDs),
+		&transfer.Message,
+		&transfer.Status,
+		&transfer.RespondedAt,
+		&transfer.TransferredCount,
+		&moved,
+		&transfer.CreatedAt,
+		&transfer.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	transfer.Moved = nil
+	if moved != nil {
+		if err := json.Unmarshal(moved, &transfer.Moved); err != nil {
+			return fmt.Errorf("failed to decode moved records: %w", err)
+		}
+	}
+
+	return nil
+}
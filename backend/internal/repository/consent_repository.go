@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ConsentRepository handles database operations for the contact consent log
+type ConsentRepository struct {
+	db *database.DB
+}
+
+// contactConsentColumns lists the contact consent columns in the order expected by scanContactConsent
+const contactConsentColumns = `
+	id, broker_id, channel, contact, client_id, status, source, note, recorded_by, recorded_at`
+
+// NewConsentRepository creates a new ConsentRepository instance
+func NewConsentRepository(db *database.DB) *ConsentRepository {
+	return &ConsentRepository{db: db}
+}
+
+// Record appends consent entries to the log in one transaction
+func (r *ConsentRepository) Record(consents []models.ContactConsent) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO contact_consents (broker_id, channel, contact, client_id, status, source, note, recorded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, recorded_at
+	`
+
+	for i := range consents {
+		consent := &consents[i]
+		err := tx.QueryRow(
+			query,
+			consent.BrokerID,
+			consent.Channel,
+			consent.Contact,
+			consent.ClientID,
+			consent.Status,
+			consent.Source,
+			consent.Note,
+			consent.RecordedBy,
+		).Scan(&consent.ID, &consent.RecordedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record %s consent: %w", consent.Channel, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetHistory retrieves the broker's consent log for the given contacts, newest first
+// channels and contacts are parallel lists naming one contact per channel
+func (r *ConsentRepository) GetHistory(brokerID string, channels, contacts []string) ([]models.ContactConsent, error) {
+	query := `
+		SELECT ` + contactConsentColumns + `
+		FROM contact_consents
+		WHERE broker_id = $1
+			AND (channel, contact) IN (SELECT * FROM unnest($2::TEXT[], $3::TEXT[]))
+		ORDER BY recorded_at DESC, id
+	`
+
+	return r.queryConsents(query, brokerID, pq.Array(channels), pq.Array(contacts))
+}
+
+// GetWithdrawn retrieves the broker's do-not-contact registry: every contact whose latest entry on a
+// channel withdraws consent, most recent first; an empty channel lists all channels
+func (r *ConsentRepository) GetWithdrawn(brokerID, channel string) ([]models.ContactConsent, error) {
+	query := `
+		SELECT ` + contactConsentColumns + `
+		FROM (
+			SELECT DISTINCT ON (channel, contact) ` + contactConsentColumns + `
+			FROM contact_consents
+			WHERE broker_id = $1 AND ($2 = '' OR channel = $2)
+			ORDER BY channel, contact, recorded_at DESC
+		) latest
+		WHERE status = 'withdrawn'
+		ORDER BY recorded_at DESC
+	`
+
+	return r.queryConsents(query, brokerID, channel)
+}
+
+// queryConsents runs a query selecting contactConsentColumns and scans every row
+func (r *ConsentRepository) queryConsents(query string, args ...interface{}) ([]models.ContactConsent, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contact consents: %w", err)
+	}
+	defer rows.Close()
+
+	consents := []models.ContactConsent{}
+
+	for rows.Next() {
+		var consent models.ContactConsent
+		if err := scanContactConsent(rows, &consent); err != nil {
+			return nil, fmt.Errorf("failed to scan contact consent row: %w", err)
+		}
+		consents = append(consents, consent)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contact consent rows: %w", err)
+	}
+
+	return consents, nil
+}
+
+// scanContactConsent scans a row selected with contactConsentColumns into a contact consent
+func scanContactConsent(scanner rowScanner, consent *models.ContactConsent) error {
+	return scanner.Scan(
+		&consent.ID,
+		&consent.BrokerID,
+		&consent.Channel,
+		&consent.Contact,
+		&consent.ClientID,
+		&consent.Status,
+		&consent.Source,
+		&consent.Note,
+		&consent.RecordedBy,
+		&consent.RecordedAt,
+	)
+}
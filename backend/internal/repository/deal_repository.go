@@ -0,0 +1,399 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// DealRepository handles database operations for deals and their brokerage milestones
+type DealRepository struct {
+	db *database.DB
+}
+
+// dealColumns lists the deal columns, with the party names joined in, in the order expected by scanDeal
+const dealColumns = `
+	d.id, d.broker_id, TRIM(b.first_name || ' ' || b.last_name),
+	d.client_id, TRIM(c.first_name || ' ' || c.last_name), d.property_id, pr.title,
+	d.partner_id, TRIM(p.first_name || ' ' || p.last_name), d.partner_share_percent,
+	d.agreed_price, d.token_amount, TO_CHAR(d.token_date, 'YYYY-MM-DD'),
+	d.brokerage_percent, d.brokerage_amount,
+	d.status, TO_CHAR(d.expected_close_date, 'YYYY-MM-DD'), TO_CHAR(d.closed_on, 'YYYY-MM-DD'), d.notes,
+	d.created_at, d.updated_at`
+
+// dealJoins joins the users, client and property a deal names
+const dealJoins = `
+	FROM deals d
+	JOIN users b ON b.id = d.broker_id
+	LEFT JOIN users p ON p.id = d.partner_id
+	LEFT JOIN clients c ON c.id = d.client_id
+	LEFT JOIN properties pr ON pr.id = d.property_id`
+
+// dealMilestoneColumns lists the milestone columns in the order expected by scanDealMilestone
+const dealMilestoneColumns = `
+	id, deal_id, name, amount, TO_CHAR(due_date, 'YYYY-MM-DD'), status, TO_CHAR(received_on, 'YYYY-MM-DD'),
+	created_at, updated_at`
+
+// NewDealRepository creates a new DealRepository instance
+func NewDealRepository(db *database.DB) *DealRepository {
+	return &DealRepository{db: db}
+}
+
+// Create inserts a new deal and its milestones in one transaction
+func (r *DealRepository) Create(deal *models.Deal) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO deals (
+			broker_id, client_id, property_id, partner_id, partner_share_percent,
+			agreed_price, token_amount, token_date, brokerage_percent, brokerage_amount,
+			status, expected_close_date, closed_on, notes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id
+	`
+
+	err = tx.QueryRow(
+		query,
+		deal.BrokerID,
+		deal.ClientID,
+		deal.PropertyID,
+		deal.PartnerID,
+		deal.PartnerSharePercent,
+		deal.AgreedPrice,
+		deal.TokenAmount,
+		deal.TokenDate,
+		deal.BrokeragePercent,
+		deal.BrokerageAmount,
+		deal.Status,
+		deal.ExpectedCloseDate,
+		deal.ClosedOn,
+		deal.Notes,
+	).Scan(&deal.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create deal: %w", err)
+	}
+
+	for i := range deal.Milestones {
+		deal.Milestones[i].DealID = deal.ID
+		if err := insertDealMilestone(tx, &deal.Milestones[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a single deal by ID with its milestones
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *DealRepository) GetByID(id string) (*models.Deal, error) {
+	query := `SELECT ` + dealColumns + dealJoins + ` WHERE d.id = $1`
+
+	var deal models.Deal
+
+	err := scanDeal(r.db.QueryRow(query, id), &deal)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("deal not found")
+		}
+		return nil, fmt.Errorf("failed to get deal by ID: %w", err)
+	}
+
+	deals := []models.Deal{deal}
+	if err := r.fillMilestones(deals); err != nil {
+		return nil, err
+	}
+
+	return &deals[0], nil
+}
+
+// GetForUser retrieves the deals a user owns or is the partner on, newest first, with their milestones
+func (r *DealRepository) GetForUser(userID string, filters models.DealFilters) ([]models.Deal, error) {
+	query := `SELECT ` + dealColumns + dealJoins
+
+	switch filters.Role {
+	case "broker":
+		query += ` WHERE d.broker_id = $1`
+	case "partner":
+		query += ` WHERE d.partner_id = $1`
+	default:
+		query += ` WHERE (d.broker_id = $1 OR d.partner_id = $1)`
+	}
+	args := []interface{}{userID}
+
+	if filters.Status != "" {
+		args = append(args, filters.Status)
+		query += fmt.Sprintf(" AND d.status = $%d", len(args))
+	}
+	if filters.ClientID != "" {
+		args = append(args, filters.ClientID)
+		query += fmt.Sprintf(" AND d.client_id = $%d", len(args))
+	}
+
+	query += ` ORDER BY d.created_at DESC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deals: %w", err)
+	}
+	defer rows.Close()
+
+	deals := []models.Deal{}
+
+	for rows.Next() {
+		var deal models.Deal
+		if err := scanDeal(rows, &deal); err != nil {
+			return nil, fmt.Errorf("failed to scan deal row: %w", err)
+		}
+		deals = append(deals, deal)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deal rows: %w", err)
+	}
+
+	if err := r.fillMilestones(deals); err != nil {
+		return nil, err
+	}
+
+	return deals, nil
+}
+
+// Update modifies an existing deal's terms and progress; milestones are changed separately
+func (r *DealRepository) Update(deal *models.Deal) error {
+	query := `
+		UPDATE deals SET
+			property_id = $1, partner_id = $2, partner_share_percent = $3,
+			agreed_price = $4, token_amount = $5, token_date = $6, brokerage_percent = $7, brokerage_amount = $8,
+			status = $9, expected_close_date = $10, closed_on = $11, notes = $12
+		WHERE id = $13
+	`
+
+	result, err := r.db.Exec(
+		query,
+		deal.PropertyID,
+		deal.PartnerID,
+		deal.PartnerSharePercent,
+		deal.AgreedPrice,
+		deal.TokenAmount,
+		deal.TokenDate,
+		deal.BrokeragePercent,
+		deal.BrokerageAmount,
+		deal.Status,
+		deal.ExpectedCloseDate,
+		deal.ClosedOn,
+		deal.Notes,
+		deal.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update deal: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("deal not found")
+	}
+
+	return nil
+}
+
+// Delete removes a deal and its milestones from the database
+func (r *DealRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM deals WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete deal: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("deal not found")
+	}
+
+	return nil
+}
+
+// CreateMilestone adds a brokerage milestone to a deal
+func (r *DealRepository) CreateMilestone(milestone *models.DealMilestone) error {
+	return insertDealMilestone(r.db, milestone)
+}
+
+// UpdateMilestone modifies one of a deal's milestones
+func (r *DealRepository) UpdateMilestone(milestone *models.DealMilestone) error {
+	query := `
+		UPDATE deal_milestones SET name = $1, amount = $2, due_date = $3, status = $4, received_on = $5
+		WHERE id = $6 AND deal_id = $7
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		milestone.Name,
+		milestone.Amount,
+		milestone.DueDate,
+		milestone.Status,
+		milestone.ReceivedOn,
+		milestone.ID,
+		milestone.DealID,
+	).Scan(&milestone.CreatedAt, &milestone.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("deal milestone not found")
+		}
+		return fmt.Errorf("failed to update deal milestone: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMilestone removes one of a deal's milestones
+func (r *DealRepository) DeleteMilestone(dealID, milestoneID string) error {
+	result, err := r.db.Exec(`DELETE FROM deal_milestones WHERE id = $1 AND deal_id = $2`, milestoneID, dealID)
+	if err != nil {
+		return fmt.Errorf("failed to delete deal milestone: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("deal milestone not found")
+	}
+
+	return nil
+}
+
+// fillMilestones loads the milestones of the given deals, soonest due first
+func (r *DealRepository) fillMilestones(deals []models.Deal) error {
+	if len(deals) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(deals))
+	index := make(map[string]int, len(deals))
+	for i := range deals {
+		ids[i] = deals[i].ID
+		index[deals[i].ID] = i
+		deals[i].Milestones = []models.DealMilestone{}
+	}
+
+	query := `
+		SELECT ` + dealMilestoneColumns + `
+		FROM deal_milestones
+		WHERE deal_id::text = ANY($1)
+		ORDER BY due_date NULLS LAST, created_at
+	`
+
+	rows, err := r.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query deal milestones: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var milestone models.DealMilestone
+		if err := scanDealMilestone(rows, &milestone); err != nil {
+			return fmt.Errorf("failed to scan deal milestone row: %w", err)
+		}
+		deal := &deals[index[milestone.DealID]]
+		deal.Milestones = append(deal.Milestones, milestone)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating deal milestone rows: %w", err)
+	}
+
+	return nil
+}
+
+// dealQueryer is satisfied by both *database.DB and *sql.Tx
+type dealQueryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// insertDealMilestone inserts a milestone, so deals can add theirs inside the creating transaction
+func insertDealMilestone(queryer dealQueryer, milestone *models.DealMilestone) error {
+	query := `
+		INSERT INTO deal_milestones (deal_id, name, amount, due_date, status, received_on)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := queryer.QueryRow(
+		query,
+		milestone.DealID,
+		milestone.Name,
+		milestone.Amount,
+		milestone.DueDate,
+		milestone.Status,
+		milestone.ReceivedOn,
+	).Scan(&milestone.ID, &milestone.CreatedAt, &milestone.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create deal milestone: %w", err)
+	}
+
+	return nil
+}
+
+// scanDeal scans a row selected with dealColumns into a deal
+func scanDeal(scanner rowScanner, deal *models.Deal) error {
+	return scanner.Scan(
+		&deal.ID,
+		&deal.BrokerID,
+		&deal.BrokerName,
+		&deal.ClientID,
+		&deal.ClientName,
+		&deal.PropertyID,
+		&deal.PropertyTitle,
+		&deal.PartnerID,
+		&deal.PartnerName,
+		&deal.PartnerSharePercent,
+		&deal.AgreedPrice,
+		&deal.TokenAmount,
+		&deal.TokenDate,
+		&deal.BrokeragePercent,
+		&deal.BrokerageAmount,
+		&deal.Status,
+		&deal.ExpectedCloseDate,
+		&deal.ClosedOn,
+		&deal.Notes,
+		&deal.CreatedAt,
+		&deal.UpdatedAt,
+	)
+}
+
+// scanDealMilestone scans a row selected with dealMilestoneColumns into a milestone
+func scanDealMilestone(scanner rowScanner, milestone *models.DealMilestone) error {
+	return scanner.Scan(
+		&milestone.ID,
+		&milestone.DealID,
+		&milestone.Name,
+		&milestone.Amount,
+		&milestone.DueDate,
+		&milestone.Status,
+		&milestone.ReceivedOn,
+		&milestone.CreatedAt,
+		&milestone.UpdatedAt,
+	)
+}
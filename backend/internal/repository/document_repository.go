@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+)
+
+// DocumentRepository handles database operations for the documents vault
+type DocumentRepository struct {
+	db *database.DB
+}
+
+// documentColumns lists the document columns in the order expected by scanDocument
+const documentColumns = `
+	id, broker_id, property_id, client_id, document_type, title, visibility,
+	file_name, original_name, content_type, size_bytes, created_at, updated_at`
+
+// NewDocumentRepository creates a new DocumentRepository instance
+func NewDocumentRepository(db *database.DB) *DocumentRepository {
+	return &DocumentRepository{db: db}
+}
+
+// Create inserts a new document into the database
+func (r *DocumentRepository) Create(document *models.Document) error {
+	query := `
+		INSERT INTO documents (
+			broker_id, property_id, client_id, document_type, title, visibility,
+			file_name, original_name, content_type, size_bytes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		document.BrokerID,
+		document.PropertyID,
+		document.ClientID,
+		document.DocumentType,
+		document.Title,
+		document.Visibility,
+		document.FileName,
+		document.OriginalName,
+		document.ContentType,
+		document.SizeBytes,
+	).Scan(
+		&document.ID,
+		&document.CreatedAt,
+		&document.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create document: %w", err)
+	}
+
+	return nil
+}
+
+// GetByBrokerID retrieves a broker's documents matching the filters, newest first
+func (r *DocumentRepository) GetByBrokerID(brokerID string, filters models.DocumentFilters) ([]models.Document, error) {
+	query := `
+		SELECT ` + documentColumns + `
+		FROM documents
+		WHERE broker_id = $1
+	`
+	args := []interface{}{brokerID}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if filters.PropertyID != "" {
+		addCondition("property_id = $%d", filters.PropertyID)
+	}
+	if filters.ClientID != "" {
+		addCondition("client_id = $%d", filters.ClientID)
+	}
+	if filters.DocumentType != "" {
+		addCondition("document_type = $%d", filters.DocumentType)
+	}
+	if filters.Visibility != "" {
+		addCondition("visibility = $%d", filters.Visibility)
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	documents, err := r.queryDocuments(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query documents by broker ID: %w", err)
+	}
+
+	return documents, nil
+}
+
+// GetByID retrieves a single document by ID
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *DocumentRepository) GetByID(id string) (*models.Document, error) {
+	query := `
+		SELECT ` + documentColumns + `
+		FROM documents
+		WHERE id = $1
+	`
+
+	var document models.Document
+
+	err := scanDocument(r.db.QueryRow(query, id), &document)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("document not found")
+		}
+		return nil, fmt.Errorf("failed to get document by ID: %w", err)
+	}
+
+	return &document, nil
+}
+
+// Update updates a document's metadata
+func (r *DocumentRepository) Update(document *models.Document) error {
+	query := `
+		UPDATE documents SET
+			document_type = $1, title = $2, visibility = $3
+		WHERE id = $4
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		document.DocumentType,
+		document.Title,
+		document.Visibility,
+		document.ID,
+	).Scan(
+		&document.CreatedAt,
+		&document.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("document not found")
+		}
+		return fmt.Errorf("failed to update document: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a document record from the database
+func (r *DocumentRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM documents WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("document not found")
+	}
+
+	return nil
+}
+
+// DeleteForPurgedOwners removes documents filed under properties or clients that have been
+// in the trash since before the cutoff, returning the stored file names to clean up
+// Runs ahead of the owner purge, which would otherwise drop the rows through ON DELETE CASCADE
+func (r *DocumentRepository) DeleteForPurgedOwners(before time.Time) ([]string, error) {
+	query := `
+		DELETE FROM documents d
+		WHERE EXISTS (SELECT 1 FROM properties p WHERE p.id = d.property_id AND p.deleted_at < $1)
+			OR EXISTS (SELECT 1 FROM clients c WHERE c.id = d.client_id AND c.deleted_at < $1)
+		RETURNING d.file_name
+	`
+
+	rows, err := r.db.Query(query, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete documents of purged records: %w", err)
+	}
+	defer rows.Close()
+
+	var fileNames []string
+
+	for rows.Next() {
+		var fileName string
+		if err := rows.Scan(&fileName); err != nil {
+			return nil, fmt.Errorf("failed to scan document file name: %w", err)
+		}
+		fileNames = append(fileNames, fileName)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating document rows: %w", err)
+	}
+
+	return fileNames, nil
+}
+
+// queryDocuments runs a query selecting documentColumns and scans every row
+func (r *DocumentRepository) queryDocuments(query string, args ...interface{}) ([]models.Document, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []models.Document
+
+	for rows.Next() {
+		var document models.Document
+		if err := scanDocument(rows, &document); err != nil {
+			return nil, fmt.Errorf("failed to scan document row: %w", err)
+		}
+		documents = append(documents, document)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating document rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no documents found
+	if documents == nil {
+		documents = []models.Document{}
+	}
+
+	return documents, nil
+}
+
+// scanDocument scans a row selected with documentColumns into a document
+func scanDocument(scanner rowScanner, document *models.Document) error {
+	return scanner.Scan(
+		&document.ID,
+		&document.BrokerID,
+		&document.PropertyID,
+		&document.ClientID,
+		&document.DocumentType,
+		&document.Title,
+		&document.Visibility,
+		&document.FileName,
+		&document.OriginalName,
+		&document.ContentType,
+		&document.SizeBytes,
+		&document.CreatedAt,
+		&document.UpdatedAt,
+	)
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// DuplicateRepository handles database operations for duplicate listing detection
+type DuplicateRepository struct {
+	db *database.DB
+}
+
+// NewDuplicateRepository creates a new DuplicateRepository instance
+func NewDuplicateRepository(db *database.DB) *DuplicateRepository {
+	return &DuplicateRepository{db: db}
+}
+
+// FindCandidates finds listings that are likely duplicates of the given property
+// Matches on same city and listing type, trigram address similarity, and price/area within tolerance
+// Pairs an admin has already dismissed are excluded
+func (r *DuplicateRepository) FindCandidates(property *models.Property, minSimilarity, tolerance float64) ([]models.DuplicateWarning, error) {
+	query := `
+		SELECT
+			p.id, p.title, p.address, p.price, p.area, p.broker_id, p.broker_name,
+			similarity(p.address, $2) AS address_similarity
+		FROM properties p
+		WHERE p.id <> $1
+			AND p.deleted_at IS NULL
+			AND LOWER(p.city) = LOWER($3)
+			AND p.listing_type = $4
+			AND p.address % $2
+			AND similarity(p.address, $2) >= $5
+			AND p.price BETWEEN $6 * (1 - $8) AND $6 * (1 + $8)
+			AND p.area BETWEEN $7 * (1 - $8) AND $7 * (1 + $8)
+			AND NOT EXISTS (
+				SELECT 1 FROM property_duplicates d
+				WHERE d.status = 'dismissed'
+					AND d.property_a_id = LEAST(p.id, $1::uuid)
+					AND d.property_b_id = GREATEST(p.id, $1::uuid)
+			)
+		ORDER BY address_similarity DESC
+		LIMIT 10
+	`
+
+	rows, err := r.db.Query(
+		query,
+		property.ID,
+		property.Address,
+		property.City,
+		property.ListingType,
+		minSimilarity,
+		property.Price,
+		property.Area,
+		tolerance,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query duplicate candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []models.DuplicateWarning
+
+	for rows.Next() {
+		var candidate models.DuplicateWarning
+
+		err := rows.Scan(
+			&candidate.PropertyID,
+			&candidate.Title,
+			&candidate.Address,
+			&candidate.Price,
+			&candidate.Area,
+			&candidate.BrokerID,
+			&candidate.BrokerName,
+			&candidate.AddressSimilarity,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate candidate row: %w", err)
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duplicate candidate rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no candidates found
+	if candidates == nil {
+		candidates = []models.DuplicateWarning{}
+	}
+
+	return candidates, nil
+}
+
+// SyncForProperty records the current duplicate pairs for a property
+// Pending pairs that no longer qualify are removed; reviewed pairs keep their status
+func (r *DuplicateRepository) SyncForProperty(propertyID string, candidates []models.DuplicateWarning) error {
+	candidateIDs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidateIDs = append(candidateIDs, candidate.PropertyID)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleteQuery := `
+		DELETE FROM property_duplicates
+		WHERE status = 'pending'
+			AND (property_a_id = $1 OR property_b_id = $1)
+			AND NOT (property_a_id::text = ANY($2) OR property_b_id::text = ANY($2))
+	`
+	if _, err := tx.Exec(deleteQuery, propertyID, pq.Array(candidateIDs)); err != nil {
+		return fmt.Errorf("failed to remove stale duplicate pairs: %w", err)
+	}
+
+	upsertQuery := `
+		INSERT INTO property_duplicates (property_a_id, property_b_id, address_similarity)
+		VALUES (LEAST($1::uuid, $2::uuid), GREATEST($1::uuid, $2::uuid), $3)
+		ON CONFLICT (property_a_id, property_b_id)
+		DO UPDATE SET address_similarity = EXCLUDED.address_similarity
+	`
+	for _, candidate := range candidates {
+		if _, err := tx.Exec(upsertQuery, propertyID, candidate.PropertyID, candidate.AddressSimilarity); err != nil {
+			return fmt.Errorf("failed to record duplicate pair: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit duplicate pairs: %w", err)
+	}
+
+	return nil
+}
+
+// GetByStatus retrieves duplicate pairs with the given review status, most recent first
+func (r *DuplicateRepository) GetByStatus(status string) ([]models.PropertyDuplicate, error) {
+	query := `
+		SELECT
+			id, property_a_id, property_b_id, address_similarity,
+			status, reviewed_by, reviewed_at, created_at, updated_at
+		FROM property_duplicates
+		WHERE status = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query duplicate pairs: %w", err)
+	}
+	defer rows.Close()
+
+	var duplicates []models.PropertyDuplicate
+
+	for rows.Next() {
+		var duplicate models.PropertyDuplicate
+		if err := scanDuplicate(rows, &duplicate); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate pair row: %w", err)
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duplicate pair rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no pairs found
+	if duplicates == nil {
+		duplicates = []models.PropertyDuplicate{}
+	}
+
+	return duplicates, nil
+}
+
+// UpdateStatus records an admin review decision on a duplicate pair
+func (r *DuplicateRepository) UpdateStatus(id, status, reviewerID string) (*models.PropertyDuplicate, error) {
+	query := `
+		UPDATE property_duplicates
+		SET status = $1, reviewed_by = $2, reviewed_at = NOW()
+		WHERE id = $3
+		RETURNING
+			id, property_a_id, property_b_id, address_similarity,
+			status, reviewed_by, reviewed_at, created_at, updated_at
+	`
+
+	var duplicate models.PropertyDuplicate
+
+	err := scanDuplicate(r.db.QueryRow(query, status, reviewerID, id), &duplicate)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("duplicate pair not found")
+		}
+		return nil, fmt.Errorf("failed to update duplicate pair: %w", err)
+	}
+
+	return &duplicate, nil
+}
+
+// scanDuplicate scans a duplicate pair row
+func scanDuplicate(scanner rowScanner, duplicate *models.PropertyDuplicate) error {
+	return scanner.Scan(
+		&duplicate.ID,
+		&duplicate.PropertyAID,
+		&duplicate.PropertyBID,
+		&duplicate.AddressSimilarity,
+		&duplicate.Status,
+		&duplicate.ReviewedBy,
+		&duplicate.ReviewedAt,
+		&duplicate.CreatedAt,
+		&duplicate.UpdatedAt,
+	)
+}
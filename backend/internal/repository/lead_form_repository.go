@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+)
+
+// LeadFormRepository handles database operations for public lead capture forms
+type LeadFormRepository struct {
+	db *database.DB
+}
+
+// leadFormColumns lists the lead form columns in the order expected by scanLeadForm
+const leadFormColumns = `
+	id, broker_id, form_key, enabled, default_type,
+	submission_count, spam_count, last_submission_at, created_at, updated_at`
+
+// NewLeadFormRepository creates a new LeadFormRepository instance
+func NewLeadFormRepository(db *database.DB) *LeadFormRepository {
+	return &LeadFormRepository{db: db}
+}
+
+// GetOrCreate retrieves a broker's lead form, creating it with formKey when the broker has none
+func (r *LeadFormRepository) GetOrCreate(brokerID, formKey string) (*models.LeadForm, error) {
+	selectQuery := `
+		SELECT ` + leadFormColumns + `
+		FROM lead_forms
+		WHERE broker_id = $1
+	`
+
+	var form models.LeadForm
+
+	err := scanLeadForm(r.db.QueryRow(selectQuery, brokerID), &form)
+	if err == nil {
+		return &form, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get lead form: %w", err)
+	}
+
+	// Another request may create the form first; its row is read back either way
+	insertQuery := `
+		INSERT INTO lead_forms (broker_id, form_key)
+		VALUES ($1, $2)
+		ON CONFLICT (broker_id) DO NOTHING
+	`
+	if _, err := r.db.Exec(insertQuery, brokerID, formKey); err != nil {
+		return nil, fmt.Errorf("failed to create lead form: %w", err)
+	}
+
+	if err := scanLeadForm(r.db.QueryRow(selectQuery, brokerID), &form); err != nil {
+		return nil, fmt.Errorf("failed to get lead form: %w", err)
+	}
+
+	return &form, nil
+}
+
+// GetByKey retrieves a lead form by its public key
+func (r *LeadFormRepository) GetByKey(formKey string) (*models.LeadForm, error) {
+	query := `
+		SELECT ` + leadFormColumns + `
+		FROM lead_forms
+		WHERE form_key = $1
+	`
+
+	var form models.LeadForm
+
+	err := scanLeadForm(r.db.QueryRow(query, formKey), &form)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("lead form not found")
+		}
+		return nil, fmt.Errorf("failed to get lead form by key: %w", err)
+	}
+
+	return &form, nil
+}
+
+// Update saves a lead form's options
+func (r *LeadFormRepository) Update(form *models.LeadForm) error {
+	query := `
+		UPDATE lead_forms
+		SET enabled = $1, default_type = $2
+		WHERE id = $3
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(query, form.Enabled, form.DefaultType, form.ID).Scan(&form.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("lead form not found")
+		}
+		return fmt.Errorf("failed to update lead form: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateKey replaces a lead form's key, so forms embedded with the old key stop working
+func (r *LeadFormRepository) UpdateKey(form *models.LeadForm, formKey string) error {
+	query := `
+		UPDATE lead_forms
+		SET form_key = $1
+		WHERE id = $2
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(query, formKey, form.ID).Scan(&form.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("lead form not found")
+		}
+		return fmt.Errorf("failed to update lead form key: %w", err)
+	}
+
+	form.FormKey = formKey
+	return nil
+}
+
+// RecordSubmission counts a submission to a lead form, either as a lead or as spam
+func (r *LeadFormRepository) RecordSubmission(id string, spam bool) error {
+	query := `
+		UPDATE lead_forms
+		SET submission_count = submission_count + 1, last_submission_at = NOW()
+		WHERE id = $1
+	`
+	if spam {
+		query = `UPDATE lead_forms SET spam_count = spam_count + 1 WHERE id = $1`
+	}
+
+	if _, err := r.db.Exec(query, id); err != nil {
+		return fmt.Errorf("failed to record lead form submission: %w", err)
+	}
+
+	return nil
+}
+
+// scanLeadForm scans a row selected with leadFormColumns into a lead form
+func scanLeadForm(scanner rowScanner, form *models.LeadForm) error {
+	return scanner.Scan(
+		&form.ID,
+		&form.BrokerID,
+		&form.FormKey,
+		&form.Enabled,
+		&form.DefaultType,
+		&form.SubmissionCount,
+		&form.SpamCount,
+		&form.LastSubmissionAt,
+		&form.CreatedAt,
+		&form.UpdatedAt,
+	)
+}
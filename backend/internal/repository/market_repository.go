@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+)
+
+// MarketRepository computes anonymized market statistics across all brokers' listings
+// Every query groups listings and only returns groups with at least minListings listings
+// from minBrokers distinct brokers, so no single broker's data can be read back
+type MarketRepository struct {
+	db *database.DB
+}
+
+// NewMarketRepository creates a new MarketRepository instance
+func NewMarketRepository(db *database.DB) *MarketRepository {
+	return &MarketRepository{db: db}
+}
+
+// MarketQuery scopes a market statistic to a city and optional property type
+type MarketQuery struct {
+	City         string
+	PropertyType string
+	Since        time.Time // start of the lookback window
+	MinListings  int
+	MinBrokers   int
+}
+
+// notConfirmedDuplicate keeps the second listing of a confirmed duplicate pair out of
+// market statistics so the same property isn't counted twice
+const notConfirmedDuplicate = `NOT EXISTS (
+	SELECT 1 FROM property_duplicates d
+	WHERE d.property_b_id = properties.id AND d.status = 'confirmed'
+)`
+
+// marketScope builds the WHERE clause shared by market queries
+// Arguments $1..$3 are the city, minimum listings and minimum brokers
+func marketScope(q MarketQuery) (string, []interface{}) {
+	where := `LOWER(TRIM(city)) = LOWER(TRIM($1)) AND deleted_at IS NULL AND ` + notConfirmedDuplicate
+	args := []interface{}{q.City, q.MinListings, q.MinBrokers}
+
+	if q.PropertyType != "" {
+		args = append(args, q.PropertyType)
+		where += fmt.Sprintf(" AND type = $%d", len(args))
+	}
+
+	return where, args
+}
+
+// marketThreshold is the HAVING clause that suppresses undersized groups
+const marketThreshold = `HAVING COUNT(*) >= $2 AND COUNT(DISTINCT broker_id) >= $3`
+
+// GetInventory counts currently available listings in a city for sale and for rent
+func (r *MarketRepository) GetInventory(q MarketQuery) ([]models.MarketInventory, error) {
+	where, args := marketScope(q)
+	query := `
+		SELECT listing_type, COUNT(*)
+		FROM properties
+		WHERE ` + where + ` AND status = 'available'
+		GROUP BY listing_type
+		` + marketThreshold + `
+		ORDER BY listing_type
+	`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market inventory: %w", err)
+	}
+	defer rows.Close()
+
+	inventory := []models.MarketInventory{}
+
+	for rows.Next() {
+		var item models.MarketInventory
+		if err := rows.Scan(&item.ListingType, &item.Listings); err != nil {
+			return nil, fmt.Errorf("failed to scan market inventory row: %w", err)
+		}
+		inventory = append(inventory, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating market inventory rows: %w", err)
+	}
+
+	return inventory, nil
+}
+
+// GetLocalityPrices computes the median asking price per square foot by locality
+// Localities are matched case-insensitively; listings listed during the window are included
+func (r *MarketRepository) GetLocalityPrices(q MarketQuery) ([]models.LocalityPrice, error) {
+	where, args := marketScope(q)
+	args = append(args, q.Since)
+	query := fmt.Sprintf(`
+		SELECT MODE() WITHIN GROUP (ORDER BY TRIM(location)), listing_type, COUNT(*),
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY price / area)
+		FROM properties
+		WHERE %s AND area > 0 AND created_at >= $%d
+		GROUP BY LOWER(TRIM(location)), listing_type
+		%s
+		ORDER BY listing_type, COUNT(*) DESC
+	`, where, len(args), marketThreshold)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query locality prices: %w", err)
+	}
+	defer rows.Close()
+
+	localities := []models.LocalityPrice{}
+
+	for rows.Next() {
+		var locality models.LocalityPrice
+		if err := rows.Scan(&locality.Locality, &locality.ListingType, &locality.Listings, &locality.MedianPricePerSqft); err != nil {
+			return nil, fmt.Errorf("failed to scan locality price row: %w", err)
+		}
+		localities = append(localities, locality)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating locality price rows: %w", err)
+	}
+
+	return localities, nil
+}
+
+// GetDaysOnMarket computes the median days from listing to sale or rental for listings closed during the window
+func (r *MarketRepository) GetDaysOnMarket(q MarketQuery) ([]models.MarketDaysOnMarket, error) {
+	where, args := marketScope(q)
+	args = append(args, q.Since)
+	query := fmt.Sprintf(`
+		SELECT listing_type, COUNT(*),
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM closed_at - created_at) / 86400)
+		FROM properties
+		WHERE %s AND closed_at IS NOT NULL AND closed_at >= $%d
+		GROUP BY listing_type
+		%s
+		ORDER BY listing_type
+	`, where, len(args), marketThreshold)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query days on market: %w", err)
+	}
+	defer rows.Close()
+
+	daysOnMarket := []models.MarketDaysOnMarket{}
+
+	for rows.Next() {
+		var item models.MarketDaysOnMarket
+		if err := rows.Scan(&item.ListingType, &item.Closed, &item.MedianDays); err != nil {
+			return nil, fmt.Errorf("failed to scan days on market row: %w", err)
+		}
+		daysOnMarket = append(daysOnMarket, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating days on market rows: %w", err)
+	}
+
+	return daysOnMarket, nil
+}
+
+// GetCities lists cities with enough available listings for market analytics, largest first
+func (r *MarketRepository) GetCities(minListings, minBrokers int) ([]models.MarketCity, error) {
+	query := `
+		SELECT MODE() WITHIN GROUP (ORDER BY TRIM(city)), COUNT(*)
+		FROM properties
+		WHERE status = 'available' AND deleted_at IS NULL AND ` + notConfirmedDuplicate + `
+		GROUP BY LOWER(TRIM(city))
+		HAVING COUNT(*) >= $1 AND COUNT(DISTINCT broker_id) >= $2
+		ORDER BY COUNT(*) DESC
+	`
+
+	rows, err := r.db.Query(query, minListings, minBrokers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market cities: %w", err)
+	}
+	defer rows.Close()
+
+	cities := []models.MarketCity{}
+
+	for rows.Next() {
+		var city models.MarketCity
+		if err := rows.Scan(&city.City, &city.Listings); err != nil {
+			return nil, fmt.Errorf("failed to scan market city row: %w", err)
+		}
+		cities = append(cities, city)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating market city rows: %w", err)
+	}
+
+	return cities, nil
+}
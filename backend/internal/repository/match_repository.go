@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// MatchRepository handles database operations for property matches
+type MatchRepository struct {
+	db *database.DB
+}
+
+// NewMatchRepository creates a new MatchRepository instance
+func NewMatchRepository(db *database.DB) *MatchRepository {
+	return &MatchRepository{db: db}
+}
+
+// ReplaceForClient stores the current set of matches for a client
+// Matches no longer present are removed; existing matches keep their original created_at
+func (r *MatchRepository) ReplaceForClient(clientID string, matches []models.PropertyMatch) error {
+	propertyIDs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		propertyIDs = append(propertyIDs, match.PropertyID)
+	}
+
+	_, err := r.replace(
+		`DELETE FROM property_matches WHERE client_id = $1 AND NOT (property_id::text = ANY($2))
+		RETURNING property_id`,
+		clientID, propertyIDs, matches,
+	)
+	return err
+}
+
+// ReplaceForProperty stores the current set of matches for a property
+// Matches no longer present are removed; existing matches keep their original created_at
+// Returns the IDs of every client whose matches changed: those matched now and those no longer matched
+func (r *MatchRepository) ReplaceForProperty(propertyID string, matches []models.PropertyMatch) ([]string, error) {
+	clientIDs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		clientIDs = append(clientIDs, match.ClientID)
+	}
+
+	removedIDs, err := r.replace(
+		`DELETE FROM property_matches WHERE property_id = $1 AND NOT (client_id::text = ANY($2))
+		RETURNING client_id`,
+		propertyID, clientIDs, matches,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(clientIDs, removedIDs...), nil
+}
+
+// replace removes stale matches and upserts the given ones in a single transaction
+// deleteQuery returns the other side of each removed match, and those IDs are returned
+func (r *MatchRepository) replace(deleteQuery, ownerID string, keepIDs []string, matches []models.PropertyMatch) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(deleteQuery, ownerID, pq.Array(keepIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove stale matches: %w", err)
+	}
+
+	removedIDs := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan removed match: %w", err)
+		}
+		removedIDs = append(removedIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating removed matches: %w", err)
+	}
+
+	upsertQuery := `
+		INSERT INTO property_matches (client_id, property_id, score, reasons)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (client_id, property_id)
+		DO UPDATE SET score = EXCLUDED.score, reasons = EXCLUDED.reasons
+	`
+
+	for _, match := range matches {
+		_, err := tx.Exec(upsertQuery, match.ClientID, match.PropertyID, match.Score, pq.Array(match.Reasons))
+		if err != nil {
+			return nil, fmt.Errorf("failed to upsert match: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit matches: %w", err)
+	}
+
+	return removedIDs, nil
+}
+
+// GetByClientID retrieves matched properties for a client, best matches first
+// Only properties that are still available are returned
+func (r *MatchRepository) GetByClientID(clientID string) ([]models.ClientPropertyMatch, error) {
+	query := `
+		SELECT
+			m.score, m.reasons, m.created_at,
+			` + qualifyColumns("p", propertyColumns) + `
+		FROM property_matches m
+		JOIN properties p ON p.id = m.property_id
+		WHERE m.client_id = $1 AND p.status = 'available' AND p.deleted_at IS NULL
+		ORDER BY m.score DESC, m.created_at DESC
+	`
+
+	rows, err := r.db.Query(query, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matches by client ID: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []models.ClientPropertyMatch
+
+	for rows.Next() {
+		var match models.ClientPropertyMatch
+		err := scanProperty(
+			withLeadingColumns(rows, &match.Score, pq.Array(&match.Reasons), &match.MatchedAt),
+			&match.Property,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan match row: %w", err)
+		}
+
+		matches = append(matches, match)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating match rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no matches found
+	if matches == nil {
+		matches = []models.ClientPropertyMatch{}
+	}
+
+	return matches, nil
+}
+
+// GetByPropertyID retrieves matched clients for a property, best matches first
+// Only clients belonging to the given broker are returned so client data never leaks across brokers
+func (r *MatchRepository) GetByPropertyID(propertyID, brokerID string) ([]models.PropertyClientMatch, error) {
+	query := `
+		SELECT
+			m.score, m.reasons, m.created_at,
+			` + qualifyColumns("c", clientColumns) + `
+		FROM property_matches m
+		JOIN clients c ON c.id = m.client_id
+		WHERE m.property_id = $1 AND c.broker_id = $2 AND c.deleted_at IS NULL
+		ORDER BY m.score DESC, m.created_at DESC
+	`
+
+	rows, err := r.db.Query(query, propertyID, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matches by property ID: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []models.PropertyClientMatch
+
+	for rows.Next() {
+		var match models.PropertyClientMatch
+		err := scanClient(
+			withLeadingColumns(rows, &match.Score, pq.Array(&match.Reasons), &match.MatchedAt),
+			&match.Client,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan match row: %w", err)
+		}
+
+		matches = append(matches, match)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating match rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no matches found
+	if matches == nil {
+		matches = []models.PropertyClientMatch{}
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+)
+
+// NotificationRepository handles database operations for in-app notifications
+type NotificationRepository struct {
+	db *database.DB
+}
+
+// NewNotificationRepository creates a new NotificationRepository instance
+func NewNotificationRepository(db *database.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Create inserts a new notification into the database
+func (r *NotificationRepository) Create(notification *models.Notification) error {
+	query := `
+		INSERT INTO notifications (user_id, type, title, message, entity_type, entity_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, is_read, created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Message,
+		notification.EntityType,
+		notification.EntityID,
+	).Scan(
+		&notification.ID,
+		&notification.IsRead,
+		&notification.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserID retrieves notifications for a user, newest first
+// Uses index (user_id, created_at DESC) for fast retrieval
+func (r *NotificationRepository) GetByUserID(userID string, filters models.NotificationFilters) ([]models.Notification, error) {
+	query := `
+		SELECT
+			id, user_id, type, title, message, entity_type, entity_id,
+			is_read, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1
+	`
+
+	if filters.UnreadOnly {
+		query += " AND is_read = FALSE"
+	}
+	query += " ORDER BY created_at DESC LIMIT $2"
+
+	rows, err := r.db.Query(query, userID, filters.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications by user ID: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+
+	for rows.Next() {
+		var notification models.Notification
+
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Message,
+			&notification.EntityType,
+			&notification.EntityID,
+			&notification.IsRead,
+			&notification.ReadAt,
+			&notification.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification row: %w", err)
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notification rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no notifications found
+	if notifications == nil {
+		notifications = []models.Notification{}
+	}
+
+	return notifications, nil
+}
+
+// CountUnread returns the number of unread notifications for a user
+func (r *NotificationRepository) CountUnread(userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND is_read = FALSE`
+
+	var count int
+	if err := r.db.QueryRow(query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkRead marks a single notification as read for its owner
+func (r *NotificationRepository) MarkRead(id, userID string) error {
+	query := `
+		UPDATE notifications SET is_read = TRUE, read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`
+
+	result, err := r.db.Exec(query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("notification not found")
+	}
+
+	return nil
+}
+
+// MarkAllRead marks every unread notification for a user as read
+func (r *NotificationRepository) MarkAllRead(userID string) error {
+	query := `UPDATE notifications SET is_read = TRUE, read_at = NOW() WHERE user_id = $1 AND is_read = FALSE`
+
+	if _, err := r.db.Exec(query, userID); err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,505 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// PipelineRepository handles database operations for pipeline stages and client stage transitions
+type PipelineRepository struct {
+	db *database.DB
+}
+
+// pipelineStageColumns lists the stage columns in the order expected by scanPipelineStage
+const pipelineStageColumns = `id, broker_id, name, kind, position, created_at, updated_at`
+
+// stageTransitionColumns lists the transition columns in the order expected by scanStageTransition
+const stageTransitionColumns = `
+	id, client_id, changed_by, from_stage_id, from_stage_name,
+	to_stage_id, to_stage_name, lost_reason, created_at`
+
+// NewPipelineRepository creates a new PipelineRepository instance
+func NewPipelineRepository(db *database.DB) *PipelineRepository {
+	return &PipelineRepository{db: db}
+}
+
+// GetStages retrieves a broker's pipeline stages in board order
+func (r *PipelineRepository) GetStages(brokerID string) ([]models.PipelineStage, error) {
+	query := `
+		SELECT ` + pipelineStageColumns + `
+		FROM pipeline_stages
+		WHERE broker_id = $1
+		ORDER BY position, created_at
+	`
+
+	stages, err := r.queryStages(r.db, query, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pipeline stages: %w", err)
+	}
+
+	return stages, nil
+}
+
+// EnsureStages gives a broker the default stages if they have none yet and places clients
+// without a stage: converted clients in the first won stage, inactive ones in the first lost
+// stage and everyone else in the first open stage
+// A per-broker advisory lock keeps concurrent first requests from seeding twice
+func (r *PipelineRepository) EnsureStages(brokerID string, defaults []models.PipelineStage) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('pipeline:' || $1))`, brokerID); err != nil {
+		return fmt.Errorf("failed to lock pipeline: %w", err)
+	}
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM pipeline_stages WHERE broker_id = $1`, brokerID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to count pipeline stages: %w", err)
+	}
+
+	if count == 0 {
+		for i, stage := range defaults {
+			_, err := tx.Exec(
+				`INSERT INTO pipeline_stages (broker_id, name, kind, position) VALUES ($1, $2, $3, $4)`,
+				brokerID, stage.Name, stage.Kind, i+1,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create default pipeline stage: %w", err)
+			}
+		}
+	}
+
+	// Place unstaged clients and record where each one entered the pipeline
+	query := `
+		WITH placed AS (
+			UPDATE clients c SET
+				pipeline_stage_id = (
+					SELECT s.id FROM pipeline_stages s
+					WHERE s.broker_id = c.broker_id
+					ORDER BY
+						CASE
+							WHEN c.status = 'converted' AND s.kind = 'won' THEN 0
+							WHEN c.status = 'inactive' AND s.kind = 'lost' THEN 0
+							WHEN s.kind = 'open' THEN 1
+							ELSE 2
+						END,
+						s.position
+					LIMIT 1
+				),
+				stage_changed_at = NOW()
+			WHERE c.broker_id = $1 AND c.pipeline_stage_id IS NULL
+			RETURNING c.id, c.pipeline_stage_id
+		)
+		INSERT INTO client_stage_transitions (client_id, to_stage_id, to_stage_name)
+		SELECT placed.id, s.id, s.name
+		FROM placed
+		JOIN pipeline_stages s ON s.id = placed.pipeline_stage_id
+	`
+	if _, err := tx.Exec(query, brokerID); err != nil {
+		return fmt.Errorf("failed to place clients in the pipeline: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit pipeline setup: %w", err)
+	}
+
+	return nil
+}
+
+// GetStageByID retrieves a single pipeline stage by ID
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *PipelineRepository) GetStageByID(id string) (*models.PipelineStage, error) {
+	query := `
+		SELECT ` + pipelineStageColumns + `
+		FROM pipeline_stages
+		WHERE id = $1
+	`
+
+	var stage models.PipelineStage
+
+	err := scanPipelineStage(r.db.QueryRow(query, id), &stage)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("pipeline stage not found")
+		}
+		return nil, fmt.Errorf("failed to get pipeline stage by ID: %w", err)
+	}
+
+	return &stage, nil
+}
+
+// CreateStage appends a stage to the end of a broker's pipeline
+func (r *PipelineRepository) CreateStage(stage *models.PipelineStage) error {
+	query := `
+		INSERT INTO pipeline_stages (broker_id, name, kind, position)
+		SELECT $1, $2, $3, COALESCE(MAX(position), 0) + 1
+		FROM pipeline_stages
+		WHERE broker_id = $1
+		RETURNING id, position, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(query, stage.BrokerID, stage.Name, stage.Kind).Scan(
+		&stage.ID,
+		&stage.Position,
+		&stage.CreatedAt,
+		&stage.UpdatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("pipeline stage %s already exists", stage.Name)
+		}
+		return fmt.Errorf("failed to create pipeline stage: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStage updates a stage's name and kind
+func (r *PipelineRepository) UpdateStage(stage *models.PipelineStage) error {
+	query := `
+		UPDATE pipeline_stages SET name = $1, kind = $2
+		WHERE id = $3
+		RETURNING position, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(query, stage.Name, stage.Kind, stage.ID).Scan(
+		&stage.Position,
+		&stage.CreatedAt,
+		&stage.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("pipeline stage not found")
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("pipeline stage %s already exists", stage.Name)
+		}
+		return fmt.Errorf("failed to update pipeline stage: %w", err)
+	}
+
+	return nil
+}
+
+// ReorderStages renumbers a broker's stages in the given order
+// stageIDs must list every stage of the broker exactly once
+func (r *PipelineRepository) ReorderStages(brokerID string, stageIDs []string) ([]models.PipelineStage, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE pipeline_stages s SET position = o.position
+		FROM unnest($2::UUID[]) WITH ORDINALITY AS o(id, position)
+		WHERE s.id = o.id AND s.broker_id = $1
+	`
+	result, err := tx.Exec(query, brokerID, pq.Array(stageIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder pipeline stages: %w", err)
+	}
+
+	var total int64
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM pipeline_stages WHERE broker_id = $1`, brokerID).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count pipeline stages: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if updated != total || int64(len(stageIDs)) != total {
+		return nil, fmt.Errorf("invalid stage order: stage_ids must list every pipeline stage exactly once")
+	}
+
+	stages, err := r.queryStages(tx, `
+		SELECT `+pipelineStageColumns+`
+		FROM pipeline_stages
+		WHERE broker_id = $1
+		ORDER BY position
+	`, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pipeline stages: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit stage order: %w", err)
+	}
+
+	return stages, nil
+}
+
+// DeleteStage removes a stage that no client is in
+// Clients in the trash still count, since restoring them needs the stage
+func (r *PipelineRepository) DeleteStage(id string) error {
+	result, err := r.db.Exec(`DELETE FROM pipeline_stages WHERE id = $1`, id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return fmt.Errorf("cannot delete a pipeline stage that still has clients; move them to another stage first")
+		}
+		return fmt.Errorf("failed to delete pipeline stage: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("pipeline stage not found")
+	}
+
+	return nil
+}
+
+// MoveClient puts a client in a stage and records the transition in one transaction
+// status is the client status implied by the stage kind; lostReason is cleared outside lost stages
+func (r *PipelineRepository) MoveClient(client *models.Client, stage *models.PipelineStage, status string, lostReason *string, changedBy string) (*models.StageTransition, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var fromStageID, fromStageName *string
+	query := `
+		SELECT c.pipeline_stage_id, s.name
+		FROM clients c
+		LEFT JOIN pipeline_stages s ON s.id = c.pipeline_stage_id
+		WHERE c.id = $1 AND c.deleted_at IS NULL
+		FOR UPDATE OF c
+	`
+	if err := tx.QueryRow(query, client.ID).Scan(&fromStageID, &fromStageName); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("client not found")
+		}
+		return nil, fmt.Errorf("failed to lock client: %w", err)
+	}
+
+	err = tx.QueryRow(`
+		UPDATE clients SET pipeline_stage_id = $1, stage_changed_at = NOW(), lost_reason = $2, status = $3
+		WHERE id = $4
+		RETURNING stage_changed_at, updated_at
+	`, stage.ID, lostReason, status, client.ID).Scan(&client.StageChangedAt, &client.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move client: %w", err)
+	}
+	client.PipelineStageID = &stage.ID
+	client.LostReason = lostReason
+	client.Status = status
+
+	transition := models.StageTransition{
+		ClientID:      client.ID,
+		ChangedBy:     &changedBy,
+		FromStageID:   fromStageID,
+		FromStageName: fromStageName,
+		ToStageID:     &stage.ID,
+		ToStageName:   stage.Name,
+		LostReason:    lostReason,
+	}
+	err = tx.QueryRow(`
+		INSERT INTO client_stage_transitions (
+			client_id, changed_by, from_stage_id, from_stage_name, to_stage_id, to_stage_name, lost_reason
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`,
+		transition.ClientID,
+		transition.ChangedBy,
+		transition.FromStageID,
+		transition.FromStageName,
+		transition.ToStageID,
+		transition.ToStageName,
+		transition.LostReason,
+	).Scan(&transition.ID, &transition.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record stage transition: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit stage change: %w", err)
+	}
+
+	return &transition, nil
+}
+
+// GetTransitions retrieves a client's stage history, newest first
+func (r *PipelineRepository) GetTransitions(clientID string) ([]models.StageTransition, error) {
+	query := `
+		SELECT ` + stageTransitionColumns + `
+		FROM client_stage_transitions
+		WHERE client_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stage transitions: %w", err)
+	}
+	defer rows.Close()
+
+	transitions := []models.StageTransition{}
+
+	for rows.Next() {
+		var transition models.StageTransition
+		if err := scanStageTransition(rows, &transition); err != nil {
+			return nil, fmt.Errorf("failed to scan stage transition row: %w", err)
+		}
+		transitions = append(transitions, transition)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stage transition rows: %w", err)
+	}
+
+	return transitions, nil
+}
+
+// GetBoard retrieves every stage of a broker's pipeline with its client count, total budget
+// and up to clientsPerStage clients, most recently moved first
+func (r *PipelineRepository) GetBoard(brokerID string, clientsPerStage int) ([]models.PipelineBoardColumn, error) {
+	stages, err := r.GetStages(brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT pipeline_stage_id, COUNT(*), COALESCE(SUM(budget_max), 0)
+		FROM clients
+		WHERE broker_id = $1 AND deleted_at IS NULL AND pipeline_stage_id IS NOT NULL
+		GROUP BY pipeline_stage_id
+	`
+	rows, err := r.db.Query(query, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pipeline totals: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	budgets := make(map[string]float64)
+	for rows.Next() {
+		var stageID string
+		var count int
+		var budget float64
+		if err := rows.Scan(&stageID, &count, &budget); err != nil {
+			return nil, fmt.Errorf("failed to scan pipeline totals row: %w", err)
+		}
+		counts[stageID] = count
+		budgets[stageID] = budget
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pipeline totals rows: %w", err)
+	}
+
+	// Top clients of every stage in one query
+	clientQuery := `
+		SELECT ` + clientColumns + `
+		FROM (
+			SELECT *, ROW_NUMBER() OVER (
+				PARTITION BY pipeline_stage_id ORDER BY stage_changed_at DESC, id
+			) AS stage_rank
+			FROM clients
+			WHERE broker_id = $1 AND deleted_at IS NULL AND pipeline_stage_id IS NOT NULL
+		) ranked
+		WHERE stage_rank <= $2
+		ORDER BY stage_changed_at DESC, id
+	`
+	clientRows, err := r.db.Query(clientQuery, brokerID, clientsPerStage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pipeline clients: %w", err)
+	}
+	defer clientRows.Close()
+
+	clientsByStage := make(map[string][]models.Client)
+	for clientRows.Next() {
+		var client models.Client
+		if err := scanClient(clientRows, &client); err != nil {
+			return nil, fmt.Errorf("failed to scan pipeline client row: %w", err)
+		}
+		clientsByStage[*client.PipelineStageID] = append(clientsByStage[*client.PipelineStageID], client)
+	}
+	if err = clientRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pipeline client rows: %w", err)
+	}
+
+	columns := make([]models.PipelineBoardColumn, 0, len(stages))
+	for _, stage := range stages {
+		clients := clientsByStage[stage.ID]
+		if clients == nil {
+			clients = []models.Client{}
+		}
+		columns = append(columns, models.PipelineBoardColumn{
+			Stage:       stage,
+			ClientCount: counts[stage.ID],
+			TotalBudget: budgets[stage.ID],
+			Clients:     clients,
+		})
+	}
+
+	return columns, nil
+}
+
+// stageQueryer is satisfied by both *database.DB and *sql.Tx
+type stageQueryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryStages runs a query selecting pipelineStageColumns and scans every row
+func (r *PipelineRepository) queryStages(queryer stageQueryer, query string, args ...interface{}) ([]models.PipelineStage, error) {
+	rows, err := queryer.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stages := []models.PipelineStage{}
+
+	for rows.Next() {
+		var stage models.PipelineStage
+		if err := scanPipelineStage(rows, &stage); err != nil {
+			return nil, fmt.Errorf("failed to scan pipeline stage row: %w", err)
+		}
+		stages = append(stages, stage)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pipeline stage rows: %w", err)
+	}
+
+	return stages, nil
+}
+
+// scanPipelineStage scans a row selected with pipelineStageColumns into a stage
+func scanPipelineStage(scanner rowScanner, stage *models.PipelineStage) error {
+	return scanner.Scan(
+		&stage.ID,
+		&stage.BrokerID,
+		&stage.Name,
+		&stage.Kind,
+		&stage.Position,
+		&stage.CreatedAt,
+		&stage.UpdatedAt,
+	)
+}
+
+// scanStageTransition scans a row selected with stageTransitionColumns into a transition
+func scanStageTransition(scanner rowScanner, transition *models.StageTransition) error {
+	return scanner.Scan(
+		&transition.ID,
+		&transition.ClientID,
+		&transition.ChangedBy,
+		&transition.FromStageID,
+		&transition.FromStageName,
+		&transition.ToStageID,
+		&transition.ToStageName,
+		&transition.LostReason,
+		&transition.CreatedAt,
+	)
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// PortalRepository handles database operations for client portal sign-in links and shared listings
+type PortalRepository struct {
+	db *database.DB
+}
+
+// NewPortalRepository creates a new PortalRepository instance
+func NewPortalRepository(db *database.DB) *PortalRepository {
+	return &PortalRepository{db: db}
+}
+
+// CreateToken stores a sign-in link for a client, clearing the client's used and expired links
+func (r *PortalRepository) CreateToken(clientID, tokenHash string, expiresAt time.Time) error {
+	_, err := r.db.Exec(`
+		DELETE FROM client_portal_tokens
+		WHERE client_id = $1 AND (used_at IS NOT NULL OR expires_at <= NOW())
+	`, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to clear spent portal tokens: %w", err)
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO client_portal_tokens (client_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`, clientID, tokenHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create portal token: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeToken marks an unused, unexpired sign-in link as used and returns the client it signs in to
+func (r *PortalRepository) ConsumeToken(tokenHash string) (string, error) {
+	query := `
+		UPDATE client_portal_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING client_id
+	`
+
+	var clientID string
+	if err := r.db.QueryRow(query, tokenHash).Scan(&clientID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("portal token not found")
+		}
+		return "", fmt.Errorf("failed to use portal token: %w", err)
+	}
+
+	return clientID, nil
+}
+
+// GetSharedProperties retrieves the listings shared with a client, newest first
+// Listings in the trash are left out
+func (r *PortalRepository) GetSharedProperties(clientID string) ([]models.ClientSharedProperty, error) {
+	query := `
+		SELECT s.id, s.client_id, s.property_id, p.title, p.status, s.note, s.shared_by, s.shared_at
+		FROM client_shared_properties s
+		JOIN properties p ON p.id = s.property_id
+		WHERE s.client_id = $1 AND p.deleted_at IS NULL
+		ORDER BY s.shared_at DESC, s.id
+	`
+
+	rows, err := r.db.Query(query, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shared properties: %w", err)
+	}
+	defer rows.Close()
+
+	shared := []models.ClientSharedProperty{}
+
+	for rows.Next() {
+		var property models.ClientSharedProperty
+		err := rows.Scan(
+			&property.ID,
+			&property.ClientID,
+			&property.PropertyID,
+			&property.PropertyTitle,
+			&property.PropertyStatus,
+			&property.Note,
+			&property.SharedBy,
+			&property.SharedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shared property row: %w", err)
+		}
+		shared = append(shared, property)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shared property rows: %w", err)
+	}
+
+	return shared, nil
+}
+
+// ShareProperties shares listings with a client; listings already shared get the new note and move to the top
+func (r *PortalRepository) ShareProperties(clientID string, propertyIDs []string, note *string, sharedBy string) error {
+	query := `
+		INSERT INTO client_shared_properties (client_id, property_id, note, shared_by)
+		SELECT $1, property_id, $3, $4 FROM UNNEST($2::UUID[]) AS property_id
+		ON CONFLICT (client_id, property_id) DO UPDATE SET
+			note = EXCLUDED.note, shared_by = EXCLUDED.shared_by, shared_at = NOW()
+	`
+
+	if _, err := r.db.Exec(query, clientID, pq.Array(propertyIDs), note, sharedBy); err != nil {
+		return fmt.Errorf("failed to share properties: %w", err)
+	}
+
+	return nil
+}
+
+// UnshareProperty stops sharing a listing with a client
+func (r *PortalRepository) UnshareProperty(clientID, propertyID string) error {
+	result, err := r.db.Exec(
+		`DELETE FROM client_shared_properties WHERE client_id = $1 AND property_id = $2`,
+		clientID, propertyID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unshare property: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("shared property not found")
+	}
+
+	return nil
+}
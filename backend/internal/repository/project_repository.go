@@ -0,0 +1,558 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ProjectRepository handles database operations for channel partner projects and their units
+type ProjectRepository struct {
+	db *database.DB
+}
+
+// unitBlockExpired is true for units whose block has lapsed without being released
+// Lapsed blocks are treated as available everywhere so no cleanup job is needed
+const unitBlockExpired = `(u.status = 'blocked' AND u.block_expires_at < NOW())`
+
+// unitEffectiveStatus reports lapsed blocks as available
+const unitEffectiveStatus = `CASE WHEN ` + unitBlockExpired + ` THEN 'available' ELSE u.status END`
+
+// projectColumns lists the project columns in the order expected by scanProject
+// Dates are formatted as YYYY-MM-DD and unit counts are computed from project_units
+const projectColumns = `
+	p.id, p.name, p.builder_name, p.project_type,
+	p.location, p.city, p.state, p.price_range_min, p.price_range_max,
+	(SELECT COUNT(*) FROM project_units u WHERE u.project_id = p.id),
+	(SELECT COUNT(*) FROM project_units u WHERE u.project_id = p.id AND ` + unitEffectiveStatus + ` = 'available'),
+	p.description, p.amenities, p.images, p.brochure_url,
+	TO_CHAR(p.launch_date, 'YYYY-MM-DD'), TO_CHAR(p.possession_date, 'YYYY-MM-DD'),
+	p.status, p.channel_partner_id, p.created_at, p.updated_at`
+
+// projectUnitColumns lists the unit columns in the order expected by scanProjectUnit
+const projectUnitColumns = `
+	u.id, u.project_id, u.tower, u.floor, u.unit_number, u.configuration, u.carpet_area, u.price,
+	` + unitEffectiveStatus + `,
+	CASE WHEN ` + unitBlockExpired + ` THEN NULL ELSE u.blocked_by_broker_id END,
+	CASE WHEN ` + unitBlockExpired + ` THEN NULL ELSE u.blocked_for_client_id END,
+	CASE WHEN ` + unitBlockExpired + ` THEN NULL ELSE u.blocked_at END,
+	CASE WHEN ` + unitBlockExpired + ` THEN NULL ELSE u.block_expires_at END,
+	u.created_at, u.updated_at`
+
+// NewProjectRepository creates a new ProjectRepository instance
+func NewProjectRepository(db *database.DB) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+// Create inserts a new project into the database
+func (r *ProjectRepository) Create(project *models.Project) error {
+	query := `
+		INSERT INTO projects (
+			name, builder_name, project_type, location, city, state,
+			price_range_min, price_range_max, description, amenities, images,
+			launch_date, possession_date, status, channel_partner_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		project.Name,
+		project.BuilderName,
+		project.ProjectType,
+		project.Location,
+		project.City,
+		project.State,
+		project.PriceRangeMin,
+		project.PriceRangeMax,
+		project.Description,
+		pq.Array(project.Amenities), // Handle PostgreSQL array type
+		pq.Array(project.Images),
+		project.LaunchDate,
+		project.PossessionDate,
+		project.Status,
+		project.ChannelPartnerID,
+	).Scan(
+		&project.ID,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return nil
+}
+
+// GetByChannelPartnerID retrieves all projects owned by a channel partner with optional filters
+// Uses composite index (channel_partner_id, created_at DESC) for fast retrieval
+func (r *ProjectRepository) GetByChannelPartnerID(channelPartnerID string, filters models.ProjectFilters) ([]models.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects p
+		WHERE p.channel_partner_id = $1
+	`
+
+	query, args := appendProjectFilters(query, []interface{}{channelPartnerID}, filters)
+	query += " ORDER BY p.created_at DESC"
+
+	projects, err := r.queryProjects(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects by channel partner ID: %w", err)
+	}
+
+	return projects, nil
+}
+
+// Search retrieves projects from all channel partners matching the given filters, newest first
+func (r *ProjectRepository) Search(filters models.ProjectFilters) ([]models.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects p
+		WHERE TRUE
+	`
+
+	query, args := appendProjectFilters(query, []interface{}{}, filters)
+	query += " ORDER BY p.created_at DESC"
+
+	projects, err := r.queryProjects(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// appendProjectFilters adds a WHERE condition for each project filter that is set
+func appendProjectFilters(query string, args []interface{}, filters models.ProjectFilters) (string, []interface{}) {
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if filters.City != nil {
+		addCondition("LOWER(p.city) = LOWER($%d)", *filters.City)
+	}
+	if filters.Status != nil {
+		addCondition("p.status = $%d", *filters.Status)
+	}
+	if filters.ProjectType != nil {
+		addCondition("p.project_type = $%d", *filters.ProjectType)
+	}
+
+	return query, args
+}
+
+// GetByID retrieves a single project by ID
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *ProjectRepository) GetByID(id string) (*models.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects p
+		WHERE p.id = $1
+	`
+
+	var project models.Project
+
+	err := scanProject(r.db.QueryRow(query, id), &project)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("project not found")
+		}
+		return nil, fmt.Errorf("failed to get project by ID: %w", err)
+	}
+
+	return &project, nil
+}
+
+// Update modifies an existing project in the database
+// The updated_at timestamp is automatically updated by database trigger
+func (r *ProjectRepository) Update(project *models.Project) error {
+	query := `
+		UPDATE projects SET
+			name = $1, builder_name = $2, project_type = $3, location = $4, city = $5, state = $6,
+			price_range_min = $7, price_range_max = $8, description = $9, amenities = $10, images = $11,
+			launch_date = $12, possession_date = $13, status = $14, brochure_url = $15
+		WHERE id = $16
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		project.Name,
+		project.BuilderName,
+		project.ProjectType,
+		project.Location,
+		project.City,
+		project.State,
+		project.PriceRangeMin,
+		project.PriceRangeMax,
+		project.Description,
+		pq.Array(project.Amenities), // Handle PostgreSQL array type
+		pq.Array(project.Images),
+		project.LaunchDate,
+		project.PossessionDate,
+		project.Status,
+		project.BrochureURL,
+		project.ID,
+	).Scan(
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("project not found")
+		}
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a project and, through ON DELETE CASCADE, all of its units
+func (r *ProjectRepository) Delete(id string) error {
+	query := `DELETE FROM projects WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("project not found")
+	}
+
+	return nil
+}
+
+// CreateUnits inserts a batch of units for a project in a single transaction
+// Either every unit is created or none are
+func (r *ProjectRepository) CreateUnits(units []models.ProjectUnit) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO project_units (
+			project_id, tower, floor, unit_number, configuration, carpet_area, price
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, created_at, updated_at
+	`
+
+	for i := range units {
+		unit := &units[i]
+
+		err := tx.QueryRow(
+			query,
+			unit.ProjectID,
+			unit.Tower,
+			unit.Floor,
+			unit.UnitNumber,
+			unit.Configuration,
+			unit.CarpetArea,
+			unit.Price,
+		).Scan(
+			&unit.ID,
+			&unit.Status,
+			&unit.CreatedAt,
+			&unit.UpdatedAt,
+		)
+
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return fmt.Errorf("unit %s in tower %s already exists", unit.UnitNumber, unit.Tower)
+			}
+			return fmt.Errorf("failed to create project unit: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit project units: %w", err)
+	}
+
+	return nil
+}
+
+// GetUnitsByProjectID retrieves a project's units with optional filters, ordered by tower, floor and unit
+func (r *ProjectRepository) GetUnitsByProjectID(projectID string, filters models.ProjectUnitFilters) ([]models.ProjectUnit, error) {
+	query := `
+		SELECT ` + projectUnitColumns + `
+		FROM project_units u
+		WHERE u.project_id = $1
+	`
+	args := []interface{}{projectID}
+
+	if filters.Status != nil {
+		args = append(args, *filters.Status)
+		query += fmt.Sprintf(" AND "+unitEffectiveStatus+" = $%d", len(args))
+	}
+	if filters.Configuration != nil {
+		args = append(args, *filters.Configuration)
+		query += fmt.Sprintf(" AND LOWER(u.configuration) = LOWER($%d)", len(args))
+	}
+	if filters.Tower != nil {
+		args = append(args, *filters.Tower)
+		query += fmt.Sprintf(" AND u.tower = $%d", len(args))
+	}
+	query += " ORDER BY u.tower, u.floor, u.unit_number"
+
+	units, err := r.queryProjectUnits(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query project units: %w", err)
+	}
+
+	return units, nil
+}
+
+// GetUnitByID retrieves a single unit of a project
+func (r *ProjectRepository) GetUnitByID(projectID, unitID string) (*models.ProjectUnit, error) {
+	query := `
+		SELECT ` + projectUnitColumns + `
+		FROM project_units u
+		WHERE u.id = $1 AND u.project_id = $2
+	`
+
+	var unit models.ProjectUnit
+
+	err := scanProjectUnit(r.db.QueryRow(query, unitID, projectID), &unit)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("unit not found")
+		}
+		return nil, fmt.Errorf("failed to get project unit by ID: %w", err)
+	}
+
+	return &unit, nil
+}
+
+// UpdateUnit modifies a unit's details, status and block fields
+func (r *ProjectRepository) UpdateUnit(unit *models.ProjectUnit) error {
+	query := `
+		UPDATE project_units SET
+			configuration = $1, carpet_area = $2, price = $3, status = $4,
+			blocked_by_broker_id = $5, blocked_for_client_id = $6, blocked_at = $7, block_expires_at = $8
+		WHERE id = $9
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		unit.Configuration,
+		unit.CarpetArea,
+		unit.Price,
+		unit.Status,
+		unit.BlockedByBrokerID,
+		unit.BlockedForClientID,
+		unit.BlockedAt,
+		unit.BlockExpiresAt,
+		unit.ID,
+	).Scan(
+		&unit.CreatedAt,
+		&unit.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("unit not found")
+		}
+		return fmt.Errorf("failed to update project unit: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUnit removes a unit from a project
+func (r *ProjectRepository) DeleteUnit(projectID, unitID string) error {
+	query := `DELETE FROM project_units WHERE id = $1 AND project_id = $2`
+
+	result, err := r.db.Exec(query, unitID, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to delete project unit: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("unit not found")
+	}
+
+	return nil
+}
+
+// BlockUnit atomically blocks an available unit for a broker's client
+// The conditional update guarantees two brokers can never hold the same unit
+func (r *ProjectRepository) BlockUnit(projectID, unitID, brokerID, clientID string, duration time.Duration) (*models.ProjectUnit, error) {
+	query := `
+		UPDATE project_units u SET
+			status = 'blocked',
+			blocked_by_broker_id = $3,
+			blocked_for_client_id = $4,
+			blocked_at = NOW(),
+			block_expires_at = NOW() + $5 * INTERVAL '1 second'
+		WHERE u.id = $1 AND u.project_id = $2
+			AND (u.status = 'available' OR ` + unitBlockExpired + `)
+		RETURNING ` + projectUnitColumns
+
+	var unit models.ProjectUnit
+
+	err := scanProjectUnit(r.db.QueryRow(query, unitID, projectID, brokerID, clientID, duration.Seconds()), &unit)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("unit is not available for blocking")
+		}
+		return nil, fmt.Errorf("failed to block project unit: %w", err)
+	}
+
+	return &unit, nil
+}
+
+// ReleaseUnit releases a block held by the given broker
+func (r *ProjectRepository) ReleaseUnit(projectID, unitID, brokerID string) (*models.ProjectUnit, error) {
+	query := `
+		UPDATE project_units u SET
+			status = 'available',
+			blocked_by_broker_id = NULL,
+			blocked_for_client_id = NULL,
+			blocked_at = NULL,
+			block_expires_at = NULL
+		WHERE u.id = $1 AND u.project_id = $2
+			AND u.status = 'blocked' AND u.blocked_by_broker_id = $3
+		RETURNING ` + projectUnitColumns
+
+	var unit models.ProjectUnit
+
+	err := scanProjectUnit(r.db.QueryRow(query, unitID, projectID, brokerID), &unit)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("unit is not blocked by this broker")
+		}
+		return nil, fmt.Errorf("failed to release project unit: %w", err)
+	}
+
+	return &unit, nil
+}
+
+// queryProjects runs a project query and scans every returned row
+func (r *ProjectRepository) queryProjects(query string, args ...interface{}) ([]models.Project, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []models.Project
+
+	for rows.Next() {
+		var project models.Project
+		if err := scanProject(rows, &project); err != nil {
+			return nil, fmt.Errorf("failed to scan project row: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating project rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no projects found
+	if projects == nil {
+		projects = []models.Project{}
+	}
+
+	return projects, nil
+}
+
+// queryProjectUnits runs a project unit query and scans every returned row
+func (r *ProjectRepository) queryProjectUnits(query string, args ...interface{}) ([]models.ProjectUnit, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var units []models.ProjectUnit
+
+	for rows.Next() {
+		var unit models.ProjectUnit
+		if err := scanProjectUnit(rows, &unit); err != nil {
+			return nil, fmt.Errorf("failed to scan project unit row: %w", err)
+		}
+		units = append(units, unit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating project unit rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no units found
+	if units == nil {
+		units = []models.ProjectUnit{}
+	}
+
+	return units, nil
+}
+
+// scanProject scans a row selected with projectColumns into a project
+func scanProject(scanner rowScanner, project *models.Project) error {
+	return scanner.Scan(
+		&project.ID,
+		&project.Name,
+		&project.BuilderName,
+		&project.ProjectType,
+		&project.Location,
+		&project.City,
+		&project.State,
+		&project.PriceRangeMin,
+		&project.PriceRangeMax,
+		&project.TotalUnits,
+		&project.AvailableUnits,
+		&project.Description,
+		pq.Array(&project.Amenities), // Handle PostgreSQL array type
+		pq.Array(&project.Images),
+		&project.BrochureURL,
+		&project.LaunchDate,
+		&project.PossessionDate,
+		&project.Status,
+		&project.ChannelPartnerID,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+}
+
+// scanProjectUnit scans a row selected with projectUnitColumns into a unit
+func scanProjectUnit(scanner rowScanner, unit *models.ProjectUnit) error {
+	return scanner.Scan(
+		&unit.ID,
+		&unit.ProjectID,
+		&unit.Tower,
+		&unit.Floor,
+		&unit.UnitNumber,
+		&unit.Configuration,
+		&unit.CarpetArea,
+		&unit.Price,
+		&unit.Status,
+		&unit.BlockedByBrokerID,
+		&unit.BlockedForClientID,
+		&unit.BlockedAt,
+		&unit.BlockExpiresAt,
+		&unit.CreatedAt,
+		&unit.UpdatedAt,
+	)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"enfor-data-backend/internal/database"
 	"enfor-data-backend/internal/models"
@@ -15,6 +16,13 @@ type PropertyRepository struct {
 	db *database.DB
 }
 
+// propertyColumns lists the property columns in the order expected by scanProperty
+const propertyColumns = `
+	id, title, type, listing_type, price, area,
+	bedrooms, bathrooms, location, address, city, state,
+	description, amenities, images, tags, status, broker_id,
+	broker_name, broker_city, expires_at, archived_at, deleted_at, created_at, updated_at`
+
 // NewPropertyRepository creates a new PropertyRepository instance
 func NewPropertyRepository(db *database.DB) *PropertyRepository {
 	return &PropertyRepository{db: db}
@@ -27,8 +35,8 @@ func (r *PropertyRepository) Create(property *models.Property) error {
 		INSERT INTO properties (
 			title, type, listing_type, price, area,
 			bedrooms, bathrooms, location, address, city, state,
-			description, amenities, status, broker_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			description, amenities, images, tags, status, broker_id, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 		RETURNING id, broker_name, broker_city, created_at, updated_at
 	`
 
@@ -47,8 +55,11 @@ func (r *PropertyRepository) Create(property *models.Property) error {
 		property.State,
 		property.Description,
 		pq.Array(property.Amenities), // Handle PostgreSQL array type
+		pq.Array(property.Images),
+		pq.Array(property.Tags),
 		property.Status,
 		property.BrokerID,
+		property.ExpiresAt,
 	).Scan(
 		&property.ID,
 		&property.BrokerName,
@@ -64,58 +75,366 @@ func (r *PropertyRepository) Create(property *models.Property) error {
 	return nil
 }
 
-// GetByBrokerID retrieves all properties for a specific broker
+// GetByBrokerID retrieves all properties for a specific broker with optional filters
 // Uses optimized composite index (broker_id, created_at DESC) for fast retrieval
-func (r *PropertyRepository) GetByBrokerID(brokerID string) ([]models.Property, error) {
+func (r *PropertyRepository) GetByBrokerID(brokerID string, filters models.PropertyFilters) ([]models.Property, error) {
 	query := `
-		SELECT 
-			id, title, type, listing_type, price, area,
-			bedrooms, bathrooms, location, address, city, state,
-			description, amenities, status, broker_id,
-			broker_name, broker_city, created_at, updated_at
+		SELECT ` + propertyColumns + `
 		FROM properties
 		WHERE broker_id = $1
-		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.Query(query, brokerID)
+	query, args := appendPropertyFilters(query, []interface{}{brokerID}, filters)
+	query += " ORDER BY created_at DESC"
+
+	properties, err := r.queryProperties(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query properties by broker ID: %w", err)
 	}
+
+	return properties, nil
+}
+
+// Search retrieves properties from all brokers matching the given filters, newest first
+func (r *PropertyRepository) Search(filters models.PropertyFilters, limit int) ([]models.Property, error) {
+	query := `
+		SELECT ` + propertyColumns + `
+		FROM properties
+		WHERE TRUE
+	`
+
+	query, args := appendPropertyFilters(query, []interface{}{}, filters)
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args))
+
+	properties, err := r.queryProperties(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search properties: %w", err)
+	}
+
+	return properties, nil
+}
+
+// appendPropertyFilters adds a WHERE condition for each filter that is set
+// Properties in the trash are always excluded
+// Keep in sync with models.PropertyFilters.Matches
+func appendPropertyFilters(query string, args []interface{}, filters models.PropertyFilters) (string, []interface{}) {
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	query += " AND deleted_at IS NULL"
+
+	if filters.Type != nil {
+		addCondition("type = $%d", *filters.Type)
+	}
+	if filters.ListingType != nil {
+		addCondition("listing_type = $%d", *filters.ListingType)
+	}
+	if filters.Status != nil {
+		addCondition("status = $%d", *filters.Status)
+	} else {
+		query += " AND status <> 'archived'"
+	}
+	if filters.City != nil {
+		addCondition("LOWER(city) = LOWER($%d)", *filters.City)
+	}
+	if filters.Location != nil {
+		// Trigram index on location supports ILIKE substring search
+		args = append(args, "%"+*filters.Location+"%")
+		query += fmt.Sprintf(" AND (location ILIKE $%d OR address ILIKE $%d)", len(args), len(args))
+	}
+	if filters.MinPrice != nil {
+		addCondition("price >= $%d", *filters.MinPrice)
+	}
+	if filters.MaxPrice != nil {
+		addCondition("price <= $%d", *filters.MaxPrice)
+	}
+	if filters.MinArea != nil {
+		addCondition("area >= $%d", *filters.MinArea)
+	}
+	if filters.MaxArea != nil {
+		addCondition("area <= $%d", *filters.MaxArea)
+	}
+	if filters.MinBedrooms != nil {
+		addCondition("bedrooms >= $%d", *filters.MinBedrooms)
+	}
+	if filters.MaxBedrooms != nil {
+		addCondition("bedrooms <= $%d", *filters.MaxBedrooms)
+	}
+	if len(filters.Tags) > 0 {
+		// GIN index on tags supports array containment; tags are matched to the broker's spelling beforehand
+		addCondition("tags @> $%d::TEXT[]", pq.Array(filters.Tags))
+	}
+
+	return query, args
+}
+
+// GetByID retrieves a single property by ID; properties in the trash are not found
+// This method does NOT validate broker ownership - that should be done at the service layer
+func (r *PropertyRepository) GetByID(id string) (*models.Property, error) {
+	query := `
+		SELECT ` + propertyColumns + `
+		FROM properties
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	var property models.Property
+
+	err := scanProperty(r.db.QueryRow(query, id), &property)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("property not found")
+		}
+		return nil, fmt.Errorf("failed to get property by ID: %w", err)
+	}
+
+	return &property, nil
+}
+
+// Update modifies an existing property in the database
+// The updated_at timestamp is automatically updated by database trigger
+// Changing expires_at re-arms the expiry reminder
+func (r *PropertyRepository) Update(property *models.Property) error {
+	query := `
+		UPDATE properties SET
+			title = $1, type = $2, listing_type = $3, price = $4, area = $5,
+			bedrooms = $6, bathrooms = $7, location = $8, address = $9, city = $10, state = $11,
+			description = $12, amenities = $13, images = $14, tags = $15, status = $16,
+			expires_at = $17, archived_at = $18,
+			expiry_notified_at = CASE
+				WHEN expires_at IS DISTINCT FROM $17 THEN NULL
+				ELSE expiry_notified_at
+			END
+		WHERE id = $19 AND deleted_at IS NULL
+		RETURNING broker_name, broker_city, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		property.Title,
+		property.Type,
+		property.ListingType,
+		property.Price,
+		property.Area,
+		property.Bedrooms,
+		property.Bathrooms,
+		property.Location,
+		property.Address,
+		property.City,
+		property.State,
+		property.Description,
+		pq.Array(property.Amenities), // Handle PostgreSQL array type
+		pq.Array(property.Images),
+		pq.Array(property.Tags),
+		property.Status,
+		property.ExpiresAt,
+		property.ArchivedAt,
+		property.ID,
+	).Scan(
+		&property.BrokerName,
+		&property.BrokerCity,
+		&property.CreatedAt,
+		&property.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("property not found")
+		}
+		return fmt.Errorf("failed to update property: %w", err)
+	}
+
+	return nil
+}
+
+// GetMatchCandidates retrieves available properties in a city for the matching engine
+// Uses index (LOWER(city), listing_type, status) for fast candidate lookup
+func (r *PropertyRepository) GetMatchCandidates(city, listingType string) ([]models.Property, error) {
+	query := `
+		SELECT ` + propertyColumns + `
+		FROM properties
+		WHERE LOWER(city) = LOWER($1) AND listing_type = $2 AND status = 'available'
+			AND deleted_at IS NULL
+	`
+
+	return r.queryProperties(query, city, listingType)
+}
+
+// GetByIDs retrieves properties by a set of IDs
+// This method does NOT validate broker ownership - that should be done at the service layer
+func (r *PropertyRepository) GetByIDs(ids []string) ([]models.Property, error) {
+	query := `
+		SELECT ` + propertyColumns + `
+		FROM properties
+		WHERE id::text = ANY($1) AND deleted_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	return r.queryProperties(query, pq.Array(ids))
+}
+
+// GetFeedListings retrieves a broker's listings for a syndication feed, oldest change first
+// A full feed (since nil) contains available listings only; an incremental feed contains every
+// listing changed after since, whatever its status and including deleted ones, so portals can
+// take down sold, rented or deleted listings
+func (r *PropertyRepository) GetFeedListings(brokerID string, since *time.Time) ([]models.Property, error) {
+	query := `
+		SELECT ` + propertyColumns + `
+		FROM properties
+		WHERE broker_id = $1
+	`
+	args := []interface{}{brokerID}
+
+	if since == nil {
+		query += " AND status = 'available' AND deleted_at IS NULL"
+	} else {
+		query += " AND updated_at > $2"
+		args = append(args, *since)
+	}
+	query += " ORDER BY updated_at"
+
+	return r.queryProperties(query, args...)
+}
+
+// GetExpiringListings retrieves available listings expiring before the given time
+// whose brokers have not yet been reminded
+func (r *PropertyRepository) GetExpiringListings(before time.Time) ([]models.Property, error) {
+	query := `
+		SELECT ` + propertyColumns + `
+		FROM properties
+		WHERE status = 'available'
+			AND deleted_at IS NULL
+			AND expires_at > NOW()
+			AND expires_at <= $1
+			AND expiry_notified_at IS NULL
+		ORDER BY expires_at
+	`
+
+	return r.queryProperties(query, before)
+}
+
+// MarkExpiryNotified records that the expiry reminder for a listing has been sent
+func (r *PropertyRepository) MarkExpiryNotified(id string) error {
+	_, err := r.db.Exec(`UPDATE properties SET expiry_notified_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark property expiry notified: %w", err)
+	}
+
+	return nil
+}
+
+// ArchiveExpired archives every available listing past its expiry and returns the archived listings
+func (r *PropertyRepository) ArchiveExpired() ([]models.Property, error) {
+	query := `
+		UPDATE properties
+		SET status = 'archived', archived_at = NOW()
+		WHERE status = 'available' AND expires_at <= NOW() AND deleted_at IS NULL
+		RETURNING ` + propertyColumns
+
+	return r.queryProperties(query)
+}
+
+// SoftDelete moves a property to the trash
+func (r *PropertyRepository) SoftDelete(id string) error {
+	result, err := r.db.Exec(`UPDATE properties SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete property: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("property not found")
+	}
+
+	return nil
+}
+
+// GetDeletedByBrokerID retrieves a broker's properties in the trash, most recently deleted first
+func (r *PropertyRepository) GetDeletedByBrokerID(brokerID string) ([]models.Property, error) {
+	query := `
+		SELECT ` + propertyColumns + `
+		FROM properties
+		WHERE broker_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
+
+	return r.queryProperties(query, brokerID)
+}
+
+// GetDeletedByID retrieves a single property from the trash
+// This method does NOT validate broker ownership - that should be done at the service layer
+func (r *PropertyRepository) GetDeletedByID(id string) (*models.Property, error) {
+	query := `
+		SELECT ` + propertyColumns + `
+		FROM properties
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	var property models.Property
+
+	err := scanProperty(r.db.QueryRow(query, id), &property)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("property not found")
+		}
+		return nil, fmt.Errorf("failed to get deleted property by ID: %w", err)
+	}
+
+	return &property, nil
+}
+
+// Restore takes a property out of the trash
+func (r *PropertyRepository) Restore(id string) (*models.Property, error) {
+	query := `
+		UPDATE properties SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING ` + propertyColumns
+
+	var property models.Property
+
+	err := scanProperty(r.db.QueryRow(query, id), &property)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("property not found")
+		}
+		return nil, fmt.Errorf("failed to restore property: %w", err)
+	}
+
+	return &property, nil
+}
+
+// PurgeDeleted permanently removes properties that have been in the trash since before the cutoff
+func (r *PropertyRepository) PurgeDeleted(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM properties WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted properties: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// queryProperties runs a property query and scans every returned row
+func (r *PropertyRepository) queryProperties(query string, args ...interface{}) ([]models.Property, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query properties: %w", err)
+	}
 	defer rows.Close()
 
 	var properties []models.Property
 
 	for rows.Next() {
 		var property models.Property
-
-		err := rows.Scan(
-			&property.ID,
-			&property.Title,
-			&property.Type,
-			&property.ListingType,
-			&property.Price,
-			&property.Area,
-			&property.Bedrooms,
-			&property.Bathrooms,
-			&property.Location,
-			&property.Address,
-			&property.City,
-			&property.State,
-			&property.Description,
-			pq.Array(&property.Amenities), // Handle PostgreSQL array type
-			&property.Status,
-			&property.BrokerID,
-			&property.BrokerName,
-			&property.BrokerCity,
-			&property.CreatedAt,
-			&property.UpdatedAt,
-		)
-
-		if err != nil {
+		if err := scanProperty(rows, &property); err != nil {
 			return nil, fmt.Errorf("failed to scan property row: %w", err)
 		}
-
 		properties = append(properties, property)
 	}
 
@@ -131,22 +450,9 @@ func (r *PropertyRepository) GetByBrokerID(brokerID string) ([]models.Property,
 	return properties, nil
 }
 
-// GetByID retrieves a single property by ID
-// This method does NOT validate broker ownership - that should be done at the service layer
-func (r *PropertyRepository) GetByID(id string) (*models.Property, error) {
-	query := `
-		SELECT 
-			id, title, type, listing_type, price, area,
-			bedrooms, bathrooms, location, address, city, state,
-			description, amenities, status, broker_id,
-			broker_name, broker_city, created_at, updated_at
-		FROM properties
-		WHERE id = $1
-	`
-
-	var property models.Property
-
-	err := r.db.QueryRow(query, id).Scan(
+// scanProperty scans a row selected with propertyColumns into a property
+func scanProperty(scanner rowScanner, property *models.Property) error {
+	return scanner.Scan(
 		&property.ID,
 		&property.Title,
 		&property.Type,
@@ -161,20 +467,16 @@ func (r *PropertyRepository) GetByID(id string) (*models.Property, error) {
 		&property.State,
 		&property.Description,
 		pq.Array(&property.Amenities), // Handle PostgreSQL array type
+		pq.Array(&property.Images),
+		pq.Array(&property.Tags),
 		&property.Status,
 		&property.BrokerID,
 		&property.BrokerName,
 		&property.BrokerCity,
+		&property.ExpiresAt,
+		&property.ArchivedAt,
+		&property.DeletedAt,
 		&property.CreatedAt,
 		&property.UpdatedAt,
 	)
-
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("property not found")
-		}
-		return nil, fmt.Errorf("failed to get property by ID: %w", err)
-	}
-
-	return &property, nil
 }
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+)
+
+// SavedSearchRepository handles database operations for saved searches
+type SavedSearchRepository struct {
+	db *database.DB
+}
+
+// savedSearchColumns lists the saved search columns in the order expected by scanSavedSearch
+const savedSearchColumns = `
+	id, user_id, client_id, name, filters,
+	notify_in_app, notify_email, is_active, last_notified_at, created_at, updated_at`
+
+// NewSavedSearchRepository creates a new SavedSearchRepository instance
+func NewSavedSearchRepository(db *database.DB) *SavedSearchRepository {
+	return &SavedSearchRepository{db: db}
+}
+
+// Create inserts a new saved search into the database
+func (r *SavedSearchRepository) Create(search *models.SavedSearch) error {
+	filters, err := json.Marshal(search.Filters)
+	if err != nil {
+		return fmt.Errorf("failed to encode saved search filters: %w", err)
+	}
+
+	query := `
+		INSERT INTO saved_searches (
+			user_id, client_id, name, filters, notify_in_app, notify_email, is_active
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = r.db.QueryRow(
+		query,
+		search.UserID,
+		search.ClientID,
+		search.Name,
+		filters,
+		search.NotifyInApp,
+		search.NotifyEmail,
+		search.IsActive,
+	).Scan(
+		&search.ID,
+		&search.CreatedAt,
+		&search.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserID retrieves a user's saved searches, optionally only those attached to one client
+func (r *SavedSearchRepository) GetByUserID(userID string, clientID *string) ([]models.SavedSearch, error) {
+	query := `
+		SELECT ` + savedSearchColumns + `
+		FROM saved_searches
+		WHERE user_id = $1
+	`
+	args := []interface{}{userID}
+
+	if clientID != nil {
+		query += " AND client_id = $2"
+		args = append(args, *clientID)
+	}
+	query += " ORDER BY created_at DESC"
+
+	searches, err := r.querySavedSearches(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved searches by user ID: %w", err)
+	}
+
+	return searches, nil
+}
+
+// GetByID retrieves a single saved search by ID
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *SavedSearchRepository) GetByID(id string) (*models.SavedSearch, error) {
+	query := `
+		SELECT ` + savedSearchColumns + `
+		FROM saved_searches
+		WHERE id = $1
+	`
+
+	var search models.SavedSearch
+
+	err := scanSavedSearch(r.db.QueryRow(query, id), &search)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("saved search not found")
+		}
+		return nil, fmt.Errorf("failed to get saved search by ID: %w", err)
+	}
+
+	return &search, nil
+}
+
+// GetActiveCandidates retrieves active saved searches that could match a property
+// Prefilters on the city and listing type keys so only plausible searches are evaluated in memory
+func (r *SavedSearchRepository) GetActiveCandidates(city, listingType string) ([]models.SavedSearch, error) {
+	query := `
+		SELECT ` + savedSearchColumns + `
+		FROM saved_searches
+		WHERE is_active = TRUE
+			AND (filters->>'city' IS NULL OR LOWER(filters->>'city') = LOWER($1))
+			AND (filters->>'listing_type' IS NULL OR filters->>'listing_type' = $2)
+	`
+
+	searches, err := r.querySavedSearches(query, city, listingType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query candidate saved searches: %w", err)
+	}
+
+	return searches, nil
+}
+
+// Update modifies an existing saved search in the database
+func (r *SavedSearchRepository) Update(search *models.SavedSearch) error {
+	filters, err := json.Marshal(search.Filters)
+	if err != nil {
+		return fmt.Errorf("failed to encode saved search filters: %w", err)
+	}
+
+	query := `
+		UPDATE saved_searches SET
+			name = $1, filters = $2, notify_in_app = $3, notify_email = $4, is_active = $5
+		WHERE id = $6
+		RETURNING created_at, updated_at
+	`
+
+	err = r.db.QueryRow(
+		query,
+		search.Name,
+		filters,
+		search.NotifyInApp,
+		search.NotifyEmail,
+		search.IsActive,
+		search.ID,
+	).Scan(
+		&search.CreatedAt,
+		&search.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("saved search not found")
+		}
+		return fmt.Errorf("failed to update saved search: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a saved search from the database
+func (r *SavedSearchRepository) Delete(id string) error {
+	query := `DELETE FROM saved_searches WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("saved search not found")
+	}
+
+	return nil
+}
+
+// RecordAlert records that a property was alerted for a saved search
+// Returns false if the property had already been alerted for this search
+func (r *SavedSearchRepository) RecordAlert(searchID, propertyID string) (bool, error) {
+	query := `
+		INSERT INTO saved_search_alerts (saved_search_id, property_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	result, err := r.db.Exec(query, searchID, propertyID)
+	if err != nil {
+		return false, fmt.Errorf("failed to record saved search alert: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected > 0 {
+		if _, err := r.db.Exec(`UPDATE saved_searches SET last_notified_at = NOW() WHERE id = $1`, searchID); err != nil {
+			return true, fmt.Errorf("failed to update last notified time: %w", err)
+		}
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// querySavedSearches runs a saved search query and scans every returned row
+func (r *SavedSearchRepository) querySavedSearches(query string, args ...interface{}) ([]models.SavedSearch, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var searches []models.SavedSearch
+
+	for rows.Next() {
+		var search models.SavedSearch
+		if err := scanSavedSearch(rows, &search); err != nil {
+			return nil, fmt.Errorf("failed to scan saved search row: %w", err)
+		}
+		searches = append(searches, search)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved search rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no saved searches found
+	if searches == nil {
+		searches = []models.SavedSearch{}
+	}
+
+	return searches, nil
+}
+
+// scanSavedSearch scans a row selected with savedSearchColumns into a saved search
+func scanSavedSearch(scanner rowScanner, search *models.SavedSearch) error {
+	var filters []byte
+
+	err := scanner.Scan(
+		&search.ID,
+		&search.UserID,
+		&search.ClientID,
+		&search.Name,
+		&filters,
+		&search.NotifyInApp,
+		&search.NotifyEmail,
+		&search.IsActive,
+		&search.LastNotifiedAt,
+		&search.CreatedAt,
+		&search.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(filters, &search.Filters); err != nil {
+		return fmt.Errorf("failed to decode saved search filters: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"strings"
+)
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// qualifyColumns prefixes each column in a comma-separated column list with a table alias
+// Used when a shared column list is selected from a joined table
+func qualifyColumns(alias, columns string) string {
+	parts := strings.Split(columns, ",")
+	for i, part := range parts {
+		parts[i] = alias + "." + strings.TrimSpace(part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// leadingScanner fills extra leading columns before handing the rest to another scan function
+type leadingScanner struct {
+	scanner rowScanner
+	leading []interface{}
+}
+
+// Scan implements rowScanner
+func (s leadingScanner) Scan(dest ...interface{}) error {
+	return s.scanner.Scan(append(append([]interface{}{}, s.leading...), dest...)...)
+}
+
+// withLeadingColumns wraps a scanner so the first selected columns are scanned into dest
+// Lets shared scan helpers be reused for joined queries that select extra columns first
+func withLeadingColumns(scanner rowScanner, dest ...interface{}) rowScanner {
+	return leadingScanner{scanner: scanner, leading: dest}
+}
+
+// trailingScanner fills extra trailing columns after the ones another scan function expects
+type trailingScanner struct {
+	scanner  rowScanner
+	trailing []interface{}
+}
+
+// Scan implements rowScanner
+func (s trailingScanner) Scan(dest ...interface{}) error {
+	return s.scanner.Scan(append(append([]interface{}{}, dest...), s.trailing...)...)
+}
+
+// withTrailingColumns wraps a scanner so the last selected columns are scanned into dest
+// Lets shared scan helpers be reused for queries that select extra columns after them
+func withTrailingColumns(scanner rowScanner, dest ...interface{}) rowScanner {
+	return trailingScanner{scanner: scanner, trailing: dest}
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+)
+
+// ShareLinkRepository handles database operations for property share links
+type ShareLinkRepository struct {
+	db *database.DB
+}
+
+// shareLinkColumns lists the share link columns in the order expected by scanShareLink
+const shareLinkColumns = `
+	id, property_id, broker_id, token, show_broker_contact, hide_address,
+	expires_at, revoked_at, view_count, last_viewed_at, created_at, updated_at`
+
+// NewShareLinkRepository creates a new ShareLinkRepository instance
+func NewShareLinkRepository(db *database.DB) *ShareLinkRepository {
+	return &ShareLinkRepository{db: db}
+}
+
+// Create inserts a new share link into the database
+func (r *ShareLinkRepository) Create(link *models.ShareLink) error {
+	query := `
+		INSERT INTO property_share_links (
+			property_id, broker_id, token, show_broker_contact, hide_address, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, view_count, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		link.PropertyID,
+		link.BrokerID,
+		link.Token,
+		link.ShowBrokerContact,
+		link.HideAddress,
+		link.ExpiresAt,
+	).Scan(
+		&link.ID,
+		&link.ViewCount,
+		&link.CreatedAt,
+		&link.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return nil
+}
+
+// GetByPropertyID retrieves all share links for a property, newest first
+func (r *ShareLinkRepository) GetByPropertyID(propertyID string) ([]models.ShareLink, error) {
+	query := `
+		SELECT ` + shareLinkColumns + `
+		FROM property_share_links
+		WHERE property_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, propertyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query share links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []models.ShareLink
+
+	for rows.Next() {
+		var link models.ShareLink
+		if err := scanShareLink(rows, &link); err != nil {
+			return nil, fmt.Errorf("failed to scan share link row: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating share link rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no links found
+	if links == nil {
+		links = []models.ShareLink{}
+	}
+
+	return links, nil
+}
+
+// GetByID retrieves a single share link by ID
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *ShareLinkRepository) GetByID(id string) (*models.ShareLink, error) {
+	query := `
+		SELECT ` + shareLinkColumns + `
+		FROM property_share_links
+		WHERE id = $1
+	`
+
+	var link models.ShareLink
+
+	err := scanShareLink(r.db.QueryRow(query, id), &link)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("share link not found")
+		}
+		return nil, fmt.Errorf("failed to get share link by ID: %w", err)
+	}
+
+	return &link, nil
+}
+
+// GetActiveByToken retrieves a live share link by token
+func (r *ShareLinkRepository) GetActiveByToken(token string) (*models.ShareLink, error) {
+	query := `
+		SELECT ` + shareLinkColumns + `
+		FROM property_share_links
+		WHERE token = $1
+			AND revoked_at IS NULL
+			AND (expires_at IS NULL OR expires_at > NOW())
+	`
+
+	var link models.ShareLink
+
+	err := scanShareLink(r.db.QueryRow(query, token), &link)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("share link not found")
+		}
+		return nil, fmt.Errorf("failed to get share link by token: %w", err)
+	}
+
+	return &link, nil
+}
+
+// RecordView counts a view of a share link
+func (r *ShareLinkRepository) RecordView(id string) error {
+	_, err := r.db.Exec(`
+		UPDATE property_share_links
+		SET view_count = view_count + 1, last_viewed_at = NOW()
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to record share link view: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke disables a share link so its URL stops resolving
+func (r *ShareLinkRepository) Revoke(id string) (*models.ShareLink, error) {
+	query := `
+		UPDATE property_share_links
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1
+		RETURNING ` + shareLinkColumns
+
+	var link models.ShareLink
+
+	err := scanShareLink(r.db.QueryRow(query, id), &link)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("share link not found")
+		}
+		return nil, fmt.Errorf("failed to revoke share link: %w", err)
+	}
+
+	return &link, nil
+}
+
+// scanShareLink scans a row selected with shareLinkColumns into a share link
+func scanShareLink(scanner rowScanner, link *models.ShareLink) error {
+	return scanner.Scan(
+		&link.ID,
+		&link.PropertyID,
+		&link.BrokerID,
+		&link.Token,
+		&link.ShowBrokerContact,
+		&link.HideAddress,
+		&link.ExpiresAt,
+		&link.RevokedAt,
+		&link.ViewCount,
+		&link.LastViewedAt,
+		&link.CreatedAt,
+		&link.UpdatedAt,
+	)
+}
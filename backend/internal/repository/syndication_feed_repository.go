@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+)
+
+// SyndicationFeedRepository handles database operations for portal syndication feeds
+type SyndicationFeedRepository struct {
+	db *database.DB
+}
+
+// syndicationFeedColumns lists the feed columns in the order expected by scanSyndicationFeed
+const syndicationFeedColumns = `
+	id, broker_id, name, portal, format, root_element, item_element, fields,
+	is_active, last_generated_at, last_file_path, created_at, updated_at`
+
+// NewSyndicationFeedRepository creates a new SyndicationFeedRepository instance
+func NewSyndicationFeedRepository(db *database.DB) *SyndicationFeedRepository {
+	return &SyndicationFeedRepository{db: db}
+}
+
+// Create inserts a new syndication feed into the database
+func (r *SyndicationFeedRepository) Create(feed *models.SyndicationFeed) error {
+	fields, err := json.Marshal(feed.Fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode feed fields: %w", err)
+	}
+
+	query := `
+		INSERT INTO syndication_feeds (
+			broker_id, name, portal, format, root_element, item_element, fields, is_active
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = r.db.QueryRow(
+		query,
+		feed.BrokerID,
+		feed.Name,
+		feed.Portal,
+		feed.Format,
+		feed.RootElement,
+		feed.ItemElement,
+		fields,
+		feed.IsActive,
+	).Scan(
+		&feed.ID,
+		&feed.CreatedAt,
+		&feed.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create syndication feed: %w", err)
+	}
+
+	return nil
+}
+
+// GetByBrokerID retrieves all syndication feeds for a broker, newest first
+func (r *SyndicationFeedRepository) GetByBrokerID(brokerID string) ([]models.SyndicationFeed, error) {
+	query := `
+		SELECT ` + syndicationFeedColumns + `
+		FROM syndication_feeds
+		WHERE broker_id = $1
+		ORDER BY created_at DESC
+	`
+
+	feeds, err := r.queryFeeds(query, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query syndication feeds by broker ID: %w", err)
+	}
+
+	return feeds, nil
+}
+
+// GetActive retrieves every active syndication feed for scheduled generation
+func (r *SyndicationFeedRepository) GetActive() ([]models.SyndicationFeed, error) {
+	query := `
+		SELECT ` + syndicationFeedColumns + `
+		FROM syndication_feeds
+		WHERE is_active = TRUE
+		ORDER BY created_at
+	`
+
+	feeds, err := r.queryFeeds(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active syndication feeds: %w", err)
+	}
+
+	return feeds, nil
+}
+
+// GetByID retrieves a single syndication feed by ID
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *SyndicationFeedRepository) GetByID(id string) (*models.SyndicationFeed, error) {
+	query := `
+		SELECT ` + syndicationFeedColumns + `
+		FROM syndication_feeds
+		WHERE id = $1
+	`
+
+	var feed models.SyndicationFeed
+
+	err := scanSyndicationFeed(r.db.QueryRow(query, id), &feed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("syndication feed not found")
+		}
+		return nil, fmt.Errorf("failed to get syndication feed by ID: %w", err)
+	}
+
+	return &feed, nil
+}
+
+// Update modifies an existing syndication feed definition
+func (r *SyndicationFeedRepository) Update(feed *models.SyndicationFeed) error {
+	fields, err := json.Marshal(feed.Fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode feed fields: %w", err)
+	}
+
+	query := `
+		UPDATE syndication_feeds SET
+			name = $1, portal = $2, format = $3, root_element = $4, item_element = $5,
+			fields = $6, is_active = $7
+		WHERE id = $8
+		RETURNING created_at, updated_at
+	`
+
+	err = r.db.QueryRow(
+		query,
+		feed.Name,
+		feed.Portal,
+		feed.Format,
+		feed.RootElement,
+		feed.ItemElement,
+		fields,
+		feed.IsActive,
+		feed.ID,
+	).Scan(
+		&feed.CreatedAt,
+		&feed.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("syndication feed not found")
+		}
+		return fmt.Errorf("failed to update syndication feed: %w", err)
+	}
+
+	return nil
+}
+
+// MarkGenerated records a scheduled generation run and the file it produced
+func (r *SyndicationFeedRepository) MarkGenerated(id string, generatedAt time.Time, filePath string) error {
+	query := `
+		UPDATE syndication_feeds
+		SET last_generated_at = $1, last_file_path = $2
+		WHERE id = $3
+	`
+
+	if _, err := r.db.Exec(query, generatedAt, filePath, id); err != nil {
+		return fmt.Errorf("failed to record feed generation: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a syndication feed from the database
+func (r *SyndicationFeedRepository) Delete(id string) error {
+	query := `DELETE FROM syndication_feeds WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete syndication feed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("syndication feed not found")
+	}
+
+	return nil
+}
+
+// queryFeeds runs a syndication feed query and scans every returned row
+func (r *SyndicationFeedRepository) queryFeeds(query string, args ...interface{}) ([]models.SyndicationFeed, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var feeds []models.SyndicationFeed
+
+	for rows.Next() {
+		var feed models.SyndicationFeed
+		if err := scanSyndicationFeed(rows, &feed); err != nil {
+			return nil, fmt.Errorf("failed to scan syndication feed row: %w", err)
+		}
+		feeds = append(feeds, feed)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating syndication feed rows: %w", err)
+	}
+
+	// Return empty slice instead of nil if no feeds found
+	if feeds == nil {
+		feeds = []models.SyndicationFeed{}
+	}
+
+	return feeds, nil
+}
+
+// scanSyndicationFeed scans a row selected with syndicationFeedColumns into a feed
+func scanSyndicationFeed(scanner rowScanner, feed *models.SyndicationFeed) error {
+	var fields []byte
+
+	err := scanner.Scan(
+		&feed.ID,
+		&feed.BrokerID,
+		&feed.Name,
+		&feed.Portal,
+		&feed.Format,
+		&feed.RootElement,
+		&feed.ItemElement,
+		&fields,
+		&feed.IsActive,
+		&feed.LastGeneratedAt,
+		&feed.LastFilePath,
+		&feed.CreatedAt,
+		&feed.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(fields, &feed.Fields); err != nil {
+		return fmt.Errorf("failed to decode feed fields: %w", err)
+	}
+
+	return nil
+}
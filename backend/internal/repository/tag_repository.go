@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// TagRepository handles database operations for the tags on a broker's clients and properties
+// Tags have no table of their own: a tag exists while some client or property carries it
+type TagRepository struct {
+	db *database.DB
+}
+
+// taggedTables lists the tables whose rows carry a tags column
+var taggedTables = []string{"clients", "properties"}
+
+// retagQuery rewrites the tags of a broker's rows in one table
+// Tags matching $3 (lowercased) are dropped, the tags in $2 are appended, and case-insensitive
+// duplicates are collapsed keeping the earliest spelling
+const retagQuery = `
+	UPDATE %s SET tags = (
+		SELECT COALESCE(array_agg(tag ORDER BY ordinal), '{}')
+		FROM (
+			SELECT DISTINCT ON (LOWER(tag)) tag, ordinal
+			FROM (
+				SELECT kept.tag, kept.ordinal
+				FROM unnest(tags) WITH ORDINALITY AS kept(tag, ordinal)
+				WHERE LOWER(kept.tag) <> ALL($3::TEXT[])
+				UNION ALL
+				SELECT added.tag, cardinality(tags) + added.ordinal
+				FROM unnest($2::TEXT[]) WITH ORDINALITY AS added(tag, ordinal)
+			) combined
+			ORDER BY LOWER(tag), ordinal
+		) deduplicated
+	)
+	WHERE broker_id = $1 AND %s
+`
+
+// NewTagRepository creates a new TagRepository instance
+func NewTagRepository(db *database.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// GetByBrokerID retrieves a broker's tags with how many of their clients and properties carry each
+// Records in the trash are not counted
+func (r *TagRepository) GetByBrokerID(brokerID string) ([]models.Tag, error) {
+	query := `
+		SELECT tag, SUM(client_count)::INTEGER, SUM(property_count)::INTEGER
+		FROM (
+			SELECT unnest(tags) AS tag, 1 AS client_count, 0 AS property_count
+			FROM clients
+			WHERE broker_id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT unnest(tags), 0, 1
+			FROM properties
+			WHERE broker_id = $1 AND deleted_at IS NULL
+		) tagged
+		GROUP BY tag
+		ORDER BY LOWER(tag), tag
+	`
+
+	rows, err := r.db.Query(query, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags by broker ID: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []models.Tag{}
+
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.Name, &tag.ClientCount, &tag.PropertyCount); err != nil {
+			return nil, fmt.Errorf("failed to scan tag row: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tag rows: %w", err)
+	}
+
+	return tags, nil
+}
+
+// Rename replaces a tag on every client and property of the broker, trashed ones included
+// Returns the number of records changed
+func (r *TagRepository) Rename(brokerID, name, newName string) (int64, error) {
+	return r.retagEverywhere(brokerID, name, []string{newName})
+}
+
+// Delete removes a tag from every client and property of the broker, trashed ones included
+// Returns the number of records changed
+func (r *TagRepository) Delete(brokerID, name string) (int64, error) {
+	return r.retagEverywhere(brokerID, name, []string{})
+}
+
+// BulkUpdateClients adds and removes tags on some of a broker's clients
+// IDs of clients the broker does not own, or that are in the trash, are skipped
+func (r *TagRepository) BulkUpdateClients(brokerID string, ids, add, remove []string) (int64, error) {
+	return r.bulkUpdate("clients", brokerID, ids, add, remove)
+}
+
+// BulkUpdateProperties adds and removes tags on some of a broker's properties
+// IDs of properties the broker does not own, or that are in the trash, are skipped
+func (r *TagRepository) BulkUpdateProperties(brokerID string, ids, add, remove []string) (int64, error) {
+	return r.bulkUpdate("properties", brokerID, ids, add, remove)
+}
+
+// bulkUpdate adds and removes tags on the listed rows of one tagged table
+func (r *TagRepository) bulkUpdate(table, brokerID string, ids, add, remove []string) (int64, error) {
+	query := fmt.Sprintf(retagQuery, table, "id::text = ANY($4) AND deleted_at IS NULL")
+
+	result, err := r.db.Exec(query, brokerID, pq.Array(add), pq.Array(lowerTags(remove)), pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("failed to update %s tags: %w", table, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// retagEverywhere replaces one tag with others on all the broker's tagged records in a single transaction
+func (r *TagRepository) retagEverywhere(brokerID, name string, add []string) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var changed int64
+	for _, table := range taggedTables {
+		query := fmt.Sprintf(retagQuery, table, "EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = LOWER($4))")
+
+		result, err := tx.Exec(query, brokerID, pq.Array(add), pq.Array(lowerTags([]string{name})), name)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update %s tags: %w", table, err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		changed += rowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit tag update: %w", err)
+	}
+
+	return changed, nil
+}
+
+// lowerTags lowercases tag names for case-insensitive comparison
+// Always returns a non-nil slice so it binds as an empty array
+func lowerTags(tags []string) []string {
+	lowered := make([]string, len(tags))
+	for i, tag := range tags {
+		lowered[i] = strings.ToLower(tag)
+	}
+	return lowered
+}
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"enfor-data-backend/internal/database"
+	"enfor-data-backend/internal/models"
+)
+
+// TaskRepository handles database operations for tasks
+type TaskRepository struct {
+	db *database.DB
+}
+
+// taskSelect selects tasks with their client, property and assignee names in the order expected by scanTask
+const taskSelect = `
+	SELECT t.id, t.broker_id, t.assignee_id, t.client_id, t.property_id,
+		NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), p.title,
+		NULLIF(TRIM(u.first_name || ' ' || u.last_name), ''),
+		t.title, t.description, t.priority, t.status,
+		t.due_at, t.remind_at, t.reminded_at, t.completed_at, t.created_at, t.updated_at
+	FROM tasks t
+	LEFT JOIN clients c ON c.id = t.client_id
+	LEFT JOIN properties p ON p.id = t.property_id
+	JOIN users u ON u.id = t.assignee_id`
+
+// taskVisible hides tasks about clients or properties in the trash; they come back on restore
+const taskVisible = `(t.client_id IS NULL OR c.deleted_at IS NULL) AND (t.property_id IS NULL OR p.deleted_at IS NULL)`
+
+// TaskQuery scopes a task list to a user and optional filters
+type TaskQuery struct {
+	UserID     string
+	Scope      string // assigned, created or all
+	Status     string
+	Priority   string
+	ClientID   string
+	PropertyID string
+	DueFrom    time.Time // inclusive; zero leaves the range open
+	DueBefore  time.Time // exclusive; zero leaves the range open
+}
+
+// NewTaskRepository creates a new TaskRepository instance
+func NewTaskRepository(db *database.DB) *TaskRepository {
+	return &TaskRepository{db: db}
+}
+
+// Create inserts a new task and fills in its generated and joined fields
+func (r *TaskRepository) Create(task *models.Task) error {
+	query := `
+		INSERT INTO tasks (
+			broker_id, assignee_id, client_id, property_id, title, description,
+			priority, status, due_at, remind_at, completed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+
+	err := r.db.QueryRow(
+		query,
+		task.BrokerID,
+		task.AssigneeID,
+		task.ClientID,
+		task.PropertyID,
+		task.Title,
+		task.Description,
+		task.Priority,
+		task.Status,
+		task.DueAt,
+		task.RemindAt,
+		task.CompletedAt,
+	).Scan(&task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+
+	return r.reload(task)
+}
+
+// GetByID retrieves a single task by ID
+// This method does NOT validate ownership - that should be done at the service layer
+func (r *TaskRepository) GetByID(id string) (*models.Task, error) {
+	query := taskSelect + `
+		WHERE t.id = $1 AND ` + taskVisible
+
+	var task models.Task
+
+	err := scanTask(r.db.QueryRow(query, id), &task)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("task not found")
+		}
+		return nil, fmt.Errorf("failed to get task by ID: %w", err)
+	}
+
+	return &task, nil
+}
+
+// Update saves a task; moving the reminder re-arms it
+func (r *TaskRepository) Update(task *models.Task) error {
+	query := `
+		UPDATE tasks SET
+			assignee_id = $1, client_id = $2, property_id = $3, title = $4, description = $5,
+			priority = $6, status = $7, due_at = $8, remind_at = $9, completed_at = $10,
+			reminded_at = CASE
+				WHEN remind_at IS DISTINCT FROM $9 THEN NULL
+				ELSE reminded_at
+			END
+		WHERE id = $11
+	`
+
+	result, err := r.db.Exec(
+		query,
+		task.AssigneeID,
+		task.ClientID,
+		task.PropertyID,
+		task.Title,
+		task.Description,
+		task.Priority,
+		task.Status,
+		task.DueAt,
+		task.RemindAt,
+		task.CompletedAt,
+		task.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("task not found")
+	}
+
+	return r.reload(task)
+}
+
+// Delete permanently removes a task
+func (r *TaskRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM tasks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("task not found")
+	}
+
+	return nil
+}
+
+// GetTasks retrieves the tasks a user created or is assigned, soonest due first
+func (r *TaskRepository) GetTasks(q TaskQuery) ([]models.Task, error) {
+	query := taskSelect + `
+		WHERE ` + taskVisible
+	args := []interface{}{q.UserID}
+
+	switch q.Scope {
+	case "assigned":
+		query += " AND t.assignee_id = $1"
+	case "created":
+		query += " AND t.broker_id = $1"
+	default:
+		query += " AND (t.assignee_id = $1 OR t.broker_id = $1)"
+	}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if q.Status != "" {
+		addCondition("t.status = $%d", q.Status)
+	}
+	if q.Priority != "" {
+		addCondition("t.priority = $%d", q.Priority)
+	}
+	if q.ClientID != "" {
+		addCondition("t.client_id = $%d", q.ClientID)
+	}
+	if q.PropertyID != "" {
+		addCondition("t.property_id = $%d", q.PropertyID)
+	}
+	if !q.DueFrom.IsZero() {
+		addCondition("t.due_at >= $%d", q.DueFrom)
+	}
+	if !q.DueBefore.IsZero() {
+		addCondition("t.due_at < $%d", q.DueBefore)
+	}
+
+	query += " ORDER BY t.due_at, CASE t.priority WHEN 'high' THEN 0 WHEN 'medium' THEN 1 ELSE 2 END, t.id"
+
+	return r.queryTasks(query, args...)
+}
+
+// GetDueReminders retrieves open tasks whose reminder time has come
+// Tasks more than an hour overdue are skipped so stale tasks don't set off a burst of reminders
+func (r *TaskRepository) GetDueReminders() ([]models.Task, error) {
+	query := taskSelect + `
+		WHERE t.status = 'open'
+			AND t.reminded_at IS NULL
+			AND t.remind_at <= NOW()
+			AND t.due_at > NOW() - INTERVAL '1 hour'
+			AND ` + taskVisible + `
+		ORDER BY t.remind_at
+	`
+
+	return r.queryTasks(query)
+}
+
+// MarkReminded records that the reminder for a task has been sent
+func (r *TaskRepository) MarkReminded(id string) error {
+	_, err := r.db.Exec(`UPDATE tasks SET reminded_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark task reminded: %w", err)
+	}
+
+	return nil
+}
+
+// reload refreshes a task's generated and joined fields after a write
+func (r *TaskRepository) reload(task *models.Task) error {
+	query := taskSelect + `
+		WHERE t.id = $1
+	`
+
+	if err := scanTask(r.db.QueryRow(query, task.ID), task); err != nil {
+		return fmt.Errorf("failed to reload task: %w", err)
+	}
+
+	return nil
+}
+
+// queryTasks runs a query selecting taskSelect columns and scans every row
+func (r *TaskRepository) queryTasks(query string, args ...interface{}) ([]models.Task, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+
+	for rows.Next() {
+		var task models.Task
+		if err := scanTask(rows, &task); err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task rows: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// scanTask scans a row selected with taskSelect into a task
+func scanTask(scanner rowScanner, task *models.Task) error {
+	return scanner.Scan(
+		&task.ID,
+		&task.BrokerID,
+		&task.AssigneeID,
+		&task.ClientID,
+		&task.PropertyID,
+		&task.ClientName,
+		&task.PropertyTitle,
+		&task.AssigneeName,
+		&task.Title,
+		&task.Description,
+		&task.Priority,
+		&task.Status,
+		&task.DueAt,
+		&task.RemindAt,
+		&task.RemindedAt,
+		&task.CompletedAt,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	)
+}
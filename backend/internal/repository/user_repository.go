@@ -101,6 +101,20 @@ func (r *UserRepository) GetUserByID(id string) (*models.User, error) {
 	return user, nil
 }
 
+// GetIDByEmail retrieves a user's ID by email, including deactivated accounts
+func (r *UserRepository) GetIDByEmail(email string) (string, error) {
+	var id string
+	err := r.db.QueryRow(`SELECT id FROM users WHERE email = $1`, email).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("user not found")
+		}
+		return "", fmt.Errorf("failed to get user ID by email: %w", err)
+	}
+
+	return id, nil
+}
+
 // UpdateUserProfileImage updates the user's profile image
 func (r *UserRepository) UpdateUserProfileImage(userID, imagePath string) error {
 	query := `UPDATE users SET profile_image = $1, updated_at = NOW() WHERE id = $2`
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// defaultTimelineLimit is how many timeline entries a page holds when no limit is given
+const defaultTimelineLimit = 30
+
+// manualActivityTypes are the interaction types brokers log by hand; only these can be deleted
+var manualActivityTypes = map[string]bool{
+	models.ActivityCall:      true,
+	models.ActivityWhatsApp:  true,
+	models.ActivityEmail:     true,
+	models.ActivityNote:      true,
+	models.ActivitySiteVisit: true,
+	models.ActivityMeeting:   true,
+}
+
+// appointmentTypeLabels names appointment types in timeline entries
+var appointmentTypeLabels = map[string]string{
+	"site_visit": "Site Visit",
+	"meeting":    "Meeting",
+	"call":       "Call",
+}
+
+// ActivityService handles the client interaction log and timeline
+type ActivityService struct {
+	activityRepo     *repository.ActivityRepository
+	clientRepo       *repository.ClientRepository
+	leadScoreService *LeadScoreService
+}
+
+// NewActivityService creates a new ActivityService instance
+func NewActivityService(
+	activityRepo *repository.ActivityRepository,
+	clientRepo *repository.ClientRepository,
+	leadScoreService *LeadScoreService,
+) *ActivityService {
+	return &ActivityService{
+		activityRepo:     activityRepo,
+		clientRepo:       clientRepo,
+		leadScoreService: leadScoreService,
+	}
+}
+
+// LogActivity records an interaction with a client
+func (s *ActivityService) LogActivity(clientID string, req *models.CreateClientActivityRequest, brokerID string) (*models.ClientActivity, error) {
+	client, err := s.getClient(clientID, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := &models.ClientActivity{
+		ClientID: client.ID,
+		BrokerID: client.BrokerID,
+		AuthorID: &brokerID,
+		Type:     req.Type,
+		Body:     strings.TrimSpace(req.Body),
+	}
+
+	if activity.Body == "" {
+		return nil, fmt.Errorf("invalid body: must not be blank")
+	}
+
+	if req.OccurredAt != nil {
+		occurredAt, err := time.Parse(time.RFC3339, *req.OccurredAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid occurred_at: %w", err)
+		}
+		if occurredAt.After(time.Now().Add(time.Minute)) {
+			return nil, fmt.Errorf("invalid occurred_at: must not be in the future")
+		}
+		activity.OccurredAt = occurredAt
+	}
+
+	if err := s.activityRepo.Create(activity); err != nil {
+		return nil, fmt.Errorf("failed to log activity: %w", err)
+	}
+
+	s.leadScoreService.RefreshClients(client.ID)
+
+	return activity, nil
+}
+
+// GetTimeline retrieves a page of a client's timeline, newest first
+func (s *ActivityService) GetTimeline(clientID string, req *models.ClientTimelineRequest, brokerID string) ([]models.ClientActivity, *models.PageInfo, error) {
+	if _, err := s.getClient(clientID, brokerID); err != nil {
+		return nil, nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTimelineLimit
+	}
+
+	entries, page, err := s.activityRepo.GetTimeline(clientID, req.Types, limit, req.Cursor)
+	if err != nil {
+		if err.Error() == "invalid cursor" {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to get client timeline: %w", err)
+	}
+
+	return entries, page, nil
+}
+
+// DeleteActivity removes an interaction the broker logged by hand
+// Entries recorded automatically are part of the client's history and stay
+func (s *ActivityService) DeleteActivity(clientID, activityID, brokerID string) error {
+	if _, err := s.getClient(clientID, brokerID); err != nil {
+		return err
+	}
+
+	activity, err := s.activityRepo.GetByID(activityID)
+	if err != nil {
+		return err
+	}
+
+	if activity.ClientID != clientID {
+		return fmt.Errorf("activity not found")
+	}
+
+	if !manualActivityTypes[activity.Type] {
+		return fmt.Errorf("cannot delete an automatically recorded activity")
+	}
+
+	if activity.AuthorID == nil || *activity.AuthorID != brokerID {
+		return fmt.Errorf("cannot delete an activity logged by another user")
+	}
+
+	if err := s.activityRepo.Delete(activityID); err != nil {
+		return fmt.Errorf("failed to delete activity: %w", err)
+	}
+
+	s.leadScoreService.RefreshClients(clientID)
+
+	return nil
+}
+
+// RecordStatusChange adds a status change to a client's timeline
+func (s *ActivityService) RecordStatusChange(client *models.Client, previousStatus, authorID string) {
+	s.record(&models.ClientActivity{
+		ClientID: client.ID,
+		BrokerID: client.BrokerID,
+		AuthorID: &authorID,
+		Type:     models.ActivityStatusChange,
+		Body:     fmt.Sprintf("Status changed from %s to %s", previousStatus, client.Status),
+	})
+}
+
+// RecordNote keeps a copy of a client's notes on the timeline whenever they are edited
+func (s *ActivityService) RecordNote(client *models.Client, authorID string) {
+	if client.Notes == nil || strings.TrimSpace(*client.Notes) == "" {
+		return
+	}
+
+	s.record(&models.ClientActivity{
+		ClientID: client.ID,
+		BrokerID: client.BrokerID,
+		AuthorID: &authorID,
+		Type:     models.ActivityNote,
+		Body:     strings.TrimSpace(*client.Notes),
+	})
+}
+
+// RecordMerge notes on the surviving client's timeline which duplicate record was merged into it
+func (s *ActivityService) RecordMerge(survivor, merged *models.Client, authorID string) {
+	contact := []string{}
+	for _, value := range []string{merged.Phone, merged.Email} {
+		if strings.TrimSpace(value) != "" {
+			contact = append(contact, strings.TrimSpace(value))
+		}
+	}
+
+	body := fmt.Sprintf("Merged duplicate record %s %s", merged.FirstName, merged.LastName)
+	if len(contact) > 0 {
+		body += fmt.Sprintf(" (%s)", strings.Join(contact, ", "))
+	}
+
+	s.record(&models.ClientActivity{
+		ClientID: survivor.ID,
+		BrokerID: survivor.BrokerID,
+		AuthorID: &authorID,
+		Type:     models.ActivityNote,
+		Body:     body,
+	})
+}
+
+// RecordEnquiry adds an enquiry received through the broker's lead form to a client's timeline
+func (s *ActivityService) RecordEnquiry(client *models.Client, source, message string) {
+	body := fmt.Sprintf("Enquiry received via %s", strings.ReplaceAll(source, "_", "-"))
+	if message = strings.TrimSpace(message); message != "" {
+		body += ": " + message
+	}
+
+	s.record(&models.ClientActivity{
+		ClientID: client.ID,
+		BrokerID: client.BrokerID,
+		Type:     models.ActivityNote,
+		Body:     body,
+	})
+}
+
+// RecordAppointmentEvent adds an appointment being scheduled, rescheduled, completed or cancelled
+// to the client's timeline
+func (s *ActivityService) RecordAppointmentEvent(appointment *models.Appointment, eventType, authorID string) {
+	label := appointmentTypeLabels[appointment.Type]
+	if label == "" {
+		label = "Appointment"
+	}
+
+	var body string
+	switch eventType {
+	case models.ActivityAppointmentScheduled:
+		body = fmt.Sprintf("%s scheduled for %s: %s", label, appointmentWhen(appointment), appointment.Title)
+	case models.ActivityAppointmentRescheduled:
+		body = fmt.Sprintf("%s rescheduled to %s: %s", label, appointmentWhen(appointment), appointment.Title)
+	case models.ActivityAppointmentCompleted:
+		body = fmt.Sprintf("%s completed: %s", label, appointment.Title)
+	case models.ActivityAppointmentCancelled:
+		body = fmt.Sprintf("%s cancelled: %s", label, appointment.Title)
+	default:
+		return
+	}
+
+	s.record(&models.ClientActivity{
+		ClientID:      appointment.ClientID,
+		BrokerID:      appointment.BrokerID,
+		AuthorID:      &authorID,
+		AppointmentID: &appointment.ID,
+		Type:          eventType,
+		Body:          body,
+	})
+}
+
+// RecordClientResponse adds a client confirming or cancelling an appointment on the portal to their timeline
+func (s *ActivityService) RecordClientResponse(appointment *models.Appointment, reason string) {
+	label := appointmentTypeLabels[appointment.Type]
+	if label == "" {
+		label = "Appointment"
+	}
+
+	eventType := models.ActivityNote
+	body := fmt.Sprintf("%s on %s confirmed by the client on the portal: %s", label, appointmentWhen(appointment), appointment.Title)
+	if appointment.Status == "cancelled" {
+		eventType = models.ActivityAppointmentCancelled
+		body = fmt.Sprintf("%s cancelled by the client on the portal: %s", label, appointment.Title)
+		if reason = strings.TrimSpace(reason); reason != "" {
+			body += " (" + reason + ")"
+		}
+	}
+
+	s.record(&models.ClientActivity{
+		ClientID:      appointment.ClientID,
+		BrokerID:      appointment.BrokerID,
+		AppointmentID: &appointment.ID,
+		Type:          eventType,
+		Body:          body,
+	})
+}
+
+// RecordDealEvent adds a deal being recorded or changing status to its client's timeline
+func (s *ActivityService) RecordDealEvent(deal *models.Deal, body, authorID string) {
+	if deal.ClientID == nil {
+		return
+	}
+
+	s.record(&models.ClientActivity{
+		ClientID: *deal.ClientID,
+		BrokerID: deal.BrokerID,
+		AuthorID: &authorID,
+		Type:     models.ActivityNote,
+		Body:     body,
+	})
+}
+
+// record stores an automatic timeline entry and refreshes the client's lead score
+// Recording is best-effort and never fails the write that triggered it
+func (s *ActivityService) record(activity *models.ClientActivity) {
+	if err := s.activityRepo.Create(activity); err != nil {
+		log.Printf("Failed to record %s activity for client %s: %v", activity.Type, activity.ClientID, err)
+	}
+
+	s.leadScoreService.RefreshClients(activity.ClientID)
+}
+
+// getClient retrieves a client with broker ownership verification
+func (s *ActivityService) getClient(clientID, brokerID string) (*models.Client, error) {
+	client, err := s.clientRepo.GetByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: client does not belong to this broker")
+	}
+
+	return client, nil
+}
+
+// appointmentWhen formats an appointment's date and time as "2006-01-02 at 15:04"
+func appointmentWhen(appointment *models.Appointment) string {
+	date, clock := appointmentDateTime(appointment)
+	return date + " at " + clock
+}
+
+// appointmentDateTime returns an appointment's date as "2006-01-02" and time as "15:04"
+// Dates and times read back from the database come as full timestamps and are trimmed here
+func appointmentDateTime(appointment *models.Appointment) (string, string) {
+	date := appointment.Date
+	if len(date) > 10 {
+		date = date[:10]
+	}
+
+	clock := appointment.Time
+	if i := strings.Index(clock, "T"); i >= 0 {
+		clock = clock[i+1:]
+	}
+	if len(clock) > 5 {
+		clock = clock[:5]
+	}
+
+	return date, clock
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+const (
+	// analyticsDateLayout is the calendar day format used by analytics ranges
+	analyticsDateLayout = "2006-01-02"
+
+	// defaultAnalyticsDays is the range length used when no from date is given
+	defaultAnalyticsDays = 30
+
+	// maxAnalyticsDays bounds a single report so daily series stay small
+	maxAnalyticsDays = 366
+)
+
+// AnalyticsService tracks listing engagement and builds analytics reports
+type AnalyticsService struct {
+	analyticsRepo *repository.AnalyticsRepository
+	propertyRepo  *repository.PropertyRepository
+}
+
+// NewAnalyticsService creates a new AnalyticsService instance
+func NewAnalyticsService(
+	analyticsRepo *repository.AnalyticsRepository,
+	propertyRepo *repository.PropertyRepository,
+) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		propertyRepo:  propertyRepo,
+	}
+}
+
+// RecordEvent counts an engagement event for a listing
+// Tracking is best effort: failures are logged and never fail the request being tracked
+func (s *AnalyticsService) RecordEvent(propertyID, brokerID, event string) {
+	if err := s.analyticsRepo.RecordEvent(propertyID, brokerID, event); err != nil {
+		log.Printf("Failed to record %s for property %s: %v", event, propertyID, err)
+	}
+}
+
+// RecordInquiry logs an inquiry the broker received for one of their listings
+func (s *AnalyticsService) RecordInquiry(propertyID, brokerID string) error {
+	if _, err := s.getOwnedProperty(propertyID, brokerID); err != nil {
+		return err
+	}
+
+	return s.analyticsRepo.RecordEvent(propertyID, brokerID, models.ListingEventInquiry)
+}
+
+// GetPropertyAnalytics builds the engagement report for one of the broker's listings
+func (s *AnalyticsService) GetPropertyAnalytics(propertyID, brokerID string, req *models.AnalyticsRangeRequest) (*models.PropertyAnalytics, error) {
+	property, err := s.getOwnedProperty(propertyID, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := resolveAnalyticsRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	daily, err := s.analyticsRepo.GetPropertyDaily(propertyID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PropertyAnalytics{
+		PropertyID: property.ID,
+		Title:      property.Title,
+		From:       from,
+		To:         to,
+		Totals:     sumDailyEngagement(daily),
+		Daily:      daily,
+	}, nil
+}
+
+// GetBrokerAnalytics builds the engagement report across all of the broker's listings
+func (s *AnalyticsService) GetBrokerAnalytics(brokerID string, req *models.AnalyticsRangeRequest) (*models.BrokerListingAnalytics, error) {
+	from, to, err := resolveAnalyticsRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	daily, err := s.analyticsRepo.GetBrokerDaily(brokerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := s.analyticsRepo.GetBrokerPropertyTotals(brokerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BrokerListingAnalytics{
+		From:       from,
+		To:         to,
+		Totals:     sumDailyEngagement(daily),
+		Daily:      daily,
+		Properties: properties,
+	}, nil
+}
+
+// getOwnedProperty fetches a property and verifies it belongs to the broker
+func (s *AnalyticsService) getOwnedProperty(propertyID, brokerID string) (*models.Property, error) {
+	property, err := s.propertyRepo.GetByID(propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if property.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: property does not belong to this broker")
+	}
+
+	return property, nil
+}
+
+// resolveAnalyticsRange applies the range defaults and checks the bounds
+// to defaults to today and from to the 30 days ending at to
+func resolveAnalyticsRange(req *models.AnalyticsRangeRequest) (string, string, error) {
+	to := time.Now()
+	if req.To != "" {
+		parsed, err := time.Parse(analyticsDateLayout, req.To)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid to date: %w", err)
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if req.From != "" {
+		parsed, err := time.Parse(analyticsDateLayout, req.From)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid from date: %w", err)
+		}
+		from = parsed
+	}
+
+	fromDay, toDay := from.Format(analyticsDateLayout), to.Format(analyticsDateLayout)
+
+	// Compare calendar days so the time of day of the default to date doesn't matter
+	fromDate, _ := time.Parse(analyticsDateLayout, fromDay)
+	toDate, _ := time.Parse(analyticsDateLayout, toDay)
+	if fromDate.After(toDate) {
+		return "", "", fmt.Errorf("invalid date range: from must not be after to")
+	}
+	if toDate.Sub(fromDate) >= maxAnalyticsDays*24*time.Hour {
+		return "", "", fmt.Errorf("invalid date range: at most %d days can be requested", maxAnalyticsDays)
+	}
+
+	return fromDay, toDay, nil
+}
+
+// sumDailyEngagement totals a daily series
+func sumDailyEngagement(daily []models.DailyEngagement) models.ListingEngagement {
+	var totals models.ListingEngagement
+	for _, day := range daily {
+		totals.ShareViews += day.ShareViews
+		totals.AppViews += day.AppViews
+		totals.Inquiries += day.Inquiries
+		totals.Appointments += day.Appointments
+	}
+	totals.Views = totals.ShareViews + totals.AppViews
+	return totals
+}
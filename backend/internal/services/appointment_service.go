@@ -7,11 +7,21 @@ import (
 	"enfor-data-backend/internal/repository"
 )
 
+// appointmentStatusActivities maps appointment statuses to the timeline entry recorded when an appointment enters them
+var appointmentStatusActivities = map[string]string{
+	"scheduled": models.ActivityAppointmentScheduled,
+	"completed": models.ActivityAppointmentCompleted,
+	"cancelled": models.ActivityAppointmentCancelled,
+}
+
 // AppointmentService handles business logic for appointments
 type AppointmentService struct {
-	appointmentRepo *repository.AppointmentRepository
-	clientRepo      *repository.ClientRepository
-	propertyRepo    *repository.PropertyRepository
+	appointmentRepo  *repository.AppointmentRepository
+	clientRepo       *repository.ClientRepository
+	propertyRepo     *repository.PropertyRepository
+	analyticsService *AnalyticsService
+	activityService  *ActivityService
+	leadScoreService *LeadScoreService
 }
 
 // NewAppointmentService creates a new AppointmentService instance
@@ -19,11 +29,17 @@ func NewAppointmentService(
 	appointmentRepo *repository.AppointmentRepository,
 	clientRepo *repository.ClientRepository,
 	propertyRepo *repository.PropertyRepository,
+	analyticsService *AnalyticsService,
+	activityService *ActivityService,
+	leadScoreService *LeadScoreService,
 ) *AppointmentService {
 	return &AppointmentService{
-		appointmentRepo: appointmentRepo,
-		clientRepo:      clientRepo,
-		propertyRepo:    propertyRepo,
+		appointmentRepo:  appointmentRepo,
+		clientRepo:       clientRepo,
+		propertyRepo:     propertyRepo,
+		analyticsService: analyticsService,
+		activityService:  activityService,
+		leadScoreService: leadScoreService,
 	}
 }
 
@@ -72,6 +88,14 @@ func (s *AppointmentService) CreateAppointment(req *models.CreateAppointmentRequ
 		return nil, fmt.Errorf("failed to create appointment: %w", err)
 	}
 
+	// Count the booking in the listing's analytics
+	if appointment.PropertyID != nil && *appointment.PropertyID != "" {
+		s.analyticsService.RecordEvent(*appointment.PropertyID, brokerID, models.ListingEventAppointment)
+	}
+
+	// Add the booking to the client's timeline
+	s.activityService.RecordAppointmentEvent(appointment, models.ActivityAppointmentScheduled, brokerID)
+
 	return appointment, nil
 }
 
@@ -108,6 +132,17 @@ func (s *AppointmentService) UpdateAppointment(id string, req *models.UpdateAppo
 		return nil, err
 	}
 
+	// Remember the listing so a move to another listing can be counted
+	previousPropertyID := ""
+	if appointment.PropertyID != nil {
+		previousPropertyID = *appointment.PropertyID
+	}
+
+	// Remember what the client's timeline already shows
+	previousClientID := appointment.ClientID
+	previousStatus := appointment.Status
+	previousWhen := appointmentWhen(appointment)
+
 	// Validate client_id if being updated
 	if req.ClientID != nil && *req.ClientID != "" {
 		client, err := s.clientRepo.GetByID(*req.ClientID)
@@ -165,29 +200,60 @@ func (s *AppointmentService) UpdateAppointment(id string, req *models.UpdateAppo
 		appointment.Status = *req.Status
 	}
 
+	// A client's portal answer holds for the time and client it was given for, and a cancelled
+	// appointment the broker schedules again needs a fresh answer
+	if appointment.ClientID != previousClientID || appointmentWhen(appointment) != previousWhen ||
+		(previousStatus == "cancelled" && appointment.Status == "scheduled") {
+		appointment.ClientResponse = nil
+		appointment.ClientRespondedAt = nil
+	}
+
 	// Update appointment in database
 	err = s.appointmentRepo.Update(appointment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update appointment: %w", err)
 	}
 
+	// An appointment moved to another listing counts as a booking for that listing
+	if appointment.PropertyID != nil && *appointment.PropertyID != "" && *appointment.PropertyID != previousPropertyID {
+		s.analyticsService.RecordEvent(*appointment.PropertyID, brokerID, models.ListingEventAppointment)
+	}
+
+	// Add the change to the client's timeline; an appointment handed to another client
+	// appears there as newly scheduled
+	switch {
+	case appointment.ClientID != previousClientID:
+		s.activityService.RecordAppointmentEvent(appointment, models.ActivityAppointmentScheduled, brokerID)
+	case appointment.Status != previousStatus:
+		s.activityService.RecordAppointmentEvent(appointment, appointmentStatusActivities[appointment.Status], brokerID)
+	case appointmentWhen(appointment) != previousWhen:
+		s.activityService.RecordAppointmentEvent(appointment, models.ActivityAppointmentRescheduled, brokerID)
+	}
+
+	// Timeline entries refresh the current client's lead score; the previous client loses the appointment
+	if appointment.ClientID != previousClientID {
+		s.leadScoreService.RefreshClients(previousClientID)
+	}
+
 	return appointment, nil
 }
 
-// DeleteAppointment deletes an appointment with ownership verification
+// DeleteAppointment moves an appointment to the trash with ownership verification
 func (s *AppointmentService) DeleteAppointment(id, brokerID string) error {
 	// Verify ownership by fetching the appointment
-	_, err := s.GetAppointmentByID(id, brokerID)
+	appointment, err := s.GetAppointmentByID(id, brokerID)
 	if err != nil {
 		return err
 	}
 
-	// Delete appointment from database
-	err = s.appointmentRepo.Delete(id)
+	// Soft delete so the appointment can be restored from the trash
+	err = s.appointmentRepo.SoftDelete(id)
 	if err != nil {
 		return fmt.Errorf("failed to delete appointment: %w", err)
 	}
 
+	s.leadScoreService.RefreshClients(appointment.ClientID)
+
 	return nil
 }
 
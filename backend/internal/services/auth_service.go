@@ -125,8 +125,18 @@ func (s *AuthService) GetUserByID(userID string) (*models.User, error) {
 }
 
 // ValidateToken validates a JWT token and returns user information
+// Client portal tokens are signed with the same secret but are not accepted as user tokens
 func (s *AuthService) ValidateToken(tokenString string) (*utils.Claims, error) {
-	return s.jwtUtil.ValidateToken(tokenString)
+	claims, err := s.jwtUtil.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Role == models.PortalRole {
+		return nil, fmt.Errorf("invalid token: client portal tokens can only be used on the portal")
+	}
+
+	return claims, nil
 }
 
 // UpdateProfileImage updates the user's profile image
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// duplicateClientNameSimilarity is the pg_trgm similarity of full names above which two clients
+// are flagged even when their phone and email differ
+const duplicateClientNameSimilarity = 0.6
+
+// ClientDuplicateService detects likely duplicate clients in a broker's book and merges them
+type ClientDuplicateService struct {
+	clientDuplicateRepo *repository.ClientDuplicateRepository
+	clientRepo          *repository.ClientRepository
+	matchService        *MatchService
+	activityService     *ActivityService
+}
+
+// NewClientDuplicateService creates a new ClientDuplicateService instance
+func NewClientDuplicateService(
+	clientDuplicateRepo *repository.ClientDuplicateRepository,
+	clientRepo *repository.ClientRepository,
+	matchService *MatchService,
+	activityService *ActivityService,
+) *ClientDuplicateService {
+	return &ClientDuplicateService{
+		clientDuplicateRepo: clientDuplicateRepo,
+		clientRepo:          clientRepo,
+		matchService:        matchService,
+		activityService:     activityService,
+	}
+}
+
+// DetectForClient finds likely duplicates of a saved client, records them for review,
+// and returns them as warnings for the broker who created or updated the client
+func (s *ClientDuplicateService) DetectForClient(client *models.Client) ([]models.ClientDuplicateWarning, error) {
+	warnings, err := s.clientDuplicateRepo.FindCandidates(client, duplicateClientNameSimilarity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate client candidates: %w", err)
+	}
+
+	if err := s.clientDuplicateRepo.SyncForClient(client, warnings); err != nil {
+		return nil, fmt.Errorf("failed to record duplicate client pairs: %w", err)
+	}
+
+	for i := range warnings {
+		switch {
+		case warnings[i].PhoneMatch:
+			warnings[i].Message = "You already have a client with this phone number"
+		case warnings[i].EmailMatch:
+			warnings[i].Message = "You already have a client with this email address"
+		default:
+			warnings[i].Message = "You already have a client with a similar name"
+		}
+	}
+
+	return warnings, nil
+}
+
+// GetDuplicates retrieves a broker's duplicate client pairs with the given status, with both clients filled in
+func (s *ClientDuplicateService) GetDuplicates(brokerID, status string) ([]models.ClientDuplicate, error) {
+	duplicates, err := s.clientDuplicateRepo.GetByStatus(brokerID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get duplicate client pairs: %w", err)
+	}
+
+	clientIDs := []string{}
+	seen := make(map[string]bool)
+	for _, duplicate := range duplicates {
+		for _, id := range []string{duplicate.ClientAID, duplicate.ClientBID} {
+			if !seen[id] {
+				seen[id] = true
+				clientIDs = append(clientIDs, id)
+			}
+		}
+	}
+
+	clients, err := s.clientRepo.GetByIDs(clientIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get duplicate clients: %w", err)
+	}
+
+	clientsByID := make(map[string]models.Client, len(clients))
+	for _, client := range clients {
+		clientsByID[client.ID] = client
+	}
+
+	for i := range duplicates {
+		duplicates[i].Clients = []models.Client{
+			clientsByID[duplicates[i].ClientAID],
+			clientsByID[duplicates[i].ClientBID],
+		}
+	}
+
+	return duplicates, nil
+}
+
+// ReviewDuplicate records a broker decision on one of their duplicate client pairs
+func (s *ClientDuplicateService) ReviewDuplicate(id string, req *models.ReviewClientDuplicateRequest, brokerID string) (*models.ClientDuplicate, error) {
+	duplicate, err := s.clientDuplicateRepo.UpdateStatus(id, brokerID, req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicate, nil
+}
+
+// MergeClients folds a duplicate client into the surviving one
+// The survivor keeps its own values; blanks are filled from the duplicate, lists are combined
+// and differing notes are kept side by side. Appointments, documents, tasks, saved searches,
+// timeline entries and blocked units move to the survivor and the duplicate is deleted.
+func (s *ClientDuplicateService) MergeClients(survivorID string, req *models.MergeClientsRequest, brokerID string) (*models.ClientMergeResult, error) {
+	if survivorID == req.DuplicateClientID {
+		return nil, fmt.Errorf("cannot merge a client into itself")
+	}
+
+	survivor, err := s.getClient(survivorID, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := s.getClient(req.DuplicateClientID, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duplicate_client_id: %w", err)
+	}
+
+	combineClients(survivor, merged)
+
+	reassigned, err := s.clientDuplicateRepo.Merge(survivor, merged.ID)
+	if err != nil {
+		if err.Error() == "client not found" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to merge clients: %w", err)
+	}
+
+	s.activityService.RecordMerge(survivor, merged, brokerID)
+
+	// The combined requirements can match different properties, and the merged contact
+	// details can make the survivor a duplicate of someone else
+	if err := s.matchService.EvaluateClient(survivor); err != nil {
+		log.Printf("Failed to evaluate matches for client %s: %v", survivor.ID, err)
+	}
+	if _, err := s.DetectForClient(survivor); err != nil {
+		log.Printf("Failed to detect duplicates for client %s: %v", survivor.ID, err)
+	}
+
+	return &models.ClientMergeResult{
+		Client:         survivor,
+		MergedClientID: merged.ID,
+		Reassigned:     reassigned,
+	}, nil
+}
+
+// getClient retrieves a client with broker ownership verification
+func (s *ClientDuplicateService) getClient(clientID, brokerID string) (*models.Client, error) {
+	client, err := s.clientRepo.GetByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: client does not belong to this broker")
+	}
+
+	return client, nil
+}
+
+// combineClients fills the survivor's missing details from the merged client
+// Ranges are taken as a pair so a one-sided fill can't invert them
+func combineClients(survivor, merged *models.Client) {
+	for _, field := range []struct{ survivor, merged *string }{
+		{&survivor.Email, &merged.Email},
+		{&survivor.Phone, &merged.Phone},
+		{&survivor.PreferredLocation, &merged.PreferredLocation},
+		{&survivor.Address, &merged.Address},
+		{&survivor.City, &merged.City},
+		{&survivor.State, &merged.State},
+		{&survivor.PostalCode, &merged.PostalCode},
+	} {
+		if strings.TrimSpace(*field.survivor) == "" {
+			*field.survivor = *field.merged
+		}
+	}
+
+	survivor.Requirements = combineText(survivor.Requirements, merged.Requirements)
+
+	notes := ""
+	if survivor.Notes != nil {
+		notes = *survivor.Notes
+	}
+	if merged.Notes != nil {
+		notes = combineText(notes, *merged.Notes)
+	}
+	if notes != "" {
+		survivor.Notes = &notes
+	}
+
+	if survivor.BudgetMin == nil && survivor.BudgetMax == nil {
+		survivor.BudgetMin, survivor.BudgetMax = merged.BudgetMin, merged.BudgetMax
+	}
+	if survivor.BedroomsMin == nil && survivor.BedroomsMax == nil {
+		survivor.BedroomsMin, survivor.BedroomsMax = merged.BedroomsMin, merged.BedroomsMax
+	}
+	if survivor.AreaMin == nil && survivor.AreaMax == nil {
+		survivor.AreaMin, survivor.AreaMax = merged.AreaMin, merged.AreaMax
+	}
+	if survivor.ListingType == nil {
+		survivor.ListingType = merged.ListingType
+	}
+	if survivor.Source == nil {
+		survivor.Source, survivor.Campaign = merged.Source, merged.Campaign
+	}
+
+	survivor.PropertyTypes = normalizeRequirementList(append(survivor.PropertyTypes, merged.PropertyTypes...))
+	survivor.PreferredLocalities = normalizeRequirementList(append(survivor.PreferredLocalities, merged.PreferredLocalities...))
+	survivor.RequiredAmenities = normalizeRequirementList(append(survivor.RequiredAmenities, merged.RequiredAmenities...))
+	survivor.Tags = normalizeRequirementList(append(survivor.Tags, merged.Tags...))
+}
+
+// combineText keeps both free-text values when they differ, the survivor's first
+func combineText(survivor, merged string) string {
+	survivor = strings.TrimSpace(survivor)
+	merged = strings.TrimSpace(merged)
+
+	switch {
+	case merged == "" || strings.EqualFold(survivor, merged):
+		return survivor
+	case survivor == "":
+		return merged
+	default:
+		return survivor + "\n\n" + merged
+	}
+}
@@ -0,0 +1,844 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/mail"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+	"enfor-data-backend/internal/utils"
+)
+
+// importProgressInterval is how many rows are processed between progress saves
+const importProgressInterval = 100
+
+// exportPageSize is how many clients an export reads per query
+const exportPageSize = 200
+
+// importHeaderAliases maps normalized CSV headers to the client field they hold
+// Covers common spreadsheet headings and the phone contact exports of Google and Outlook
+var importHeaderAliases = map[string]string{
+	"name": "name", "full name": "name", "contact name": "name", "client name": "name", "display name": "name",
+	"first name": "first_name", "firstname": "first_name", "given name": "first_name",
+	"last name": "last_name", "lastname": "last_name", "surname": "last_name", "family name": "last_name",
+	"email": "email", "e mail": "email", "email address": "email", "e mail address": "email", "e mail 1 value": "email",
+	"phone": "phone", "mobile": "phone", "mobile number": "phone", "mobile phone": "phone", "phone number": "phone",
+	"contact number": "phone", "cell": "phone", "whatsapp": "phone", "phone 1 value": "phone",
+	"type": "type", "client type": "type",
+	"preferred location": "preferred_location", "location": "preferred_location", "locality": "preferred_location",
+	"address": "address", "street": "address", "street address": "address", "home street": "address",
+	"city": "city", "town": "city", "home city": "city",
+	"state": "state", "region": "state", "home state": "state",
+	"postal code": "postal_code", "pincode": "postal_code", "pin code": "postal_code", "pin": "postal_code",
+	"zip": "postal_code", "zip code": "postal_code", "postcode": "postal_code", "home postal code": "postal_code",
+	"budget min": "budget_min", "min budget": "budget_min", "budget from": "budget_min",
+	"budget max": "budget_max", "max budget": "budget_max", "budget": "budget_max", "budget to": "budget_max",
+	"requirements": "requirements", "requirement": "requirements", "enquiry": "requirements",
+	"notes": "notes", "note": "notes", "remarks": "notes", "comments": "notes",
+	"source": "source", "lead source": "source", "channel": "source",
+	"campaign": "campaign", "utm campaign": "campaign",
+	"tags": "tags", "tag": "tags", "labels": "tags",
+}
+
+// importFieldLimits are the longest values the clients table accepts per field
+var importFieldLimits = map[string]int{
+	"first_name": 100, "last_name": 100, "email": 255, "preferred_location": 255,
+	"city": 100, "state": 100, "postal_code": 20, "campaign": 100,
+}
+
+// nonAlphanumeric matches runs of characters that are ignored when comparing CSV headers
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// importRecord is one row or contact of an import file, keyed by client field
+type importRecord struct {
+	row    int
+	fields map[string]string
+}
+
+// ClientImportService imports clients from CSV and vCard files and exports them back out
+type ClientImportService struct {
+	importRepo          *repository.ClientImportRepository
+	clientRepo          *repository.ClientRepository
+	clientService       *ClientService
+	notificationService *NotificationService
+	maxFileSize         int64
+	maxRows             int
+	countryCode         string // calling code for phone numbers written without one
+}
+
+// NewClientImportService creates a new ClientImportService instance
+func NewClientImportService(
+	importRepo *repository.ClientImportRepository,
+	clientRepo *repository.ClientRepository,
+	clientService *ClientService,
+	notificationService *NotificationService,
+	maxFileSize int64,
+	maxRows int,
+	countryCode string,
+) *ClientImportService {
+	return &ClientImportService{
+		importRepo:          importRepo,
+		clientRepo:          clientRepo,
+		clientService:       clientService,
+		notificationService: notificationService,
+		maxFileSize:         maxFileSize,
+		maxRows:             maxRows,
+		countryCode:         strings.TrimPrefix(strings.TrimSpace(countryCode), "+"),
+	}
+}
+
+// MaxFileSize returns the largest contacts file that can be imported
+func (s *ClientImportService) MaxFileSize() int64 {
+	return s.maxFileSize
+}
+
+// StartImport reads a contacts file and imports its rows in the background
+// The file is parsed and its column mapping checked up front so a malformed file is rejected
+// immediately; the returned import is polled for progress and the per-row report
+func (s *ClientImportService) StartImport(req *models.ClientImportRequest, file io.Reader, fileName, brokerID string) (*models.ClientImport, error) {
+	format := req.Format
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(fileName)) {
+		case ".csv", ".txt":
+			format = "csv"
+		case ".vcf", ".vcard":
+			format = "vcard"
+		default:
+			return nil, fmt.Errorf("invalid format: name the format or upload a .csv or .vcf file")
+		}
+	}
+
+	clientImport := &models.ClientImport{
+		BrokerID:        brokerID,
+		FileName:        filepath.Base(fileName),
+		Format:          format,
+		DuplicatePolicy: req.DuplicatePolicy,
+		DefaultType:     req.DefaultType,
+	}
+	if clientImport.DuplicatePolicy == "" {
+		clientImport.DuplicatePolicy = models.ImportDuplicateSkip
+	}
+	if clientImport.DefaultType == "" {
+		clientImport.DefaultType = "buyer"
+	}
+	if len(clientImport.FileName) > 255 {
+		clientImport.FileName = clientImport.FileName[:255]
+	}
+
+	var records []importRecord
+	var err error
+	if format == "csv" {
+		var mapping map[string]string
+		if req.Mapping != "" {
+			if err := json.Unmarshal([]byte(req.Mapping), &mapping); err != nil {
+				return nil, fmt.Errorf("invalid mapping: must be a JSON object of column name to client field")
+			}
+		}
+		records, clientImport.Mapping, err = readCSVRecords(file, mapping)
+	} else {
+		records, err = readVCardRecords(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("invalid file: no rows to import")
+	}
+	if len(records) > s.maxRows {
+		return nil, fmt.Errorf("invalid file: at most %d rows can be imported at once", s.maxRows)
+	}
+
+	clientImport.TotalRows = len(records)
+	clientImport.Report = []models.ClientImportRowResult{}
+
+	if err := s.importRepo.Create(clientImport); err != nil {
+		return nil, fmt.Errorf("failed to create client import: %w", err)
+	}
+
+	// The background run works on its own copy so the response isn't read while rows are counted
+	running := *clientImport
+	go s.run(&running, records)
+
+	return clientImport, nil
+}
+
+// GetImports retrieves a broker's recent imports without their row reports
+func (s *ClientImportService) GetImports(brokerID string) ([]models.ClientImport, error) {
+	imports, err := s.importRepo.GetByBrokerID(brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client imports: %w", err)
+	}
+
+	return imports, nil
+}
+
+// GetImport retrieves an import with its row report and ownership verification
+func (s *ClientImportService) GetImport(id, brokerID string) (*models.ClientImport, error) {
+	clientImport, err := s.importRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientImport.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: client import does not belong to this broker")
+	}
+
+	return clientImport, nil
+}
+
+// FailInterrupted marks imports cut off by a server restart as failed
+// Called once at startup, before any new import can begin
+func (s *ClientImportService) FailInterrupted() {
+	count, err := s.importRepo.FailInterrupted("Import was interrupted by a server restart; upload the file again to import the remaining rows")
+	if err != nil {
+		log.Printf("Failed to mark interrupted client imports: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("Marked %d interrupted client imports as failed", count)
+	}
+}
+
+// ExportClients renders the broker's clients matching the filters as a CSV or vCard file
+// Returns the file content and its extension
+func (s *ClientImportService) ExportClients(brokerID string, req *models.ClientExportRequest) ([]byte, string, error) {
+	filters := req.ClientFilters
+	filters.Limit = exportPageSize
+	filters.Cursor = ""
+
+	var buf bytes.Buffer
+	format := req.Format
+	if format == "" {
+		format = "csv"
+	}
+
+	var writer *csv.Writer
+	if format == "csv" {
+		writer = csv.NewWriter(&buf)
+		if err := writer.Write(clientExportColumns); err != nil {
+			return nil, "", fmt.Errorf("failed to write export: %w", err)
+		}
+	}
+
+	for {
+		clients, page, err := s.clientService.GetBrokerClients(brokerID, filters)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for i := range clients {
+			if format == "csv" {
+				err = writer.Write(clientExportRow(&clients[i]))
+			} else {
+				err = utils.WriteVCard(&buf, clientVCard(&clients[i]))
+			}
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+
+		if !page.HasMore {
+			break
+		}
+		filters.Cursor = page.NextCursor
+	}
+
+	if format == "csv" {
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return nil, "", fmt.Errorf("failed to write export: %w", err)
+		}
+		return buf.Bytes(), "csv", nil
+	}
+
+	return buf.Bytes(), "vcf", nil
+}
+
+// run imports every record of a file, saving progress as it goes, and tells the broker when it is done
+// A panic fails the import rather than the server
+func (s *ClientImportService) run(clientImport *models.ClientImport, records []importRecord) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Client import %s panicked: %v", clientImport.ID, r)
+			message := "Import stopped unexpectedly"
+			clientImport.Status = "failed"
+			clientImport.Error = &message
+			if err := s.importRepo.SaveProgress(clientImport); err != nil {
+				log.Printf("Failed to save client import %s: %v", clientImport.ID, err)
+			}
+		}
+	}()
+
+	for i, record := range records {
+		result := s.importRecord(clientImport, record)
+
+		switch result.Status {
+		case models.ImportRowCreated:
+			clientImport.CreatedRows++
+		case models.ImportRowMerged:
+			clientImport.MergedRows++
+		case models.ImportRowSkipped:
+			clientImport.SkippedRows++
+		case models.ImportRowFailed:
+			clientImport.FailedRows++
+		}
+		if result.Status != models.ImportRowCreated {
+			clientImport.Report = append(clientImport.Report, result)
+		}
+		clientImport.ProcessedRows = i + 1
+
+		if clientImport.ProcessedRows%importProgressInterval == 0 && clientImport.ProcessedRows < len(records) {
+			if err := s.importRepo.SaveProgress(clientImport); err != nil {
+				log.Printf("Failed to save client import %s progress: %v", clientImport.ID, err)
+			}
+		}
+	}
+
+	clientImport.Status = "completed"
+	if err := s.importRepo.SaveProgress(clientImport); err != nil {
+		log.Printf("Failed to save client import %s: %v", clientImport.ID, err)
+		return
+	}
+
+	err := s.notificationService.Notify(
+		clientImport.BrokerID,
+		"client_import_completed",
+		"Client import finished",
+		fmt.Sprintf("%s: %d created, %d merged, %d skipped, %d failed.",
+			clientImport.FileName, clientImport.CreatedRows, clientImport.MergedRows, clientImport.SkippedRows, clientImport.FailedRows),
+		NotifyOptions{InApp: true, EntityType: "client_import", EntityID: clientImport.ID},
+	)
+	logNotifyError("client import "+clientImport.ID, err)
+}
+
+// importRecord validates one record and creates, merges or skips it according to the duplicate policy
+func (s *ClientImportService) importRecord(clientImport *models.ClientImport, record importRecord) models.ClientImportRowResult {
+	result := models.ClientImportRowResult{Row: record.row}
+
+	client, err := s.recordClient(record, clientImport)
+	if client != nil {
+		result.Name = strings.TrimSpace(client.FirstName + " " + client.LastName)
+	}
+	if err != nil {
+		result.Status = models.ImportRowFailed
+		result.Message = err.Error()
+		return result
+	}
+
+	if clientImport.DuplicatePolicy != models.ImportDuplicateCreate {
+		existing, err := s.clientRepo.FindByContact(client.BrokerID, client.Phone, client.Email)
+		if err != nil {
+			result.Status = models.ImportRowFailed
+			result.Message = "could not check for an existing client"
+			log.Printf("Client import %s row %d: %v", clientImport.ID, record.row, err)
+			return result
+		}
+
+		if len(existing) > 0 {
+			match := &existing[0]
+			result.ClientID = &match.ID
+
+			if clientImport.DuplicatePolicy == models.ImportDuplicateSkip {
+				result.Status = models.ImportRowSkipped
+				result.Message = fmt.Sprintf("matches existing client %s %s", match.FirstName, match.LastName)
+				return result
+			}
+
+			if err := s.clientService.MergeImportedClient(match, client); err != nil {
+				result.Status = models.ImportRowFailed
+				result.Message = "could not merge into the existing client"
+				log.Printf("Client import %s row %d: %v", clientImport.ID, record.row, err)
+				return result
+			}
+
+			result.Status = models.ImportRowMerged
+			result.Message = fmt.Sprintf("merged into existing client %s %s", match.FirstName, match.LastName)
+			return result
+		}
+	}
+
+	if err := s.clientService.AddClient(client); err != nil {
+		result.Status = models.ImportRowFailed
+		result.Message = "could not save the client"
+		log.Printf("Client import %s row %d: %v", clientImport.ID, record.row, err)
+		return result
+	}
+
+	result.Status = models.ImportRowCreated
+	result.ClientID = &client.ID
+	return result
+}
+
+// recordClient builds and validates a client from an import record
+// The client is returned even when invalid so the report can name the row
+func (s *ClientImportService) recordClient(record importRecord, clientImport *models.ClientImport) (*models.Client, error) {
+	fields := record.fields
+
+	client := &models.Client{
+		FirstName:           fields["first_name"],
+		LastName:            fields["last_name"],
+		Email:               strings.ToLower(fields["email"]),
+		Type:                strings.ToLower(fields["type"]),
+		PreferredLocation:   fields["preferred_location"],
+		Address:             fields["address"],
+		City:                fields["city"],
+		State:               fields["state"],
+		PostalCode:          fields["postal_code"],
+		Requirements:        fields["requirements"],
+		BrokerID:            clientImport.BrokerID,
+		PropertyTypes:       []string{},
+		PreferredLocalities: []string{},
+		RequiredAmenities:   []string{},
+	}
+	if notes := fields["notes"]; notes != "" {
+		client.Notes = &notes
+	}
+	if campaign := fields["campaign"]; campaign != "" {
+		client.Campaign = &campaign
+	}
+	if tags := fields["tags"]; tags != "" {
+		client.Tags = strings.Split(tags, ";") // tidied and matched to the broker's tags when saved
+	}
+
+	// A full name fills whichever name parts the row left empty
+	if name := strings.Fields(fields["name"]); len(name) > 0 && client.FirstName == "" && client.LastName == "" {
+		client.FirstName = name[0]
+		client.LastName = strings.Join(name[1:], " ")
+	}
+
+	if client.FirstName == "" {
+		if client.LastName == "" {
+			return client, fmt.Errorf("name is required")
+		}
+		client.FirstName, client.LastName = client.LastName, ""
+	}
+
+	if client.Type == "" {
+		client.Type = clientImport.DefaultType
+	}
+	switch client.Type {
+	case "buyer", "seller", "tenant", "owner":
+	default:
+		return client, fmt.Errorf("type must be one of: buyer seller tenant owner")
+	}
+
+	// Sources are written the way they are read out loud ("Walk-in"), so compare them loosely
+	if raw := fields["source"]; raw != "" {
+		source := strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(raw), "_"), "_")
+		switch source {
+		case "website", "portal", "referral", "walk_in", "other":
+			client.Source = &source
+		default:
+			return client, fmt.Errorf("source must be one of: website portal referral walk_in other")
+		}
+	}
+
+	if fields["phone"] == "" && client.Email == "" {
+		return client, fmt.Errorf("a phone number or email is required")
+	}
+
+	if fields["phone"] != "" {
+		phone, err := normalizePhone(fields["phone"], s.countryCode)
+		if err != nil {
+			return client, err
+		}
+		client.Phone = phone
+	}
+
+	if client.Email != "" {
+		address, err := mail.ParseAddress(client.Email)
+		if err != nil || address.Address != client.Email {
+			return client, fmt.Errorf("email %q is not a valid address", fields["email"])
+		}
+	}
+
+	for field, limit := range importFieldLimits {
+		if len([]rune(importFieldValue(client, field))) > limit {
+			return client, fmt.Errorf("%s is longer than %d characters", field, limit)
+		}
+	}
+
+	for _, field := range []struct {
+		name  string
+		value **float64
+	}{
+		{"budget_min", &client.BudgetMin},
+		{"budget_max", &client.BudgetMax},
+	} {
+		raw := strings.NewReplacer(",", "", " ", "").Replace(fields[field.name])
+		if raw == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(raw, 64)
+		if err != nil || amount <= 0 {
+			return client, fmt.Errorf("%s must be a positive number", field.name)
+		}
+		*field.value = &amount
+	}
+	if client.BudgetMin != nil && client.BudgetMax != nil && *client.BudgetMin > *client.BudgetMax {
+		return client, fmt.Errorf("budget_min cannot be greater than budget_max")
+	}
+
+	return client, nil
+}
+
+// importFieldValue returns the value of a length-limited client field
+func importFieldValue(client *models.Client, field string) string {
+	switch field {
+	case "first_name":
+		return client.FirstName
+	case "last_name":
+		return client.LastName
+	case "email":
+		return client.Email
+	case "preferred_location":
+		return client.PreferredLocation
+	case "city":
+		return client.City
+	case "state":
+		return client.State
+	case "postal_code":
+		return client.PostalCode
+	case "campaign":
+		if client.Campaign != nil {
+			return *client.Campaign
+		}
+	}
+	return ""
+}
+
+// normalizePhone turns a phone number into international format, "+<country code><number>"
+// Numbers without a country code are taken to be local: ten digits, or eleven with a trunk 0
+func normalizePhone(raw, countryCode string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	digits := phoneDigitsOnly(trimmed)
+
+	var normalized string
+	switch {
+	case strings.HasPrefix(trimmed, "+"):
+		normalized = "+" + digits
+	case strings.HasPrefix(digits, "00"):
+		normalized = "+" + digits[2:]
+	case len(digits) == 10:
+		normalized = "+" + countryCode + digits
+	case len(digits) == 11 && digits[0] == '0':
+		normalized = "+" + countryCode + digits[1:]
+	case len(digits) == len(countryCode)+10 && strings.HasPrefix(digits, countryCode):
+		normalized = "+" + digits
+	default:
+		return "", fmt.Errorf("phone number %q is not in a recognized format", raw)
+	}
+
+	// E.164 numbers hold at most 15 digits; fewer than 8 is an extension or a typo
+	if length := len(normalized) - 1; length < 8 || length > 15 {
+		return "", fmt.Errorf("phone number %q is not in a recognized format", raw)
+	}
+
+	return normalized, nil
+}
+
+// phoneDigitsOnly strips everything but digits from a phone number
+func phoneDigitsOnly(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+// readCSVRecords reads a CSV file into import records using the given column mapping,
+// or a mapping detected from the header row when none is given
+// Comma, semicolon and tab separated files are accepted. Returns the mapping that was used.
+func readCSVRecords(file io.Reader, mapping map[string]string) ([]importRecord, map[string]string, error) {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = detectCSVDelimiter(content)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid file: could not read the CSV header row")
+	}
+
+	columns, resolved, err := mapCSVColumns(header, mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records := []importRecord{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid file: %v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := importRecord{row: line, fields: make(map[string]string)}
+		for index, field := range columns {
+			if index < len(row) {
+				if value := strings.TrimSpace(spreadsheetUnescape(row[index])); value != "" {
+					record.fields[field] = value
+				}
+			}
+		}
+
+		// Blank lines and rows with nothing in the mapped columns are not counted
+		if len(record.fields) > 0 {
+			records = append(records, record)
+		}
+	}
+
+	return records, resolved, nil
+}
+
+// detectCSVDelimiter picks the separator used most in the header line
+func detectCSVDelimiter(content []byte) rune {
+	header := content
+	if end := bytes.IndexByte(content, '\n'); end >= 0 {
+		header = content[:end]
+	}
+
+	delimiter, best := ',', bytes.Count(header, []byte(","))
+	for _, candidate := range []rune{';', '\t'} {
+		if count := bytes.Count(header, []byte(string(candidate))); count > best {
+			delimiter, best = candidate, count
+		}
+	}
+
+	return delimiter
+}
+
+// mapCSVColumns resolves which client field each column index holds
+// An explicit mapping names columns by header (case-insensitive); without one, headers are
+// matched against known aliases and the first column for each field wins
+func mapCSVColumns(header []string, mapping map[string]string) (map[int]string, map[string]string, error) {
+	columns := make(map[int]string)
+	resolved := make(map[string]string)
+	used := make(map[string]bool)
+
+	validFields := make(map[string]bool, len(models.ClientImportFields))
+	for _, field := range models.ClientImportFields {
+		validFields[field] = true
+	}
+
+	if mapping != nil {
+		headerIndex := make(map[string]int, len(header))
+		for index, name := range header {
+			key := strings.ToLower(strings.TrimSpace(name))
+			if _, exists := headerIndex[key]; !exists {
+				headerIndex[key] = index
+			}
+		}
+
+		for column, field := range mapping {
+			if field == "" {
+				continue // explicitly ignored column
+			}
+			if !validFields[field] {
+				return nil, nil, fmt.Errorf("invalid mapping: unknown client field %q for column %q", field, column)
+			}
+			index, exists := headerIndex[strings.ToLower(strings.TrimSpace(column))]
+			if !exists {
+				return nil, nil, fmt.Errorf("invalid mapping: column %q is not in the file", column)
+			}
+			if used[field] {
+				return nil, nil, fmt.Errorf("invalid mapping: more than one column is mapped to %s", field)
+			}
+			used[field] = true
+			columns[index] = field
+			resolved[header[index]] = field
+		}
+	} else {
+		for index, name := range header {
+			key := strings.TrimSpace(nonAlphanumeric.ReplaceAllString(strings.ToLower(name), " "))
+			field, known := importHeaderAliases[key]
+			if !known || used[field] {
+				continue
+			}
+			used[field] = true
+			columns[index] = field
+			resolved[name] = field
+		}
+	}
+
+	if !used["name"] && !used["first_name"] && !used["last_name"] {
+		return nil, nil, fmt.Errorf("invalid mapping: map a column to name, first_name or last_name")
+	}
+	if !used["phone"] && !used["email"] {
+		return nil, nil, fmt.Errorf("invalid mapping: map a column to phone or email")
+	}
+
+	return columns, resolved, nil
+}
+
+// readVCardRecords reads a vCard file into import records, one per contact
+func readVCardRecords(file io.Reader) ([]importRecord, error) {
+	cards, err := utils.ParseVCards(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file: %v", err)
+	}
+
+	records := make([]importRecord, 0, len(cards))
+	for i, card := range cards {
+		fields := map[string]string{
+			"first_name":  card.GivenName,
+			"last_name":   card.FamilyName,
+			"address":     card.Street,
+			"city":        card.City,
+			"state":       card.Region,
+			"postal_code": card.PostalCode,
+			"notes":       card.Note,
+		}
+		if card.GivenName == "" && card.FamilyName == "" {
+			fields["name"] = card.FormattedName
+		}
+		if len(card.Phones) > 0 {
+			fields["phone"] = card.Phones[0]
+		}
+		if len(card.Emails) > 0 {
+			fields["email"] = card.Emails[0]
+		}
+
+		for field, value := range fields {
+			if value = strings.TrimSpace(value); value == "" {
+				delete(fields, field)
+			} else {
+				fields[field] = value
+			}
+		}
+
+		records = append(records, importRecord{row: i + 1, fields: fields})
+	}
+
+	return records, nil
+}
+
+// clientExportColumns is the CSV export header; the names are import fields so exports re-import as-is
+var clientExportColumns = []string{
+	"first_name", "last_name", "email", "phone", "type", "status",
+	"preferred_location", "address", "city", "state", "postal_code",
+	"budget_min", "budget_max", "listing_type", "property_types", "preferred_localities", "required_amenities",
+	"requirements", "notes", "source", "campaign", "tags", "created_at",
+}
+
+// clientExportRow renders a client as a CSV export row
+func clientExportRow(client *models.Client) []string {
+	optional := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+	amount := func(value *float64) string {
+		if value == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*value, 'f', -1, 64)
+	}
+
+	row := []string{
+		client.FirstName,
+		client.LastName,
+		client.Email,
+		client.Phone,
+		client.Type,
+		client.Status,
+		client.PreferredLocation,
+		client.Address,
+		client.City,
+		client.State,
+		client.PostalCode,
+		amount(client.BudgetMin),
+		amount(client.BudgetMax),
+		optional(client.ListingType),
+		strings.Join(client.PropertyTypes, "; "),
+		strings.Join(client.PreferredLocalities, "; "),
+		strings.Join(client.RequiredAmenities, "; "),
+		client.Requirements,
+		optional(client.Notes),
+		optional(client.Source),
+		optional(client.Campaign),
+		strings.Join(client.Tags, "; "),
+		client.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	for i := range row {
+		row[i] = spreadsheetSafe(row[i])
+	}
+
+	return row
+}
+
+// spreadsheetSafe stops a cell from being evaluated as a formula when the export is opened in a spreadsheet
+// by prefixing an apostrophe to any cell starting with a formula character, phone numbers included;
+// spreadsheetUnescape removes it again when the export is re-imported
+func spreadsheetSafe(value string) string {
+	if value != "" && strings.ContainsRune(spreadsheetFormulaChars, rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// spreadsheetUnescape reverses spreadsheetSafe for a cell read from an import file
+func spreadsheetUnescape(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(spreadsheetFormulaChars, rune(value[1])) {
+		return value[1:]
+	}
+
+	return value
+}
+
+// spreadsheetFormulaChars are the leading characters that make a spreadsheet evaluate a cell
+const spreadsheetFormulaChars = "=+-@\t\r"
+
+// clientVCard converts a client to a vCard contact
+func clientVCard(client *models.Client) utils.VCard {
+	card := utils.VCard{
+		FormattedName: strings.TrimSpace(client.FirstName + " " + client.LastName),
+		FamilyName:    client.LastName,
+		GivenName:     client.FirstName,
+		Street:        client.Address,
+		City:          client.City,
+		Region:        client.State,
+		PostalCode:    client.PostalCode,
+	}
+	if client.Phone != "" {
+		card.Phones = []string{client.Phone}
+	}
+	if client.Email != "" {
+		card.Emails = []string{client.Email}
+	}
+
+	notes := []string{}
+	if client.Requirements != "" {
+		notes = append(notes, client.Requirements)
+	}
+	if client.Notes != nil && *client.Notes != "" {
+		notes = append(notes, *client.Notes)
+	}
+	card.Note = strings.Join(notes, "\n\n")
+
+	return card
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// ClientSegmentService handles saved client segments and resolving them into client list filters
+type ClientSegmentService struct {
+	segmentRepo *repository.ClientSegmentRepository
+	tagService  *TagService
+}
+
+// NewClientSegmentService creates a new ClientSegmentService instance
+func NewClientSegmentService(segmentRepo *repository.ClientSegmentRepository, tagService *TagService) *ClientSegmentService {
+	return &ClientSegmentService{
+		segmentRepo: segmentRepo,
+		tagService:  tagService,
+	}
+}
+
+// CreateSegment saves a named set of client list filters for the broker
+func (s *ClientSegmentService) CreateSegment(req *models.CreateClientSegmentRequest, brokerID string) (*models.ClientSegment, error) {
+	filters, err := s.normalizeFilters(brokerID, req.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	segment := &models.ClientSegment{
+		BrokerID:    brokerID,
+		Name:        req.Name,
+		Description: optionalText(req.Description),
+		Filters:     filters,
+	}
+
+	if err := s.segmentRepo.Create(segment); err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+// GetSegments retrieves the broker's client segments
+func (s *ClientSegmentService) GetSegments(brokerID string) ([]models.ClientSegment, error) {
+	segments, err := s.segmentRepo.GetByBrokerID(brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client segments: %w", err)
+	}
+
+	return segments, nil
+}
+
+// GetSegment retrieves a client segment with ownership verification
+func (s *ClientSegmentService) GetSegment(id, brokerID string) (*models.ClientSegment, error) {
+	segment, err := s.segmentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if segment.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: client segment does not belong to this broker")
+	}
+
+	return segment, nil
+}
+
+// UpdateSegment updates a client segment with ownership verification
+// Filters sent in the request replace the stored filters as a whole
+func (s *ClientSegmentService) UpdateSegment(id string, req *models.UpdateClientSegmentRequest, brokerID string) (*models.ClientSegment, error) {
+	segment, err := s.GetSegment(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		segment.Name = *req.Name
+	}
+	if req.Description != nil {
+		segment.Description = optionalText(req.Description) // an empty description clears it
+	}
+	if req.Filters != nil {
+		filters, err := s.normalizeFilters(brokerID, *req.Filters)
+		if err != nil {
+			return nil, err
+		}
+		segment.Filters = filters
+	}
+
+	if err := s.segmentRepo.Update(segment); err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+// DeleteSegment deletes a client segment with ownership verification
+func (s *ClientSegmentService) DeleteSegment(id, brokerID string) error {
+	if _, err := s.GetSegment(id, brokerID); err != nil {
+		return err
+	}
+
+	if err := s.segmentRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete client segment: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveFilters prepares client list filters for the repository
+// A segment fills in every filter the request leaves unset and its tags are added to the requested
+// ones, so a segment can be narrowed further; tags are then matched to the broker's spelling
+func (s *ClientSegmentService) ResolveFilters(brokerID string, filters *models.ClientFilters) error {
+	if filters.SegmentID != "" {
+		segment, err := s.GetSegment(filters.SegmentID, brokerID)
+		if err != nil {
+			return fmt.Errorf("invalid segment_id: client segment not found")
+		}
+
+		// Overlaying the requested filters' JSON keeps only the fields that were given,
+		// since every segment filter is omitted from JSON when unset
+		given, err := json.Marshal(filters.ClientSegmentFilters)
+		if err != nil {
+			return fmt.Errorf("failed to encode client filters: %w", err)
+		}
+
+		resolved := segment.Filters
+		if err := json.Unmarshal(given, &resolved); err != nil {
+			return fmt.Errorf("failed to apply client segment: %w", err)
+		}
+		resolved.Tags = append(append([]string{}, segment.Filters.Tags...), filters.Tags...)
+
+		filters.ClientSegmentFilters = resolved
+	}
+
+	tags, err := s.tagService.NormalizeTags(brokerID, filters.Tags)
+	if err != nil {
+		return err
+	}
+	filters.Tags = tags
+
+	if filters.CreatedFrom != "" && filters.CreatedTo != "" && filters.CreatedFrom > filters.CreatedTo {
+		return fmt.Errorf("invalid date range: created_from must not be after created_to")
+	}
+
+	return nil
+}
+
+// normalizeFilters checks a segment's filters and matches its tags to the broker's spelling
+func (s *ClientSegmentService) normalizeFilters(brokerID string, filters models.ClientSegmentFilters) (models.ClientSegmentFilters, error) {
+	if filters.CreatedFrom != "" && filters.CreatedTo != "" && filters.CreatedFrom > filters.CreatedTo {
+		return filters, fmt.Errorf("invalid date range: created_from must not be after created_to")
+	}
+
+	tags, err := s.tagService.NormalizeTags(brokerID, filters.Tags)
+	if err != nil {
+		return filters, err
+	}
+	filters.Tags = tags
+
+	return filters, nil
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"fmt"
+	"log"
+	"strings"
 
 	"enfor-data-backend/internal/models"
 	"enfor-data-backend/internal/repository"
@@ -9,29 +11,56 @@ import (
 
 // ClientService handles business logic for client operations
 type ClientService struct {
-	clientRepo *repository.ClientRepository
-	userRepo   *repository.UserRepository
+	clientRepo       *repository.ClientRepository
+	userRepo         *repository.UserRepository
+	matchService     *MatchService
+	pipelineService  *PipelineService
+	activityService  *ActivityService
+	duplicateService *ClientDuplicateService
+	tagService       *TagService
+	segmentService   *ClientSegmentService
 }
 
 // NewClientService creates a new ClientService instance
-func NewClientService(clientRepo *repository.ClientRepository, userRepo *repository.UserRepository) *ClientService {
+func NewClientService(
+	clientRepo *repository.ClientRepository,
+	userRepo *repository.UserRepository,
+	matchService *MatchService,
+	pipelineService *PipelineService,
+	activityService *ActivityService,
+	duplicateService *ClientDuplicateService,
+	tagService *TagService,
+	segmentService *ClientSegmentService,
+) *ClientService {
 	return &ClientService{
-		clientRepo: clientRepo,
-		userRepo:   userRepo,
+		clientRepo:       clientRepo,
+		userRepo:         userRepo,
+		matchService:     matchService,
+		pipelineService:  pipelineService,
+		activityService:  activityService,
+		duplicateService: duplicateService,
+		tagService:       tagService,
+		segmentService:   segmentService,
 	}
 }
 
 // CreateClient creates a new client with validation and broker information
-func (s *ClientService) CreateClient(req *models.CreateClientRequest, brokerID string) (*models.Client, error) {
+// Likely duplicates already in the broker's book are returned as warnings; they never block the create
+func (s *ClientService) CreateClient(req *models.CreateClientRequest, brokerID string) (*models.Client, []models.ClientDuplicateWarning, error) {
 	// Validate budget range if both min and max provided
 	if err := s.validateBudgetRange(req.BudgetMin, req.BudgetMax); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Validate structured requirement ranges
+	if err := validateRequirementRanges(req.BedroomsMin, req.BedroomsMax, req.AreaMin, req.AreaMax); err != nil {
+		return nil, nil, err
 	}
 
 	// Fetch broker information from userRepo to validate broker exists
 	_, err := s.userRepo.GetUserByID(brokerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch broker information: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch broker information: %w", err)
 	}
 
 	// Create Client model from CreateClientRequest
@@ -52,27 +81,132 @@ func (s *ClientService) CreateClient(req *models.CreateClientRequest, brokerID s
 		Requirements:      req.Requirements,
 		Notes:             req.Notes,
 		BrokerID:          brokerID,
+
+		PropertyTypes:       normalizeRequirementList(req.PropertyTypes),
+		ListingType:         req.ListingType,
+		BedroomsMin:         req.BedroomsMin,
+		BedroomsMax:         req.BedroomsMax,
+		AreaMin:             req.AreaMin,
+		AreaMax:             req.AreaMax,
+		PreferredLocalities: normalizeRequirementList(req.PreferredLocalities),
+		RequiredAmenities:   normalizeRequirementList(req.RequiredAmenities),
+
+		Source:   req.Source,
+		Campaign: optionalText(req.Campaign),
+		Tags:     req.Tags,
+	}
+
+	if err := s.saveNewClient(client); err != nil {
+		return nil, nil, err
+	}
+
+	// Return created client with all populated fields (including broker info from trigger)
+	return client, s.detectDuplicates(client), nil
+}
+
+// AddClient saves a client that didn't come through the create endpoint, such as an imported
+// contact or a captured lead
+// These clients go through the same defaults, pipeline placement, matching and duplicate
+// detection as clients created through the API
+func (s *ClientService) AddClient(client *models.Client) error {
+	client.Status = "active"
+
+	if err := s.saveNewClient(client); err != nil {
+		return err
+	}
+
+	s.detectDuplicates(client)
+
+	return nil
+}
+
+// MergeImportedClient fills an existing client's blank details from a matching imported row
+func (s *ClientService) MergeImportedClient(existing, imported *models.Client) error {
+	tags, err := s.tagService.NormalizeTags(existing.BrokerID, imported.Tags)
+	if err != nil {
+		return err
+	}
+	imported.Tags = tags
+
+	combineClients(existing, imported)
+
+	if err := s.clientRepo.Update(existing); err != nil {
+		return fmt.Errorf("failed to update client: %w", err)
+	}
+
+	s.evaluateMatches(existing)
+
+	return nil
+}
+
+// saveNewClient applies requirement defaults, inserts the client, places it in the pipeline and
+// looks for matching properties
+func (s *ClientService) saveNewClient(client *models.Client) error {
+	// Tags take the spelling the broker already uses
+	tags, err := s.tagService.NormalizeTags(client.BrokerID, client.Tags)
+	if err != nil {
+		return err
+	}
+	client.Tags = tags
+
+	// Buyers look for sale listings and tenants for rentals unless told otherwise
+	if client.ListingType == nil {
+		if listingType, ok := listingTypeForClient(client.Type); ok {
+			client.ListingType = &listingType
+		}
+	}
+
+	// The preferred location is the first locality unless localities are given
+	if len(client.PreferredLocalities) == 0 {
+		client.PreferredLocalities = normalizeRequirementList([]string{client.PreferredLocation})
 	}
 
 	// Call repository Create method
 	if err := s.clientRepo.Create(client); err != nil {
-		return nil, fmt.Errorf("failed to create client: %w", err)
+		return fmt.Errorf("failed to create client: %w", err)
 	}
 
-	// Return created client with all populated fields (including broker info from trigger)
-	return client, nil
+	// New clients enter the first open stage of the broker's pipeline
+	s.pipelineService.PlaceNewClient(client)
+
+	// Surface matching properties for the new client
+	s.evaluateMatches(client)
+
+	return nil
 }
 
-// GetBrokerClients retrieves all clients for a specific broker
-func (s *ClientService) GetBrokerClients(brokerID string) ([]models.Client, error) {
+// GetBrokerClients retrieves a page of a broker's clients matching the filters
+// A segment_id applies that saved segment's filters to any filter the request leaves unset
+func (s *ClientService) GetBrokerClients(brokerID string, filters models.ClientFilters) ([]models.Client, *models.PageInfo, error) {
+	// Apply the segment and reject inverted date ranges before hitting the database
+	if err := s.segmentService.ResolveFilters(brokerID, &filters); err != nil {
+		return nil, nil, err
+	}
+
 	// Call repository GetByBrokerID with broker_id
-	clients, err := s.clientRepo.GetByBrokerID(brokerID)
+	clients, page, err := s.clientRepo.GetByBrokerID(brokerID, filters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get broker clients: %w", err)
+		if err.Error() == "invalid cursor" {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to get broker clients: %w", err)
 	}
 
-	// Return clients array directly (no additional processing needed)
-	return clients, nil
+	return clients, page, nil
+}
+
+// GetSourceSummary counts the broker's clients per lead source and campaign
+func (s *ClientService) GetSourceSummary(brokerID string, req *models.ClientSourceSummaryRequest) ([]models.ClientSourceSummary, error) {
+	if req.CreatedFrom != "" && req.CreatedTo != "" && req.CreatedFrom > req.CreatedTo {
+		return nil, fmt.Errorf("invalid date range: created_from must not be after created_to")
+	}
+
+	summary, err := s.clientRepo.GetSourceSummary(brokerID, req.CreatedFrom, req.CreatedTo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client source summary: %w", err)
+	}
+
+	return summary, nil
 }
 
 // GetClientByID retrieves a client by ID with ownership verification
@@ -93,16 +227,24 @@ func (s *ClientService) GetClientByID(id, brokerID string) (*models.Client, erro
 }
 
 // UpdateClient updates a client with ownership verification and validation
-func (s *ClientService) UpdateClient(id string, req *models.UpdateClientRequest, brokerID string) (*models.Client, error) {
+// Likely duplicates of the updated client are returned as warnings
+func (s *ClientService) UpdateClient(id string, req *models.UpdateClientRequest, brokerID string) (*models.Client, []models.ClientDuplicateWarning, error) {
 	// Call GetClientByID to verify ownership (reuse existing logic)
 	client, err := s.GetClientByID(id, brokerID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Validate budget range if both values provided in update
 	if err := s.validateBudgetRange(req.BudgetMin, req.BudgetMax); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Remember what changes the timeline should record
+	previousStatus := client.Status
+	previousNotes := ""
+	if client.Notes != nil {
+		previousNotes = *client.Notes
 	}
 
 	// Apply updates to client model
@@ -151,17 +293,70 @@ func (s *ClientService) UpdateClient(id string, req *models.UpdateClientRequest,
 	if req.Notes != nil {
 		client.Notes = req.Notes
 	}
+	if req.PropertyTypes != nil {
+		client.PropertyTypes = normalizeRequirementList(req.PropertyTypes)
+	}
+	if req.ListingType != nil {
+		client.ListingType = req.ListingType
+	}
+	if req.BedroomsMin != nil {
+		client.BedroomsMin = req.BedroomsMin
+	}
+	if req.BedroomsMax != nil {
+		client.BedroomsMax = req.BedroomsMax
+	}
+	if req.AreaMin != nil {
+		client.AreaMin = req.AreaMin
+	}
+	if req.AreaMax != nil {
+		client.AreaMax = req.AreaMax
+	}
+	if req.PreferredLocalities != nil {
+		client.PreferredLocalities = normalizeRequirementList(req.PreferredLocalities)
+	}
+	if req.RequiredAmenities != nil {
+		client.RequiredAmenities = normalizeRequirementList(req.RequiredAmenities)
+	}
+	if req.Source != nil {
+		client.Source = req.Source
+	}
+	if req.Campaign != nil {
+		client.Campaign = optionalText(req.Campaign) // an empty campaign clears it
+	}
+	if req.Tags != nil {
+		tags, err := s.tagService.NormalizeTags(brokerID, req.Tags)
+		if err != nil {
+			return nil, nil, err
+		}
+		client.Tags = tags
+	}
+
+	// Ranges are checked on the merged values so a one-sided update can't invert them
+	if err := validateRequirementRanges(client.BedroomsMin, client.BedroomsMax, client.AreaMin, client.AreaMax); err != nil {
+		return nil, nil, err
+	}
 
 	// Call repository Update method
 	if err := s.clientRepo.Update(client); err != nil {
-		return nil, fmt.Errorf("failed to update client: %w", err)
+		return nil, nil, fmt.Errorf("failed to update client: %w", err)
+	}
+
+	// Status changes and edited notes are kept on the client's timeline
+	if client.Status != previousStatus {
+		s.activityService.RecordStatusChange(client, previousStatus, brokerID)
+	}
+	if client.Notes != nil && *client.Notes != previousNotes {
+		s.activityService.RecordNote(client, brokerID)
 	}
 
+	// Budget, requirement or status changes can add or remove matches
+	s.evaluateMatches(client)
+
 	// Return updated client
-	return client, nil
+	return client, s.detectDuplicates(client), nil
 }
 
-// DeleteClient deletes a client with ownership verification
+// DeleteClient moves a client and its appointments to the trash with ownership verification
 func (s *ClientService) DeleteClient(id, brokerID string) error {
 	// Call GetClientByID to verify ownership
 	_, err := s.GetClientByID(id, brokerID)
@@ -169,14 +364,33 @@ func (s *ClientService) DeleteClient(id, brokerID string) error {
 		return err
 	}
 
-	// Call repository Delete method
-	if err := s.clientRepo.Delete(id); err != nil {
+	// Soft delete so the client can be restored from the trash
+	if err := s.clientRepo.SoftDelete(id); err != nil {
 		return fmt.Errorf("failed to delete client: %w", err)
 	}
 
 	return nil
 }
 
+// evaluateMatches refreshes stored property matches for a client
+// Matching is best-effort and never fails the client write that triggered it
+func (s *ClientService) evaluateMatches(client *models.Client) {
+	if err := s.matchService.EvaluateClient(client); err != nil {
+		log.Printf("Failed to evaluate matches for client %s: %v", client.ID, err)
+	}
+}
+
+// detectDuplicates finds likely duplicates of a saved client
+// Detection is best-effort and never fails the client write that triggered it
+func (s *ClientService) detectDuplicates(client *models.Client) []models.ClientDuplicateWarning {
+	warnings, err := s.duplicateService.DetectForClient(client)
+	if err != nil {
+		log.Printf("Failed to detect duplicates for client %s: %v", client.ID, err)
+		return []models.ClientDuplicateWarning{}
+	}
+	return warnings
+}
+
 // validateBudgetRange validates that budget_min <= budget_max when both are provided
 func (s *ClientService) validateBudgetRange(budgetMin, budgetMax *float64) error {
 	// Check if both budget_min and budget_max are provided
@@ -203,3 +417,46 @@ func (s *ClientService) validateBudgetRange(budgetMin, budgetMax *float64) error
 
 	return nil
 }
+
+// validateRequirementRanges validates that the bedroom and area ranges are not inverted
+func validateRequirementRanges(bedroomsMin, bedroomsMax *int, areaMin, areaMax *float64) error {
+	if bedroomsMin != nil && bedroomsMax != nil && *bedroomsMin > *bedroomsMax {
+		return fmt.Errorf("bedrooms_min cannot be greater than bedrooms_max")
+	}
+	if areaMin != nil && areaMax != nil && *areaMin > *areaMax {
+		return fmt.Errorf("area_min cannot be greater than area_max")
+	}
+
+	return nil
+}
+
+// normalizeRequirementList trims entries and drops blanks and case-insensitive duplicates
+// Always returns a non-nil slice so the column is stored as an empty array
+func normalizeRequirementList(values []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, value)
+	}
+
+	return normalized
+}
+
+// optionalText trims a free-text value, treating a blank value as unset
+func optionalText(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// clientTransferRoles are the roles that can receive clients
+var clientTransferRoles = map[string]bool{
+	"broker":          true,
+	"channel_partner": true,
+}
+
+// ClientTransferService handles handing clients from one broker to another
+type ClientTransferService struct {
+	transferRepo        *repository.ClientTransferRepository
+	clientRepo          *repository.ClientRepository
+	userRepo            *repository.UserRepository
+	pipelineService     *PipelineService
+	duplicateService    *ClientDuplicateService
+	notificationService *NotificationService
+}
+
+// NewClientTransferService creates a new ClientTransferService instance
+func NewClientTransferService(
+	transferRepo *repository.ClientTransferRepository,
+	clientRepo *repository.ClientRepository,
+	userRepo *repository.UserRepository,
+	pipelineService *PipelineService,
+	duplicateService *ClientDuplicateService,
+	notificationService *NotificationService,
+) *ClientTransferService {
+	return &ClientTransferService{
+		transferRepo:        transferRepo,
+		clientRepo:          clientRepo,
+		userRepo:            userRepo,
+		pipelineService:     pipelineService,
+		duplicateService:    duplicateService,
+		notificationService: notificationService,
+	}
+}
+
+// CreateTransfer offers some of the broker's clients to another broker
+func (s *ClientTransferService) CreateTransfer(req *models.CreateClientTransferRequest, brokerID string) (*models.ClientTransfer, error) {
+	return s.createTransfer(brokerID, req.ToBrokerEmail, req.ClientIDs, req.Message, brokerID)
+}
+
+// CreateAdminTransfer offers a broker's clients to another broker on the giving broker's behalf
+// The giving broker's account may already be deactivated; without client_ids their whole book is offered
+func (s *ClientTransferService) CreateAdminTransfer(req *models.AdminClientTransferRequest, adminID string) (*models.ClientTransfer, error) {
+	fromBrokerID, err := s.userRepo.GetIDByEmail(strings.TrimSpace(req.FromBrokerEmail))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("invalid from_broker_email: no user has this email")
+		}
+		return nil, err
+	}
+
+	clientIDs := req.ClientIDs
+	if len(clientIDs) == 0 {
+		clientIDs, err = s.transferRepo.GetClientIDsByBroker(fromBrokerID)
+		if err != nil {
+			return nil, err
+		}
+		if len(clientIDs) == 0 {
+			return nil, fmt.Errorf("invalid from_broker_email: the broker has no clients")
+		}
+	}
+
+	return s.createTransfer(fromBrokerID, req.ToBrokerEmail, clientIDs, req.Message, adminID)
+}
+
+// GetTransfers retrieves the transfers a user gives, receives or proposed, with the offered clients filled in
+func (s *ClientTransferService) GetTransfers(userID string, filters models.ClientTransferFilters) ([]models.ClientTransfer, error) {
+	transfers, err := s.transferRepo.GetForUser(userID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client transfers: %w", err)
+	}
+
+	if err := s.fillClients(transfers); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// GetTransfer retrieves a transfer the user gives, receives or proposed, with the offered clients filled in
+func (s *ClientTransferService) GetTransfer(id, userID string) (*models.ClientTransfer, error) {
+	transfer, err := s.getTransfer(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := []models.ClientTransfer{*transfer}
+	if err := s.fillClients(transfers); err != nil {
+		return nil, err
+	}
+
+	return &transfers[0], nil
+}
+
+// AcceptTransfer moves a transfer's clients into the receiving broker's book
+// Clients the giving broker deleted, merged or handed elsewhere in the meantime are skipped
+func (s *ClientTransferService) AcceptTransfer(id, brokerID string) (*models.ClientTransfer, error) {
+	transfer, err := s.getTransfer(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.ToBrokerID != brokerID {
+		return nil, fmt.Errorf("cannot accept a transfer offered to another broker")
+	}
+
+	movedIDs, err := s.transferRepo.Accept(transfer, brokerID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "cannot") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to accept client transfer: %w", err)
+	}
+
+	if len(movedIDs) > 0 {
+		s.pipelineService.PlaceTransferredClients(brokerID)
+		s.detectDuplicates(movedIDs)
+	}
+
+	s.notifyProposer(transfer, "client_transfer_accepted", "Client transfer accepted",
+		fmt.Sprintf("%s accepted %s.", transfer.ToBrokerName, clientCount(transfer.TransferredCount)))
+
+	return s.GetTransfer(transfer.ID, brokerID)
+}
+
+// DeclineTransfer turns down a transfer offered to the broker
+func (s *ClientTransferService) DeclineTransfer(id, brokerID string) (*models.ClientTransfer, error) {
+	transfer, err := s.getTransfer(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.ToBrokerID != brokerID {
+		return nil, fmt.Errorf("cannot decline a transfer offered to another broker")
+	}
+
+	if err := s.transferRepo.Respond(transfer.ID, "declined"); err != nil {
+		return nil, err
+	}
+
+	s.notifyProposer(transfer, "client_transfer_declined", "Client transfer declined",
+		fmt.Sprintf("%s declined %s.", transfer.ToBrokerName, clientCount(len(transfer.ClientIDs))))
+
+	return s.GetTransfer(transfer.ID, brokerID)
+}
+
+// CancelTransfer withdraws a pending transfer the user gives or proposed
+func (s *ClientTransferService) CancelTransfer(id, userID string) (*models.ClientTransfer, error) {
+	transfer, err := s.getTransfer(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	proposedBy := transfer.RequestedBy != nil && *transfer.RequestedBy == userID
+	if transfer.FromBrokerID != userID && !proposedBy {
+		return nil, fmt.Errorf("cannot cancel a transfer offered to you; decline it instead")
+	}
+
+	if err := s.transferRepo.Respond(transfer.ID, "cancelled"); err != nil {
+		return nil, err
+	}
+
+	err = s.notificationService.Notify(transfer.ToBrokerID, "client_transfer_cancelled", "Client transfer withdrawn",
+		fmt.Sprintf("The transfer of %s from %s was withdrawn.", clientCount(len(transfer.ClientIDs)), transfer.FromBrokerName),
+		NotifyOptions{InApp: true, EntityType: "client_transfer", EntityID: transfer.ID})
+	logNotifyError("client transfer", err)
+
+	return s.GetTransfer(transfer.ID, userID)
+}
+
+// GetOwnershipHistory retrieves a client's changes of broker with ownership verification
+func (s *ClientTransferService) GetOwnershipHistory(clientID, brokerID string) ([]models.ClientOwnershipChange, error) {
+	client, err := s.clientRepo.GetByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: client does not belong to this broker")
+	}
+
+	history, err := s.transferRepo.GetOwnershipHistory(clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client ownership history: %w", err)
+	}
+
+	return history, nil
+}
+
+// createTransfer validates and records a pending transfer, then asks the receiving broker to accept it
+func (s *ClientTransferService) createTransfer(fromBrokerID, toBrokerEmail string, clientIDs []string, message *string, requestedBy string) (*models.ClientTransfer, error) {
+	recipient, err := s.userRepo.GetUserByEmail(strings.TrimSpace(toBrokerEmail))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("invalid to_broker_email: no active broker has this email")
+		}
+		return nil, err
+	}
+	if !clientTransferRoles[recipient.Role] {
+		return nil, fmt.Errorf("invalid to_broker_email: no active broker has this email")
+	}
+	if recipient.ID == fromBrokerID {
+		return nil, fmt.Errorf("invalid to_broker_email: clients cannot be transferred to the broker who has them")
+	}
+
+	clientIDs = uniqueStrings(clientIDs)
+
+	clients, err := s.clientRepo.GetByIDs(clientIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clients: %w", err)
+	}
+	owned := 0
+	for _, client := range clients {
+		if client.BrokerID == fromBrokerID {
+			owned++
+		}
+	}
+	if owned != len(clientIDs) {
+		return nil, fmt.Errorf("invalid client_ids: %d of the clients were not found", len(clientIDs)-owned)
+	}
+
+	pending, err := s.transferRepo.GetPendingClientIDs(clientIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("cannot offer %s already offered in a pending transfer", clientCount(len(pending)))
+	}
+
+	transfer := &models.ClientTransfer{
+		FromBrokerID: fromBrokerID,
+		ToBrokerID:   recipient.ID,
+		RequestedBy:  &requestedBy,
+		ClientIDs:    clientIDs,
+		Message:      optionalText(message),
+	}
+
+	if err := s.transferRepo.Create(transfer); err != nil {
+		return nil, err
+	}
+
+	created, err := s.GetTransfer(transfer.ID, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf("%s would like to hand you %s. Accept the transfer to add them to your book.",
+		created.FromBrokerName, clientCount(len(clientIDs)))
+	if created.Message != nil {
+		body += "\n\n" + *created.Message
+	}
+	err = s.notificationService.Notify(recipient.ID, "client_transfer_requested", "Clients offered to you", body,
+		NotifyOptions{InApp: true, Email: true, EntityType: "client_transfer", EntityID: created.ID})
+	logNotifyError("client transfer", err)
+
+	return created, nil
+}
+
+// getTransfer retrieves a transfer the user gives, receives or proposed
+func (s *ClientTransferService) getTransfer(id, userID string) (*models.ClientTransfer, error) {
+	transfer, err := s.transferRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	proposedBy := transfer.RequestedBy != nil && *transfer.RequestedBy == userID
+	if transfer.FromBrokerID != userID && transfer.ToBrokerID != userID && !proposedBy {
+		return nil, fmt.Errorf("access denied: transfer does not involve this user")
+	}
+
+	return transfer, nil
+}
+
+// fillClients attaches the offered clients that still exist to each transfer
+func (s *ClientTransferService) fillClients(transfers []models.ClientTransfer) error {
+	clientIDs := []string{}
+	for _, transfer := range transfers {
+		clientIDs = append(clientIDs, transfer.ClientIDs...)
+	}
+	if len(clientIDs) == 0 {
+		return nil
+	}
+
+	clients, err := s.clientRepo.GetByIDs(uniqueStrings(clientIDs))
+	if err != nil {
+		return fmt.Errorf("failed to get transfer clients: %w", err)
+	}
+
+	byID := make(map[string]models.ClientTransferClient, len(clients))
+	for _, client := range clients {
+		byID[client.ID] = models.ClientTransferClient{
+			ID:        client.ID,
+			FirstName: client.FirstName,
+			LastName:  client.LastName,
+			Type:      client.Type,
+			Status:    client.Status,
+			City:      client.City,
+		}
+	}
+
+	for i := range transfers {
+		transfers[i].Clients = []models.ClientTransferClient{}
+		for _, id := range transfers[i].ClientIDs {
+			if client, ok := byID[id]; ok {
+				transfers[i].Clients = append(transfers[i].Clients, client)
+			}
+		}
+	}
+
+	return nil
+}
+
+// detectDuplicates looks for transferred clients the receiving broker already had
+// Detection is best-effort and never fails the transfer that triggered it
+func (s *ClientTransferService) detectDuplicates(clientIDs []string) {
+	clients, err := s.clientRepo.GetByIDs(clientIDs)
+	if err != nil {
+		log.Printf("Failed to load transferred clients for duplicate detection: %v", err)
+		return
+	}
+
+	for i := range clients {
+		if _, err := s.duplicateService.DetectForClient(&clients[i]); err != nil {
+			log.Printf("Failed to detect duplicates of transferred client %s: %v", clients[i].ID, err)
+		}
+	}
+}
+
+// notifyProposer tells the giving broker, and the admin who proposed the transfer if it was not them,
+// how the receiving broker responded
+func (s *ClientTransferService) notifyProposer(transfer *models.ClientTransfer, notificationType, title, message string) {
+	recipients := []string{transfer.FromBrokerID}
+	if transfer.RequestedBy != nil && *transfer.RequestedBy != transfer.FromBrokerID {
+		recipients = append(recipients, *transfer.RequestedBy)
+	}
+
+	for _, userID := range recipients {
+		err := s.notificationService.Notify(userID, notificationType, title, message, NotifyOptions{
+			InApp:      true,
+			EntityType: "client_transfer",
+			EntityID:   transfer.ID,
+		})
+		logNotifyError("client transfer", err)
+	}
+}
+
+// clientCount describes a number of clients, e.g. "1 client" or "3 clients"
+func clientCount(count int) string {
+	if count == 1 {
+		return "1 client"
+	}
+	return fmt.Sprintf("%d clients", count)
+}
+
+// uniqueStrings returns values without repeats, keeping the first occurrence of each
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,433 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+	"enfor-data-backend/internal/utils"
+)
+
+// ErrNoConsent is returned, wrapped with the reason, when a client may not be contacted on a channel
+// Its message starts with "cannot" so handlers report it as a conflict
+var ErrNoConsent = errors.New("cannot contact client")
+
+// optOutLinkTTL is how long the opt-out link in a client email keeps working
+const optOutLinkTTL = 365 * 24 * time.Hour
+
+// ConsentService handles clients' consent to be contacted and the broker's do-not-contact registry
+// Every message sent to a client goes through it, so contacts who have not consented or who opted out
+// are never messaged
+type ConsentService struct {
+	consentRepo *repository.ConsentRepository
+	clientRepo  *repository.ClientRepository
+	mailer      *utils.Mailer
+	signer      *utils.URLSigner
+	publicURL   string
+}
+
+// NewConsentService creates a new ConsentService instance
+// publicURL is the externally reachable base URL used to build opt-out links
+func NewConsentService(
+	consentRepo *repository.ConsentRepository,
+	clientRepo *repository.ClientRepository,
+	mailer *utils.Mailer,
+	signer *utils.URLSigner,
+	publicURL string,
+) *ConsentService {
+	return &ConsentService{
+		consentRepo: consentRepo,
+		clientRepo:  clientRepo,
+		mailer:      mailer,
+		signer:      signer,
+		publicURL:   strings.TrimRight(publicURL, "/"),
+	}
+}
+
+// GetClientConsents retrieves a client's current consent per channel and its history
+func (s *ConsentService) GetClientConsents(clientID, brokerID string) (*models.ClientConsents, error) {
+	client, err := s.ownedClient(clientID, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.clientConsents(client)
+}
+
+// RecordClientConsent records a client granting or withdrawing consent on some or all channels
+func (s *ConsentService) RecordClientConsent(clientID, brokerID string, req *models.RecordConsentRequest) (*models.ClientConsents, error) {
+	client, err := s.ownedClient(clientID, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := uniqueStrings(req.Channels)
+	if len(channels) == 0 {
+		channels = models.ConsentChannels
+	}
+
+	consents := []models.ContactConsent{}
+	for _, channel := range channels {
+		contact := clientContact(client, channel)
+		if contact == "" {
+			if len(req.Channels) == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("invalid channel %s: client has no %s to contact", channel, contactKind(channel))
+		}
+
+		consents = append(consents, models.ContactConsent{
+			BrokerID:   brokerID,
+			Channel:    channel,
+			Contact:    contact,
+			ClientID:   &client.ID,
+			Status:     req.Status,
+			Source:     req.Source,
+			Note:       optionalText(req.Note),
+			RecordedBy: &brokerID,
+		})
+	}
+
+	if len(consents) == 0 {
+		return nil, fmt.Errorf("invalid request: client has no phone number or email to record consent for")
+	}
+
+	if err := s.consentRepo.Record(consents); err != nil {
+		return nil, fmt.Errorf("failed to record consent: %w", err)
+	}
+
+	return s.clientConsents(client)
+}
+
+// AddToRegistry withdraws consent for a phone number and/or email address in the broker's book,
+// whether or not a client has it yet; a client added later with the contact starts out opted out
+func (s *ConsentService) AddToRegistry(brokerID string, req *models.DoNotContactRequest) ([]models.ContactConsent, error) {
+	phone := contactKey(models.ConsentChannelCall, req.Phone)
+	email := contactKey(models.ConsentChannelEmail, req.Email)
+
+	if strings.TrimSpace(req.Phone) != "" && phone == "" {
+		return nil, fmt.Errorf("invalid phone: %q is not a phone number", req.Phone)
+	}
+	if phone == "" && email == "" {
+		return nil, fmt.Errorf("invalid request: a phone number or email is required")
+	}
+
+	source := req.Source
+	if source == "" {
+		source = "client_request"
+	}
+
+	// Link the entries to the client with the contact, when there is one, for the client's history
+	var clientID *string
+	existing, err := s.clientRepo.FindByContact(brokerID, req.Phone, req.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up existing clients: %w", err)
+	}
+	if len(existing) > 0 {
+		clientID = &existing[0].ID
+	}
+
+	channels := uniqueStrings(req.Channels)
+	if len(channels) == 0 {
+		channels = models.ConsentChannels
+	}
+
+	consents := []models.ContactConsent{}
+	for _, channel := range channels {
+		contact := phone
+		if channel == models.ConsentChannelEmail {
+			contact = email
+		}
+		if contact == "" {
+			if len(req.Channels) == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("invalid channel %s: no %s given", channel, contactKind(channel))
+		}
+
+		consents = append(consents, models.ContactConsent{
+			BrokerID:   brokerID,
+			Channel:    channel,
+			Contact:    contact,
+			ClientID:   clientID,
+			Status:     "withdrawn",
+			Source:     source,
+			Note:       optionalText(req.Note),
+			RecordedBy: &brokerID,
+		})
+	}
+
+	if err := s.consentRepo.Record(consents); err != nil {
+		return nil, fmt.Errorf("failed to add to do-not-contact registry: %w", err)
+	}
+
+	return consents, nil
+}
+
+// GetRegistry retrieves the broker's do-not-contact registry
+func (s *ConsentService) GetRegistry(brokerID string, filters models.DoNotContactFilters) ([]models.ContactConsent, error) {
+	consents, err := s.consentRepo.GetWithdrawn(brokerID, filters.Channel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get do-not-contact registry: %w", err)
+	}
+
+	return consents, nil
+}
+
+// RecordLeadConsent records the channels a lead ticked on the broker's lead form
+// Contacts who withdrew consent on a channel stay withdrawn: a public form cannot undo an opt-out,
+// only the broker recording the client's consent again can
+// Failures are returned for the caller to log; the lead itself has already been saved
+func (s *ConsentService) RecordLeadConsent(client *models.Client, channels []string) error {
+	channels = uniqueStrings(channels)
+	historyChannels := []string{}
+	contacts := []string{}
+	for _, channel := range channels {
+		if contact := clientContact(client, channel); contact != "" {
+			historyChannels = append(historyChannels, channel)
+			contacts = append(contacts, contact)
+		}
+	}
+
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	history, err := s.consentRepo.GetHistory(client.BrokerID, historyChannels, contacts)
+	if err != nil {
+		return fmt.Errorf("failed to check consent: %w", err)
+	}
+
+	consents := leadConsents(client, channels, history)
+	if len(consents) == 0 {
+		return nil
+	}
+
+	return s.consentRepo.Record(consents)
+}
+
+// leadConsents builds the granted entries for the channels a lead ticked, skipping channels without a
+// contact and contacts whose latest entry on the channel, in the newest-first history, is withdrawn
+func leadConsents(client *models.Client, channels []string, history []models.ContactConsent) []models.ContactConsent {
+	consents := []models.ContactConsent{}
+	for _, channel := range channels {
+		contact := clientContact(client, channel)
+		if contact == "" {
+			continue
+		}
+
+		withdrawn := false
+		for i := range history {
+			if history[i].Channel == channel && history[i].Contact == contact {
+				withdrawn = history[i].Status == "withdrawn"
+				break
+			}
+		}
+		if withdrawn {
+			continue
+		}
+
+		consents = append(consents, models.ContactConsent{
+			BrokerID: client.BrokerID,
+			Channel:  channel,
+			Contact:  contact,
+			ClientID: &client.ID,
+			Status:   "granted",
+			Source:   "lead_form",
+		})
+	}
+
+	return consents
+}
+
+// RequireConsent checks that the client's latest consent on the channel is granted, returning ErrNoConsent when it is not
+func (s *ConsentService) RequireConsent(client *models.Client, channel string) error {
+	contact := clientContact(client, channel)
+	if contact == "" {
+		return fmt.Errorf("%w by %s: client has no %s", ErrNoConsent, channel, contactKind(channel))
+	}
+
+	history, err := s.consentRepo.GetHistory(client.BrokerID, []string{channel}, []string{contact})
+	if err != nil {
+		return fmt.Errorf("failed to check consent: %w", err)
+	}
+
+	switch {
+	case len(history) == 0:
+		return fmt.Errorf("%w by %s: no consent recorded", ErrNoConsent, channel)
+	case history[0].Status != "granted":
+		return fmt.Errorf("%w by %s: client opted out", ErrNoConsent, channel)
+	}
+
+	return nil
+}
+
+// EmailClient emails a client who has consented to email, adding a link to opt out of further messages
+func (s *ConsentService) EmailClient(client *models.Client, subject, body string) error {
+	if err := s.RequireConsent(client, models.ConsentChannelEmail); err != nil {
+		return err
+	}
+
+	body = strings.TrimRight(body, "\n") + "\n\n--\nTo stop receiving messages from your broker, open:\n" + s.optOutURL(client.ID) + "\n"
+	return s.mailer.Send(client.Email, subject, body)
+}
+
+// OpenOptOut verifies a signed opt-out link and returns the client it is for
+func (s *ConsentService) OpenOptOut(clientID, expires, signature string) (*models.Client, error) {
+	if err := s.signer.Verify(optOutResource(clientID), expires, signature); err != nil {
+		return nil, err
+	}
+
+	// Clients in the trash can still opt out, since they may be restored
+	client, err := s.clientRepo.GetByID(clientID)
+	if err != nil {
+		client, err = s.clientRepo.GetDeletedByID(clientID)
+		if err != nil {
+			return nil, fmt.Errorf("client not found")
+		}
+	}
+
+	return client, nil
+}
+
+// OptOut withdraws a client's consent on every channel through a signed opt-out link
+func (s *ConsentService) OptOut(clientID, expires, signature string) (*models.Client, error) {
+	client, err := s.OpenOptOut(clientID, expires, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	consents := []models.ContactConsent{}
+	for _, channel := range models.ConsentChannels {
+		if contact := clientContact(client, channel); contact != "" {
+			consents = append(consents, models.ContactConsent{
+				BrokerID: client.BrokerID,
+				Channel:  channel,
+				Contact:  contact,
+				ClientID: &client.ID,
+				Status:   "withdrawn",
+				Source:   "opt_out_link",
+			})
+		}
+	}
+
+	if err := s.consentRepo.Record(consents); err != nil {
+		return nil, fmt.Errorf("failed to record opt-out: %w", err)
+	}
+
+	return client, nil
+}
+
+// ownedClient retrieves a client with ownership verification
+func (s *ConsentService) ownedClient(clientID, brokerID string) (*models.Client, error) {
+	client, err := s.clientRepo.GetByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: client does not belong to this broker")
+	}
+
+	return client, nil
+}
+
+// clientConsents works out a client's current consent per channel from the log
+func (s *ConsentService) clientConsents(client *models.Client) (*models.ClientConsents, error) {
+	channels := []string{}
+	contacts := []string{}
+	for _, channel := range models.ConsentChannels {
+		if contact := clientContact(client, channel); contact != "" {
+			channels = append(channels, channel)
+			contacts = append(contacts, contact)
+		}
+	}
+
+	history, err := s.consentRepo.GetHistory(client.BrokerID, channels, contacts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consent history: %w", err)
+	}
+
+	consents := &models.ClientConsents{
+		ClientID: client.ID,
+		Channels: []models.ChannelConsent{},
+		History:  history,
+	}
+
+	for _, channel := range models.ConsentChannels {
+		current := models.ChannelConsent{
+			Channel: channel,
+			Contact: clientContact(client, channel),
+			Status:  "none",
+		}
+
+		// History is newest first, so the first entry for the channel is in force
+		for i := range history {
+			if history[i].Channel == channel {
+				current.Status = history[i].Status
+				current.Source = &history[i].Source
+				current.RecordedAt = &history[i].RecordedAt
+				break
+			}
+		}
+
+		consents.Channels = append(consents.Channels, current)
+	}
+
+	return consents, nil
+}
+
+// optOutURL builds the signed link a client follows to opt out of further messages
+func (s *ConsentService) optOutURL(clientID string) string {
+	expiresAt := time.Now().Add(optOutLinkTTL).Truncate(time.Second)
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", s.signer.Sign(optOutResource(clientID), expiresAt))
+
+	return s.publicURL + "/opt-out/" + clientID + "?" + query.Encode()
+}
+
+// optOutResource is the resource string signed into a client's opt-out link
+func optOutResource(clientID string) string {
+	return "opt-out/" + clientID
+}
+
+// clientContact returns the consent log key for the client's phone number or email on a channel
+func clientContact(client *models.Client, channel string) string {
+	if channel == models.ConsentChannelEmail {
+		return contactKey(channel, client.Email)
+	}
+	return contactKey(channel, client.Phone)
+}
+
+// contactKey turns a phone number or email into the form the consent log stores: the last 10 digits
+// of a phone number, matching how clients are matched by phone, or the lowercased email address
+// Returns "" when there is nothing usable to key on
+func contactKey(channel, value string) string {
+	if channel == models.ConsentChannelEmail {
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+
+	digits := phoneDigitsOnly(value)
+	if len(digits) < 7 {
+		return "" // extensions and partial numbers can't identify a contact
+	}
+	if len(digits) > 10 {
+		digits = digits[len(digits)-10:]
+	}
+	return digits
+}
+
+// contactKind names what a channel reaches a client on, for error messages
+func contactKind(channel string) string {
+	if channel == models.ConsentChannelEmail {
+		return "email"
+	}
+	return "phone number"
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"enfor-data-backend/internal/models"
+)
+
+func TestRequireConsentWithoutContact(t *testing.T) {
+	service := &ConsentService{}
+
+	tests := []struct {
+		name    string
+		client  models.Client
+		channel string
+		want    string
+	}{
+		{"no email", models.Client{Phone: "+919820012345"}, models.ConsentChannelEmail, "cannot contact client by email: client has no email"},
+		{"blank email", models.Client{Email: "  "}, models.ConsentChannelEmail, "cannot contact client by email: client has no email"},
+		{"no phone", models.Client{Email: "priya@example.com"}, models.ConsentChannelWhatsApp, "cannot contact client by whatsapp: client has no phone number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.RequireConsent(&tt.client, tt.channel)
+			if !errors.Is(err, ErrNoConsent) {
+				t.Fatalf("RequireConsent() error = %v, want ErrNoConsent", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("RequireConsent() error = %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLeadConsentsKeepWithdrawals(t *testing.T) {
+	client := &models.Client{ID: "client-1", BrokerID: "broker-1", Phone: "+91 98200 12345", Email: "Priya@example.com"}
+	channels := []string{models.ConsentChannelWhatsApp, models.ConsentChannelSMS, models.ConsentChannelEmail}
+
+	tests := []struct {
+		name    string
+		history []models.ContactConsent
+		want    []string
+	}{
+		{"new contact", nil, []string{"whatsapp", "sms", "email"}},
+		{
+			name: "opted out by link",
+			history: []models.ContactConsent{
+				{Channel: "email", Contact: "priya@example.com", Status: "withdrawn", Source: "opt_out_link"},
+				{Channel: "email", Contact: "priya@example.com", Status: "granted", Source: "lead_form"},
+			},
+			want: []string{"whatsapp", "sms"},
+		},
+		{
+			name: "on the do-not-contact registry",
+			history: []models.ContactConsent{
+				{Channel: "whatsapp", Contact: "9820012345", Status: "withdrawn", Source: "client_request"},
+				{Channel: "sms", Contact: "9820012345", Status: "withdrawn", Source: "client_request"},
+			},
+			want: []string{"email"},
+		},
+		{
+			name: "consent given again by the broker",
+			history: []models.ContactConsent{
+				{Channel: "email", Contact: "priya@example.com", Status: "granted", Source: "written"},
+				{Channel: "email", Contact: "priya@example.com", Status: "withdrawn", Source: "opt_out_link"},
+			},
+			want: []string{"whatsapp", "sms", "email"},
+		},
+		{
+			name: "withdrawn on another contact",
+			history: []models.ContactConsent{
+				{Channel: "email", Contact: "rahul@example.com", Status: "withdrawn", Source: "opt_out_link"},
+			},
+			want: []string{"whatsapp", "sms", "email"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consents := leadConsents(client, channels, tt.history)
+
+			got := []string{}
+			for _, consent := range consents {
+				if consent.Status != "granted" || consent.Source != "lead_form" {
+					t.Errorf("leadConsents() entry = %s/%s, want granted/lead_form", consent.Status, consent.Source)
+				}
+				got = append(got, consent.Channel)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("leadConsents() channels = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeadConsentsSkipsMissingContacts(t *testing.T) {
+	client := &models.Client{ID: "client-1", BrokerID: "broker-1", Phone: "+919820012345"}
+
+	consents := leadConsents(client, []string{models.ConsentChannelEmail, models.ConsentChannelCall}, nil)
+	if len(consents) != 1 || consents[0].Channel != models.ConsentChannelCall || consents[0].Contact != "9820012345" {
+		t.Errorf("leadConsents() = %+v, want one call entry for 9820012345", consents)
+	}
+}
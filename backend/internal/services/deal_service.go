@@ -0,0 +1,628 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// maxCommissionReportMonths caps how many months one commission report covers
+const maxCommissionReportMonths = 36
+
+// dealPartnerRoles are the roles that can share a deal's brokerage
+var dealPartnerRoles = map[string]bool{
+	"broker":          true,
+	"channel_partner": true,
+}
+
+// dealStatusLabels describe deal statuses on the client timeline
+var dealStatusLabels = map[string]string{
+	"token_paid":       "token paid",
+	"agreement_signed": "agreement signed",
+	"completed":        "completed",
+	"cancelled":        "cancelled",
+}
+
+// DealService handles deals, their brokerage milestones and commission reporting
+type DealService struct {
+	dealRepo            *repository.DealRepository
+	clientRepo          *repository.ClientRepository
+	propertyRepo        *repository.PropertyRepository
+	userRepo            *repository.UserRepository
+	activityService     *ActivityService
+	notificationService *NotificationService
+}
+
+// NewDealService creates a new DealService instance
+func NewDealService(
+	dealRepo *repository.DealRepository,
+	clientRepo *repository.ClientRepository,
+	propertyRepo *repository.PropertyRepository,
+	userRepo *repository.UserRepository,
+	activityService *ActivityService,
+	notificationService *NotificationService,
+) *DealService {
+	return &DealService{
+		dealRepo:            dealRepo,
+		clientRepo:          clientRepo,
+		propertyRepo:        propertyRepo,
+		userRepo:            userRepo,
+		activityService:     activityService,
+		notificationService: notificationService,
+	}
+}
+
+// CreateDeal records a deal for one of the broker's clients
+func (s *DealService) CreateDeal(req *models.CreateDealRequest, brokerID string) (*models.Deal, error) {
+	client, err := s.clientRepo.GetByID(req.ClientID)
+	if err != nil || client.BrokerID != brokerID {
+		return nil, fmt.Errorf("invalid client_id: client not found")
+	}
+
+	if err := s.checkProperty(req.PropertyID); err != nil {
+		return nil, err
+	}
+
+	partnerID, err := s.resolvePartner(req.PartnerEmail, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	deal := &models.Deal{
+		BrokerID:          brokerID,
+		ClientID:          &client.ID,
+		PropertyID:        req.PropertyID,
+		PartnerID:         partnerID,
+		AgreedPrice:       req.AgreedPrice,
+		TokenAmount:       req.TokenAmount,
+		TokenDate:         req.TokenDate,
+		BrokeragePercent:  req.BrokeragePercent,
+		Status:            "token_paid",
+		ExpectedCloseDate: req.ExpectedCloseDate,
+		Notes:             optionalText(req.Notes),
+	}
+	if req.PartnerSharePercent != nil {
+		deal.PartnerSharePercent = *req.PartnerSharePercent
+	}
+	if req.Status != nil {
+		deal.Status = *req.Status
+	}
+
+	switch {
+	case req.BrokerageAmount != nil:
+		deal.BrokerageAmount = *req.BrokerageAmount
+	case req.BrokeragePercent != nil:
+		deal.BrokerageAmount = percentOf(deal.AgreedPrice, *req.BrokeragePercent)
+	default:
+		return nil, fmt.Errorf("invalid request: brokerage_percent or brokerage_amount is required")
+	}
+
+	if err := checkDealPartner(deal); err != nil {
+		return nil, err
+	}
+	if deal.Status == "completed" {
+		deal.ClosedOn = todayDate()
+	}
+
+	// Without milestones the whole brokerage is due when the deal is expected to close
+	deal.Milestones = []models.DealMilestone{}
+	for _, milestone := range req.Milestones {
+		deal.Milestones = append(deal.Milestones, models.DealMilestone{
+			Name:    strings.TrimSpace(milestone.Name),
+			Amount:  milestone.Amount,
+			DueDate: milestone.DueDate,
+			Status:  "pending",
+		})
+	}
+	if len(deal.Milestones) == 0 && deal.BrokerageAmount > 0 {
+		deal.Milestones = append(deal.Milestones, models.DealMilestone{
+			Name:    "Brokerage",
+			Amount:  deal.BrokerageAmount,
+			DueDate: deal.ExpectedCloseDate,
+			Status:  "pending",
+		})
+	}
+	if err := checkMilestoneTotal(deal); err != nil {
+		return nil, err
+	}
+
+	if err := s.dealRepo.Create(deal); err != nil {
+		return nil, err
+	}
+
+	created, err := s.dealRepo.GetByID(deal.ID)
+	if err != nil {
+		return nil, err
+	}
+	fillCommission(created)
+
+	s.activityService.RecordDealEvent(created, dealSummary("Deal recorded", created), brokerID)
+	s.notifyPartner(created)
+
+	return created, nil
+}
+
+// GetDeals retrieves the deals the user owns or is the partner on
+func (s *DealService) GetDeals(userID string, filters models.DealFilters) ([]models.Deal, error) {
+	deals, err := s.dealRepo.GetForUser(userID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deals: %w", err)
+	}
+
+	for i := range deals {
+		fillCommission(&deals[i])
+	}
+
+	return deals, nil
+}
+
+// GetDeal retrieves a deal the user owns or is the partner on
+func (s *DealService) GetDeal(id, userID string) (*models.Deal, error) {
+	deal, err := s.dealRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if deal.BrokerID != userID && (deal.PartnerID == nil || *deal.PartnerID != userID) {
+		return nil, fmt.Errorf("access denied: deal does not belong to this user")
+	}
+
+	fillCommission(deal)
+	return deal, nil
+}
+
+// UpdateDeal changes a deal's terms or progress; only the deal's broker can change it
+func (s *DealService) UpdateDeal(id string, req *models.UpdateDealRequest, brokerID string) (*models.Deal, error) {
+	deal, err := s.ownedDeal(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousStatus := deal.Status
+	previousPartner := deal.PartnerID
+
+	if req.PropertyID != nil {
+		if err := s.checkProperty(req.PropertyID); err != nil {
+			return nil, err
+		}
+		deal.PropertyID = req.PropertyID
+	}
+	if req.PartnerEmail != nil {
+		partnerID, err := s.resolvePartner(req.PartnerEmail, brokerID)
+		if err != nil {
+			return nil, err
+		}
+		deal.PartnerID = partnerID
+		if partnerID == nil {
+			deal.PartnerSharePercent = 0
+		}
+	}
+
+	applyDealChanges(deal, req)
+
+	if err := checkDealPartner(deal); err != nil {
+		return nil, err
+	}
+	if err := checkMilestoneTotal(deal); err != nil {
+		return nil, err
+	}
+
+	if err := s.dealRepo.Update(deal); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.GetDeal(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated.Status != previousStatus {
+		s.activityService.RecordDealEvent(updated, dealSummary("Deal "+dealStatusLabels[updated.Status], updated), brokerID)
+	}
+	if updated.PartnerID != nil && (previousPartner == nil || *previousPartner != *updated.PartnerID) {
+		s.notifyPartner(updated)
+	}
+
+	return updated, nil
+}
+
+// DeleteDeal deletes a deal and its milestones; only the deal's broker can delete it
+func (s *DealService) DeleteDeal(id, brokerID string) error {
+	if _, err := s.ownedDeal(id, brokerID); err != nil {
+		return err
+	}
+
+	if err := s.dealRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete deal: %w", err)
+	}
+
+	return nil
+}
+
+// AddMilestone adds a brokerage installment to a deal
+func (s *DealService) AddMilestone(dealID string, req *models.DealMilestoneRequest, brokerID string) (*models.Deal, error) {
+	deal, err := s.ownedDeal(dealID, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	milestone := models.DealMilestone{
+		DealID:  deal.ID,
+		Name:    strings.TrimSpace(req.Name),
+		Amount:  req.Amount,
+		DueDate: req.DueDate,
+		Status:  "pending",
+	}
+
+	deal.Milestones = append(deal.Milestones, milestone)
+	if err := checkMilestoneTotal(deal); err != nil {
+		return nil, err
+	}
+
+	if err := s.dealRepo.CreateMilestone(&milestone); err != nil {
+		return nil, err
+	}
+
+	return s.GetDeal(dealID, brokerID)
+}
+
+// UpdateMilestone changes a brokerage installment or records it as received
+func (s *DealService) UpdateMilestone(dealID, milestoneID string, req *models.UpdateDealMilestoneRequest, brokerID string) (*models.Deal, error) {
+	deal, err := s.ownedDeal(dealID, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var milestone *models.DealMilestone
+	for i := range deal.Milestones {
+		if deal.Milestones[i].ID == milestoneID {
+			milestone = &deal.Milestones[i]
+		}
+	}
+	if milestone == nil {
+		return nil, fmt.Errorf("deal milestone not found")
+	}
+
+	if req.Name != nil {
+		milestone.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Amount != nil {
+		milestone.Amount = *req.Amount
+	}
+	if req.DueDate != nil {
+		milestone.DueDate = req.DueDate
+	}
+	if req.Status != nil {
+		milestone.Status = *req.Status
+	}
+
+	switch {
+	case milestone.Status == "pending":
+		if req.ReceivedOn != nil {
+			return nil, fmt.Errorf("invalid received_on: the milestone has not been received")
+		}
+		milestone.ReceivedOn = nil
+	case req.ReceivedOn != nil:
+		milestone.ReceivedOn = req.ReceivedOn
+	case milestone.ReceivedOn == nil:
+		milestone.ReceivedOn = todayDate()
+	}
+
+	if err := checkMilestoneTotal(deal); err != nil {
+		return nil, err
+	}
+
+	if err := s.dealRepo.UpdateMilestone(milestone); err != nil {
+		return nil, err
+	}
+
+	return s.GetDeal(dealID, brokerID)
+}
+
+// DeleteMilestone removes a brokerage installment from a deal
+func (s *DealService) DeleteMilestone(dealID, milestoneID, brokerID string) (*models.Deal, error) {
+	if _, err := s.ownedDeal(dealID, brokerID); err != nil {
+		return nil, err
+	}
+
+	if err := s.dealRepo.DeleteMilestone(dealID, milestoneID); err != nil {
+		return nil, err
+	}
+
+	return s.GetDeal(dealID, brokerID)
+}
+
+// GetCommissionReport totals the user's share of brokerage received and still due, month by month
+// The user's share is what remains after the partner's share on their own deals, and the partner's
+// share on deals they partner on. Installments without a due date fall due when the deal is expected
+// to close, as does any brokerage not yet split into installments
+func (s *DealService) GetCommissionReport(userID string, req *models.CommissionReportRequest) (*models.CommissionReport, error) {
+	months, err := commissionMonths(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	deals, err := s.dealRepo.GetForUser(userID, models.DealFilters{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deals: %w", err)
+	}
+
+	return commissionReport(userID, months, deals), nil
+}
+
+// ownedDeal retrieves a deal the broker owns; partners can see a deal but not change it
+func (s *DealService) ownedDeal(id, brokerID string) (*models.Deal, error) {
+	deal, err := s.GetDeal(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deal.BrokerID != brokerID {
+		return nil, fmt.Errorf("forbidden: only the deal's broker can change it")
+	}
+
+	return deal, nil
+}
+
+// checkProperty verifies a deal's property exists; it may be another broker's listing
+func (s *DealService) checkProperty(propertyID *string) error {
+	if propertyID == nil {
+		return nil
+	}
+
+	if _, err := s.propertyRepo.GetByID(*propertyID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return fmt.Errorf("invalid property_id: property not found")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// resolvePartner looks up the co-broker or channel partner named by email; an empty email means none
+func (s *DealService) resolvePartner(email *string, brokerID string) (*string, error) {
+	if email == nil || strings.TrimSpace(*email) == "" {
+		return nil, nil
+	}
+
+	partner, err := s.userRepo.GetUserByEmail(strings.TrimSpace(*email))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("invalid partner_email: no active broker or channel partner has this email")
+		}
+		return nil, err
+	}
+	if !dealPartnerRoles[partner.Role] {
+		return nil, fmt.Errorf("invalid partner_email: no active broker or channel partner has this email")
+	}
+	if partner.ID == brokerID {
+		return nil, fmt.Errorf("invalid partner_email: the deal's broker cannot be its partner")
+	}
+
+	return &partner.ID, nil
+}
+
+// notifyPartner tells a deal's partner they have been added to it
+func (s *DealService) notifyPartner(deal *models.Deal) {
+	if deal.PartnerID == nil {
+		return
+	}
+
+	message := fmt.Sprintf("%s added you to a deal at ₹%.0f with a %.2f%% share of the ₹%.0f brokerage.",
+		deal.BrokerName, deal.AgreedPrice, deal.PartnerSharePercent, deal.BrokerageAmount)
+	err := s.notificationService.Notify(*deal.PartnerID, "deal_partner", "You were added to a deal", message, NotifyOptions{
+		InApp:      true,
+		Email:      true,
+		EntityType: "deal",
+		EntityID:   deal.ID,
+	})
+	logNotifyError("deal partner", err)
+}
+
+// applyDealChanges applies the terms and progress in an update request to a deal
+// The property and partner are looked up by the caller
+func applyDealChanges(deal *models.Deal, req *models.UpdateDealRequest) {
+	if req.PartnerSharePercent != nil {
+		deal.PartnerSharePercent = *req.PartnerSharePercent
+	}
+	if req.AgreedPrice != nil {
+		deal.AgreedPrice = *req.AgreedPrice
+	}
+	if req.TokenAmount != nil {
+		deal.TokenAmount = req.TokenAmount
+	}
+	if req.TokenDate != nil {
+		deal.TokenDate = req.TokenDate
+	}
+	if req.BrokeragePercent != nil {
+		deal.BrokeragePercent = req.BrokeragePercent
+	}
+
+	// An explicit amount replaces a percentage brokerage; otherwise a percentage follows the price
+	switch {
+	case req.BrokerageAmount != nil:
+		deal.BrokerageAmount = *req.BrokerageAmount
+		if req.BrokeragePercent == nil {
+			deal.BrokeragePercent = nil
+		}
+	case deal.BrokeragePercent != nil && (req.BrokeragePercent != nil || req.AgreedPrice != nil):
+		deal.BrokerageAmount = percentOf(deal.AgreedPrice, *deal.BrokeragePercent)
+	}
+
+	if req.Status != nil {
+		deal.Status = *req.Status
+	}
+	if req.ExpectedCloseDate != nil {
+		deal.ExpectedCloseDate = req.ExpectedCloseDate
+	}
+
+	// Completing a deal closes it today unless a date is given; reopening or cancelling it clears the date
+	switch {
+	case req.ClosedOn != nil:
+		deal.ClosedOn = req.ClosedOn
+	case deal.Status != "completed":
+		deal.ClosedOn = nil
+	case deal.ClosedOn == nil:
+		deal.ClosedOn = todayDate()
+	}
+
+	if req.Notes != nil {
+		deal.Notes = optionalText(req.Notes) // empty notes clear them
+	}
+}
+
+// checkDealPartner rejects a partner share without a partner
+func checkDealPartner(deal *models.Deal) error {
+	if deal.PartnerID == nil && deal.PartnerSharePercent > 0 {
+		return fmt.Errorf("invalid partner_share_percent: the deal has no partner")
+	}
+	return nil
+}
+
+// checkMilestoneTotal rejects installments that add up to more than the brokerage
+func checkMilestoneTotal(deal *models.Deal) error {
+	total := 0.0
+	for _, milestone := range deal.Milestones {
+		total += milestone.Amount
+	}
+
+	if total > deal.BrokerageAmount+0.005 {
+		return fmt.Errorf("invalid milestones: installments total ₹%.2f, more than the ₹%.2f brokerage", total, deal.BrokerageAmount)
+	}
+	return nil
+}
+
+// commissionMonths lists the months a commission report covers, from req or the six months up to now
+func commissionMonths(req *models.CommissionReportRequest, now time.Time) ([]models.CommissionMonth, error) {
+	to := now
+	if req.To != "" {
+		to, _ = time.Parse("2006-01", req.To)
+	}
+	from := time.Date(to.Year(), to.Month()-5, 1, 0, 0, 0, 0, time.UTC)
+	if req.From != "" {
+		from, _ = time.Parse("2006-01", req.From)
+	}
+
+	months := []models.CommissionMonth{}
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); month.Format("2006-01") <= to.Format("2006-01"); month = month.AddDate(0, 1, 0) {
+		months = append(months, models.CommissionMonth{Month: month.Format("2006-01")})
+	}
+	if len(months) == 0 {
+		return nil, fmt.Errorf("invalid range: from must not be after to")
+	}
+	if len(months) > maxCommissionReportMonths {
+		return nil, fmt.Errorf("invalid range: a report covers at most %d months", maxCommissionReportMonths)
+	}
+
+	return months, nil
+}
+
+// commissionReport totals the user's share of the deals' brokerage over the given months
+func commissionReport(userID string, months []models.CommissionMonth, deals []models.Deal) *models.CommissionReport {
+	index := make(map[string]int)
+	for i, month := range months {
+		index[month.Month] = i
+	}
+
+	report := &models.CommissionReport{
+		From:   months[0].Month,
+		To:     months[len(months)-1].Month,
+		Months: months,
+	}
+
+	for i := range deals {
+		deal := &deals[i]
+		share := (100 - deal.PartnerSharePercent) / 100
+		if deal.BrokerID != userID {
+			share = deal.PartnerSharePercent / 100
+		}
+
+		// pending adds an amount still due in the month of date, or to the unscheduled total
+		pending := func(amount float64, date *string) {
+			if date == nil {
+				report.Unscheduled += amount * share
+			} else if m, ok := index[(*date)[:7]]; ok {
+				report.Months[m].Pending += amount * share
+			}
+		}
+
+		// Money received counts even on deals that were later cancelled
+		scheduled := 0.0
+		for _, milestone := range deal.Milestones {
+			scheduled += milestone.Amount
+			switch {
+			case milestone.Status == "received" && milestone.ReceivedOn != nil:
+				if m, ok := index[(*milestone.ReceivedOn)[:7]]; ok {
+					report.Months[m].Earned += milestone.Amount * share
+				}
+			case deal.Status != "cancelled" && milestone.DueDate != nil:
+				pending(milestone.Amount, milestone.DueDate)
+			case deal.Status != "cancelled":
+				pending(milestone.Amount, deal.ExpectedCloseDate)
+			}
+		}
+
+		if remainder := deal.BrokerageAmount - scheduled; remainder > 0.005 && deal.Status != "cancelled" {
+			pending(remainder, deal.ExpectedCloseDate)
+		}
+	}
+
+	for i := range report.Months {
+		month := &report.Months[i]
+		month.Earned = roundMoney(month.Earned)
+		month.Pending = roundMoney(month.Pending)
+		report.Earned += month.Earned
+		report.Pending += month.Pending
+	}
+	report.Earned = roundMoney(report.Earned)
+	report.Pending = roundMoney(report.Pending)
+	report.Unscheduled = roundMoney(report.Unscheduled)
+
+	return report
+}
+
+// fillCommission works out how much of a deal's brokerage has been received and how much is still due
+func fillCommission(deal *models.Deal) {
+	received := 0.0
+	for _, milestone := range deal.Milestones {
+		if milestone.Status == "received" {
+			received += milestone.Amount
+		}
+	}
+
+	deal.CommissionReceived = roundMoney(received)
+	deal.CommissionPending = 0
+	if deal.Status != "cancelled" && deal.BrokerageAmount > received {
+		deal.CommissionPending = roundMoney(deal.BrokerageAmount - received)
+	}
+}
+
+// dealSummary describes a deal for the client timeline
+func dealSummary(prefix string, deal *models.Deal) string {
+	summary := fmt.Sprintf("%s at ₹%.0f", prefix, deal.AgreedPrice)
+	if deal.PropertyTitle != nil {
+		summary += " for " + *deal.PropertyTitle
+	}
+	return summary
+}
+
+// percentOf returns percent of amount rounded to the paisa
+func percentOf(amount, percent float64) float64 {
+	return roundMoney(amount * percent / 100)
+}
+
+// roundMoney rounds an amount to the paisa
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// todayDate returns today's date as YYYY-MM-DD
+func todayDate() *string {
+	today := time.Now().Format("2006-01-02")
+	return &today
+}
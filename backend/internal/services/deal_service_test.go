@@ -0,0 +1,402 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"enfor-data-backend/internal/models"
+)
+
+func TestApplyDealChangesBrokerage(t *testing.T) {
+	tests := []struct {
+		name        string
+		percent     *float64
+		amount      float64
+		req         models.UpdateDealRequest
+		wantPercent *float64
+		wantAmount  float64
+	}{
+		{
+			name:        "percentage follows a new price",
+			percent:     floatPtr(2),
+			amount:      200000,
+			req:         models.UpdateDealRequest{AgreedPrice: floatPtr(12500000)},
+			wantPercent: floatPtr(2),
+			wantAmount:  250000,
+		},
+		{
+			name:        "new percentage is applied to the price",
+			percent:     floatPtr(2),
+			amount:      200000,
+			req:         models.UpdateDealRequest{BrokeragePercent: floatPtr(1.5)},
+			wantPercent: floatPtr(1.5),
+			wantAmount:  150000,
+		},
+		{
+			name:       "flat brokerage ignores a new price",
+			amount:     175000,
+			req:        models.UpdateDealRequest{AgreedPrice: floatPtr(12500000)},
+			wantAmount: 175000,
+		},
+		{
+			name:       "flat amount replaces a percentage",
+			percent:    floatPtr(2),
+			amount:     200000,
+			req:        models.UpdateDealRequest{BrokerageAmount: floatPtr(180000)},
+			wantAmount: 180000,
+		},
+		{
+			name:        "percentage replaces a flat amount",
+			amount:      175000,
+			req:         models.UpdateDealRequest{BrokeragePercent: floatPtr(1)},
+			wantPercent: floatPtr(1),
+			wantAmount:  100000,
+		},
+		{
+			name:        "amount given with a percentage is kept as is",
+			percent:     floatPtr(2),
+			amount:      200000,
+			req:         models.UpdateDealRequest{BrokeragePercent: floatPtr(2), BrokerageAmount: floatPtr(190000)},
+			wantPercent: floatPtr(2),
+			wantAmount:  190000,
+		},
+		{
+			name:        "percentage rounds to the paisa",
+			percent:     floatPtr(2),
+			amount:      200000,
+			req:         models.UpdateDealRequest{AgreedPrice: floatPtr(3333333)},
+			wantPercent: floatPtr(2),
+			wantAmount:  66666.66,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deal := &models.Deal{
+				AgreedPrice:      10000000,
+				BrokeragePercent: tt.percent,
+				BrokerageAmount:  tt.amount,
+				Status:           "token_paid",
+			}
+
+			applyDealChanges(deal, &tt.req)
+
+			if deal.BrokerageAmount != tt.wantAmount {
+				t.Errorf("brokerage_amount = %v, want %v", deal.BrokerageAmount, tt.wantAmount)
+			}
+			switch {
+			case tt.wantPercent == nil && deal.BrokeragePercent != nil:
+				t.Errorf("brokerage_percent = %v, want none", *deal.BrokeragePercent)
+			case tt.wantPercent != nil && (deal.BrokeragePercent == nil || *deal.BrokeragePercent != *tt.wantPercent):
+				t.Errorf("brokerage_percent = %v, want %v", deal.BrokeragePercent, *tt.wantPercent)
+			}
+		})
+	}
+}
+
+func TestApplyDealChangesClosedOn(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+
+	tests := []struct {
+		name     string
+		status   string
+		closedOn *string
+		req      models.UpdateDealRequest
+		want     *string
+	}{
+		{
+			name:   "completing closes the deal today",
+			status: "agreement_signed",
+			req:    models.UpdateDealRequest{Status: stringPtr("completed")},
+			want:   &today,
+		},
+		{
+			name:   "completing on a given date",
+			status: "agreement_signed",
+			req:    models.UpdateDealRequest{Status: stringPtr("completed"), ClosedOn: stringPtr("2026-09-30")},
+			want:   stringPtr("2026-09-30"),
+		},
+		{
+			name:     "completed deal keeps its date",
+			status:   "completed",
+			closedOn: stringPtr("2026-09-30"),
+			req:      models.UpdateDealRequest{Notes: stringPtr("Registration done")},
+			want:     stringPtr("2026-09-30"),
+		},
+		{
+			name:     "reopening clears the date",
+			status:   "completed",
+			closedOn: stringPtr("2026-09-30"),
+			req:      models.UpdateDealRequest{Status: stringPtr("agreement_signed")},
+		},
+		{
+			name:     "cancelling clears the date",
+			status:   "completed",
+			closedOn: stringPtr("2026-09-30"),
+			req:      models.UpdateDealRequest{Status: stringPtr("cancelled")},
+		},
+		{
+			name:   "open deal stays open",
+			status: "token_paid",
+			req:    models.UpdateDealRequest{Status: stringPtr("agreement_signed")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deal := &models.Deal{
+				AgreedPrice:     10000000,
+				BrokerageAmount: 200000,
+				Status:          tt.status,
+				ClosedOn:        tt.closedOn,
+			}
+
+			applyDealChanges(deal, &tt.req)
+
+			switch {
+			case tt.want == nil && deal.ClosedOn != nil:
+				t.Errorf("closed_on = %s, want none", *deal.ClosedOn)
+			case tt.want != nil && (deal.ClosedOn == nil || *deal.ClosedOn != *tt.want):
+				t.Errorf("closed_on = %v, want %s", deal.ClosedOn, *tt.want)
+			}
+		})
+	}
+}
+
+func TestFillCommission(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       string
+		milestones   []models.DealMilestone
+		wantReceived float64
+		wantPending  float64
+	}{
+		{
+			name:        "nothing received",
+			status:      "token_paid",
+			milestones:  []models.DealMilestone{{Amount: 200000, Status: "pending"}},
+			wantPending: 200000,
+		},
+		{
+			name:   "part received",
+			status: "agreement_signed",
+			milestones: []models.DealMilestone{
+				{Amount: 50000, Status: "received", ReceivedOn: stringPtr("2026-08-10")},
+				{Amount: 150000, Status: "pending"},
+			},
+			wantReceived: 50000,
+			wantPending:  150000,
+		},
+		{
+			name:   "brokerage not yet split into installments is still due",
+			status: "agreement_signed",
+			milestones: []models.DealMilestone{
+				{Amount: 50000, Status: "received", ReceivedOn: stringPtr("2026-08-10")},
+			},
+			wantReceived: 50000,
+			wantPending:  150000,
+		},
+		{
+			name:   "fully received",
+			status: "completed",
+			milestones: []models.DealMilestone{
+				{Amount: 80000.005, Status: "received", ReceivedOn: stringPtr("2026-08-10")},
+				{Amount: 119999.995, Status: "received", ReceivedOn: stringPtr("2026-09-10")},
+			},
+			wantReceived: 200000,
+		},
+		{
+			name:   "cancelled deal has nothing pending",
+			status: "cancelled",
+			milestones: []models.DealMilestone{
+				{Amount: 50000, Status: "received", ReceivedOn: stringPtr("2026-08-10")},
+				{Amount: 150000, Status: "pending"},
+			},
+			wantReceived: 50000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deal := &models.Deal{BrokerageAmount: 200000, Status: tt.status, Milestones: tt.milestones}
+
+			fillCommission(deal)
+
+			if deal.CommissionReceived != tt.wantReceived || deal.CommissionPending != tt.wantPending {
+				t.Errorf("fillCommission() received %v and pending %v, want %v and %v",
+					deal.CommissionReceived, deal.CommissionPending, tt.wantReceived, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestCommissionMonths(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		req       models.CommissionReportRequest
+		wantFirst string
+		wantLast  string
+		wantCount int
+		wantErr   string
+	}{
+		{name: "defaults to the last six months", wantFirst: "2026-05", wantLast: "2026-10", wantCount: 6},
+		{name: "six months up to the given month", req: models.CommissionReportRequest{To: "2026-02"}, wantFirst: "2025-09", wantLast: "2026-02", wantCount: 6},
+		{name: "across a year end", req: models.CommissionReportRequest{From: "2025-11", To: "2026-02"}, wantFirst: "2025-11", wantLast: "2026-02", wantCount: 4},
+		{name: "single month", req: models.CommissionReportRequest{From: "2026-03", To: "2026-03"}, wantFirst: "2026-03", wantLast: "2026-03", wantCount: 1},
+		{name: "longest report", req: models.CommissionReportRequest{From: "2024-01", To: "2026-12"}, wantFirst: "2024-01", wantLast: "2026-12", wantCount: 36},
+		{name: "too long", req: models.CommissionReportRequest{From: "2024-01", To: "2027-01"}, wantErr: "invalid range: a report covers at most 36 months"},
+		{name: "from after to", req: models.CommissionReportRequest{From: "2026-04", To: "2026-03"}, wantErr: "invalid range: from must not be after to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			months, err := commissionMonths(&tt.req, now)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("commissionMonths() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("commissionMonths() error = %v", err)
+			}
+
+			if len(months) != tt.wantCount || months[0].Month != tt.wantFirst || months[len(months)-1].Month != tt.wantLast {
+				t.Errorf("commissionMonths() = %d months from %s to %s, want %d from %s to %s",
+					len(months), months[0].Month, months[len(months)-1].Month, tt.wantCount, tt.wantFirst, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestCommissionReport(t *testing.T) {
+	const userID = "broker-1"
+
+	received := func(amount float64, on string) models.DealMilestone {
+		return models.DealMilestone{Amount: amount, Status: "received", ReceivedOn: &on}
+	}
+	due := func(amount float64, on *string) models.DealMilestone {
+		return models.DealMilestone{Amount: amount, Status: "pending", DueDate: on}
+	}
+
+	tests := []struct {
+		name            string
+		deal            models.Deal
+		wantEarned      map[string]float64
+		wantPending     map[string]float64
+		wantUnscheduled float64
+	}{
+		{
+			name: "received on the last and first day of a month",
+			deal: models.Deal{
+				BrokerID: userID, BrokerageAmount: 200000, Status: "completed",
+				Milestones: []models.DealMilestone{received(80000, "2026-03-31"), received(120000, "2026-04-01")},
+			},
+			wantEarned: map[string]float64{"2026-03": 80000, "2026-04": 120000},
+		},
+		{
+			name: "received outside the report is left out",
+			deal: models.Deal{
+				BrokerID: userID, BrokerageAmount: 200000, Status: "completed",
+				Milestones: []models.DealMilestone{received(50000, "2026-02-28"), received(150000, "2026-06-01")},
+			},
+		},
+		{
+			name: "percentage brokerage due on the expected close date",
+			deal: models.Deal{
+				BrokerID: userID, BrokeragePercent: floatPtr(2), BrokerageAmount: 250000, Status: "token_paid",
+				ExpectedCloseDate: stringPtr("2026-05-31"),
+				Milestones:        []models.DealMilestone{due(250000, nil)},
+			},
+			wantPending: map[string]float64{"2026-05": 250000},
+		},
+		{
+			name: "installments due in their own months",
+			deal: models.Deal{
+				BrokerID: userID, BrokerageAmount: 300000, Status: "agreement_signed",
+				ExpectedCloseDate: stringPtr("2026-05-15"),
+				Milestones:        []models.DealMilestone{due(100000, stringPtr("2026-03-01")), due(100000, stringPtr("2026-04-30")), due(100000, nil)},
+			},
+			wantPending: map[string]float64{"2026-03": 100000, "2026-04": 100000, "2026-05": 100000},
+		},
+		{
+			name: "brokerage not split into installments",
+			deal: models.Deal{
+				BrokerID: userID, BrokerageAmount: 175000, Status: "agreement_signed",
+				ExpectedCloseDate: stringPtr("2026-04-10"),
+				Milestones:        []models.DealMilestone{received(75000, "2026-03-05")},
+			},
+			wantEarned:  map[string]float64{"2026-03": 75000},
+			wantPending: map[string]float64{"2026-04": 100000},
+		},
+		{
+			name: "no expected close date",
+			deal: models.Deal{
+				BrokerID: userID, BrokerageAmount: 175000, Status: "token_paid",
+				Milestones: []models.DealMilestone{due(175000, nil)},
+			},
+			wantUnscheduled: 175000,
+		},
+		{
+			name: "co-broker's share is taken off",
+			deal: models.Deal{
+				BrokerID: userID, PartnerID: stringPtr("partner-1"), PartnerSharePercent: 25,
+				BrokerageAmount: 200000, Status: "agreement_signed", ExpectedCloseDate: stringPtr("2026-05-20"),
+				Milestones: []models.DealMilestone{received(100000, "2026-03-15"), due(100000, nil)},
+			},
+			wantEarned:  map[string]float64{"2026-03": 75000},
+			wantPending: map[string]float64{"2026-05": 75000},
+		},
+		{
+			name: "partner earns their share of another broker's deal",
+			deal: models.Deal{
+				BrokerID: "broker-2", PartnerID: stringPtr(userID), PartnerSharePercent: 33.33,
+				BrokerageAmount: 100000, Status: "completed",
+				Milestones: []models.DealMilestone{received(100000, "2026-04-20")},
+			},
+			wantEarned: map[string]float64{"2026-04": 33330},
+		},
+		{
+			name: "cancelled deal keeps what was received and drops what is due",
+			deal: models.Deal{
+				BrokerID: userID, BrokerageAmount: 200000, Status: "cancelled",
+				ExpectedCloseDate: stringPtr("2026-05-01"),
+				Milestones:        []models.DealMilestone{received(50000, "2026-03-20"), due(100000, stringPtr("2026-04-01"))},
+			},
+			wantEarned: map[string]float64{"2026-03": 50000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			months, err := commissionMonths(&models.CommissionReportRequest{From: "2026-03", To: "2026-05"}, time.Now())
+			if err != nil {
+				t.Fatalf("commissionMonths() error = %v", err)
+			}
+
+			report := commissionReport(userID, months, []models.Deal{tt.deal})
+
+			wantEarned, wantPending := 0.0, 0.0
+			for _, month := range report.Months {
+				if month.Earned != tt.wantEarned[month.Month] {
+					t.Errorf("%s earned = %v, want %v", month.Month, month.Earned, tt.wantEarned[month.Month])
+				}
+				if month.Pending != tt.wantPending[month.Month] {
+					t.Errorf("%s pending = %v, want %v", month.Month, month.Pending, tt.wantPending[month.Month])
+				}
+				wantEarned += tt.wantEarned[month.Month]
+				wantPending += tt.wantPending[month.Month]
+			}
+
+			if report.Earned != wantEarned || report.Pending != wantPending || report.Unscheduled != tt.wantUnscheduled {
+				t.Errorf("report totals earned %v, pending %v, unscheduled %v; want %v, %v, %v",
+					report.Earned, report.Pending, report.Unscheduled, wantEarned, wantPending, tt.wantUnscheduled)
+			}
+			if report.From != "2026-03" || report.To != "2026-05" {
+				t.Errorf("report covers %s to %s, want 2026-03 to 2026-05", report.From, report.To)
+			}
+		})
+	}
+}
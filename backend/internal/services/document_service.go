@@ -0,0 +1,362 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+	"enfor-data-backend/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// Lifetimes of signed document download URLs
+const (
+	documentDownloadTTL   = 15 * time.Minute   // links opened from the app
+	clientDocumentLinkTTL = 7 * 24 * time.Hour // links emailed to clients
+)
+
+// Scopes signed into document download URLs
+// Client-scoped links stop working once the document is no longer shareable
+const (
+	documentScopeBroker = "broker"
+	documentScopeClient = "client"
+)
+
+// documentSniffBytes is how much of a file http.DetectContentType inspects
+const documentSniffBytes = 512
+
+// documentExtensions maps the sniffed content types accepted in the vault to stored file extensions
+var documentExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+}
+
+// DocumentService handles the property and client documents vault
+type DocumentService struct {
+	documentRepo   *repository.DocumentRepository
+	propertyRepo   *repository.PropertyRepository
+	clientRepo     *repository.ClientRepository
+	userRepo       *repository.UserRepository
+	consentService *ConsentService
+	signer         *utils.URLSigner
+	publicURL      string
+	storagePath    string
+	maxFileSize    int64
+}
+
+// NewDocumentService creates a new DocumentService instance
+// storagePath must not be publicly served; files are only reachable through signed download URLs
+func NewDocumentService(
+	documentRepo *repository.DocumentRepository,
+	propertyRepo *repository.PropertyRepository,
+	clientRepo *repository.ClientRepository,
+	userRepo *repository.UserRepository,
+	consentService *ConsentService,
+	signer *utils.URLSigner,
+	publicURL string,
+	storagePath string,
+	maxFileSize int64,
+) *DocumentService {
+	return &DocumentService{
+		documentRepo:   documentRepo,
+		propertyRepo:   propertyRepo,
+		clientRepo:     clientRepo,
+		userRepo:       userRepo,
+		consentService: consentService,
+		signer:         signer,
+		publicURL:      strings.TrimRight(publicURL, "/"),
+		storagePath:    storagePath,
+		maxFileSize:    maxFileSize,
+	}
+}
+
+// MaxFileSize returns the largest document the vault accepts, in bytes
+func (s *DocumentService) MaxFileSize() int64 {
+	return s.maxFileSize
+}
+
+// UploadDocument validates and stores an uploaded file and files it under a property and/or client
+func (s *DocumentService) UploadDocument(req *models.UploadDocumentRequest, file io.Reader, originalName, brokerID string) (*models.Document, error) {
+	if req.PropertyID == "" && req.ClientID == "" {
+		return nil, fmt.Errorf("invalid document: property_id or client_id is required")
+	}
+
+	document := &models.Document{
+		BrokerID:     brokerID,
+		DocumentType: req.DocumentType,
+		Title:        strings.TrimSpace(req.Title),
+		Visibility:   req.Visibility,
+		OriginalName: filepath.Base(originalName),
+	}
+	if document.Title == "" {
+		document.Title = document.OriginalName
+	}
+	if document.Visibility == "" {
+		document.Visibility = "private"
+	}
+
+	if req.PropertyID != "" {
+		property, err := s.propertyRepo.GetByID(req.PropertyID)
+		if err != nil || property.BrokerID != brokerID {
+			return nil, fmt.Errorf("invalid property_id: property not found")
+		}
+		document.PropertyID = &req.PropertyID
+	}
+	if req.ClientID != "" {
+		client, err := s.clientRepo.GetByID(req.ClientID)
+		if err != nil || client.BrokerID != brokerID {
+			return nil, fmt.Errorf("invalid client_id: client not found")
+		}
+		document.ClientID = &req.ClientID
+	}
+
+	fileName, contentType, size, err := s.storeFile(file)
+	if err != nil {
+		return nil, err
+	}
+	document.FileName = fileName
+	document.ContentType = contentType
+	document.SizeBytes = size
+
+	if err := s.documentRepo.Create(document); err != nil {
+		// Clean up stored file if the database insert fails
+		os.Remove(filepath.Join(s.storagePath, fileName))
+		return nil, fmt.Errorf("failed to create document: %w", err)
+	}
+
+	return document, nil
+}
+
+// GetBrokerDocuments retrieves the broker's documents matching the filters
+func (s *DocumentService) GetBrokerDocuments(brokerID string, filters models.DocumentFilters) ([]models.Document, error) {
+	documents, err := s.documentRepo.GetByBrokerID(brokerID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+
+	return documents, nil
+}
+
+// GetDocumentByID retrieves a document with ownership verification
+func (s *DocumentService) GetDocumentByID(id, brokerID string) (*models.Document, error) {
+	document, err := s.documentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if document.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: document does not belong to this broker")
+	}
+
+	return document, nil
+}
+
+// UpdateDocument updates a document's type, title, or visibility with ownership verification
+func (s *DocumentService) UpdateDocument(id string, req *models.UpdateDocumentRequest, brokerID string) (*models.Document, error) {
+	document, err := s.GetDocumentByID(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DocumentType != nil {
+		document.DocumentType = *req.DocumentType
+	}
+	if req.Title != nil {
+		document.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Visibility != nil {
+		document.Visibility = *req.Visibility
+	}
+
+	if err := s.documentRepo.Update(document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// DeleteDocument deletes a document and its stored file with ownership verification
+func (s *DocumentService) DeleteDocument(id, brokerID string) error {
+	document, err := s.GetDocumentByID(id, brokerID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.documentRepo.Delete(id); err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(s.storagePath, document.FileName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to remove stored file for document %s: %v", id, err)
+	}
+
+	return nil
+}
+
+// PurgeDocumentsOfPurgedRecords deletes documents, and their stored files, belonging to
+// properties or clients about to be purged from the trash
+func (s *DocumentService) PurgeDocumentsOfPurgedRecords(before time.Time) error {
+	fileNames, err := s.documentRepo.DeleteForPurgedOwners(before)
+	if err != nil {
+		return err
+	}
+
+	for _, fileName := range fileNames {
+		if err := os.Remove(filepath.Join(s.storagePath, fileName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Failed to remove purged document file %s: %v", fileName, err)
+		}
+	}
+
+	return nil
+}
+
+// CreateDownloadURL issues a short-lived signed download URL for one of the broker's documents
+func (s *DocumentService) CreateDownloadURL(id, brokerID string) (*models.DocumentDownload, error) {
+	document, err := s.GetDocumentByID(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signedDownload(document, documentScopeBroker, documentDownloadTTL), nil
+}
+
+// SendToClient emails the document's client a signed download link
+// Only documents marked shareable and filed under a client who consented to email can be sent
+func (s *DocumentService) SendToClient(id, brokerID string) (*models.DocumentDownload, error) {
+	document, err := s.GetDocumentByID(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if document.Visibility != "shareable" {
+		return nil, fmt.Errorf("document is not shareable with clients")
+	}
+	if document.ClientID == nil {
+		return nil, fmt.Errorf("document is not filed under a client")
+	}
+
+	client, err := s.clientRepo.GetByID(*document.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch client: %w", err)
+	}
+
+	broker, err := s.userRepo.GetUserByID(brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch broker information: %w", err)
+	}
+
+	download := s.signedDownload(document, documentScopeClient, clientDocumentLinkTTL)
+	body := fmt.Sprintf(
+		"Hi %s,\n\n%s %s has shared a document with you: %s\n\nDownload it here (link valid until %s):\n%s\n",
+		client.FirstName,
+		broker.FirstName,
+		broker.LastName,
+		document.Title,
+		download.ExpiresAt.Format("2 Jan 2006"),
+		download.URL,
+	)
+	if err := s.consentService.EmailClient(client, "Document shared with you: "+document.Title, body); err != nil {
+		if errors.Is(err, ErrNoConsent) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to email document link: %w", err)
+	}
+
+	return download, nil
+}
+
+// OpenSignedDownload verifies a signed download URL and returns the document and its stored file path
+func (s *DocumentService) OpenSignedDownload(id, scope, expires, signature string) (*models.Document, string, error) {
+	if err := s.signer.Verify(documentResource(id, scope), expires, signature); err != nil {
+		return nil, "", err
+	}
+
+	document, err := s.documentRepo.GetByID(id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if scope == documentScopeClient && document.Visibility != "shareable" {
+		return nil, "", fmt.Errorf("document not found")
+	}
+
+	return document, filepath.Join(s.storagePath, document.FileName), nil
+}
+
+// signedDownload builds a signed download URL for a document valid for ttl
+func (s *DocumentService) signedDownload(document *models.Document, scope string, ttl time.Duration) *models.DocumentDownload {
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	signature := s.signer.Sign(documentResource(document.ID, scope), expiresAt)
+
+	return &models.DocumentDownload{
+		URL: fmt.Sprintf("%s/api/documents/%s/download?scope=%s&expires=%d&signature=%s",
+			s.publicURL, document.ID, scope, expiresAt.Unix(), signature),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// storeFile sniffs an upload's content type, enforces the size limit, and writes it to storage
+// The client-supplied file name and Content-Type header are never trusted
+func (s *DocumentService) storeFile(file io.Reader) (string, string, int64, error) {
+	head := make([]byte, documentSniffBytes)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		if err == io.EOF {
+			return "", "", 0, fmt.Errorf("invalid document: file is empty")
+		}
+		return "", "", 0, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	head = head[:n]
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	ext, ok := documentExtensions[contentType]
+	if !ok {
+		return "", "", 0, fmt.Errorf("unsupported file type: only PDF, JPEG, PNG, and WebP documents are allowed")
+	}
+
+	if err := os.MkdirAll(s.storagePath, 0750); err != nil {
+		return "", "", 0, fmt.Errorf("failed to create document directory: %w", err)
+	}
+
+	fileName := uuid.New().String() + ext
+	filePath := filepath.Join(s.storagePath, fileName)
+
+	dst, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to create document file: %w", err)
+	}
+	defer dst.Close()
+
+	// Read one byte past the limit so oversized uploads are detected regardless of the declared size
+	size, err := io.Copy(dst, io.LimitReader(io.MultiReader(bytes.NewReader(head), file), s.maxFileSize+1))
+	if err == nil && size > s.maxFileSize {
+		err = fmt.Errorf("file too large: documents must be at most %d MB", s.maxFileSize/1024/1024)
+	} else if err != nil {
+		err = fmt.Errorf("failed to save document file: %w", err)
+	}
+	if err != nil {
+		dst.Close()
+		os.Remove(filePath)
+		return "", "", 0, err
+	}
+
+	return fileName, contentType, size, nil
+}
+
+// documentResource is the resource name signed into a document download URL
+func documentResource(id, scope string) string {
+	return "documents/" + id + "/" + scope
+}
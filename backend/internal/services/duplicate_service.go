@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// Thresholds for treating two listings as likely duplicates
+const (
+	duplicateAddressSimilarity = 0.6  // pg_trgm similarity of the addresses
+	duplicateValueTolerance    = 0.10 // relative difference allowed in price and area
+)
+
+// DuplicateService detects likely duplicate listings and supports admin review
+type DuplicateService struct {
+	duplicateRepo *repository.DuplicateRepository
+	propertyRepo  *repository.PropertyRepository
+}
+
+// NewDuplicateService creates a new DuplicateService instance
+func NewDuplicateService(duplicateRepo *repository.DuplicateRepository, propertyRepo *repository.PropertyRepository) *DuplicateService {
+	return &DuplicateService{
+		duplicateRepo: duplicateRepo,
+		propertyRepo:  propertyRepo,
+	}
+}
+
+// DetectForProperty finds likely duplicates of a saved property, records them for review,
+// and returns them as warnings for the broker who created or updated the listing
+func (s *DuplicateService) DetectForProperty(property *models.Property) ([]models.DuplicateWarning, error) {
+	warnings, err := s.duplicateRepo.FindCandidates(property, duplicateAddressSimilarity, duplicateValueTolerance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate candidates: %w", err)
+	}
+
+	if err := s.duplicateRepo.SyncForProperty(property.ID, warnings); err != nil {
+		return nil, fmt.Errorf("failed to record duplicate pairs: %w", err)
+	}
+
+	for i := range warnings {
+		warnings[i].SameBroker = warnings[i].BrokerID == property.BrokerID
+		if warnings[i].SameBroker {
+			warnings[i].Message = "You already have a similar listing at this address"
+		} else {
+			warnings[i].Message = "Another broker has a similar listing at this address"
+		}
+	}
+
+	return warnings, nil
+}
+
+// GetDuplicateClusters groups duplicate pairs with the given status into clusters of connected listings
+func (s *DuplicateService) GetDuplicateClusters(status string) ([]models.DuplicateCluster, error) {
+	pairs, err := s.duplicateRepo.GetByStatus(status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get duplicate pairs: %w", err)
+	}
+
+	// Union-find over property IDs so A~B and B~C end up in one cluster
+	parent := make(map[string]string)
+	var find func(id string) string
+	find = func(id string) string {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+
+	propertyIDs := []string{}
+	for _, pair := range pairs {
+		for _, id := range []string{pair.PropertyAID, pair.PropertyBID} {
+			if _, seen := parent[id]; !seen {
+				parent[id] = id
+				propertyIDs = append(propertyIDs, id)
+			}
+		}
+		parent[find(pair.PropertyAID)] = find(pair.PropertyBID)
+	}
+
+	properties, err := s.propertyRepo.GetByIDs(propertyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get duplicate properties: %w", err)
+	}
+
+	// Build clusters in order of first appearance (most recent pairs first)
+	clusterIndex := make(map[string]int)
+	clusters := []models.DuplicateCluster{}
+	for _, pair := range pairs {
+		root := find(pair.PropertyAID)
+		index, exists := clusterIndex[root]
+		if !exists {
+			index = len(clusters)
+			clusterIndex[root] = index
+			clusters = append(clusters, models.DuplicateCluster{
+				Properties: []models.Property{},
+				Pairs:      []models.PropertyDuplicate{},
+			})
+		}
+		clusters[index].Pairs = append(clusters[index].Pairs, pair)
+	}
+
+	for _, property := range properties {
+		if index, exists := clusterIndex[find(property.ID)]; exists {
+			clusters[index].Properties = append(clusters[index].Properties, property)
+		}
+	}
+
+	return clusters, nil
+}
+
+// ReviewDuplicate records an admin decision on a duplicate pair
+func (s *DuplicateService) ReviewDuplicate(id string, req *models.ReviewDuplicateRequest, adminID string) (*models.PropertyDuplicate, error) {
+	duplicate, err := s.duplicateRepo.UpdateStatus(id, req.Status, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicate, nil
+}
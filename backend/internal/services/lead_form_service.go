@@ -0,0 +1,319 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// leadFormKeyBytes is the amount of randomness in a lead form key (encodes to 32 characters)
+const leadFormKeyBytes = 24
+
+// LeadFormService handles brokers' public lead capture forms and the leads submitted to them
+type LeadFormService struct {
+	leadFormRepo        *repository.LeadFormRepository
+	clientRepo          *repository.ClientRepository
+	clientService       *ClientService
+	activityService     *ActivityService
+	notificationService *NotificationService
+	consentService      *ConsentService
+	publicURL           string
+	countryCode         string        // calling code for phone numbers written without one
+	minFillTime         time.Duration // quicker submissions are taken to be bots
+	maxFormAge          time.Duration // forms loaded longer ago than this are taken to be replayed
+}
+
+// NewLeadFormService creates a new LeadFormService instance
+// publicURL is the externally reachable base URL used to build form submission URLs
+func NewLeadFormService(
+	leadFormRepo *repository.LeadFormRepository,
+	clientRepo *repository.ClientRepository,
+	clientService *ClientService,
+	activityService *ActivityService,
+	notificationService *NotificationService,
+	consentService *ConsentService,
+	publicURL, countryCode string,
+	minFillTime, maxFormAge time.Duration,
+) *LeadFormService {
+	return &LeadFormService{
+		leadFormRepo:        leadFormRepo,
+		clientRepo:          clientRepo,
+		clientService:       clientService,
+		activityService:     activityService,
+		notificationService: notificationService,
+		consentService:      consentService,
+		publicURL:           strings.TrimRight(publicURL, "/"),
+		countryCode:         countryCode,
+		minFillTime:         minFillTime,
+		maxFormAge:          maxFormAge,
+	}
+}
+
+// GetLeadForm retrieves the broker's lead form, setting one up on first use
+func (s *LeadFormService) GetLeadForm(brokerID string) (*models.LeadForm, error) {
+	formKey, err := generateLeadFormKey()
+	if err != nil {
+		return nil, err
+	}
+
+	form, err := s.leadFormRepo.GetOrCreate(brokerID, formKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lead form: %w", err)
+	}
+
+	form.SubmitURL = s.submitURL(form.FormKey)
+	return form, nil
+}
+
+// UpdateLeadForm changes the broker's lead form options
+func (s *LeadFormService) UpdateLeadForm(req *models.UpdateLeadFormRequest, brokerID string) (*models.LeadForm, error) {
+	form, err := s.GetLeadForm(brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Enabled != nil {
+		form.Enabled = *req.Enabled
+	}
+	if req.DefaultType != nil {
+		form.DefaultType = *req.DefaultType
+	}
+
+	if err := s.leadFormRepo.Update(form); err != nil {
+		return nil, fmt.Errorf("failed to update lead form: %w", err)
+	}
+
+	return form, nil
+}
+
+// RotateLeadFormKey gives the broker's lead form a new key; forms embedded with the old one stop working
+func (s *LeadFormService) RotateLeadFormKey(brokerID string) (*models.LeadForm, error) {
+	form, err := s.GetLeadForm(brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	formKey, err := generateLeadFormKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.leadFormRepo.UpdateKey(form, formKey); err != nil {
+		return nil, fmt.Errorf("failed to rotate lead form key: %w", err)
+	}
+
+	form.SubmitURL = s.submitURL(form.FormKey)
+	return form, nil
+}
+
+// SubmitLead turns a submission to a public lead form into a client of the form's broker
+// A lead whose phone or email is already in the broker's book is added to that client's timeline
+// instead. Consent is recorded for the phone number and email given in the submission either way.
+// Submissions that fail the spam checks are counted and dropped without an error, so bots can't
+// tell them apart from accepted leads
+func (s *LeadFormService) SubmitLead(formKey string, req *models.LeadSubmission) error {
+	form, err := s.leadFormRepo.GetByKey(formKey)
+	if err != nil {
+		return err
+	}
+	if !form.Enabled {
+		return fmt.Errorf("lead form not found")
+	}
+
+	if reason := s.spamReason(req, time.Now()); reason != "" {
+		log.Printf("Dropped lead form submission for broker %s as spam: %s", form.BrokerID, reason)
+		if err := s.leadFormRepo.RecordSubmission(form.ID, true); err != nil {
+			log.Printf("Failed to count spam lead submission: %v", err)
+		}
+		return nil
+	}
+
+	lead, err := s.leadClient(req, form)
+	if err != nil {
+		return err
+	}
+	client := lead
+
+	existing, err := s.clientRepo.FindByContact(form.BrokerID, client.Phone, client.Email)
+	if err != nil {
+		return fmt.Errorf("failed to look up existing clients: %w", err)
+	}
+
+	if len(existing) > 0 {
+		// Repeat enquiries keep the attribution of the original lead
+		client = &existing[0]
+		s.activityService.RecordEnquiry(client, *leadSource(req), req.Message)
+		s.notifyBroker(client, "lead_enquiry", "Repeat enquiry from "+clientFullName(client), req)
+	} else {
+		if err := s.clientService.AddClient(client); err != nil {
+			return err
+		}
+		s.activityService.RecordEnquiry(client, *client.Source, req.Message)
+		s.notifyBroker(client, "lead_captured", "New lead: "+clientFullName(client), req)
+	}
+
+	// Consent covers the contact details as submitted, which may differ from an existing client's
+	lead.ID = client.ID
+	if err := s.consentService.RecordLeadConsent(lead, req.Consent); err != nil {
+		log.Printf("Failed to record lead consent for client %s: %v", client.ID, err)
+	}
+
+	if err := s.leadFormRepo.RecordSubmission(form.ID, false); err != nil {
+		log.Printf("Failed to count lead submission: %v", err)
+	}
+
+	return nil
+}
+
+// spamReason explains why a submission looks automated, or returns "" when it passes the checks
+// People never see the honeypot field, and take a few seconds to fill in a form they loaded recently
+func (s *LeadFormService) spamReason(req *models.LeadSubmission, now time.Time) string {
+	if strings.TrimSpace(req.Website) != "" {
+		return "honeypot field filled in"
+	}
+	if req.FormLoadedAt <= 0 {
+		return "form_loaded_at missing"
+	}
+
+	loadedAt := time.Unix(req.FormLoadedAt, 0)
+	if req.FormLoadedAt > 1e12 {
+		loadedAt = time.UnixMilli(req.FormLoadedAt) // JavaScript's Date.now()
+	}
+
+	elapsed := now.Sub(loadedAt)
+	switch {
+	case elapsed < s.minFillTime:
+		return fmt.Sprintf("submitted %s after the form loaded", elapsed.Round(time.Millisecond))
+	case elapsed > s.maxFormAge:
+		return "form loaded too long ago"
+	}
+
+	return ""
+}
+
+// leadClient builds the client a submission describes, validating and normalizing its contact details
+func (s *LeadFormService) leadClient(req *models.LeadSubmission, form *models.LeadForm) (*models.Client, error) {
+	client := &models.Client{
+		FirstName:           strings.TrimSpace(req.FirstName),
+		LastName:            strings.TrimSpace(req.LastName),
+		Email:               strings.ToLower(strings.TrimSpace(req.Email)),
+		Type:                req.Type,
+		PreferredLocation:   strings.TrimSpace(req.PreferredLocation),
+		BudgetMax:           req.BudgetMax,
+		Requirements:        strings.TrimSpace(req.Message),
+		BrokerID:            form.BrokerID,
+		Source:              leadSource(req),
+		PropertyTypes:       []string{},
+		PreferredLocalities: []string{},
+		RequiredAmenities:   []string{},
+	}
+
+	if campaign := optionalText(&req.Campaign); campaign != nil {
+		client.Campaign = campaign
+	} else {
+		client.Campaign = optionalText(&req.UTMCampaign)
+	}
+
+	// A full name fills whichever name parts were left empty
+	if name := strings.Fields(req.Name); len(name) > 0 && client.FirstName == "" && client.LastName == "" {
+		client.FirstName = name[0]
+		client.LastName = strings.Join(name[1:], " ")
+	}
+	if client.FirstName == "" {
+		if client.LastName == "" {
+			return nil, fmt.Errorf("invalid lead: name is required")
+		}
+		client.FirstName, client.LastName = client.LastName, ""
+	}
+	if len([]rune(client.FirstName)) > 100 || len([]rune(client.LastName)) > 100 {
+		return nil, fmt.Errorf("invalid lead: name is too long")
+	}
+
+	if client.Type == "" {
+		client.Type = form.DefaultType
+	}
+
+	if strings.TrimSpace(req.Phone) == "" && client.Email == "" {
+		return nil, fmt.Errorf("invalid lead: a phone number or email is required")
+	}
+
+	if strings.TrimSpace(req.Phone) != "" {
+		phone, err := normalizePhone(req.Phone, s.countryCode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lead: %w", err)
+		}
+		client.Phone = phone
+	}
+
+	if client.Email != "" {
+		address, err := mail.ParseAddress(client.Email)
+		if err != nil || address.Address != client.Email {
+			return nil, fmt.Errorf("invalid lead: email is not a valid address")
+		}
+	}
+
+	return client, nil
+}
+
+// notifyBroker tells the broker about a lead in-app and by email
+func (s *LeadFormService) notifyBroker(client *models.Client, notificationType, title string, req *models.LeadSubmission) {
+	contact := []string{}
+	for _, value := range []string{client.Phone, client.Email} {
+		if value != "" {
+			contact = append(contact, value)
+		}
+	}
+
+	message := fmt.Sprintf("%s (%s) enquired via your %s form", clientFullName(client), strings.Join(contact, ", "),
+		strings.ReplaceAll(*leadSource(req), "_", "-"))
+	if client.Campaign != nil {
+		message += fmt.Sprintf(" for the %s campaign", *client.Campaign)
+	}
+	message += "."
+	if text := strings.TrimSpace(req.Message); text != "" {
+		message += "\n\n" + text
+	}
+
+	err := s.notificationService.Notify(client.BrokerID, notificationType, title, message, NotifyOptions{
+		InApp:      true,
+		Email:      true,
+		EntityType: "client",
+		EntityID:   client.ID,
+	})
+	logNotifyError("lead", err)
+}
+
+// submitURL returns the public URL a lead form key accepts submissions at
+func (s *LeadFormService) submitURL(formKey string) string {
+	return s.publicURL + "/api/leads/" + formKey
+}
+
+// leadSource returns the source a submission names, defaulting to the website
+func leadSource(req *models.LeadSubmission) *string {
+	source := req.Source
+	if source == "" {
+		source = "website"
+	}
+	return &source
+}
+
+// clientFullName joins a client's first and last name
+func clientFullName(client *models.Client) string {
+	return strings.TrimSpace(client.FirstName + " " + client.LastName)
+}
+
+// generateLeadFormKey returns a random URL-safe key for a lead form
+func generateLeadFormKey() (string, error) {
+	buf := make([]byte, leadFormKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate lead form key: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"enfor-data-backend/internal/models"
+)
+
+func TestLeadFormSpamReason(t *testing.T) {
+	service := &LeadFormService{minFillTime: 3 * time.Second, maxFormAge: 24 * time.Hour}
+	now := time.Date(2026, time.October, 18, 10, 0, 0, 0, time.UTC)
+
+	seconds := func(ago time.Duration) int64 { return now.Add(-ago).Unix() }
+	millis := func(ago time.Duration) int64 { return now.Add(-ago).UnixMilli() }
+
+	tests := []struct {
+		name         string
+		website      string
+		formLoadedAt int64
+		want         string // prefix of the reason; empty when the submission passes
+	}{
+		{name: "filled in by a person, seconds", formLoadedAt: seconds(45 * time.Second)},
+		{name: "filled in by a person, milliseconds", formLoadedAt: millis(45 * time.Second)},
+		{name: "just over the minimum fill time", formLoadedAt: millis(3*time.Second + time.Millisecond)},
+		{name: "just under the maximum form age", formLoadedAt: seconds(24*time.Hour - time.Second)},
+		{name: "honeypot filled in", website: "http://spam.example", formLoadedAt: seconds(45 * time.Second), want: "honeypot field filled in"},
+		{name: "honeypot of only spaces is ignored", website: "   ", formLoadedAt: seconds(45 * time.Second)},
+		{name: "form_loaded_at missing", want: "form_loaded_at missing"},
+		{name: "form_loaded_at negative", formLoadedAt: -1, want: "form_loaded_at missing"},
+		{name: "too fast, seconds", formLoadedAt: seconds(2 * time.Second), want: "submitted 2s after the form loaded"},
+		{name: "too fast, milliseconds", formLoadedAt: millis(1500 * time.Millisecond), want: "submitted 1.5s after the form loaded"},
+		{name: "loaded in the future", formLoadedAt: millis(-time.Minute), want: "submitted -1m0s after the form loaded"},
+		{name: "stale form, seconds", formLoadedAt: seconds(25 * time.Hour), want: "form loaded too long ago"},
+		{name: "stale form, milliseconds", formLoadedAt: millis(48 * time.Hour), want: "form loaded too long ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &models.LeadSubmission{Website: tt.website, FormLoadedAt: tt.formLoadedAt}
+
+			got := service.spamReason(req, now)
+			if (tt.want == "") != (got == "") || !strings.HasPrefix(got, tt.want) {
+				t.Errorf("spamReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"log"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// LeadScoreService keeps clients' lead scores current
+// Scores are recalculated whenever a client, their appointments, timeline or matches change,
+// and periodically for every client since the time windows they count move on their own
+type LeadScoreService struct {
+	clientRepo *repository.ClientRepository
+}
+
+// NewLeadScoreService creates a new LeadScoreService instance
+func NewLeadScoreService(clientRepo *repository.ClientRepository) *LeadScoreService {
+	return &LeadScoreService{clientRepo: clientRepo}
+}
+
+// RefreshClient recalculates a client's lead score and updates the given client with the result
+// Refreshing is best-effort and never fails the write that triggered it
+func (s *LeadScoreService) RefreshClient(client *models.Client) {
+	refreshed, err := s.clientRepo.RefreshLeadScores([]string{client.ID})
+	if err != nil {
+		log.Printf("Failed to refresh lead score for client %s: %v", client.ID, err)
+		return
+	}
+
+	for _, scored := range refreshed {
+		client.LeadScore = scored.LeadScore
+		client.LeadScoreFactors = scored.LeadScoreFactors
+		client.LeadScoreUpdatedAt = scored.LeadScoreUpdatedAt
+	}
+}
+
+// RefreshClients recalculates the lead scores of the given clients
+// Refreshing is best-effort and never fails the write that triggered it
+func (s *LeadScoreService) RefreshClients(clientIDs ...string) {
+	if len(clientIDs) == 0 {
+		return
+	}
+
+	if _, err := s.clientRepo.RefreshLeadScores(clientIDs); err != nil {
+		log.Printf("Failed to refresh lead scores for %d clients: %v", len(clientIDs), err)
+	}
+}
+
+// RefreshAll recalculates every client's lead score
+func (s *LeadScoreService) RefreshAll() {
+	changed, err := s.clientRepo.RefreshAllLeadScores()
+	if err != nil {
+		log.Printf("Failed to refresh lead scores: %v", err)
+		return
+	}
+
+	if changed > 0 {
+		log.Printf("Refreshed %d lead scores", changed)
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// defaultMarketMonths is the lookback window used when none is requested
+const defaultMarketMonths = 12
+
+// MarketService builds anonymized, city-level market analytics from platform listing data
+type MarketService struct {
+	marketRepo  *repository.MarketRepository
+	minListings int
+	minBrokers  int
+}
+
+// NewMarketService creates a new MarketService instance
+// minListings and minBrokers are the sample thresholds every published statistic must meet
+func NewMarketService(marketRepo *repository.MarketRepository, minListings, minBrokers int) *MarketService {
+	return &MarketService{
+		marketRepo:  marketRepo,
+		minListings: minListings,
+		minBrokers:  minBrokers,
+	}
+}
+
+// GetCities lists the cities with enough listing data for market analytics
+func (s *MarketService) GetCities() ([]models.MarketCity, error) {
+	return s.marketRepo.GetCities(s.minListings, s.minBrokers)
+}
+
+// GetMarketInsights computes inventory, locality prices and days-on-market for a city
+// Statistics below the sample thresholds are omitted, so sparse cities return empty sections
+func (s *MarketService) GetMarketInsights(req *models.MarketInsightsRequest) (*models.MarketInsights, error) {
+	months := req.Months
+	if months == 0 {
+		months = defaultMarketMonths
+	}
+
+	query := repository.MarketQuery{
+		City:         strings.TrimSpace(req.City),
+		PropertyType: req.Type,
+		Since:        time.Now().AddDate(0, -months, 0),
+		MinListings:  s.minListings,
+		MinBrokers:   s.minBrokers,
+	}
+
+	inventory, err := s.marketRepo.GetInventory(query)
+	if err != nil {
+		return nil, err
+	}
+
+	localities, err := s.marketRepo.GetLocalityPrices(query)
+	if err != nil {
+		return nil, err
+	}
+
+	daysOnMarket, err := s.marketRepo.GetDaysOnMarket(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MarketInsights{
+		City:         query.City,
+		Type:         req.Type,
+		Months:       months,
+		MinListings:  s.minListings,
+		MinBrokers:   s.minBrokers,
+		Inventory:    inventory,
+		Localities:   localities,
+		DaysOnMarket: daysOnMarket,
+	}, nil
+}
@@ -0,0 +1,419 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// MinMatchScore is the lowest score at which a client/property pairing is stored as a match
+const MinMatchScore = 50
+
+// Score weights for each matching criterion (sum to 100)
+const (
+	budgetWeight   = 40
+	locationWeight = 35
+	bedroomsWeight = 25
+)
+
+// budgetStretch is how far above budget_max a property may be priced and still match
+const budgetStretch = 0.10
+
+// areaStretch is how far outside a client's area range a property may be and still match
+const areaStretch = 0.10
+
+// bhkPattern extracts a bedroom count such as "2BHK" or "3 bhk" from free-text requirements
+// Half BHKs count their whole bedrooms only, so "2.5 BHK" is 2
+var bhkPattern = regexp.MustCompile(`(?i)(\d+)(?:\.\d+)?\s*-?\s*bhk`)
+
+// MatchService scores properties against client requirements and keeps stored matches current
+type MatchService struct {
+	matchRepo        *repository.MatchRepository
+	clientRepo       *repository.ClientRepository
+	propertyRepo     *repository.PropertyRepository
+	leadScoreService *LeadScoreService
+}
+
+// NewMatchService creates a new MatchService instance
+func NewMatchService(
+	matchRepo *repository.MatchRepository,
+	clientRepo *repository.ClientRepository,
+	propertyRepo *repository.PropertyRepository,
+	leadScoreService *LeadScoreService,
+) *MatchService {
+	return &MatchService{
+		matchRepo:        matchRepo,
+		clientRepo:       clientRepo,
+		propertyRepo:     propertyRepo,
+		leadScoreService: leadScoreService,
+	}
+}
+
+// EvaluateClient re-scores all candidate properties for a client and replaces its stored matches
+// The client's lead score is refreshed afterwards, since it counts matches
+func (s *MatchService) EvaluateClient(client *models.Client) error {
+	matches := []models.PropertyMatch{}
+
+	listingType, ok := clientListingType(client)
+	if ok && client.Status == "active" {
+		candidates, err := s.propertyRepo.GetMatchCandidates(client.City, listingType)
+		if err != nil {
+			return fmt.Errorf("failed to get candidate properties: %w", err)
+		}
+
+		for i := range candidates {
+			if match, ok := scoreMatch(client, &candidates[i]); ok {
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	if err := s.matchRepo.ReplaceForClient(client.ID, matches); err != nil {
+		return fmt.Errorf("failed to store client matches: %w", err)
+	}
+
+	s.leadScoreService.RefreshClient(client)
+
+	return nil
+}
+
+// EvaluateProperty re-scores all candidate clients for a property and replaces its stored matches
+// Lead scores are refreshed for every client who gained or lost the match
+func (s *MatchService) EvaluateProperty(property *models.Property) error {
+	matches := []models.PropertyMatch{}
+
+	if property.Status == "available" {
+		candidates, err := s.clientRepo.GetMatchCandidates(property.City, property.ListingType)
+		if err != nil {
+			return fmt.Errorf("failed to get candidate clients: %w", err)
+		}
+
+		for i := range candidates {
+			if match, ok := scoreMatch(&candidates[i], property); ok {
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	clientIDs, err := s.matchRepo.ReplaceForProperty(property.ID, matches)
+	if err != nil {
+		return fmt.Errorf("failed to store property matches: %w", err)
+	}
+
+	s.leadScoreService.RefreshClients(clientIDs...)
+
+	return nil
+}
+
+// GetClientMatches retrieves matched properties for a client with ownership verification
+// Matched properties may come from any broker; only the client must belong to the requester
+func (s *MatchService) GetClientMatches(clientID, brokerID string) ([]models.ClientPropertyMatch, error) {
+	client, err := s.clientRepo.GetByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: client does not belong to this broker")
+	}
+
+	matches, err := s.matchRepo.GetByClientID(clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client matches: %w", err)
+	}
+
+	for i := range matches {
+		hideForeignPropertyTags(&matches[i].Property, brokerID)
+	}
+
+	return matches, nil
+}
+
+// GetPropertyMatches retrieves the requesting broker's clients matched to one of their properties
+func (s *MatchService) GetPropertyMatches(propertyID, brokerID string) ([]models.PropertyClientMatch, error) {
+	property, err := s.propertyRepo.GetByID(propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if property.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: property does not belong to this broker")
+	}
+
+	matches, err := s.matchRepo.GetByPropertyID(propertyID, brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get property matches: %w", err)
+	}
+
+	return matches, nil
+}
+
+// listingTypeForClient returns the listing type a client is looking for
+// Sellers and owners are the supply side and are never matched to listings
+func listingTypeForClient(clientType string) (string, bool) {
+	switch clientType {
+	case "buyer":
+		return "sale", true
+	case "tenant":
+		return "rent", true
+	default:
+		return "", false
+	}
+}
+
+// clientListingType returns the listing type a buyer or tenant is matched against
+// The structured listing_type wins over the one implied by the client type
+func clientListingType(client *models.Client) (string, bool) {
+	listingType, ok := listingTypeForClient(client.Type)
+	if !ok {
+		return "", false
+	}
+	if client.ListingType != nil {
+		return *client.ListingType, true
+	}
+	return listingType, true
+}
+
+// scoreMatch scores how well a property fits a client's requirements
+// Returns false when a hard requirement fails or the score is below MinMatchScore
+func scoreMatch(client *models.Client, property *models.Property) (models.PropertyMatch, bool) {
+	match := models.PropertyMatch{
+		ClientID:   client.ID,
+		PropertyID: property.ID,
+		Reasons:    []string{},
+	}
+
+	// Hard requirements: listing type and city must line up
+	listingType, ok := clientListingType(client)
+	if !ok || listingType != property.ListingType {
+		return match, false
+	}
+	if !strings.EqualFold(strings.TrimSpace(client.City), strings.TrimSpace(property.City)) {
+		return match, false
+	}
+
+	// Hard requirements from the structured fields: property type, must-have amenities, area
+	if len(client.PropertyTypes) > 0 && !containsFold(client.PropertyTypes, property.Type) {
+		return match, false
+	}
+	if len(client.RequiredAmenities) > 0 {
+		for _, amenity := range client.RequiredAmenities {
+			if !containsFold(property.Amenities, amenity) {
+				return match, false
+			}
+		}
+		match.Reasons = append(match.Reasons, "has required amenities")
+	}
+	reason, ok := checkArea(client.AreaMin, client.AreaMax, property.Area)
+	if !ok {
+		return match, false
+	}
+	if reason != "" {
+		match.Reasons = append(match.Reasons, reason)
+	}
+
+	// Budget
+	points, reason, ok := scoreBudget(client.BudgetMin, client.BudgetMax, property.Price)
+	if !ok {
+		return match, false
+	}
+	match.Score += points
+	if reason != "" {
+		match.Reasons = append(match.Reasons, reason)
+	}
+
+	// Location
+	points, reason = scoreLocalities(preferredLocalities(client), property.Location)
+	match.Score += points
+	if reason != "" {
+		match.Reasons = append(match.Reasons, reason)
+	}
+
+	// Bedrooms
+	points, reason = scoreBedrooms(client, property.Bedrooms)
+	match.Score += points
+	if reason != "" {
+		match.Reasons = append(match.Reasons, reason)
+	}
+
+	return match, match.Score >= MinMatchScore
+}
+
+// scoreBudget scores a property price against a client's budget range
+// Returns false when the price is too far above budget_max to be worth showing
+func scoreBudget(budgetMin, budgetMax *float64, price float64) (int, string, bool) {
+	if budgetMin == nil && budgetMax == nil {
+		return budgetWeight / 2, "", true
+	}
+
+	if budgetMax != nil && price > *budgetMax {
+		if price > *budgetMax*(1+budgetStretch) {
+			return 0, "", false
+		}
+		return budgetWeight / 2, "slightly above budget", true
+	}
+
+	if budgetMin != nil && price < *budgetMin {
+		return budgetWeight * 3 / 4, "below budget", true
+	}
+
+	return budgetWeight, "within budget", true
+}
+
+// checkArea checks a property's area against a client's area range
+// Returns false when the area is too far outside the range to be worth showing
+func checkArea(areaMin, areaMax *float64, area float64) (string, bool) {
+	if areaMin == nil && areaMax == nil {
+		return "", true
+	}
+
+	if areaMin != nil && area < *areaMin {
+		if area < *areaMin*(1-areaStretch) {
+			return "", false
+		}
+		return "slightly smaller than required", true
+	}
+
+	if areaMax != nil && area > *areaMax {
+		if area > *areaMax*(1+areaStretch) {
+			return "", false
+		}
+		return "slightly larger than required", true
+	}
+
+	return "area within range", true
+}
+
+// preferredLocalities returns the localities a client wants, falling back to the free-text preferred location
+func preferredLocalities(client *models.Client) []string {
+	if len(client.PreferredLocalities) > 0 {
+		return client.PreferredLocalities
+	}
+	return []string{client.PreferredLocation}
+}
+
+// scoreLocalities scores a property's locality against the best fitting of a client's preferred localities
+func scoreLocalities(preferred []string, location string) (int, string) {
+	bestPoints, bestReason := 0, ""
+	for _, locality := range preferred {
+		if points, reason := scoreLocation(locality, location); points > bestPoints {
+			bestPoints, bestReason = points, reason
+		}
+	}
+	return bestPoints, bestReason
+}
+
+// scoreLocation scores a property's locality against a client's preferred location
+func scoreLocation(preferred, location string) (int, string) {
+	preferred = strings.ToLower(strings.TrimSpace(preferred))
+	location = strings.ToLower(strings.TrimSpace(location))
+	if preferred == "" || location == "" {
+		return 0, ""
+	}
+
+	if strings.Contains(location, preferred) || strings.Contains(preferred, location) {
+		return locationWeight, "preferred location"
+	}
+
+	// Partial credit when any significant word overlaps (e.g. "Andheri West" vs "Andheri East")
+	locationWords := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(location, isLocationSeparator) {
+		locationWords[word] = true
+	}
+	for _, word := range strings.FieldsFunc(preferred, isLocationSeparator) {
+		if len(word) > 2 && locationWords[word] {
+			return locationWeight / 2, "near preferred location"
+		}
+	}
+
+	return 0, ""
+}
+
+// isLocationSeparator splits locality strings on spaces and punctuation
+func isLocationSeparator(r rune) bool {
+	return r == ' ' || r == ',' || r == '-' || r == '/'
+}
+
+// scoreBedrooms scores a property's bedrooms against the client's bedroom range
+func scoreBedrooms(client *models.Client, bedrooms *int) (int, string) {
+	wantedMin, wantedMax, ok := bedroomRange(client)
+	if !ok {
+		// No bedroom preference stated - neither reward nor penalise fully
+		return bedroomsWeight / 2, ""
+	}
+	if bedrooms == nil {
+		return 0, ""
+	}
+
+	switch {
+	case *bedrooms >= wantedMin && *bedrooms <= wantedMax:
+		return bedroomsWeight, fmt.Sprintf("%d BHK as required", *bedrooms)
+	case *bedrooms == wantedMin-1 || *bedrooms == wantedMax+1:
+		return bedroomsWeight * 2 / 5, fmt.Sprintf("%d BHK (wanted %s)", *bedrooms, formatBHKRange(wantedMin, wantedMax))
+	default:
+		return 0, ""
+	}
+}
+
+// bedroomRange returns the bedroom range a client wants
+// The structured bedrooms_min/bedrooms_max win; clients without them fall back to a BHK in the requirements text
+// An open end of the range accepts any bedroom count on that side
+func bedroomRange(client *models.Client) (int, int, bool) {
+	if client.BedroomsMin != nil || client.BedroomsMax != nil {
+		wantedMin, wantedMax := 0, math.MaxInt32
+		if client.BedroomsMin != nil {
+			wantedMin = *client.BedroomsMin
+		}
+		if client.BedroomsMax != nil {
+			wantedMax = *client.BedroomsMax
+		}
+		return wantedMin, wantedMax, true
+	}
+
+	wanted, ok := parseBHK(client.Requirements)
+	return wanted, wanted, ok
+}
+
+// formatBHKRange describes a bedroom range such as "2", "2-3" or "3+"
+func formatBHKRange(wantedMin, wantedMax int) string {
+	switch {
+	case wantedMin == wantedMax:
+		return strconv.Itoa(wantedMin)
+	case wantedMax == math.MaxInt32:
+		return fmt.Sprintf("%d+", wantedMin)
+	default:
+		return fmt.Sprintf("%d-%d", wantedMin, wantedMax)
+	}
+}
+
+// containsFold reports whether values contains target, ignoring case and surrounding spaces
+func containsFold(values []string, target string) bool {
+	target = strings.TrimSpace(target)
+	for _, value := range values {
+		if strings.EqualFold(strings.TrimSpace(value), target) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBHK extracts the first bedroom count mentioned in free-text requirements
+// Only used for clients that have no structured bedroom range
+func parseBHK(text string) (int, bool) {
+	found := bhkPattern.FindStringSubmatch(text)
+	if found == nil {
+		return 0, false
+	}
+
+	bedrooms, err := strconv.Atoi(found[1])
+	if err != nil {
+		return 0, false
+	}
+
+	return bedrooms, true
+}
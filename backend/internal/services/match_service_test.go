@@ -0,0 +1,275 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"enfor-data-backend/internal/models"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+func intPtr(v int) *int           { return &v }
+func stringPtr(v string) *string  { return &v }
+
+// matchClient is a buyer looking for a 2 BHK flat in Andheri West for 1-1.5 crore
+func matchClient() *models.Client {
+	return &models.Client{
+		ID:                  "client-1",
+		Type:                "buyer",
+		City:                "Mumbai",
+		BudgetMin:           floatPtr(10000000),
+		BudgetMax:           floatPtr(15000000),
+		PreferredLocalities: []string{"Andheri West"},
+		BedroomsMin:         intPtr(2),
+		BedroomsMax:         intPtr(2),
+	}
+}
+
+// matchProperty is a listing that fits matchClient on every criterion
+func matchProperty() *models.Property {
+	return &models.Property{
+		ID:          "property-1",
+		Type:        "apartment",
+		ListingType: "sale",
+		City:        "Mumbai",
+		Location:    "Andheri West",
+		Price:       12000000,
+		Area:        900,
+		Bedrooms:    intPtr(2),
+		Amenities:   []string{"Parking", "Lift"},
+	}
+}
+
+func TestScoreMatchBudget(t *testing.T) {
+	tests := []struct {
+		name      string
+		budgetMin *float64
+		budgetMax *float64
+		price     float64
+		wantScore int
+		wantMatch bool
+		reason    string
+	}{
+		{"within budget", floatPtr(10000000), floatPtr(15000000), 12000000, 100, true, "within budget"},
+		{"at budget max", floatPtr(10000000), floatPtr(15000000), 15000000, 100, true, "within budget"},
+		{"slightly above budget", floatPtr(10000000), floatPtr(15000000), 16000000, 80, true, "slightly above budget"},
+		{"at the stretch limit", nil, floatPtr(15000000), 16500000, 80, true, "slightly above budget"},
+		{"beyond the stretch limit", floatPtr(10000000), floatPtr(15000000), 17000000, 0, false, ""},
+		{"below budget min", floatPtr(10000000), floatPtr(15000000), 8000000, 90, true, "below budget"},
+		{"only a minimum", floatPtr(10000000), nil, 50000000, 100, true, "within budget"},
+		{"no budget", nil, nil, 12000000, 80, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := matchClient()
+			client.BudgetMin, client.BudgetMax = tt.budgetMin, tt.budgetMax
+			property := matchProperty()
+			property.Price = tt.price
+
+			match, ok := scoreMatch(client, property)
+			if ok != tt.wantMatch {
+				t.Fatalf("scoreMatch() matched = %v, want %v (score %d)", ok, tt.wantMatch, match.Score)
+			}
+			if !ok {
+				return
+			}
+			if match.Score != tt.wantScore {
+				t.Errorf("scoreMatch() score = %d, want %d", match.Score, tt.wantScore)
+			}
+			if tt.reason != "" && !containsFold(match.Reasons, tt.reason) {
+				t.Errorf("scoreMatch() reasons = %v, want %q", match.Reasons, tt.reason)
+			}
+		})
+	}
+}
+
+func TestScoreMatchLocality(t *testing.T) {
+	tests := []struct {
+		name              string
+		localities        []string
+		preferredLocation string
+		location          string
+		wantScore         int
+		reason            string
+	}{
+		{"same locality", []string{"Andheri West"}, "", "Andheri West", 100, "preferred location"},
+		{"case and spaces ignored", []string{" andheri west "}, "", "ANDHERI WEST", 100, "preferred location"},
+		{"locality within the listing's location", []string{"Andheri"}, "", "Andheri West, Mumbai", 100, "preferred location"},
+		{"neighbouring locality", []string{"Andheri West"}, "", "Andheri East", 82, "near preferred location"},
+		{"short shared word is ignored", []string{"JB Nagar"}, "", "JB Road", 65, ""},
+		{"other locality", []string{"Andheri West"}, "", "Bandra", 65, ""},
+		{"best of several localities", []string{"Bandra", "Andheri East", "Juhu"}, "", "Andheri West", 82, "near preferred location"},
+		{"free-text preferred location fallback", nil, "Powai", "Powai", 100, "preferred location"},
+		{"no preference", nil, "", "Powai", 65, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := matchClient()
+			client.PreferredLocalities = tt.localities
+			client.PreferredLocation = tt.preferredLocation
+			property := matchProperty()
+			property.Location = tt.location
+
+			match, ok := scoreMatch(client, property)
+			if !ok {
+				t.Fatalf("scoreMatch() did not match (score %d)", match.Score)
+			}
+			if match.Score != tt.wantScore {
+				t.Errorf("scoreMatch() score = %d, want %d", match.Score, tt.wantScore)
+			}
+			if tt.reason != "" && !containsFold(match.Reasons, tt.reason) {
+				t.Errorf("scoreMatch() reasons = %v, want %q", match.Reasons, tt.reason)
+			}
+		})
+	}
+}
+
+func TestScoreMatchExclusions(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   func(*models.Client)
+		property func(*models.Property)
+	}{
+		{
+			name:   "seller is never matched",
+			client: func(c *models.Client) { c.Type = "seller" },
+		},
+		{
+			name:     "buyer against a rental",
+			property: func(p *models.Property) { p.ListingType = "rent" },
+		},
+		{
+			name:   "listing type overrides the client type",
+			client: func(c *models.Client) { c.ListingType = stringPtr("rent") },
+		},
+		{
+			name:     "other city",
+			property: func(p *models.Property) { p.City = "Pune" },
+		},
+		{
+			name:   "property type not wanted",
+			client: func(c *models.Client) { c.PropertyTypes = []string{"house", "plot"} },
+		},
+		{
+			name:   "required amenity missing",
+			client: func(c *models.Client) { c.RequiredAmenities = []string{"parking", "swimming pool"} },
+		},
+		{
+			name:   "too small",
+			client: func(c *models.Client) { c.AreaMin = floatPtr(1100) },
+		},
+		{
+			name:   "too large",
+			client: func(c *models.Client) { c.AreaMax = floatPtr(800) },
+		},
+		{
+			name:     "too far above budget",
+			property: func(p *models.Property) { p.Price = 16600000 },
+		},
+		{
+			name: "score below the minimum",
+			client: func(c *models.Client) {
+				c.BudgetMin, c.BudgetMax = nil, nil
+				c.PreferredLocalities = []string{"Bandra"}
+			},
+			property: func(p *models.Property) { p.Bedrooms = intPtr(4) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := matchClient()
+			if tt.client != nil {
+				tt.client(client)
+			}
+			property := matchProperty()
+			if tt.property != nil {
+				tt.property(property)
+			}
+
+			if match, ok := scoreMatch(client, property); ok {
+				t.Errorf("scoreMatch() matched with score %d and reasons %v, want no match", match.Score, match.Reasons)
+			}
+		})
+	}
+}
+
+func TestScoreMatchHardRequirementsMet(t *testing.T) {
+	client := matchClient()
+	client.ListingType = stringPtr("sale")
+	client.PropertyTypes = []string{"Apartment"}
+	client.RequiredAmenities = []string{"parking"}
+	client.AreaMin, client.AreaMax = floatPtr(950), floatPtr(1200)
+
+	match, ok := scoreMatch(client, matchProperty())
+	if !ok {
+		t.Fatalf("scoreMatch() did not match (score %d)", match.Score)
+	}
+
+	want := []string{"has required amenities", "slightly smaller than required", "within budget", "preferred location", "2 BHK as required"}
+	if !reflect.DeepEqual(match.Reasons, want) {
+		t.Errorf("scoreMatch() reasons = %v, want %v", match.Reasons, want)
+	}
+}
+
+func TestParseBHK(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   int
+		wantOK bool
+	}{
+		{"2BHK", 2, true},
+		{"2 bhk", 2, true},
+		{"Looking for 3-BHK near station", 3, true},
+		{"2.5 BHK with a study", 2, true},
+		{"10 BHK bungalow", 10, true},
+		{"2BHK or 3BHK", 2, true},
+		{"spacious flat, sea view", 0, false},
+		{"BHK", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := parseBHK(tt.text)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseBHK(%q) = %d, %v; want %d, %v", tt.text, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestScoreMatchBedroomsFromRequirements(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements string
+		bedrooms     *int
+		wantScore    int
+	}{
+		{"as required", "2 BHK", intPtr(2), 100},
+		{"one off", "3 BHK", intPtr(2), 85},
+		{"two off", "4 BHK", intPtr(2), 75},
+		{"listing without bedrooms", "2 BHK", nil, 75},
+		{"no preference", "near the station", intPtr(2), 87},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := matchClient()
+			client.BedroomsMin, client.BedroomsMax = nil, nil
+			client.Requirements = tt.requirements
+			property := matchProperty()
+			property.Bedrooms = tt.bedrooms
+
+			match, ok := scoreMatch(client, property)
+			if !ok {
+				t.Fatalf("scoreMatch() did not match (score %d)", match.Score)
+			}
+			if match.Score != tt.wantScore {
+				t.Errorf("scoreMatch() score = %d, want %d", match.Score, tt.wantScore)
+			}
+		})
+	}
+}
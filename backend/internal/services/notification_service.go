@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+	"log"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+	"enfor-data-backend/internal/utils"
+)
+
+// Default and maximum number of notifications returned per request
+const (
+	defaultNotificationLimit = 50
+	maxNotificationLimit     = 200
+)
+
+// NotificationService delivers in-app notifications and emails
+type NotificationService struct {
+	notificationRepo *repository.NotificationRepository
+	userRepo         *repository.UserRepository
+	mailer           *utils.Mailer
+}
+
+// NewNotificationService creates a new NotificationService instance
+func NewNotificationService(
+	notificationRepo *repository.NotificationRepository,
+	userRepo *repository.UserRepository,
+	mailer *utils.Mailer,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		mailer:           mailer,
+	}
+}
+
+// NotifyOptions controls how a notification is delivered
+type NotifyOptions struct {
+	InApp      bool
+	Email      bool
+	EntityType string
+	EntityID   string
+}
+
+// Notify delivers a notification to a user in-app and/or by email
+func (s *NotificationService) Notify(userID, notificationType, title, message string, opts NotifyOptions) error {
+	if opts.InApp {
+		notification := &models.Notification{
+			UserID:  userID,
+			Type:    notificationType,
+			Title:   title,
+			Message: message,
+		}
+		if opts.EntityType != "" {
+			notification.EntityType = &opts.EntityType
+		}
+		if opts.EntityID != "" {
+			notification.EntityID = &opts.EntityID
+		}
+
+		if err := s.notificationRepo.Create(notification); err != nil {
+			return fmt.Errorf("failed to create notification: %w", err)
+		}
+	}
+
+	if opts.Email {
+		user, err := s.userRepo.GetUserByID(userID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch notification recipient: %w", err)
+		}
+
+		if err := s.mailer.Send(user.Email, title, message); err != nil {
+			return fmt.Errorf("failed to email notification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetUserNotifications retrieves a user's notifications and their unread count
+func (s *NotificationService) GetUserNotifications(userID string, filters models.NotificationFilters) ([]models.Notification, int, error) {
+	if filters.Limit <= 0 {
+		filters.Limit = defaultNotificationLimit
+	}
+	if filters.Limit > maxNotificationLimit {
+		filters.Limit = maxNotificationLimit
+	}
+
+	notifications, err := s.notificationRepo.GetByUserID(userID, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	unread, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return notifications, unread, nil
+}
+
+// MarkNotificationRead marks one of the user's notifications as read
+func (s *NotificationService) MarkNotificationRead(id, userID string) error {
+	return s.notificationRepo.MarkRead(id, userID)
+}
+
+// MarkAllNotificationsRead marks all of the user's notifications as read
+func (s *NotificationService) MarkAllNotificationsRead(userID string) error {
+	if err := s.notificationRepo.MarkAllRead(userID); err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
+
+// logNotifyError logs a failed best-effort notification
+func logNotifyError(context string, err error) {
+	if err != nil {
+		log.Printf("Failed to send %s notification: %v", context, err)
+	}
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// Pipeline stage kinds
+const (
+	StageKindOpen = "open"
+	StageKindWon  = "won"
+	StageKindLost = "lost"
+)
+
+// defaultBoardLimit is how many clients each board column shows when no limit is given
+const defaultBoardLimit = 20
+
+// defaultPipelineStages seeds a broker's pipeline the first time it is used
+var defaultPipelineStages = []models.PipelineStage{
+	{Name: "New", Kind: StageKindOpen},
+	{Name: "Contacted", Kind: StageKindOpen},
+	{Name: "Site Visit Done", Kind: StageKindOpen},
+	{Name: "Negotiation", Kind: StageKindOpen},
+	{Name: "Token Paid", Kind: StageKindOpen},
+	{Name: "Closed Won", Kind: StageKindWon},
+	{Name: "Closed Lost", Kind: StageKindLost},
+}
+
+// PipelineService handles business logic for the sales pipeline
+type PipelineService struct {
+	pipelineRepo *repository.PipelineRepository
+	clientRepo   *repository.ClientRepository
+	matchService *MatchService
+}
+
+// NewPipelineService creates a new PipelineService instance
+func NewPipelineService(
+	pipelineRepo *repository.PipelineRepository,
+	clientRepo *repository.ClientRepository,
+	matchService *MatchService,
+) *PipelineService {
+	return &PipelineService{
+		pipelineRepo: pipelineRepo,
+		clientRepo:   clientRepo,
+		matchService: matchService,
+	}
+}
+
+// GetStages retrieves a broker's pipeline stages, creating the default pipeline on first use
+func (s *PipelineService) GetStages(brokerID string) ([]models.PipelineStage, error) {
+	if err := s.pipelineRepo.EnsureStages(brokerID, defaultPipelineStages); err != nil {
+		return nil, fmt.Errorf("failed to set up pipeline: %w", err)
+	}
+
+	stages, err := s.pipelineRepo.GetStages(brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline stages: %w", err)
+	}
+
+	return stages, nil
+}
+
+// CreateStage adds a stage to the end of a broker's pipeline
+func (s *PipelineService) CreateStage(req *models.CreatePipelineStageRequest, brokerID string) (*models.PipelineStage, error) {
+	if err := s.pipelineRepo.EnsureStages(brokerID, defaultPipelineStages); err != nil {
+		return nil, fmt.Errorf("failed to set up pipeline: %w", err)
+	}
+
+	stage := &models.PipelineStage{
+		BrokerID: brokerID,
+		Name:     strings.TrimSpace(req.Name),
+		Kind:     req.Kind,
+	}
+	if stage.Kind == "" {
+		stage.Kind = StageKindOpen
+	}
+
+	if err := s.pipelineRepo.CreateStage(stage); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create pipeline stage: %w", err)
+	}
+
+	return stage, nil
+}
+
+// GetStageByID retrieves a pipeline stage with broker ownership verification
+func (s *PipelineService) GetStageByID(id, brokerID string) (*models.PipelineStage, error) {
+	stage, err := s.pipelineRepo.GetStageByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if stage.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: pipeline stage does not belong to this broker")
+	}
+
+	return stage, nil
+}
+
+// UpdateStage renames a stage or changes its kind
+// Clients already in the stage keep their status until they are next moved
+func (s *PipelineService) UpdateStage(id string, req *models.UpdatePipelineStageRequest, brokerID string) (*models.PipelineStage, error) {
+	stage, err := s.GetStageByID(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		stage.Name = strings.TrimSpace(*req.Name)
+	}
+
+	if req.Kind != nil && *req.Kind != stage.Kind {
+		if stage.Kind == StageKindOpen {
+			if err := s.ensureAnotherOpenStage(stage); err != nil {
+				return nil, err
+			}
+		}
+		stage.Kind = *req.Kind
+	}
+
+	if err := s.pipelineRepo.UpdateStage(stage); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update pipeline stage: %w", err)
+	}
+
+	return stage, nil
+}
+
+// ReorderStages sets the board order of a broker's stages
+func (s *PipelineService) ReorderStages(req *models.ReorderPipelineStagesRequest, brokerID string) ([]models.PipelineStage, error) {
+	stages, err := s.pipelineRepo.ReorderStages(brokerID, req.StageIDs)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid stage order") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to reorder pipeline stages: %w", err)
+	}
+
+	return stages, nil
+}
+
+// DeleteStage removes an empty stage from a broker's pipeline
+func (s *PipelineService) DeleteStage(id, brokerID string) error {
+	stage, err := s.GetStageByID(id, brokerID)
+	if err != nil {
+		return err
+	}
+
+	if stage.Kind == StageKindOpen {
+		if err := s.ensureAnotherOpenStage(stage); err != nil {
+			return err
+		}
+	}
+
+	if err := s.pipelineRepo.DeleteStage(id); err != nil {
+		if strings.HasPrefix(err.Error(), "cannot") {
+			return err
+		}
+		return fmt.Errorf("failed to delete pipeline stage: %w", err)
+	}
+
+	return nil
+}
+
+// MoveClient moves a client to another stage and records the transition
+// The client status follows the stage kind: won stages convert the client, lost stages
+// make it inactive and open stages make it active again
+func (s *PipelineService) MoveClient(clientID string, req *models.MoveClientStageRequest, brokerID string) (*models.Client, *models.StageTransition, error) {
+	client, err := s.getClient(clientID, brokerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stage, err := s.GetStageByID(req.StageID, brokerID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid stage_id: %w", err)
+	}
+
+	var lostReason *string
+	if stage.Kind == StageKindLost {
+		if req.LostReason == nil || strings.TrimSpace(*req.LostReason) == "" {
+			return nil, nil, fmt.Errorf("lost_reason is required when moving a client to a lost stage")
+		}
+		reason := strings.TrimSpace(*req.LostReason)
+		lostReason = &reason
+	}
+
+	transition, err := s.pipelineRepo.MoveClient(client, stage, clientStatusForStage(stage.Kind), lostReason, brokerID)
+	if err != nil {
+		if err.Error() == "client not found" {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to move client: %w", err)
+	}
+
+	// Converted and inactive clients drop out of matching
+	if err := s.matchService.EvaluateClient(client); err != nil {
+		log.Printf("Failed to evaluate matches for client %s: %v", client.ID, err)
+	}
+
+	return client, transition, nil
+}
+
+// GetStageHistory retrieves a client's stage transitions, newest first
+func (s *PipelineService) GetStageHistory(clientID, brokerID string) ([]models.StageTransition, error) {
+	if _, err := s.getClient(clientID, brokerID); err != nil {
+		return nil, err
+	}
+
+	transitions, err := s.pipelineRepo.GetTransitions(clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stage history: %w", err)
+	}
+
+	return transitions, nil
+}
+
+// GetBoard retrieves the broker's pipeline grouped by stage
+func (s *PipelineService) GetBoard(req *models.PipelineBoardRequest, brokerID string) ([]models.PipelineBoardColumn, error) {
+	if err := s.pipelineRepo.EnsureStages(brokerID, defaultPipelineStages); err != nil {
+		return nil, fmt.Errorf("failed to set up pipeline: %w", err)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultBoardLimit
+	}
+
+	columns, err := s.pipelineRepo.GetBoard(brokerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline board: %w", err)
+	}
+
+	return columns, nil
+}
+
+// PlaceNewClient puts a newly created client into the pipeline
+// Placement is best-effort and never fails the client write that triggered it
+func (s *PipelineService) PlaceNewClient(client *models.Client) {
+	if err := s.pipelineRepo.EnsureStages(client.BrokerID, defaultPipelineStages); err != nil {
+		log.Printf("Failed to place client %s in the pipeline: %v", client.ID, err)
+		return
+	}
+
+	placed, err := s.clientRepo.GetByID(client.ID)
+	if err != nil {
+		log.Printf("Failed to reload pipeline stage for client %s: %v", client.ID, err)
+		return
+	}
+
+	client.PipelineStageID = placed.PipelineStageID
+	client.StageChangedAt = placed.StageChangedAt
+}
+
+// PlaceTransferredClients puts clients handed over by another broker into the receiving broker's pipeline
+// Placement is best-effort and never fails the transfer that triggered it
+func (s *PipelineService) PlaceTransferredClients(brokerID string) {
+	if err := s.pipelineRepo.EnsureStages(brokerID, defaultPipelineStages); err != nil {
+		log.Printf("Failed to place transferred clients in the pipeline of broker %s: %v", brokerID, err)
+	}
+}
+
+// getClient retrieves a client with broker ownership verification
+func (s *PipelineService) getClient(clientID, brokerID string) (*models.Client, error) {
+	client, err := s.clientRepo.GetByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: client does not belong to this broker")
+	}
+
+	return client, nil
+}
+
+// ensureAnotherOpenStage rejects changes that would leave the pipeline without an open stage,
+// since new clients are placed in the first open stage
+func (s *PipelineService) ensureAnotherOpenStage(stage *models.PipelineStage) error {
+	stages, err := s.pipelineRepo.GetStages(stage.BrokerID)
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline stages: %w", err)
+	}
+
+	for _, other := range stages {
+		if other.ID != stage.ID && other.Kind == StageKindOpen {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot remove the last open stage from the pipeline")
+}
+
+// clientStatusForStage maps a stage kind to the client status it implies
+func clientStatusForStage(kind string) string {
+	switch kind {
+	case StageKindWon:
+		return "converted"
+	case StageKindLost:
+		return "inactive"
+	default:
+		return "active"
+	}
+}
@@ -0,0 +1,479 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+	"enfor-data-backend/internal/utils"
+)
+
+// portalTokenBytes is the amount of randomness in a portal sign-in token (encodes to 43 characters)
+const portalTokenBytes = 32
+
+// Answers a client can give to an appointment on the portal
+const (
+	portalResponseConfirmed = "confirmed"
+	portalResponseCancelled = "cancelled"
+)
+
+// PortalService handles the client portal, where buyers and tenants sign in with an emailed link to see
+// their upcoming appointments and the listings their broker shared with them
+// A portal session names one clients row, and every portal request is checked against that row only
+type PortalService struct {
+	portalRepo          *repository.PortalRepository
+	clientRepo          *repository.ClientRepository
+	appointmentRepo     *repository.AppointmentRepository
+	propertyRepo        *repository.PropertyRepository
+	userRepo            *repository.UserRepository
+	activityService     *ActivityService
+	notificationService *NotificationService
+	mailer              *utils.Mailer
+	jwtUtil             *utils.JWTUtil
+	portalURL           string
+	publicURL           string
+	linkTTL             time.Duration
+	sessionTTL          time.Duration
+}
+
+// NewPortalService creates a new PortalService instance
+// portalURL is the frontend page sign-in links open; publicURL is the base URL listing images are served from
+func NewPortalService(
+	portalRepo *repository.PortalRepository,
+	clientRepo *repository.ClientRepository,
+	appointmentRepo *repository.AppointmentRepository,
+	propertyRepo *repository.PropertyRepository,
+	userRepo *repository.UserRepository,
+	activityService *ActivityService,
+	notificationService *NotificationService,
+	mailer *utils.Mailer,
+	jwtSecret, portalURL, publicURL string,
+	linkTTL, sessionTTL time.Duration,
+) *PortalService {
+	return &PortalService{
+		portalRepo:          portalRepo,
+		clientRepo:          clientRepo,
+		appointmentRepo:     appointmentRepo,
+		propertyRepo:        propertyRepo,
+		userRepo:            userRepo,
+		activityService:     activityService,
+		notificationService: notificationService,
+		mailer:              mailer,
+		jwtUtil:             utils.NewJWTUtil(jwtSecret, sessionTTL),
+		portalURL:           portalURL,
+		publicURL:           strings.TrimRight(publicURL, "/"),
+		linkTTL:             linkTTL,
+		sessionTTL:          sessionTTL,
+	}
+}
+
+// RequestLink emails a sign-in link to a buyer or tenant client, one link per broker they are a client of
+// Addresses that belong to no such client are ignored, so callers can't tell whether a client exists
+func (s *PortalService) RequestLink(req *models.PortalLoginRequest) error {
+	clients, err := s.clientRepo.FindByEmail(req.Email, models.PortalClientTypes)
+	if err != nil {
+		return fmt.Errorf("failed to look up clients: %w", err)
+	}
+	if len(clients) == 0 {
+		return nil
+	}
+
+	expiresAt := time.Now().Add(s.linkTTL)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hello %s,\n\n", clients[0].FirstName)
+	body.WriteString("Open the link below to see your upcoming appointments and the properties shared with you.\n")
+
+	for _, client := range clients {
+		token, err := generatePortalToken()
+		if err != nil {
+			return err
+		}
+
+		if err := s.portalRepo.CreateToken(client.ID, hashPortalToken(token), expiresAt); err != nil {
+			return err
+		}
+
+		broker := "your broker"
+		if client.BrokerName != nil && *client.BrokerName != "" {
+			broker = *client.BrokerName
+		}
+		fmt.Fprintf(&body, "\nWith %s:\n%s\n", broker, s.linkURL(token))
+	}
+
+	fmt.Fprintf(&body, "\nEach link works once, within %d minutes. If you didn't ask to sign in, you can ignore this email.\n",
+		int(s.linkTTL.Minutes()))
+
+	if err := s.mailer.Send(clients[0].Email, "Your sign-in link", body.String()); err != nil {
+		return fmt.Errorf("failed to send sign-in link: %w", err)
+	}
+
+	return nil
+}
+
+// StartSession exchanges a sign-in link's token for a portal session; each link can be used once
+func (s *PortalService) StartSession(req *models.PortalSessionRequest) (*models.PortalSession, error) {
+	clientID, err := s.portalRepo.ConsumeToken(hashPortalToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.portalClient(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.sessionTTL)
+	token, err := s.jwtUtil.GenerateToken(client.ID, client.Email, models.PortalRole)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate portal token: %w", err)
+	}
+
+	profile, err := s.profile(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PortalSession{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		Client:    *profile,
+	}, nil
+}
+
+// ValidateSession validates a portal session token and returns the client it was issued for
+func (s *PortalService) ValidateSession(tokenString string) (string, error) {
+	claims, err := s.jwtUtil.ValidateToken(tokenString)
+	if err != nil {
+		return "", err
+	}
+
+	if claims.Role != models.PortalRole {
+		return "", fmt.Errorf("invalid token: not a client portal token")
+	}
+
+	return claims.UserID, nil
+}
+
+// GetProfile retrieves the signed-in client's own record and their broker's contact details
+func (s *PortalService) GetProfile(clientID string) (*models.PortalClient, error) {
+	client, err := s.portalClient(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.profile(client)
+}
+
+// GetAppointments retrieves the signed-in client's scheduled appointments from today on, soonest first
+func (s *PortalService) GetAppointments(clientID string) ([]models.PortalAppointment, error) {
+	client, err := s.portalClient(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	appointments, err := s.appointmentRepo.GetUpcomingByClientID(client.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get appointments: %w", err)
+	}
+
+	views := make([]models.PortalAppointment, len(appointments))
+	for i := range appointments {
+		views[i] = portalAppointment(&appointments[i])
+	}
+
+	return views, nil
+}
+
+// ConfirmAppointment records the signed-in client confirming they will attend an appointment
+func (s *PortalService) ConfirmAppointment(appointmentID, clientID string) (*models.PortalAppointment, error) {
+	return s.respond(appointmentID, clientID, portalResponseConfirmed, "")
+}
+
+// CancelAppointment cancels an appointment on behalf of the signed-in client
+func (s *PortalService) CancelAppointment(appointmentID, clientID string, req *models.PortalCancelRequest) (*models.PortalAppointment, error) {
+	reason := ""
+	if text := optionalText(req.Reason); text != nil {
+		reason = *text
+	}
+
+	return s.respond(appointmentID, clientID, portalResponseCancelled, reason)
+}
+
+// GetProperties retrieves the listings shared with the signed-in client, newest first
+func (s *PortalService) GetProperties(clientID string) ([]models.PortalProperty, error) {
+	client, err := s.portalClient(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := s.portalRepo.GetSharedProperties(client.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(shared))
+	for i, property := range shared {
+		ids[i] = property.PropertyID
+	}
+
+	properties, err := s.propertyRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared properties: %w", err)
+	}
+
+	byID := make(map[string]*models.Property, len(properties))
+	for i := range properties {
+		byID[properties[i].ID] = &properties[i]
+	}
+
+	views := []models.PortalProperty{}
+	for _, share := range shared {
+		property, ok := byID[share.PropertyID]
+		if !ok {
+			continue
+		}
+
+		views = append(views, models.PortalProperty{
+			PropertyID: share.PropertyID,
+			Note:       share.Note,
+			SharedAt:   share.SharedAt,
+			Listing:    newSharedListing(property, s.publicURL, false),
+		})
+	}
+
+	return views, nil
+}
+
+// GetSharedProperties retrieves the listings shared with one of the broker's clients
+func (s *PortalService) GetSharedProperties(clientID, brokerID string) ([]models.ClientSharedProperty, error) {
+	if _, err := s.ownedClient(clientID, brokerID); err != nil {
+		return nil, err
+	}
+
+	return s.portalRepo.GetSharedProperties(clientID)
+}
+
+// ShareProperties shares some of the broker's listings with one of their clients on the portal
+func (s *PortalService) ShareProperties(clientID string, req *models.SharePropertiesRequest, brokerID string) ([]models.ClientSharedProperty, error) {
+	if _, err := s.ownedClient(clientID, brokerID); err != nil {
+		return nil, err
+	}
+
+	propertyIDs := uniqueStrings(req.PropertyIDs)
+	properties, err := s.propertyRepo.GetByIDs(propertyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get properties: %w", err)
+	}
+
+	if len(properties) != len(propertyIDs) {
+		return nil, fmt.Errorf("invalid property_ids: properties not found")
+	}
+	for _, property := range properties {
+		if property.BrokerID != brokerID {
+			return nil, fmt.Errorf("invalid property_ids: only your own listings can be shared")
+		}
+	}
+
+	if err := s.portalRepo.ShareProperties(clientID, propertyIDs, optionalText(req.Note), brokerID); err != nil {
+		return nil, err
+	}
+
+	return s.portalRepo.GetSharedProperties(clientID)
+}
+
+// UnshareProperty stops sharing a listing with one of the broker's clients
+func (s *PortalService) UnshareProperty(clientID, propertyID, brokerID string) error {
+	if _, err := s.ownedClient(clientID, brokerID); err != nil {
+		return err
+	}
+
+	return s.portalRepo.UnshareProperty(clientID, propertyID)
+}
+
+// respond records the client's answer to one of their own upcoming appointments and tells the broker
+// Cancelling also cancels the appointment; confirming again changes nothing
+func (s *PortalService) respond(appointmentID, clientID, response, reason string) (*models.PortalAppointment, error) {
+	client, err := s.portalClient(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment, err := s.appointmentRepo.GetByID(appointmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if appointment.ClientID != client.ID {
+		return nil, fmt.Errorf("access denied: appointment belongs to another client")
+	}
+
+	if appointment.Status != "scheduled" {
+		return nil, fmt.Errorf("cannot %s an appointment that is %s", portalResponseVerb(response), appointment.Status)
+	}
+
+	date, _ := appointmentDateTime(appointment)
+	if date < time.Now().Format("2006-01-02") {
+		return nil, fmt.Errorf("cannot %s an appointment that has passed", portalResponseVerb(response))
+	}
+
+	if appointment.ClientResponse != nil && *appointment.ClientResponse == response {
+		view := portalAppointment(appointment)
+		return &view, nil
+	}
+
+	now := time.Now()
+	appointment.ClientResponse = &response
+	appointment.ClientRespondedAt = &now
+	if response == portalResponseCancelled {
+		appointment.Status = "cancelled"
+	}
+
+	if err := s.appointmentRepo.Update(appointment); err != nil {
+		return nil, fmt.Errorf("failed to update appointment: %w", err)
+	}
+
+	s.activityService.RecordClientResponse(appointment, reason)
+	s.notifyBroker(client, appointment, reason)
+
+	view := portalAppointment(appointment)
+	return &view, nil
+}
+
+// notifyBroker tells the broker a client confirmed or cancelled an appointment on the portal
+func (s *PortalService) notifyBroker(client *models.Client, appointment *models.Appointment, reason string) {
+	label := appointmentTypeLabels[appointment.Type]
+	if label == "" {
+		label = "Appointment"
+	}
+
+	notificationType := "appointment_confirmed"
+	title := "Appointment confirmed"
+	message := fmt.Sprintf("%s confirmed the %s on %s: %s",
+		clientFullName(client), strings.ToLower(label), appointmentWhen(appointment), appointment.Title)
+	if *appointment.ClientResponse == portalResponseCancelled {
+		notificationType = "appointment_cancelled"
+		title = "Appointment cancelled by client"
+		message = fmt.Sprintf("%s cancelled the %s on %s: %s",
+			clientFullName(client), strings.ToLower(label), appointmentWhen(appointment), appointment.Title)
+		if reason != "" {
+			message += " (" + reason + ")"
+		}
+	}
+
+	err := s.notificationService.Notify(appointment.BrokerID, notificationType, title, message, NotifyOptions{
+		InApp:      true,
+		Email:      true,
+		EntityType: "appointment",
+		EntityID:   appointment.ID,
+	})
+	logNotifyError("portal appointment", err)
+}
+
+// portalClient loads the client a portal session was issued for
+// Sessions end once the client is deleted, merged away or no longer a buyer or tenant
+func (s *PortalService) portalClient(clientID string) (*models.Client, error) {
+	client, err := s.clientRepo.GetByID(clientID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("unauthorized: portal access has ended")
+		}
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	for _, clientType := range models.PortalClientTypes {
+		if client.Type == clientType {
+			return client, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unauthorized: portal access has ended")
+}
+
+// profile builds the portal view of a client and their broker's contact details
+func (s *PortalService) profile(client *models.Client) (*models.PortalClient, error) {
+	broker, err := s.userRepo.GetUserByID(client.BrokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch broker information: %w", err)
+	}
+
+	return &models.PortalClient{
+		ID:        client.ID,
+		FirstName: client.FirstName,
+		LastName:  client.LastName,
+		Email:     client.Email,
+		Phone:     client.Phone,
+		Broker:    newSharedListingBroker(broker),
+	}, nil
+}
+
+// ownedClient loads a client with broker ownership verification
+func (s *PortalService) ownedClient(clientID, brokerID string) (*models.Client, error) {
+	client, err := s.clientRepo.GetByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: client does not belong to this broker")
+	}
+
+	return client, nil
+}
+
+// linkURL returns the portal sign-in URL carrying a token
+func (s *PortalService) linkURL(token string) string {
+	separator := "?"
+	if strings.Contains(s.portalURL, "?") {
+		separator = "&"
+	}
+	return s.portalURL + separator + "token=" + url.QueryEscape(token)
+}
+
+// portalAppointment builds the client's view of an appointment
+func portalAppointment(appointment *models.Appointment) models.PortalAppointment {
+	date, clock := appointmentDateTime(appointment)
+
+	return models.PortalAppointment{
+		ID:                appointment.ID,
+		Title:             appointment.Title,
+		Date:              date,
+		Time:              clock,
+		Type:              appointment.Type,
+		Status:            appointment.Status,
+		ClientResponse:    appointment.ClientResponse,
+		ClientRespondedAt: appointment.ClientRespondedAt,
+		PropertyID:        appointment.PropertyID,
+		PropertyAddress:   appointment.PropertyAddress,
+	}
+}
+
+// portalResponseVerb names an appointment answer in error messages
+func portalResponseVerb(response string) string {
+	if response == portalResponseCancelled {
+		return "cancel"
+	}
+	return "confirm"
+}
+
+// generatePortalToken returns a random URL-safe token for a portal sign-in link
+func generatePortalToken() (string, error) {
+	buf := make([]byte, portalTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate portal token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashPortalToken returns the form a portal sign-in token is stored in
+func hashPortalToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
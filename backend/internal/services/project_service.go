@@ -0,0 +1,390 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// UnitBlockDuration is how long a broker's block on a unit holds before it lapses
+const UnitBlockDuration = 48 * time.Hour
+
+// ProjectService handles business logic for channel partner projects and unit inventory
+type ProjectService struct {
+	projectRepo         *repository.ProjectRepository
+	clientRepo          *repository.ClientRepository
+	userRepo            *repository.UserRepository
+	notificationService *NotificationService
+}
+
+// NewProjectService creates a new ProjectService instance
+func NewProjectService(
+	projectRepo *repository.ProjectRepository,
+	clientRepo *repository.ClientRepository,
+	userRepo *repository.UserRepository,
+	notificationService *NotificationService,
+) *ProjectService {
+	return &ProjectService{
+		projectRepo:         projectRepo,
+		clientRepo:          clientRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+	}
+}
+
+// CreateProject creates a new project owned by the channel partner
+func (s *ProjectService) CreateProject(req *models.CreateProjectRequest, channelPartnerID string) (*models.Project, error) {
+	if err := validateProjectPriceRange(req.PriceRangeMin, req.PriceRangeMax); err != nil {
+		return nil, err
+	}
+
+	project := &models.Project{
+		Name:             req.Name,
+		BuilderName:      req.BuilderName,
+		ProjectType:      req.ProjectType,
+		Location:         req.Location,
+		City:             req.City,
+		State:            req.State,
+		PriceRangeMin:    req.PriceRangeMin,
+		PriceRangeMax:    req.PriceRangeMax,
+		Description:      req.Description,
+		Amenities:        req.Amenities,
+		Images:           req.Images,
+		LaunchDate:       req.LaunchDate,
+		PossessionDate:   req.PossessionDate,
+		Status:           req.Status,
+		ChannelPartnerID: channelPartnerID,
+	}
+
+	// Set default status if not provided
+	if project.Status == "" {
+		project.Status = "upcoming"
+	}
+	if project.Amenities == nil {
+		project.Amenities = []string{}
+	}
+	if project.Images == nil {
+		project.Images = []string{}
+	}
+
+	if err := s.projectRepo.Create(project); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return project, nil
+}
+
+// GetChannelPartnerProjects retrieves all projects owned by a channel partner
+func (s *ProjectService) GetChannelPartnerProjects(channelPartnerID string, filters models.ProjectFilters) ([]models.Project, error) {
+	projects, err := s.projectRepo.GetByChannelPartnerID(channelPartnerID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel partner projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// GetOwnedProject retrieves a project with channel partner ownership verification
+func (s *ProjectService) GetOwnedProject(id, channelPartnerID string) (*models.Project, error) {
+	project, err := s.projectRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.ChannelPartnerID != channelPartnerID {
+		return nil, fmt.Errorf("access denied: project does not belong to this channel partner")
+	}
+
+	return project, nil
+}
+
+// GetOwnedProjectDetail retrieves a channel partner's project with its full unit inventory
+func (s *ProjectService) GetOwnedProjectDetail(id, channelPartnerID string) (*models.ProjectDetail, error) {
+	project, err := s.GetOwnedProject(id, channelPartnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := s.projectRepo.GetUnitsByProjectID(project.ID, models.ProjectUnitFilters{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project units: %w", err)
+	}
+
+	return &models.ProjectDetail{Project: *project, Units: units}, nil
+}
+
+// UpdateProject updates a project with ownership verification
+func (s *ProjectService) UpdateProject(id string, req *models.UpdateProjectRequest, channelPartnerID string) (*models.Project, error) {
+	project, err := s.GetOwnedProject(id, channelPartnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		project.Name = *req.Name
+	}
+	if req.BuilderName != nil {
+		project.BuilderName = *req.BuilderName
+	}
+	if req.ProjectType != nil {
+		project.ProjectType = *req.ProjectType
+	}
+	if req.Location != nil {
+		project.Location = *req.Location
+	}
+	if req.City != nil {
+		project.City = *req.City
+	}
+	if req.State != nil {
+		project.State = *req.State
+	}
+	if req.PriceRangeMin != nil {
+		project.PriceRangeMin = *req.PriceRangeMin
+	}
+	if req.PriceRangeMax != nil {
+		project.PriceRangeMax = *req.PriceRangeMax
+	}
+	if req.Description != nil {
+		project.Description = *req.Description
+	}
+	if req.Amenities != nil {
+		project.Amenities = req.Amenities
+	}
+	if req.Images != nil {
+		project.Images = req.Images
+	}
+	if req.LaunchDate != nil {
+		project.LaunchDate = req.LaunchDate
+	}
+	if req.PossessionDate != nil {
+		project.PossessionDate = req.PossessionDate
+	}
+	if req.Status != nil {
+		project.Status = *req.Status
+	}
+
+	// Validate against the merged values so a partial update cannot invert the range
+	if err := validateProjectPriceRange(project.PriceRangeMin, project.PriceRangeMax); err != nil {
+		return nil, err
+	}
+
+	if err := s.projectRepo.Update(project); err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return project, nil
+}
+
+// UpdateProjectBrochure records the uploaded brochure URL on a project
+func (s *ProjectService) UpdateProjectBrochure(id, brochureURL, channelPartnerID string) (*models.Project, error) {
+	project, err := s.GetOwnedProject(id, channelPartnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	project.BrochureURL = &brochureURL
+
+	if err := s.projectRepo.Update(project); err != nil {
+		return nil, fmt.Errorf("failed to update project brochure: %w", err)
+	}
+
+	return project, nil
+}
+
+// DeleteProject deletes a project and its units with ownership verification
+func (s *ProjectService) DeleteProject(id, channelPartnerID string) error {
+	if _, err := s.GetOwnedProject(id, channelPartnerID); err != nil {
+		return err
+	}
+
+	if err := s.projectRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	return nil
+}
+
+// AddProjectUnits adds a batch of units to a channel partner's project
+func (s *ProjectService) AddProjectUnits(projectID string, req *models.CreateProjectUnitsRequest, channelPartnerID string) ([]models.ProjectUnit, error) {
+	if _, err := s.GetOwnedProject(projectID, channelPartnerID); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(req.Units))
+	units := make([]models.ProjectUnit, 0, len(req.Units))
+
+	for _, unitReq := range req.Units {
+		// Reject repeats within the batch before touching the database
+		key := unitReq.Tower + "/" + unitReq.UnitNumber
+		if seen[key] {
+			return nil, fmt.Errorf("unit %s in tower %s already exists", unitReq.UnitNumber, unitReq.Tower)
+		}
+		seen[key] = true
+
+		units = append(units, models.ProjectUnit{
+			ProjectID:     projectID,
+			Tower:         unitReq.Tower,
+			Floor:         unitReq.Floor,
+			UnitNumber:    unitReq.UnitNumber,
+			Configuration: unitReq.Configuration,
+			CarpetArea:    unitReq.CarpetArea,
+			Price:         unitReq.Price,
+		})
+	}
+
+	if err := s.projectRepo.CreateUnits(units); err != nil {
+		return nil, err
+	}
+
+	return units, nil
+}
+
+// UpdateProjectUnit updates a unit's details or status on a channel partner's project
+// Marking a unit available releases any broker block; booked keeps the block as a record of who sold it
+func (s *ProjectService) UpdateProjectUnit(projectID, unitID string, req *models.UpdateProjectUnitRequest, channelPartnerID string) (*models.ProjectUnit, error) {
+	if _, err := s.GetOwnedProject(projectID, channelPartnerID); err != nil {
+		return nil, err
+	}
+
+	unit, err := s.projectRepo.GetUnitByID(projectID, unitID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Configuration != nil {
+		unit.Configuration = *req.Configuration
+	}
+	if req.CarpetArea != nil {
+		unit.CarpetArea = req.CarpetArea
+	}
+	if req.Price != nil {
+		unit.Price = *req.Price
+	}
+	if req.Status != nil {
+		unit.Status = *req.Status
+		if unit.Status == "available" {
+			unit.BlockedByBrokerID = nil
+			unit.BlockedForClientID = nil
+			unit.BlockedAt = nil
+			unit.BlockExpiresAt = nil
+		}
+	}
+
+	if err := s.projectRepo.UpdateUnit(unit); err != nil {
+		return nil, fmt.Errorf("failed to update project unit: %w", err)
+	}
+
+	return unit, nil
+}
+
+// DeleteProjectUnit removes a unit from a channel partner's project
+func (s *ProjectService) DeleteProjectUnit(projectID, unitID, channelPartnerID string) error {
+	if _, err := s.GetOwnedProject(projectID, channelPartnerID); err != nil {
+		return err
+	}
+
+	return s.projectRepo.DeleteUnit(projectID, unitID)
+}
+
+// BrowseProjects retrieves projects from all channel partners for brokers
+func (s *ProjectService) BrowseProjects(filters models.ProjectFilters) ([]models.Project, error) {
+	projects, err := s.projectRepo.Search(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to browse projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// GetProjectForBroker retrieves a project with its units as seen by a broker
+// Other brokers' clients are hidden on blocked units
+func (s *ProjectService) GetProjectForBroker(id, brokerID string, filters models.ProjectUnitFilters) (*models.ProjectDetail, error) {
+	project, err := s.projectRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := s.projectRepo.GetUnitsByProjectID(project.ID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project units: %w", err)
+	}
+
+	for i := range units {
+		hideOtherBrokerClient(&units[i], brokerID)
+	}
+
+	return &models.ProjectDetail{Project: *project, Units: units}, nil
+}
+
+// BlockUnit blocks an available unit for one of the broker's clients for UnitBlockDuration
+// The owning channel partner is notified of the block
+func (s *ProjectService) BlockUnit(projectID, unitID string, req *models.BlockUnitRequest, brokerID string) (*models.ProjectUnit, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.clientRepo.GetByID(req.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client_id: %w", err)
+	}
+	if client.BrokerID != brokerID {
+		return nil, fmt.Errorf("client does not belong to broker")
+	}
+
+	// Distinguish a missing unit from one that is already taken
+	if _, err := s.projectRepo.GetUnitByID(projectID, unitID); err != nil {
+		return nil, err
+	}
+
+	unit, err := s.projectRepo.BlockUnit(projectID, unitID, brokerID, client.ID, UnitBlockDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	brokerName := "A broker"
+	if broker, err := s.userRepo.GetUserByID(brokerID); err == nil {
+		brokerName = broker.FirstName + " " + broker.LastName
+	}
+
+	message := fmt.Sprintf(
+		"%s blocked unit %s (tower %s, %s) in %s for a client until %s.",
+		brokerName, unit.UnitNumber, unit.Tower, unit.Configuration, project.Name,
+		unit.BlockExpiresAt.Format("02 Jan 2006 15:04 MST"),
+	)
+	err = s.notificationService.Notify(project.ChannelPartnerID, "unit_blocked", "Unit blocked", message, NotifyOptions{
+		InApp:      true,
+		Email:      true,
+		EntityType: "project",
+		EntityID:   project.ID,
+	})
+	logNotifyError("unit block", err)
+
+	return unit, nil
+}
+
+// ReleaseUnit releases a block the broker holds on a unit
+func (s *ProjectService) ReleaseUnit(projectID, unitID, brokerID string) (*models.ProjectUnit, error) {
+	if _, err := s.projectRepo.GetUnitByID(projectID, unitID); err != nil {
+		return nil, err
+	}
+
+	return s.projectRepo.ReleaseUnit(projectID, unitID, brokerID)
+}
+
+// hideOtherBrokerClient clears the client reference on units blocked by a different broker
+func hideOtherBrokerClient(unit *models.ProjectUnit, brokerID string) {
+	if unit.BlockedByBrokerID != nil && *unit.BlockedByBrokerID != brokerID {
+		unit.BlockedForClientID = nil
+	}
+}
+
+// validateProjectPriceRange checks that the project price range is not inverted
+func validateProjectPriceRange(min, max float64) error {
+	if min > max {
+		return fmt.Errorf("price_range_min cannot be greater than price_range_max")
+	}
+	return nil
+}
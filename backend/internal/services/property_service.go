@@ -2,6 +2,8 @@ package services
 
 import (
 	"fmt"
+	"log"
+	"time"
 
 	"enfor-data-backend/internal/models"
 	"enfor-data-backend/internal/repository"
@@ -9,29 +11,73 @@ import (
 
 // PropertyService handles business logic for property operations
 type PropertyService struct {
-	propertyRepo *repository.PropertyRepository
-	userRepo     *repository.UserRepository
+	propertyRepo        *repository.PropertyRepository
+	userRepo            *repository.UserRepository
+	matchService        *MatchService
+	duplicateService    *DuplicateService
+	savedSearchService  *SavedSearchService
+	notificationService *NotificationService
+	analyticsService    *AnalyticsService
+	tagService          *TagService
+	listingTTL          time.Duration
+	expiryNotice        time.Duration
 }
 
 // NewPropertyService creates a new PropertyService instance
-func NewPropertyService(propertyRepo *repository.PropertyRepository, userRepo *repository.UserRepository) *PropertyService {
+// listingTTL is how long a listing stays live; expiryNotice is how early brokers are reminded
+func NewPropertyService(
+	propertyRepo *repository.PropertyRepository,
+	userRepo *repository.UserRepository,
+	matchService *MatchService,
+	duplicateService *DuplicateService,
+	savedSearchService *SavedSearchService,
+	notificationService *NotificationService,
+	analyticsService *AnalyticsService,
+	tagService *TagService,
+	listingTTL time.Duration,
+	expiryNotice time.Duration,
+) *PropertyService {
 	return &PropertyService{
-		propertyRepo: propertyRepo,
-		userRepo:     userRepo,
+		propertyRepo:        propertyRepo,
+		userRepo:            userRepo,
+		matchService:        matchService,
+		duplicateService:    duplicateService,
+		savedSearchService:  savedSearchService,
+		notificationService: notificationService,
+		analyticsService:    analyticsService,
+		tagService:          tagService,
+		listingTTL:          listingTTL,
+		expiryNotice:        expiryNotice,
 	}
 }
 
 // CreateProperty creates a new property with business logic validation
-func (s *PropertyService) CreateProperty(req *models.CreatePropertyRequest, brokerID string) (*models.Property, error) {
+// Likely duplicate listings are returned as warnings; they never block creation
+func (s *PropertyService) CreateProperty(req *models.CreatePropertyRequest, brokerID string) (*models.Property, []models.DuplicateWarning, error) {
 	// Validate type-specific requirements
-	if err := s.validatePropertyTypeRequirements(req); err != nil {
-		return nil, err
+	if err := s.validatePropertyTypeRequirements(req.Type, req.Bedrooms, req.Bathrooms); err != nil {
+		return nil, nil, err
+	}
+
+	// Listings expire after the configured TTL unless an expiry is given
+	expiresAt := time.Now().Add(s.listingTTL)
+	if req.ExpiresAt != nil {
+		if err := validateListingExpiry(*req.ExpiresAt); err != nil {
+			return nil, nil, err
+		}
+		expiresAt = *req.ExpiresAt
 	}
 
 	// Fetch broker information from user repository
 	broker, err := s.userRepo.GetUserByID(brokerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch broker information: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch broker information: %w", err)
+	}
+
+	// Tags take the spelling the broker already uses
+	tags, err := s.tagService.NormalizeTags(brokerID, req.Tags)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Create property model from request
@@ -49,8 +95,11 @@ func (s *PropertyService) CreateProperty(req *models.CreatePropertyRequest, brok
 		State:       req.State,
 		Description: req.Description,
 		Amenities:   req.Amenities,
+		Images:      req.Images,
+		Tags:        tags,
 		Status:      "available", // Default status
 		BrokerID:    brokerID,
+		ExpiresAt:   &expiresAt,
 	}
 
 	// Populate broker information
@@ -62,18 +111,32 @@ func (s *PropertyService) CreateProperty(req *models.CreatePropertyRequest, brok
 	if property.Amenities == nil {
 		property.Amenities = []string{}
 	}
+	if property.Images == nil {
+		property.Images = []string{}
+	}
 
 	// Create property in repository
 	if err := s.propertyRepo.Create(property); err != nil {
-		return nil, fmt.Errorf("failed to create property: %w", err)
+		return nil, nil, fmt.Errorf("failed to create property: %w", err)
 	}
 
-	return property, nil
+	// Surface matching clients for the new listing and alert saved searches in the background
+	s.evaluateMatches(property)
+	go s.savedSearchService.EvaluateProperty(*property)
+
+	return property, s.detectDuplicates(property), nil
 }
 
-// GetBrokerProperties retrieves all properties for a specific broker
-func (s *PropertyService) GetBrokerProperties(brokerID string) ([]models.Property, error) {
-	properties, err := s.propertyRepo.GetByBrokerID(brokerID)
+// GetBrokerProperties retrieves all properties for a specific broker with optional filters
+func (s *PropertyService) GetBrokerProperties(brokerID string, filters models.PropertyFilters) ([]models.Property, error) {
+	// Tag filters are matched to the broker's spelling
+	tags, err := s.tagService.NormalizeTags(brokerID, filters.Tags)
+	if err != nil {
+		return nil, err
+	}
+	filters.Tags = tags
+
+	properties, err := s.propertyRepo.GetByBrokerID(brokerID, filters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get broker properties: %w", err)
 	}
@@ -81,22 +144,292 @@ func (s *PropertyService) GetBrokerProperties(brokerID string) ([]models.Propert
 	return properties, nil
 }
 
+// GetPropertyByID retrieves a property by ID with ownership verification
+func (s *PropertyService) GetPropertyByID(id, brokerID string) (*models.Property, error) {
+	property, err := s.propertyRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify property.broker_id matches requesting broker_id
+	if property.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: property does not belong to this broker")
+	}
+
+	return property, nil
+}
+
+// ViewProperty retrieves a property for its detail page and counts the view in listing analytics
+func (s *PropertyService) ViewProperty(id, brokerID string) (*models.Property, error) {
+	property, err := s.GetPropertyByID(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.analyticsService.RecordEvent(property.ID, property.BrokerID, models.ListingEventAppView)
+
+	return property, nil
+}
+
+// UpdateProperty updates a property with ownership verification and validation
+// Likely duplicate listings are returned as warnings; they never block the update
+func (s *PropertyService) UpdateProperty(id string, req *models.UpdatePropertyRequest, brokerID string) (*models.Property, []models.DuplicateWarning, error) {
+	// Verify ownership (reuse existing logic)
+	property, err := s.GetPropertyByID(id, brokerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Remember alert-relevant fields before applying updates
+	previousPrice, previousStatus := property.Price, property.Status
+
+	// Apply updates to property model
+	if req.Title != nil {
+		property.Title = *req.Title
+	}
+	if req.Type != nil {
+		property.Type = *req.Type
+	}
+	if req.ListingType != nil {
+		property.ListingType = *req.ListingType
+	}
+	if req.Price != nil {
+		property.Price = *req.Price
+	}
+	if req.Area != nil {
+		property.Area = *req.Area
+	}
+	if req.Bedrooms != nil {
+		property.Bedrooms = req.Bedrooms
+	}
+	if req.Bathrooms != nil {
+		property.Bathrooms = req.Bathrooms
+	}
+	if req.Location != nil {
+		property.Location = *req.Location
+	}
+	if req.Address != nil {
+		property.Address = *req.Address
+	}
+	if req.City != nil {
+		property.City = *req.City
+	}
+	if req.State != nil {
+		property.State = *req.State
+	}
+	if req.Description != nil {
+		property.Description = *req.Description
+	}
+	if req.Amenities != nil {
+		property.Amenities = req.Amenities
+	}
+	if req.Images != nil {
+		property.Images = req.Images
+	}
+	if req.Tags != nil {
+		tags, err := s.tagService.NormalizeTags(brokerID, req.Tags)
+		if err != nil {
+			return nil, nil, err
+		}
+		property.Tags = tags
+	}
+	if req.ExpiresAt != nil {
+		if err := validateListingExpiry(*req.ExpiresAt); err != nil {
+			return nil, nil, err
+		}
+		property.ExpiresAt = req.ExpiresAt
+	}
+	if req.Status != nil && *req.Status != property.Status {
+		property.Status = *req.Status
+		s.applyArchiveState(property)
+	}
+
+	// Validate type-specific requirements against the updated property
+	if err := s.validatePropertyTypeRequirements(property.Type, property.Bedrooms, property.Bathrooms); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.propertyRepo.Update(property); err != nil {
+		return nil, nil, fmt.Errorf("failed to update property: %w", err)
+	}
+
+	// Price, location or status changes can add or remove matches
+	s.evaluateMatches(property)
+
+	// Price drops and listings coming back on the market can satisfy saved searches
+	if property.Price != previousPrice || property.Status != previousStatus {
+		go s.savedSearchService.EvaluateProperty(*property)
+	}
+
+	return property, s.detectDuplicates(property), nil
+}
+
+// DeleteProperty moves a property to the trash with ownership verification
+func (s *PropertyService) DeleteProperty(id, brokerID string) error {
+	if _, err := s.GetPropertyByID(id, brokerID); err != nil {
+		return err
+	}
+
+	if err := s.propertyRepo.SoftDelete(id); err != nil {
+		return fmt.Errorf("failed to delete property: %w", err)
+	}
+
+	return nil
+}
+
+// RenewProperty extends a listing's expiry and brings an archived listing back on the market
+func (s *PropertyService) RenewProperty(id string, req *models.RenewPropertyRequest, brokerID string) (*models.Property, error) {
+	property, err := s.GetPropertyByID(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := s.listingTTL
+	if req.ExpiresInDays != nil {
+		ttl = time.Duration(*req.ExpiresInDays) * 24 * time.Hour
+	}
+	expiresAt := time.Now().Add(ttl)
+	property.ExpiresAt = &expiresAt
+
+	wasArchived := property.Status == "archived"
+	if wasArchived {
+		property.Status = "available"
+		s.applyArchiveState(property)
+	}
+
+	if err := s.propertyRepo.Update(property); err != nil {
+		return nil, fmt.Errorf("failed to renew property: %w", err)
+	}
+
+	// A relisted property can match clients and saved searches again
+	if wasArchived {
+		s.evaluateMatches(property)
+		go s.savedSearchService.EvaluateProperty(*property)
+	}
+
+	return property, nil
+}
+
+// RunListingExpiry reminds brokers about listings that expire soon and archives expired ones
+// Called by the background scheduler; failures are logged and retried on the next run
+func (s *PropertyService) RunListingExpiry() {
+	expiring, err := s.propertyRepo.GetExpiringListings(time.Now().Add(s.expiryNotice))
+	if err != nil {
+		log.Printf("Failed to load expiring listings: %v", err)
+	} else {
+		for i := range expiring {
+			s.notifyListingExpiring(&expiring[i])
+		}
+	}
+
+	archived, err := s.propertyRepo.ArchiveExpired()
+	if err != nil {
+		log.Printf("Failed to archive expired listings: %v", err)
+		return
+	}
+
+	for i := range archived {
+		property := &archived[i]
+
+		// Archived listings no longer match clients
+		s.evaluateMatches(property)
+
+		err := s.notificationService.Notify(
+			property.BrokerID,
+			"listing_archived",
+			"Listing archived",
+			fmt.Sprintf("%q expired and was archived. Renew it to put it back on the market.", property.Title),
+			NotifyOptions{InApp: true, Email: true, EntityType: "property", EntityID: property.ID},
+		)
+		logNotifyError("archived listing "+property.ID, err)
+	}
+
+	if len(archived) > 0 {
+		log.Printf("Archived %d expired listings", len(archived))
+	}
+}
+
+// notifyListingExpiring reminds a broker that a listing is about to expire
+// The reminder is only marked as sent once it was delivered, so failures are retried
+func (s *PropertyService) notifyListingExpiring(property *models.Property) {
+	err := s.notificationService.Notify(
+		property.BrokerID,
+		"listing_expiring",
+		"Listing expiring soon",
+		fmt.Sprintf("%q expires on %s. Renew it to keep it on the market.", property.Title, property.ExpiresAt.Format("2 Jan 2006")),
+		NotifyOptions{InApp: true, Email: true, EntityType: "property", EntityID: property.ID},
+	)
+	if err != nil {
+		logNotifyError("expiring listing "+property.ID, err)
+		return
+	}
+
+	if err := s.propertyRepo.MarkExpiryNotified(property.ID); err != nil {
+		log.Printf("Failed to mark expiry reminder for property %s: %v", property.ID, err)
+	}
+}
+
+// applyArchiveState keeps archived_at and expiry consistent with a status change
+// Unarchiving by status starts a fresh listing lifetime if the old one has lapsed
+func (s *PropertyService) applyArchiveState(property *models.Property) {
+	if property.Status == "archived" {
+		if property.ArchivedAt == nil {
+			now := time.Now()
+			property.ArchivedAt = &now
+		}
+		return
+	}
+
+	property.ArchivedAt = nil
+	if property.ExpiresAt == nil || !property.ExpiresAt.After(time.Now()) {
+		expiresAt := time.Now().Add(s.listingTTL)
+		property.ExpiresAt = &expiresAt
+	}
+}
+
+// validateListingExpiry checks that a requested listing expiry is in the future
+func validateListingExpiry(expiresAt time.Time) error {
+	if !expiresAt.After(time.Now()) {
+		return fmt.Errorf("expires_at must be in the future")
+	}
+	return nil
+}
+
+// evaluateMatches refreshes stored client matches for a property
+// Matching is best-effort and never fails the property write that triggered it
+func (s *PropertyService) evaluateMatches(property *models.Property) {
+	if err := s.matchService.EvaluateProperty(property); err != nil {
+		log.Printf("Failed to evaluate matches for property %s: %v", property.ID, err)
+	}
+}
+
+// detectDuplicates checks a saved property for likely duplicate listings
+// Detection is best-effort; failures are logged and no warnings are returned
+func (s *PropertyService) detectDuplicates(property *models.Property) []models.DuplicateWarning {
+	warnings, err := s.duplicateService.DetectForProperty(property)
+	if err != nil {
+		log.Printf("Failed to detect duplicates for property %s: %v", property.ID, err)
+		return []models.DuplicateWarning{}
+	}
+	return warnings
+}
+
 // validatePropertyTypeRequirements validates type-specific requirements
-func (s *PropertyService) validatePropertyTypeRequirements(req *models.CreatePropertyRequest) error {
+func (s *PropertyService) validatePropertyTypeRequirements(propertyType string, bedrooms, bathrooms *int) error {
 	// For apartments and houses, bedrooms and bathrooms are required
-	if req.Type == "apartment" || req.Type == "house" {
-		if req.Bedrooms == nil {
-			return fmt.Errorf("bedrooms are required for property type '%s'", req.Type)
+	if propertyType == "apartment" || propertyType == "house" {
+		if bedrooms == nil {
+			return fmt.Errorf("bedrooms are required for property type '%s'", propertyType)
 		}
-		if req.Bathrooms == nil {
-			return fmt.Errorf("bathrooms are required for property type '%s'", req.Type)
+		if bathrooms == nil {
+			return fmt.Errorf("bathrooms are required for property type '%s'", propertyType)
 		}
-		
+
 		// Validate positive values
-		if *req.Bedrooms < 0 {
+		if *bedrooms < 0 {
 			return fmt.Errorf("bedrooms must be a positive number")
 		}
-		if *req.Bathrooms < 0 {
+		if *bathrooms < 0 {
 			return fmt.Errorf("bathrooms must be a positive number")
 		}
 	}
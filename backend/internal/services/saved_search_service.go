@@ -0,0 +1,264 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// savedSearchResultLimit caps the number of listings returned when running a saved search
+const savedSearchResultLimit = 100
+
+// SavedSearchService handles saved search definitions and new-listing alerts
+type SavedSearchService struct {
+	savedSearchRepo     *repository.SavedSearchRepository
+	clientRepo          *repository.ClientRepository
+	propertyRepo        *repository.PropertyRepository
+	notificationService *NotificationService
+	consentService      *ConsentService
+}
+
+// NewSavedSearchService creates a new SavedSearchService instance
+func NewSavedSearchService(
+	savedSearchRepo *repository.SavedSearchRepository,
+	clientRepo *repository.ClientRepository,
+	propertyRepo *repository.PropertyRepository,
+	notificationService *NotificationService,
+	consentService *ConsentService,
+) *SavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepo:     savedSearchRepo,
+		clientRepo:          clientRepo,
+		propertyRepo:        propertyRepo,
+		notificationService: notificationService,
+		consentService:      consentService,
+	}
+}
+
+// CreateSavedSearch creates a saved search owned by the user, optionally attached to one of their clients
+func (s *SavedSearchService) CreateSavedSearch(req *models.CreateSavedSearchRequest, userID string) (*models.SavedSearch, error) {
+	if err := validateFilterRanges(&req.Filters); err != nil {
+		return nil, err
+	}
+
+	// Verify the client belongs to the user when the search is kept on a client's behalf
+	if req.ClientID != nil {
+		client, err := s.clientRepo.GetByID(*req.ClientID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client_id: %w", err)
+		}
+		if client.BrokerID != userID {
+			return nil, fmt.Errorf("client does not belong to broker")
+		}
+	}
+
+	search := &models.SavedSearch{
+		UserID:      userID,
+		ClientID:    req.ClientID,
+		Name:        req.Name,
+		Filters:     req.Filters,
+		NotifyInApp: true,
+		NotifyEmail: true,
+		IsActive:    true,
+	}
+	if req.NotifyInApp != nil {
+		search.NotifyInApp = *req.NotifyInApp
+	}
+	if req.NotifyEmail != nil {
+		search.NotifyEmail = *req.NotifyEmail
+	}
+
+	if err := s.savedSearchRepo.Create(search); err != nil {
+		return nil, fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	return search, nil
+}
+
+// GetUserSavedSearches retrieves a user's saved searches, optionally filtered to one client
+func (s *SavedSearchService) GetUserSavedSearches(userID string, clientID *string) ([]models.SavedSearch, error) {
+	searches, err := s.savedSearchRepo.GetByUserID(userID, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved searches: %w", err)
+	}
+
+	return searches, nil
+}
+
+// GetSavedSearchByID retrieves a saved search with ownership verification
+func (s *SavedSearchService) GetSavedSearchByID(id, userID string) (*models.SavedSearch, error) {
+	search, err := s.savedSearchRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if search.UserID != userID {
+		return nil, fmt.Errorf("access denied: saved search does not belong to this user")
+	}
+
+	return search, nil
+}
+
+// UpdateSavedSearch updates a saved search with ownership verification
+func (s *SavedSearchService) UpdateSavedSearch(id string, req *models.UpdateSavedSearchRequest, userID string) (*models.SavedSearch, error) {
+	search, err := s.GetSavedSearchByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		search.Name = *req.Name
+	}
+	if req.Filters != nil {
+		if err := validateFilterRanges(req.Filters); err != nil {
+			return nil, err
+		}
+		search.Filters = *req.Filters
+	}
+	if req.NotifyInApp != nil {
+		search.NotifyInApp = *req.NotifyInApp
+	}
+	if req.NotifyEmail != nil {
+		search.NotifyEmail = *req.NotifyEmail
+	}
+	if req.IsActive != nil {
+		search.IsActive = *req.IsActive
+	}
+
+	if err := s.savedSearchRepo.Update(search); err != nil {
+		return nil, fmt.Errorf("failed to update saved search: %w", err)
+	}
+
+	return search, nil
+}
+
+// DeleteSavedSearch deletes a saved search with ownership verification
+func (s *SavedSearchService) DeleteSavedSearch(id, userID string) error {
+	if _, err := s.GetSavedSearchByID(id, userID); err != nil {
+		return err
+	}
+
+	if err := s.savedSearchRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+
+	return nil
+}
+
+// RunSavedSearch returns the listings across all brokers that currently match a saved search
+// Only available listings are returned unless the search filters on another status
+func (s *SavedSearchService) RunSavedSearch(id, userID string) ([]models.Property, error) {
+	search, err := s.GetSavedSearchByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := search.Filters
+	if filters.Status == nil {
+		available := "available"
+		filters.Status = &available
+	}
+
+	properties, err := s.propertyRepo.Search(filters, savedSearchResultLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run saved search: %w", err)
+	}
+
+	for i := range properties {
+		hideForeignPropertyTags(&properties[i], userID)
+	}
+
+	return properties, nil
+}
+
+// EvaluateProperty alerts the owners of saved searches that a new or changed listing now matches
+// Runs in the background after property writes, so errors are logged rather than returned
+func (s *SavedSearchService) EvaluateProperty(property models.Property) {
+	if property.Status != "available" {
+		return
+	}
+
+	searches, err := s.savedSearchRepo.GetActiveCandidates(property.City, property.ListingType)
+	if err != nil {
+		log.Printf("Failed to evaluate saved searches for property %s: %v", property.ID, err)
+		return
+	}
+
+	for i := range searches {
+		search := &searches[i]
+		if !search.Filters.Matches(&property) {
+			continue
+		}
+
+		// Each listing is alerted at most once per saved search
+		isNew, err := s.savedSearchRepo.RecordAlert(search.ID, property.ID)
+		if err != nil {
+			log.Printf("Failed to record saved search alert %s/%s: %v", search.ID, property.ID, err)
+			continue
+		}
+		if !isNew {
+			continue
+		}
+
+		s.deliverAlert(search, &property)
+	}
+}
+
+// deliverAlert notifies the saved search owner and, for client searches, emails the client if they consented
+func (s *SavedSearchService) deliverAlert(search *models.SavedSearch, property *models.Property) {
+	title := fmt.Sprintf("New listing matches \"%s\"", search.Name)
+	message := fmt.Sprintf(
+		"%s in %s, %s is listed for %s at ₹%.0f (%.0f sq ft).",
+		property.Title, property.Location, property.City, property.ListingType, property.Price, property.Area,
+	)
+
+	err := s.notificationService.Notify(search.UserID, "saved_search_match", title, message, NotifyOptions{
+		InApp:      search.NotifyInApp,
+		Email:      search.NotifyEmail,
+		EntityType: "property",
+		EntityID:   property.ID,
+	})
+	logNotifyError("saved search", err)
+
+	if search.ClientID == nil || !search.NotifyEmail {
+		return
+	}
+
+	client, err := s.clientRepo.GetByID(*search.ClientID)
+	if err != nil {
+		log.Printf("Failed to fetch client for saved search %s: %v", search.ID, err)
+		return
+	}
+
+	body := fmt.Sprintf("Hello %s,\n\nA new listing matches your search \"%s\":\n\n%s\n\nYour broker %s will be in touch with details.",
+		client.FirstName, search.Name, message, stringValue(client.BrokerName))
+	// Clients who have not consented to email are skipped; the owner was still notified above
+	if err := s.consentService.EmailClient(client, title, body); err != nil && !errors.Is(err, ErrNoConsent) {
+		log.Printf("Failed to send saved search client email: %v", err)
+	}
+}
+
+// validateFilterRanges checks that min/max filter pairs are not inverted
+func validateFilterRanges(filters *models.PropertyFilters) error {
+	if filters.MinPrice != nil && filters.MaxPrice != nil && *filters.MinPrice > *filters.MaxPrice {
+		return fmt.Errorf("min_price cannot be greater than max_price")
+	}
+	if filters.MinArea != nil && filters.MaxArea != nil && *filters.MinArea > *filters.MaxArea {
+		return fmt.Errorf("min_area cannot be greater than max_area")
+	}
+	if filters.MinBedrooms != nil && filters.MaxBedrooms != nil && *filters.MinBedrooms > *filters.MaxBedrooms {
+		return fmt.Errorf("min_bedrooms cannot be greater than max_bedrooms")
+	}
+	return nil
+}
+
+// stringValue dereferences an optional string, returning "" for nil
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
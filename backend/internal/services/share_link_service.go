@@ -0,0 +1,232 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// shareTokenBytes is the amount of randomness in a share link token (encodes to 32 characters)
+const shareTokenBytes = 24
+
+// ShareLinkService handles public share links for property listings
+type ShareLinkService struct {
+	shareLinkRepo    *repository.ShareLinkRepository
+	propertyRepo     *repository.PropertyRepository
+	userRepo         *repository.UserRepository
+	analyticsService *AnalyticsService
+	publicURL        string
+}
+
+// NewShareLinkService creates a new ShareLinkService instance
+// publicURL is the externally reachable base URL used to build share link URLs
+func NewShareLinkService(
+	shareLinkRepo *repository.ShareLinkRepository,
+	propertyRepo *repository.PropertyRepository,
+	userRepo *repository.UserRepository,
+	analyticsService *AnalyticsService,
+	publicURL string,
+) *ShareLinkService {
+	return &ShareLinkService{
+		shareLinkRepo:    shareLinkRepo,
+		propertyRepo:     propertyRepo,
+		userRepo:         userRepo,
+		analyticsService: analyticsService,
+		publicURL:        strings.TrimRight(publicURL, "/"),
+	}
+}
+
+// CreateShareLink creates a share link for one of the broker's properties
+func (s *ShareLinkService) CreateShareLink(propertyID string, req *models.CreateShareLinkRequest, brokerID string) (*models.ShareLink, error) {
+	if err := s.verifyPropertyOwnership(propertyID, brokerID); err != nil {
+		return nil, err
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	link := &models.ShareLink{
+		PropertyID:        propertyID,
+		BrokerID:          brokerID,
+		Token:             token,
+		ShowBrokerContact: true,
+	}
+	if req.ShowBrokerContact != nil {
+		link.ShowBrokerContact = *req.ShowBrokerContact
+	}
+	if req.HideAddress != nil {
+		link.HideAddress = *req.HideAddress
+	}
+	if req.ExpiresInDays != nil {
+		expiresAt := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		link.ExpiresAt = &expiresAt
+	}
+
+	if err := s.shareLinkRepo.Create(link); err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	link.URL = s.ShareURL(link.Token)
+	return link, nil
+}
+
+// GetPropertyShareLinks retrieves all share links for one of the broker's properties
+func (s *ShareLinkService) GetPropertyShareLinks(propertyID, brokerID string) ([]models.ShareLink, error) {
+	if err := s.verifyPropertyOwnership(propertyID, brokerID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.shareLinkRepo.GetByPropertyID(propertyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share links: %w", err)
+	}
+
+	for i := range links {
+		links[i].URL = s.ShareURL(links[i].Token)
+	}
+
+	return links, nil
+}
+
+// RevokeShareLink disables a share link with ownership verification
+func (s *ShareLinkService) RevokeShareLink(id, brokerID string) (*models.ShareLink, error) {
+	link, err := s.shareLinkRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if link.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: share link does not belong to this broker")
+	}
+
+	link, err = s.shareLinkRepo.Revoke(id)
+	if err != nil {
+		return nil, err
+	}
+
+	link.URL = s.ShareURL(link.Token)
+	return link, nil
+}
+
+// GetSharedListing resolves a public share token to the listing it exposes
+// countView is false for link-preview crawlers so previews don't inflate view counts
+// The view is only counted once the listing has loaded, so failed requests are not counted
+func (s *ShareLinkService) GetSharedListing(token string, countView bool) (*models.SharedListing, error) {
+	link, err := s.shareLinkRepo.GetActiveByToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := s.propertyRepo.GetByID(link.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := newSharedListing(property, s.publicURL, link.HideAddress)
+	listing.ExpiresAt = link.ExpiresAt
+
+	if link.ShowBrokerContact {
+		broker, err := s.userRepo.GetUserByID(link.BrokerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch broker information: %w", err)
+		}
+		listing.Broker = newSharedListingBroker(broker)
+	}
+
+	if countView {
+		if err := s.shareLinkRepo.RecordView(link.ID); err != nil {
+			log.Printf("Failed to count view of share link %s: %v", link.ID, err)
+		}
+		s.analyticsService.RecordEvent(link.PropertyID, link.BrokerID, models.ListingEventShareView)
+	}
+
+	return &listing, nil
+}
+
+// newSharedListing builds the public view of a property, with image URLs made absolute against publicURL
+func newSharedListing(property *models.Property, publicURL string, hideAddress bool) models.SharedListing {
+	listing := models.SharedListing{
+		Title:       property.Title,
+		Type:        property.Type,
+		ListingType: property.ListingType,
+		Price:       property.Price,
+		Area:        property.Area,
+		Bedrooms:    property.Bedrooms,
+		Bathrooms:   property.Bathrooms,
+		Location:    property.Location,
+		City:        property.City,
+		State:       property.State,
+		Description: property.Description,
+		Amenities:   property.Amenities,
+		Images:      make([]string, 0, len(property.Images)),
+		Status:      property.Status,
+	}
+	if !hideAddress {
+		listing.Address = property.Address
+	}
+	for _, image := range property.Images {
+		listing.Images = append(listing.Images, publicAssetURL(publicURL, image))
+	}
+
+	return listing
+}
+
+// newSharedListingBroker builds the broker contact shown alongside shared listings
+func newSharedListingBroker(broker *models.User) *models.SharedListingBroker {
+	return &models.SharedListingBroker{
+		Name:           broker.FirstName + " " + broker.LastName,
+		FirmName:       broker.FirmName,
+		Email:          broker.Email,
+		WhatsappNumber: broker.WhatsappNumber,
+		ProfileImage:   broker.ProfileImage,
+	}
+}
+
+// ShareURL returns the public listing page URL for a share token
+func (s *ShareLinkService) ShareURL(token string) string {
+	return s.publicURL + "/s/" + token
+}
+
+// verifyPropertyOwnership checks that a property exists and belongs to the broker
+func (s *ShareLinkService) verifyPropertyOwnership(propertyID, brokerID string) error {
+	property, err := s.propertyRepo.GetByID(propertyID)
+	if err != nil {
+		return err
+	}
+
+	if property.BrokerID != brokerID {
+		return fmt.Errorf("access denied: property does not belong to this broker")
+	}
+
+	return nil
+}
+
+// publicAssetURL makes a stored image or upload path absolute so it works outside the app
+// Uploaded files are stored as /uploads/<name> but served under /api/uploads
+func publicAssetURL(baseURL, path string) string {
+	switch {
+	case strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://"):
+		return path
+	case strings.HasPrefix(path, "/uploads/"):
+		return baseURL + "/api" + path
+	default:
+		return baseURL + "/" + strings.TrimLeft(path, "/")
+	}
+}
+
+// generateShareToken returns a random URL-safe token for a share link
+func generateShareToken() (string, error) {
+	buf := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,448 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// Default element names used when a feed does not configure its own
+const (
+	defaultFeedRootElement = "listings"
+	defaultFeedItemElement = "listing"
+)
+
+// feedElementPattern restricts element and key names to valid, unprefixed XML names
+var feedElementPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// SyndicationFeedService handles portal feed definitions and feed generation
+type SyndicationFeedService struct {
+	feedRepo     *repository.SyndicationFeedRepository
+	propertyRepo *repository.PropertyRepository
+	publicURL    string
+	storagePath  string
+}
+
+// NewSyndicationFeedService creates a new SyndicationFeedService instance
+// publicURL is used to make image URLs absolute; storagePath is where scheduled feeds are written
+func NewSyndicationFeedService(
+	feedRepo *repository.SyndicationFeedRepository,
+	propertyRepo *repository.PropertyRepository,
+	publicURL string,
+	storagePath string,
+) *SyndicationFeedService {
+	return &SyndicationFeedService{
+		feedRepo:     feedRepo,
+		propertyRepo: propertyRepo,
+		publicURL:    strings.TrimRight(publicURL, "/"),
+		storagePath:  storagePath,
+	}
+}
+
+// CreateFeed creates a syndication feed owned by the broker
+func (s *SyndicationFeedService) CreateFeed(req *models.CreateSyndicationFeedRequest, brokerID string) (*models.SyndicationFeed, error) {
+	feed := &models.SyndicationFeed{
+		BrokerID:    brokerID,
+		Name:        req.Name,
+		Portal:      req.Portal,
+		Format:      req.Format,
+		RootElement: req.RootElement,
+		ItemElement: req.ItemElement,
+		Fields:      req.Fields,
+		IsActive:    true,
+	}
+	if req.IsActive != nil {
+		feed.IsActive = *req.IsActive
+	}
+	applyFeedDefaults(feed)
+
+	if err := validateFeedElements(feed); err != nil {
+		return nil, err
+	}
+
+	if err := s.feedRepo.Create(feed); err != nil {
+		return nil, fmt.Errorf("failed to create syndication feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// GetBrokerFeeds retrieves all syndication feeds for a broker
+func (s *SyndicationFeedService) GetBrokerFeeds(brokerID string) ([]models.SyndicationFeed, error) {
+	feeds, err := s.feedRepo.GetByBrokerID(brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get syndication feeds: %w", err)
+	}
+
+	return feeds, nil
+}
+
+// GetFeedByID retrieves a syndication feed with ownership verification
+func (s *SyndicationFeedService) GetFeedByID(id, brokerID string) (*models.SyndicationFeed, error) {
+	feed, err := s.feedRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if feed.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: syndication feed does not belong to this broker")
+	}
+
+	return feed, nil
+}
+
+// UpdateFeed updates a syndication feed with ownership verification
+func (s *SyndicationFeedService) UpdateFeed(id string, req *models.UpdateSyndicationFeedRequest, brokerID string) (*models.SyndicationFeed, error) {
+	feed, err := s.GetFeedByID(id, brokerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		feed.Name = *req.Name
+	}
+	if req.Portal != nil {
+		feed.Portal = *req.Portal
+	}
+	if req.Format != nil {
+		feed.Format = *req.Format
+	}
+	if req.RootElement != nil {
+		feed.RootElement = *req.RootElement
+	}
+	if req.ItemElement != nil {
+		feed.ItemElement = *req.ItemElement
+	}
+	if req.Fields != nil {
+		feed.Fields = req.Fields
+	}
+	if req.IsActive != nil {
+		feed.IsActive = *req.IsActive
+	}
+	applyFeedDefaults(feed)
+
+	if err := validateFeedElements(feed); err != nil {
+		return nil, err
+	}
+
+	if err := s.feedRepo.Update(feed); err != nil {
+		return nil, fmt.Errorf("failed to update syndication feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// DeleteFeed deletes a syndication feed with ownership verification
+// Files already written to the storage directory are left for the portal to collect
+func (s *SyndicationFeedService) DeleteFeed(id, brokerID string) error {
+	if _, err := s.GetFeedByID(id, brokerID); err != nil {
+		return err
+	}
+
+	if err := s.feedRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete syndication feed: %w", err)
+	}
+
+	return nil
+}
+
+// ExportFeed renders a feed on demand
+// With since set only listings changed after that time are included, whatever their status
+func (s *SyndicationFeedService) ExportFeed(id, brokerID string, since *time.Time) (*models.SyndicationFeed, []byte, error) {
+	feed, err := s.GetFeedByID(id, brokerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, _, err := s.renderFeed(feed, since, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return feed, content, nil
+}
+
+// RunScheduledFeeds writes an incremental file for every active feed to the storage directory
+// The first run for a feed writes a full feed; runs with no changes write nothing
+func (s *SyndicationFeedService) RunScheduledFeeds() {
+	feeds, err := s.feedRepo.GetActive()
+	if err != nil {
+		log.Printf("Failed to load syndication feeds: %v", err)
+		return
+	}
+
+	for i := range feeds {
+		if err := s.writeFeedFile(&feeds[i]); err != nil {
+			log.Printf("Failed to generate syndication feed %s: %v", feeds[i].ID, err)
+		}
+	}
+}
+
+// writeFeedFile generates one feed's incremental file and records the run
+func (s *SyndicationFeedService) writeFeedFile(feed *models.SyndicationFeed) error {
+	// Take the cut-off before querying so changes made during generation land in the next run
+	generatedAt := time.Now()
+
+	content, count, err := s.renderFeed(feed, feed.LastGeneratedAt, generatedAt)
+	if err != nil {
+		return err
+	}
+	if count == 0 && feed.LastGeneratedAt != nil {
+		return nil
+	}
+
+	dir := filepath.Join(s.storagePath, feed.ID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create feed directory: %w", err)
+	}
+
+	filePath := filepath.Join(dir, fmt.Sprintf("%s.%s", generatedAt.UTC().Format("20060102T150405Z"), feed.Format))
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write feed file: %w", err)
+	}
+
+	return s.feedRepo.MarkGenerated(feed.ID, generatedAt, filePath)
+}
+
+// renderFeed builds a feed document and returns it with the number of listings included
+func (s *SyndicationFeedService) renderFeed(feed *models.SyndicationFeed, since *time.Time, generatedAt time.Time) ([]byte, int, error) {
+	properties, err := s.propertyRepo.GetFeedListings(feed.BrokerID, since)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get feed listings: %w", err)
+	}
+
+	listings := make([][]feedValue, 0, len(properties))
+	for i := range properties {
+		listings = append(listings, s.mapListing(&properties[i], feed.Fields))
+	}
+
+	var content []byte
+	if feed.Format == "xml" {
+		content, err = renderFeedXML(feed, listings, since, generatedAt)
+	} else {
+		content, err = renderFeedJSON(feed, listings, since, generatedAt)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to render feed: %w", err)
+	}
+
+	return content, len(listings), nil
+}
+
+// feedValue is one mapped field of a listing in feed order
+type feedValue struct {
+	Name  string
+	Value interface{} // string, float64, int, []string or nil when the property has no value
+}
+
+// mapListing maps a property onto the feed's fields in their configured order
+func (s *SyndicationFeedService) mapListing(property *models.Property, fields []models.FeedFieldMapping) []feedValue {
+	values := make([]feedValue, 0, len(fields))
+	for _, field := range fields {
+		values = append(values, feedValue{Name: field.Target, Value: s.sourceValue(property, field.Source)})
+	}
+	return values
+}
+
+// sourceValue extracts a property field as a feed value
+func (s *SyndicationFeedService) sourceValue(property *models.Property, source string) interface{} {
+	switch source {
+	case "id":
+		return property.ID
+	case "title":
+		return property.Title
+	case "type":
+		return property.Type
+	case "listing_type":
+		return property.ListingType
+	case "price":
+		return property.Price
+	case "area":
+		return property.Area
+	case "bedrooms":
+		if property.Bedrooms == nil {
+			return nil
+		}
+		return *property.Bedrooms
+	case "bathrooms":
+		if property.Bathrooms == nil {
+			return nil
+		}
+		return *property.Bathrooms
+	case "location":
+		return property.Location
+	case "address":
+		return property.Address
+	case "city":
+		return property.City
+	case "state":
+		return property.State
+	case "description":
+		return property.Description
+	case "amenities":
+		return property.Amenities
+	case "images":
+		images := make([]string, 0, len(property.Images))
+		for _, image := range property.Images {
+			images = append(images, publicAssetURL(s.publicURL, image))
+		}
+		return images
+	case "status":
+		// Deleted listings appear in incremental feeds so portals can take them down
+		if property.DeletedAt != nil {
+			return "deleted"
+		}
+		return property.Status
+	case "broker_name":
+		if property.BrokerName == nil {
+			return nil
+		}
+		return *property.BrokerName
+	case "created_at":
+		return property.CreatedAt.UTC().Format(time.RFC3339)
+	case "updated_at":
+		return property.UpdatedAt.UTC().Format(time.RFC3339)
+	default:
+		return nil
+	}
+}
+
+// renderFeedXML writes listings as <root><item><field>value</field>...</item></root>
+// List fields are written as repeated <value> children
+func renderFeedXML(feed *models.SyndicationFeed, listings [][]feedValue, since *time.Time, generatedAt time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+
+	root := xml.StartElement{
+		Name: xml.Name{Local: feed.RootElement},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "portal"}, Value: feed.Portal},
+			{Name: xml.Name{Local: "generated_at"}, Value: generatedAt.UTC().Format(time.RFC3339)},
+		},
+	}
+	if since != nil {
+		root.Attr = append(root.Attr, xml.Attr{Name: xml.Name{Local: "since"}, Value: since.UTC().Format(time.RFC3339)})
+	}
+	if err := enc.EncodeToken(root); err != nil {
+		return nil, err
+	}
+
+	for _, listing := range listings {
+		item := xml.StartElement{Name: xml.Name{Local: feed.ItemElement}}
+		if err := enc.EncodeToken(item); err != nil {
+			return nil, err
+		}
+
+		for _, field := range listing {
+			element := xml.StartElement{Name: xml.Name{Local: field.Name}}
+			switch value := field.Value.(type) {
+			case nil:
+				continue
+			case []string:
+				if err := enc.EncodeElement(struct {
+					Values []string `xml:"value"`
+				}{value}, element); err != nil {
+					return nil, err
+				}
+			case float64:
+				// Avoid exponent notation such as 1.5e+06 for prices
+				if err := enc.EncodeElement(strconv.FormatFloat(value, 'f', -1, 64), element); err != nil {
+					return nil, err
+				}
+			default:
+				if err := enc.EncodeElement(value, element); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if err := enc.EncodeToken(item.End()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := enc.EncodeToken(root.End()); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// renderFeedJSON writes listings as {"portal", "generated_at", "since", root: [{field: value}]}
+func renderFeedJSON(feed *models.SyndicationFeed, listings [][]feedValue, since *time.Time, generatedAt time.Time) ([]byte, error) {
+	items := make([]map[string]interface{}, 0, len(listings))
+	for _, listing := range listings {
+		item := make(map[string]interface{}, len(listing))
+		for _, field := range listing {
+			item[field.Name] = field.Value
+		}
+		items = append(items, item)
+	}
+
+	document := map[string]interface{}{
+		"portal":         feed.Portal,
+		"generated_at":   generatedAt.UTC().Format(time.RFC3339),
+		feed.RootElement: items,
+	}
+	if since != nil {
+		document["since"] = since.UTC().Format(time.RFC3339)
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// applyFeedDefaults fills in element names and, when no mapping is given, maps every field to itself
+func applyFeedDefaults(feed *models.SyndicationFeed) {
+	if feed.RootElement == "" {
+		feed.RootElement = defaultFeedRootElement
+	}
+	if feed.ItemElement == "" {
+		feed.ItemElement = defaultFeedItemElement
+	}
+	if len(feed.Fields) == 0 {
+		feed.Fields = make([]models.FeedFieldMapping, 0, len(models.FeedSourceFields))
+		for _, source := range models.FeedSourceFields {
+			feed.Fields = append(feed.Fields, models.FeedFieldMapping{Source: source, Target: source})
+		}
+	}
+}
+
+// validateFeedElements checks element names are usable as XML names and JSON keys
+func validateFeedElements(feed *models.SyndicationFeed) error {
+	names := []string{feed.RootElement, feed.ItemElement}
+	seen := make(map[string]bool, len(feed.Fields))
+
+	for _, field := range feed.Fields {
+		if seen[field.Target] {
+			return fmt.Errorf("invalid field mapping: target %q is used more than once", field.Target)
+		}
+		seen[field.Target] = true
+		names = append(names, field.Target)
+	}
+
+	for _, name := range names {
+		if !feedElementPattern.MatchString(name) {
+			return fmt.Errorf("invalid element name %q: use letters, digits, '_', '-' or '.'", name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// TagService handles business logic for the tags brokers put on their clients and properties
+type TagService struct {
+	tagRepo *repository.TagRepository
+}
+
+// NewTagService creates a new TagService instance
+func NewTagService(tagRepo *repository.TagRepository) *TagService {
+	return &TagService{tagRepo: tagRepo}
+}
+
+// GetTags retrieves the broker's tags with how many clients and properties carry each
+func (s *TagService) GetTags(brokerID string) ([]models.Tag, error) {
+	tags, err := s.tagRepo.GetByBrokerID(brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// RenameTag renames a tag on all the broker's clients and properties
+// Renaming onto another of the broker's tags merges the two under that tag's spelling
+func (s *TagService) RenameTag(brokerID, name string, req *models.RenameTagRequest) ([]models.Tag, error) {
+	newName := cleanTag(req.Name)
+	if newName == "" {
+		return nil, fmt.Errorf("invalid tag name: must not be blank")
+	}
+
+	// Only adopt another tag's spelling when the rename is not just a change of case
+	if !strings.EqualFold(newName, cleanTag(name)) {
+		normalized, err := s.NormalizeTags(brokerID, []string{newName})
+		if err != nil {
+			return nil, err
+		}
+		newName = normalized[0]
+	}
+
+	changed, err := s.tagRepo.Rename(brokerID, cleanTag(name), newName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename tag: %w", err)
+	}
+	if changed == 0 {
+		return nil, fmt.Errorf("tag not found")
+	}
+
+	return s.GetTags(brokerID)
+}
+
+// DeleteTag removes a tag from all the broker's clients and properties
+func (s *TagService) DeleteTag(brokerID, name string) error {
+	changed, err := s.tagRepo.Delete(brokerID, cleanTag(name))
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if changed == 0 {
+		return fmt.Errorf("tag not found")
+	}
+
+	return nil
+}
+
+// BulkTagClients adds and removes tags on several of the broker's clients
+func (s *TagService) BulkTagClients(brokerID string, req *models.BulkTagRequest) (*models.BulkTagResult, error) {
+	add, remove, err := s.bulkTagLists(brokerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.tagRepo.BulkUpdateClients(brokerID, uniqueStrings(req.IDs), add, remove)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tag clients: %w", err)
+	}
+
+	return &models.BulkTagResult{Updated: updated}, nil
+}
+
+// BulkTagProperties adds and removes tags on several of the broker's properties
+func (s *TagService) BulkTagProperties(brokerID string, req *models.BulkTagRequest) (*models.BulkTagResult, error) {
+	add, remove, err := s.bulkTagLists(brokerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.tagRepo.BulkUpdateProperties(brokerID, uniqueStrings(req.IDs), add, remove)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tag properties: %w", err)
+	}
+
+	return &models.BulkTagResult{Updated: updated}, nil
+}
+
+// bulkTagLists normalizes the tags a bulk change adds and removes
+func (s *TagService) bulkTagLists(brokerID string, req *models.BulkTagRequest) ([]string, []string, error) {
+	add, err := s.NormalizeTags(brokerID, req.Add)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remove := []string{}
+	for _, tag := range req.Remove {
+		if tag = cleanTag(tag); tag != "" {
+			remove = append(remove, tag)
+		}
+	}
+
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, nil, fmt.Errorf("invalid request: add or remove at least one tag")
+	}
+
+	return add, remove, nil
+}
+
+// NormalizeTags tidies tags for storage or filtering: whitespace is collapsed, blanks and
+// case-insensitive duplicates are dropped, and a tag the broker already uses keeps its existing
+// spelling so "nri" and "NRI" stay one tag
+// Always returns a non-nil slice so the column is stored as an empty array
+func (s *TagService) NormalizeTags(brokerID string, tags []string) ([]string, error) {
+	normalized := []string{}
+	seen := make(map[string]bool)
+
+	for _, tag := range tags {
+		tag = cleanTag(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) == 0 {
+		return normalized, nil
+	}
+
+	existing, err := s.tagRepo.GetByBrokerID(brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	spellings := make(map[string]string, len(existing))
+	for _, tag := range existing {
+		if _, exists := spellings[strings.ToLower(tag.Name)]; !exists {
+			spellings[strings.ToLower(tag.Name)] = tag.Name
+		}
+	}
+
+	for i, tag := range normalized {
+		if spelling, exists := spellings[strings.ToLower(tag)]; exists {
+			normalized[i] = spelling
+		}
+	}
+
+	return normalized, nil
+}
+
+// cleanTag trims a tag and collapses runs of whitespace inside it
+func cleanTag(tag string) string {
+	return strings.Join(strings.Fields(tag), " ")
+}
+
+// hideForeignPropertyTags clears the tags of properties owned by another broker
+// Tags are a broker's private labels, so they never show on listings seen by other brokers
+func hideForeignPropertyTags(property *models.Property, brokerID string) {
+	if property.BrokerID != brokerID {
+		property.Tags = []string{}
+	}
+}
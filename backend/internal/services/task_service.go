@@ -0,0 +1,361 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// TaskService handles business logic for follow-up tasks and their reminders
+type TaskService struct {
+	taskRepo            *repository.TaskRepository
+	clientRepo          *repository.ClientRepository
+	propertyRepo        *repository.PropertyRepository
+	userRepo            *repository.UserRepository
+	notificationService *NotificationService
+	reminderLead        time.Duration  // default time between a reminder and the due time
+	location            *time.Location // decides what "today" is when the request names no zone
+}
+
+// NewTaskService creates a new TaskService instance
+func NewTaskService(
+	taskRepo *repository.TaskRepository,
+	clientRepo *repository.ClientRepository,
+	propertyRepo *repository.PropertyRepository,
+	userRepo *repository.UserRepository,
+	notificationService *NotificationService,
+	reminderLead time.Duration,
+	location *time.Location,
+) *TaskService {
+	return &TaskService{
+		taskRepo:            taskRepo,
+		clientRepo:          clientRepo,
+		propertyRepo:        propertyRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		reminderLead:        reminderLead,
+		location:            location,
+	}
+}
+
+// CreateTask creates a task; it is assigned to the creator unless someone from their firm is named
+func (s *TaskService) CreateTask(req *models.CreateTaskRequest, brokerID string) (*models.Task, error) {
+	dueAt, err := time.Parse(time.RFC3339, req.DueAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid due_at: %w", err)
+	}
+
+	task := &models.Task{
+		BrokerID:    brokerID,
+		AssigneeID:  brokerID,
+		Title:       strings.TrimSpace(req.Title),
+		Description: req.Description,
+		Priority:    req.Priority,
+		Status:      "open",
+		DueAt:       dueAt,
+	}
+	if task.Priority == "" {
+		task.Priority = "medium"
+	}
+
+	if req.AssigneeID != nil && *req.AssigneeID != "" {
+		if err := s.validateAssignee(*req.AssigneeID, brokerID); err != nil {
+			return nil, err
+		}
+		task.AssigneeID = *req.AssigneeID
+	}
+
+	if err := s.validateRelations(req.ClientID, req.PropertyID, brokerID); err != nil {
+		return nil, err
+	}
+	task.ClientID = emptyToNil(req.ClientID)
+	task.PropertyID = emptyToNil(req.PropertyID)
+
+	lead := s.reminderLead
+	if req.ReminderMinutes != nil {
+		lead = time.Duration(*req.ReminderMinutes) * time.Minute
+	}
+	remindAt := dueAt.Add(-lead)
+	task.RemindAt = &remindAt
+
+	if err := s.taskRepo.Create(task); err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	s.notifyAssigned(task, brokerID)
+
+	return task, nil
+}
+
+// GetTasks retrieves the tasks a user created or is assigned
+// Views list open tasks that are overdue, due today or due after today in the user's time zone
+func (s *TaskService) GetTasks(userID string, filters models.TaskFilters) ([]models.Task, error) {
+	location := s.location
+	if filters.Timezone != "" {
+		loaded, err := time.LoadLocation(filters.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tz: unknown time zone %q", filters.Timezone)
+		}
+		location = loaded
+	}
+
+	q := repository.TaskQuery{
+		UserID:     userID,
+		Scope:      filters.Scope,
+		Status:     filters.Status,
+		Priority:   filters.Priority,
+		ClientID:   filters.ClientID,
+		PropertyID: filters.PropertyID,
+	}
+
+	now := time.Now().In(location)
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	endOfDay := startOfDay.AddDate(0, 0, 1)
+
+	switch filters.View {
+	case "overdue":
+		q.Status = "open"
+		q.DueBefore = now
+	case "today":
+		q.Status = "open"
+		q.DueFrom = startOfDay
+		q.DueBefore = endOfDay
+	case "upcoming":
+		q.Status = "open"
+		q.DueFrom = endOfDay
+	}
+
+	tasks, err := s.taskRepo.GetTasks(q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// GetTask retrieves a task visible to its creator and its assignee
+func (s *TaskService) GetTask(id, userID string) (*models.Task, error) {
+	task, err := s.taskRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.BrokerID != userID && task.AssigneeID != userID {
+		return nil, fmt.Errorf("access denied: task does not belong to this user")
+	}
+
+	return task, nil
+}
+
+// UpdateTask updates a task with partial updates; the creator and the assignee can both edit it
+func (s *TaskService) UpdateTask(id string, req *models.UpdateTaskRequest, userID string) (*models.Task, error) {
+	task, err := s.GetTask(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousAssignee := task.AssigneeID
+
+	// Keep the reminder the same distance ahead of a moved due time
+	lead := s.reminderLead
+	if task.RemindAt != nil {
+		lead = task.DueAt.Sub(*task.RemindAt)
+	}
+
+	if req.Title != nil {
+		task.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Description != nil {
+		task.Description = req.Description
+	}
+	if req.Priority != nil {
+		task.Priority = *req.Priority
+	}
+	if req.DueAt != nil {
+		dueAt, err := time.Parse(time.RFC3339, *req.DueAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due_at: %w", err)
+		}
+		task.DueAt = dueAt
+	}
+	if req.ReminderMinutes != nil {
+		lead = time.Duration(*req.ReminderMinutes) * time.Minute
+	}
+	remindAt := task.DueAt.Add(-lead)
+	task.RemindAt = &remindAt
+
+	if req.AssigneeID != nil && *req.AssigneeID != task.AssigneeID {
+		if err := s.validateAssignee(*req.AssigneeID, task.BrokerID); err != nil {
+			return nil, err
+		}
+		task.AssigneeID = *req.AssigneeID
+	}
+
+	// Clients and properties are checked against the task creator, who owns them
+	if err := s.validateRelations(req.ClientID, req.PropertyID, task.BrokerID); err != nil {
+		return nil, err
+	}
+	if req.ClientID != nil {
+		task.ClientID = emptyToNil(req.ClientID)
+	}
+	if req.PropertyID != nil {
+		task.PropertyID = emptyToNil(req.PropertyID)
+	}
+
+	if req.Status != nil && *req.Status != task.Status {
+		task.Status = *req.Status
+		if task.Status == "done" {
+			now := time.Now()
+			task.CompletedAt = &now
+		} else {
+			task.CompletedAt = nil
+		}
+	}
+
+	if err := s.taskRepo.Update(task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	if task.AssigneeID != previousAssignee {
+		s.notifyAssigned(task, userID)
+	}
+
+	return task, nil
+}
+
+// DeleteTask removes a task; only its creator can delete it
+func (s *TaskService) DeleteTask(id, userID string) error {
+	task, err := s.GetTask(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if task.BrokerID != userID {
+		return fmt.Errorf("cannot delete a task created by another user")
+	}
+
+	if err := s.taskRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	return nil
+}
+
+// RunReminders notifies assignees of open tasks whose reminder time has come
+// Called by the background scheduler; failures are logged and retried on the next run
+func (s *TaskService) RunReminders() {
+	tasks, err := s.taskRepo.GetDueReminders()
+	if err != nil {
+		log.Printf("Failed to load due task reminders: %v", err)
+		return
+	}
+
+	for i := range tasks {
+		s.remind(&tasks[i])
+	}
+}
+
+// remind tells the assignee a task is coming up
+// The reminder is only marked as sent once it was delivered, so failures are retried
+func (s *TaskService) remind(task *models.Task) {
+	message := fmt.Sprintf("%q is due at %s.", task.Title, task.DueAt.In(s.location).Format("15:04 on 2 Jan 2006 MST"))
+	if task.ClientName != nil {
+		message += fmt.Sprintf(" Client: %s.", *task.ClientName)
+	}
+	if task.PropertyTitle != nil {
+		message += fmt.Sprintf(" Property: %s.", *task.PropertyTitle)
+	}
+
+	err := s.notificationService.Notify(
+		task.AssigneeID,
+		"task_reminder",
+		"Task due soon",
+		message,
+		NotifyOptions{InApp: true, Email: true, EntityType: "task", EntityID: task.ID},
+	)
+	if err != nil {
+		logNotifyError("task reminder "+task.ID, err)
+		return
+	}
+
+	if err := s.taskRepo.MarkReminded(task.ID); err != nil {
+		log.Printf("Failed to mark reminder for task %s: %v", task.ID, err)
+	}
+}
+
+// notifyAssigned tells a user someone else gave them a task
+func (s *TaskService) notifyAssigned(task *models.Task, assignedBy string) {
+	if task.AssigneeID == assignedBy {
+		return
+	}
+
+	err := s.notificationService.Notify(
+		task.AssigneeID,
+		"task_assigned",
+		"New task assigned",
+		fmt.Sprintf("You were assigned %q, due at %s.", task.Title, task.DueAt.In(s.location).Format("15:04 on 2 Jan 2006 MST")),
+		NotifyOptions{InApp: true, EntityType: "task", EntityID: task.ID},
+	)
+	logNotifyError("task assignment "+task.ID, err)
+}
+
+// validateAssignee checks that a task can be assigned to a user from the creator's firm
+func (s *TaskService) validateAssignee(assigneeID, brokerID string) error {
+	if assigneeID == brokerID {
+		return nil
+	}
+
+	broker, err := s.userRepo.GetUserByID(brokerID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task creator: %w", err)
+	}
+
+	assignee, err := s.userRepo.GetUserByID(assigneeID)
+	if err != nil {
+		return fmt.Errorf("invalid assignee_id: user not found")
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(assignee.FirmName), strings.TrimSpace(broker.FirmName)) {
+		return fmt.Errorf("invalid assignee_id: assignee must belong to the same firm")
+	}
+
+	return nil
+}
+
+// validateRelations checks that a task's client and property belong to the broker
+// Empty IDs detach the task and need no check
+func (s *TaskService) validateRelations(clientID, propertyID *string, brokerID string) error {
+	if clientID != nil && *clientID != "" {
+		client, err := s.clientRepo.GetByID(*clientID)
+		if err != nil {
+			return fmt.Errorf("invalid client_id: %w", err)
+		}
+		if client.BrokerID != brokerID {
+			return fmt.Errorf("invalid client_id: client does not belong to broker")
+		}
+	}
+
+	if propertyID != nil && *propertyID != "" {
+		property, err := s.propertyRepo.GetByID(*propertyID)
+		if err != nil {
+			return fmt.Errorf("invalid property_id: %w", err)
+		}
+		if property.BrokerID != brokerID {
+			return fmt.Errorf("invalid property_id: property does not belong to broker")
+		}
+	}
+
+	return nil
+}
+
+// emptyToNil turns an empty optional ID into no ID
+func emptyToNil(id *string) *string {
+	if id == nil || *id == "" {
+		return nil
+	}
+	return id
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"enfor-data-backend/internal/models"
+	"enfor-data-backend/internal/repository"
+)
+
+// TrashService lists, restores and purges soft-deleted properties, clients and appointments
+type TrashService struct {
+	propertyRepo    *repository.PropertyRepository
+	clientRepo      *repository.ClientRepository
+	appointmentRepo *repository.AppointmentRepository
+	matchService    *MatchService
+	documentService *DocumentService
+	retention       time.Duration
+}
+
+// NewTrashService creates a new TrashService instance
+// retention is how long deleted records stay in the trash before they are purged
+func NewTrashService(
+	propertyRepo *repository.PropertyRepository,
+	clientRepo *repository.ClientRepository,
+	appointmentRepo *repository.AppointmentRepository,
+	matchService *MatchService,
+	documentService *DocumentService,
+	retention time.Duration,
+) *TrashService {
+	return &TrashService{
+		propertyRepo:    propertyRepo,
+		clientRepo:      clientRepo,
+		appointmentRepo: appointmentRepo,
+		matchService:    matchService,
+		documentService: documentService,
+		retention:       retention,
+	}
+}
+
+// GetTrash retrieves everything the broker has deleted that has not been purged yet
+func (s *TrashService) GetTrash(brokerID string) (*models.Trash, error) {
+	properties, err := s.propertyRepo.GetDeletedByBrokerID(brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted properties: %w", err)
+	}
+
+	clients, err := s.clientRepo.GetDeletedByBrokerID(brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted clients: %w", err)
+	}
+
+	appointments, err := s.appointmentRepo.GetDeletedByBrokerID(brokerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted appointments: %w", err)
+	}
+
+	return &models.Trash{
+		Properties:    properties,
+		Clients:       clients,
+		Appointments:  appointments,
+		RetentionDays: int(s.retention / (24 * time.Hour)),
+	}, nil
+}
+
+// RestoreProperty takes one of the broker's properties out of the trash
+func (s *TrashService) RestoreProperty(id, brokerID string) (*models.Property, error) {
+	property, err := s.propertyRepo.GetDeletedByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if property.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: property does not belong to this broker")
+	}
+
+	property, err = s.propertyRepo.Restore(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The listing can match clients again
+	if err := s.matchService.EvaluateProperty(property); err != nil {
+		log.Printf("Failed to evaluate matches for restored property %s: %v", property.ID, err)
+	}
+
+	return property, nil
+}
+
+// RestoreClient takes one of the broker's clients out of the trash
+// Appointments deleted together with the client are restored too; the count is returned
+func (s *TrashService) RestoreClient(id, brokerID string) (*models.Client, int64, error) {
+	client, err := s.clientRepo.GetDeletedByID(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if client.BrokerID != brokerID {
+		return nil, 0, fmt.Errorf("access denied: client does not belong to this broker")
+	}
+
+	client, restoredAppointments, err := s.clientRepo.Restore(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.matchService.EvaluateClient(client); err != nil {
+		log.Printf("Failed to evaluate matches for restored client %s: %v", client.ID, err)
+	}
+
+	return client, restoredAppointments, nil
+}
+
+// RestoreAppointment takes one of the broker's appointments out of the trash
+// An appointment whose client is still in the trash cannot be restored on its own
+func (s *TrashService) RestoreAppointment(id, brokerID string) (*models.Appointment, error) {
+	appointment, err := s.appointmentRepo.GetDeletedByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if appointment.BrokerID != brokerID {
+		return nil, fmt.Errorf("access denied: appointment does not belong to this broker")
+	}
+
+	if _, err := s.clientRepo.GetByID(appointment.ClientID); err != nil {
+		return nil, fmt.Errorf("cannot restore appointment while its client is in the trash; restore the client instead")
+	}
+
+	return s.appointmentRepo.Restore(id)
+}
+
+// PurgeExpired permanently deletes records that have been in the trash longer than the retention window
+// Called by the background scheduler; failures are logged and retried on the next run
+func (s *TrashService) PurgeExpired() {
+	before := time.Now().Add(-s.retention)
+
+	// Documents go first so their stored files are removed along with the rows
+	if err := s.documentService.PurgeDocumentsOfPurgedRecords(before); err != nil {
+		log.Printf("Failed to purge documents of deleted records: %v", err)
+		return
+	}
+
+	appointments, err := s.appointmentRepo.PurgeDeleted(before)
+	if err != nil {
+		log.Printf("Failed to purge deleted appointments: %v", err)
+	}
+
+	clients, err := s.clientRepo.PurgeDeleted(before)
+	if err != nil {
+		log.Printf("Failed to purge deleted clients: %v", err)
+	}
+
+	properties, err := s.propertyRepo.PurgeDeleted(before)
+	if err != nil {
+		log.Printf("Failed to purge deleted properties: %v", err)
+	}
+
+	if appointments+clients+properties > 0 {
+		log.Printf("Purged %d properties, %d clients and %d appointments from the trash", properties, clients, appointments)
+	}
+}
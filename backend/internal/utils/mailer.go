@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"fmt"
+	"log"
+	"net/mail"
+	"net/smtp"
+	"strings"
+)
+
+type Mailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewMailer(host, port, username, password, from string) *Mailer {
+	return &Mailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Send delivers a plain-text email
+// When no SMTP host is configured the email is logged instead, which keeps local development working
+func (m *Mailer) Send(to, subject, body string) error {
+	if m.host == "" {
+		log.Printf("Email not sent (SMTP not configured) to=%s subject=%q", to, subject)
+		return nil
+	}
+
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	// Strip line breaks from header values so user-provided text cannot inject headers
+	headerSafe := strings.NewReplacer("\r", " ", "\n", " ")
+	to = headerSafe.Replace(to)
+	subject = headerSafe.Replace(subject)
+
+	message := strings.Join([]string{
+		"From: " + m.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"utf-8\"",
+		"",
+		body,
+	}, "\r\n")
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	if err := smtp.SendMail(m.host+":"+m.port, auth, sender.Address, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
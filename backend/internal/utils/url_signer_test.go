@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestURLSignerVerify(t *testing.T) {
+	signer := NewURLSigner("test-secret")
+	const resource = "documents/3f1c2a9e-8d4b-4c1e-9a7f-2b6d5e8c1a40"
+
+	valid := time.Now().Add(time.Hour)
+	expired := time.Now().Add(-time.Minute)
+	validUnix := strconv.FormatInt(valid.Unix(), 10)
+
+	tests := []struct {
+		name      string
+		resource  string
+		expires   string
+		signature string
+		wantErr   string
+	}{
+		{
+			name:      "valid signature",
+			resource:  resource,
+			expires:   validUnix,
+			signature: signer.Sign(resource, valid),
+		},
+		{
+			name:      "expired",
+			resource:  resource,
+			expires:   strconv.FormatInt(expired.Unix(), 10),
+			signature: signer.Sign(resource, expired),
+			wantErr:   "signed url expired",
+		},
+		{
+			name:      "tampered signature",
+			resource:  resource,
+			expires:   validUnix,
+			signature: tamper(signer.Sign(resource, valid)),
+			wantErr:   "invalid signed url",
+		},
+		{
+			name:      "upper-case signature",
+			resource:  resource,
+			expires:   validUnix,
+			signature: strings.ToUpper(signer.Sign(resource, valid)),
+			wantErr:   "invalid signed url",
+		},
+		{
+			name:      "empty signature",
+			resource:  resource,
+			expires:   validUnix,
+			signature: "",
+			wantErr:   "invalid signed url",
+		},
+		{
+			name:      "expiry pushed back",
+			resource:  resource,
+			expires:   strconv.FormatInt(valid.Add(24*time.Hour).Unix(), 10),
+			signature: signer.Sign(resource, valid),
+			wantErr:   "invalid signed url",
+		},
+		{
+			name:      "expired link given a new expiry",
+			resource:  resource,
+			expires:   validUnix,
+			signature: signer.Sign(resource, expired),
+			wantErr:   "invalid signed url",
+		},
+		{
+			name:      "signature for another resource",
+			resource:  "documents/0b7e4d2c-1f3a-4e6b-8c9d-5a2f7e1b3c60",
+			expires:   validUnix,
+			signature: signer.Sign(resource, valid),
+			wantErr:   "invalid signed url",
+		},
+		{
+			name:      "expiry not a timestamp",
+			resource:  resource,
+			expires:   "tomorrow",
+			signature: signer.Sign(resource, valid),
+			wantErr:   "invalid signed url",
+		},
+		{
+			name:      "signed with another secret",
+			resource:  resource,
+			expires:   validUnix,
+			signature: NewURLSigner("other-secret").Sign(resource, valid),
+			wantErr:   "invalid signed url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := signer.Verify(tt.resource, tt.expires, tt.signature)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Verify() error = %v, want none", err)
+			case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+				t.Errorf("Verify() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestURLSignerSign(t *testing.T) {
+	expiresAt := time.Date(2026, time.October, 18, 10, 0, 0, 0, time.UTC)
+	signer := NewURLSigner("test-secret")
+
+	signature := signer.Sign("documents/1", expiresAt)
+	if len(signature) != 64 || strings.Trim(signature, "0123456789abcdef") != "" {
+		t.Errorf("Sign() = %q, want 64 lower-case hex digits", signature)
+	}
+	if again := NewURLSigner("test-secret").Sign("documents/1", expiresAt); again != signature {
+		t.Errorf("Sign() is not stable for the same secret: %q and %q", signature, again)
+	}
+	if other := signer.Sign("documents/1", expiresAt.Add(time.Second)); other == signature {
+		t.Error("Sign() gives the same signature for different expiry times")
+	}
+}
+
+// tamper flips the last hex digit of a signature
+func tamper(signature string) string {
+	last := signature[len(signature)-1]
+	replacement := "0"
+	if last == '0' {
+		replacement = "1"
+	}
+	return signature[:len(signature)-1] + replacement
+}
@@ -0,0 +1,362 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"strings"
+	"unicode/utf8"
+)
+
+// VCard holds the contact details read from or written to a vCard
+// Phones and emails are ordered with the preferred (or mobile) entry first
+type VCard struct {
+	FormattedName string
+	FamilyName    string
+	GivenName     string
+	Phones        []string
+	Emails        []string
+	Street        string
+	City          string
+	Region        string
+	PostalCode    string
+	Note          string
+}
+
+// vCardMaxLineOctets is the longest line a written vCard holds before it is folded (RFC 6350)
+const vCardMaxLineOctets = 75
+
+// ParseVCards reads every card in a vCard 2.1, 3.0 or 4.0 file
+// Unknown properties are ignored; quoted-printable values from older phone exports are decoded
+func ParseVCards(r io.Reader) ([]VCard, error) {
+	lines, err := vCardLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := []VCard{}
+	var card *VCard
+	var preferredPhones, preferredEmails int
+
+	for _, line := range lines {
+		name, params, value, ok := splitVCardLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCARD"):
+			card = &VCard{}
+			preferredPhones, preferredEmails = 0, 0
+			continue
+		case name == "END" && strings.EqualFold(value, "VCARD"):
+			if card != nil {
+				cards = append(cards, *card)
+			}
+			card = nil
+			continue
+		case card == nil:
+			continue
+		}
+
+		if params["ENCODING"] == "QUOTED-PRINTABLE" {
+			decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(value)))
+			if err == nil {
+				value = string(decoded)
+			}
+		}
+
+		switch name {
+		case "FN":
+			card.FormattedName = unescapeVCardValue(value)
+		case "N":
+			parts := splitVCardComponents(value)
+			card.FamilyName = componentAt(parts, 0)
+			card.GivenName = strings.TrimSpace(componentAt(parts, 1) + " " + componentAt(parts, 2))
+		case "TEL":
+			phone := strings.TrimPrefix(unescapeVCardValue(value), "tel:")
+			if phone == "" {
+				continue
+			}
+			// Preferred and mobile numbers go ahead of the rest, keeping file order among each group
+			if isPreferred(params) || params["TYPE:CELL"] != "" {
+				card.Phones = insertAt(card.Phones, preferredPhones, phone)
+				preferredPhones++
+			} else {
+				card.Phones = append(card.Phones, phone)
+			}
+		case "EMAIL":
+			email := unescapeVCardValue(value)
+			if email == "" {
+				continue
+			}
+			if isPreferred(params) {
+				card.Emails = insertAt(card.Emails, preferredEmails, email)
+				preferredEmails++
+			} else {
+				card.Emails = append(card.Emails, email)
+			}
+		case "ADR":
+			// The first address wins; the components are PO box, extended, street, locality, region, postal code, country
+			if card.Street != "" || card.City != "" {
+				continue
+			}
+			parts := splitVCardComponents(value)
+			card.Street = strings.TrimSpace(strings.Join(nonBlank(componentAt(parts, 0), componentAt(parts, 1), componentAt(parts, 2)), ", "))
+			card.City = componentAt(parts, 3)
+			card.Region = componentAt(parts, 4)
+			card.PostalCode = componentAt(parts, 5)
+		case "NOTE":
+			card.Note = unescapeVCardValue(value)
+		}
+	}
+
+	if len(cards) == 0 {
+		return nil, fmt.Errorf("no contacts found in vCard file")
+	}
+
+	return cards, nil
+}
+
+// WriteVCard writes a contact as a vCard 3.0 card with CRLF line endings and folded long lines
+func WriteVCard(w io.Writer, card VCard) error {
+	lines := []string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"N:" + escapeVCardValue(card.FamilyName) + ";" + escapeVCardValue(card.GivenName) + ";;;",
+		"FN:" + escapeVCardValue(card.FormattedName),
+	}
+
+	for i, phone := range card.Phones {
+		params := ";TYPE=CELL"
+		if i == 0 {
+			params += ",PREF"
+		}
+		lines = append(lines, "TEL"+params+":"+escapeVCardValue(phone))
+	}
+	for _, email := range card.Emails {
+		lines = append(lines, "EMAIL;TYPE=INTERNET:"+escapeVCardValue(email))
+	}
+	if card.Street != "" || card.City != "" || card.Region != "" || card.PostalCode != "" {
+		lines = append(lines, "ADR;TYPE=HOME:;;"+strings.Join([]string{
+			escapeVCardValue(card.Street),
+			escapeVCardValue(card.City),
+			escapeVCardValue(card.Region),
+			escapeVCardValue(card.PostalCode),
+			"",
+		}, ";"))
+	}
+	if card.Note != "" {
+		lines = append(lines, "NOTE:"+escapeVCardValue(card.Note))
+	}
+	lines = append(lines, "END:VCARD")
+
+	for _, line := range lines {
+		if _, err := io.WriteString(w, foldVCardLine(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// vCardLines reads a vCard file into logical lines, joining folded lines and quoted-printable soft breaks
+func vCardLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	lines := []string{}
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		last := len(lines) - 1
+		switch {
+		case last >= 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")):
+			// Folded continuation of the previous line
+			lines[last] += line[1:]
+		case last >= 0 && strings.HasSuffix(lines[last], "=") && isQuotedPrintableLine(lines[last]):
+			// Quoted-printable soft line break: the value carries on without leading whitespace
+			lines[last] = strings.TrimSuffix(lines[last], "=") + line
+		case line != "":
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read vCard file: %w", err)
+	}
+
+	return lines, nil
+}
+
+// isQuotedPrintableLine reports whether a content line's value is quoted-printable encoded
+func isQuotedPrintableLine(line string) bool {
+	colon := strings.Index(line, ":")
+	return colon > 0 && strings.Contains(strings.ToUpper(line[:colon]), "QUOTED-PRINTABLE")
+}
+
+// splitVCardLine splits a content line into its upper-case property name, parameters and raw value
+// Parameters are keyed by upper-case name; every TYPE value is also present as "TYPE:<value>",
+// including vCard 2.1 bare types such as "TEL;CELL:..."
+func splitVCardLine(line string) (string, map[string]string, string, bool) {
+	colon := -1
+	quoted := false
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return "", nil, "", false
+	}
+
+	segments := strings.Split(line[:colon], ";")
+	name := strings.ToUpper(segments[0])
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:] // drop group prefixes like "item1."
+	}
+
+	params := make(map[string]string)
+	for _, segment := range segments[1:] {
+		key, value, hasValue := strings.Cut(segment, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.Trim(strings.TrimSpace(value), `"`))
+		if !hasValue {
+			key, value = "TYPE", key
+		}
+		params[key] = value
+		if key == "TYPE" {
+			for _, typ := range strings.Split(value, ",") {
+				params["TYPE:"+strings.TrimSpace(typ)] = "1"
+			}
+		}
+	}
+
+	return name, params, line[colon+1:], true
+}
+
+// splitVCardComponents splits a structured value such as N or ADR on unescaped semicolons
+func splitVCardComponents(value string) []string {
+	parts := []string{}
+	var current strings.Builder
+	escaped := false
+
+	for _, r := range value {
+		switch {
+		case escaped:
+			current.WriteRune('\\')
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			parts = append(parts, unescapeVCardValue(current.String()))
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	return append(parts, unescapeVCardValue(current.String()))
+}
+
+// unescapeVCardValue decodes backslash escapes in a text value
+func unescapeVCardValue(value string) string {
+	var b strings.Builder
+	escaped := false
+
+	for _, r := range value {
+		if escaped {
+			switch r {
+			case 'n', 'N':
+				b.WriteRune('\n')
+			default:
+				b.WriteRune(r)
+			}
+			escaped = false
+			continue
+		}
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// escapeVCardValue escapes a text value for a content line
+func escapeVCardValue(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		",", `\,`,
+		";", `\;`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// foldVCardLine breaks a line longer than 75 octets into continuation lines without splitting characters
+func foldVCardLine(line string) string {
+	if len(line) <= vCardMaxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	limit := vCardMaxLineOctets
+	width := 0
+
+	for len(line) > 0 {
+		r, size := utf8.DecodeRuneInString(line)
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 0
+			limit = vCardMaxLineOctets - 1 // the leading space counts toward the continuation line
+		}
+		b.WriteRune(r)
+		width += size
+		line = line[size:]
+	}
+
+	return b.String()
+}
+
+// isPreferred reports whether a property is marked preferred (TYPE=PREF in 2.1/3.0, PREF=1 in 4.0)
+func isPreferred(params map[string]string) bool {
+	return params["TYPE:PREF"] != "" || params["PREF"] != ""
+}
+
+// componentAt returns the structured value component at index, or "" when the value is shorter
+func componentAt(parts []string, index int) string {
+	if index < len(parts) {
+		return parts[index]
+	}
+	return ""
+}
+
+// insertAt inserts value into values at index
+func insertAt(values []string, index int, value string) []string {
+	values = append(values, "")
+	copy(values[index+1:], values[index:])
+	values[index] = value
+	return values
+}
+
+// nonBlank returns the values that are not blank
+func nonBlank(values ...string) []string {
+	result := []string{}
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
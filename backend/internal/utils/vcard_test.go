@@ -0,0 +1,331 @@
+package utils
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// iosExport is modelled on a contact shared from the iPhone Contacts app: vCard 3.0, repeated
+// lower-case type parameters, item groups and a note folded at 75 octets
+const iosExport = `BEGIN:VCARD
+VERSION:3.0
+PRODID:-//Apple Inc.//iPhone OS 17.4//EN
+N:Sharma;Priya;;;
+FN:Priya Sharma
+ORG:Sharma Realty;
+TEL;type=HOME;type=VOICE:022 2634 5678
+TEL;type=CELL;type=VOICE;type=pref:+91 98200 12345
+TEL;type=WORK;type=VOICE:+91 22 4000 1234
+item1.EMAIL;type=INTERNET;type=HOME:priya.home@example.com
+item2.EMAIL;type=INTERNET;type=pref:priya@example.com
+item3.ADR;type=HOME;type=pref:;;Flat 12\, Sea Breeze\;Wing B;Mumbai;Maharashtra;400
+ 053;India
+item3.X-ABADR:in
+NOTE:Looking for a 2BHK near Andheri station\, ready to move. Budget up to 1.
+ 5 crore\nPrefers evening calls
+END:VCARD
+`
+
+// androidExport is modelled on a contacts backup from an Android phone: vCard 2.1, bare
+// parameters, quoted-printable names with soft line breaks and several numbers
+const androidExport = `BEGIN:VCARD
+VERSION:2.1
+N;CHARSET=UTF-8;ENCODING=QUOTED-PRINTABLE:=E0=A4=B5=E0=A4=B0=E0=A5=8D=E0=A4=AE=E0=A4=BE;=E0=A4=B0=E0=A4=BE=E0=A4=B9=
+=E0=A5=81=E0=A4=B2;;;
+FN;CHARSET=UTF-8;ENCODING=QUOTED-PRINTABLE:=E0=A4=B0=E0=A4=BE=E0=A4=B9=E0=A5=81=E0=A4=B2 =E0=A4=B5=E0=A4=B0=E0=A5=8D=
+=E0=A4=AE=E0=A4=BE
+TEL;HOME:020-2567-8901
+TEL;CELL:+919822012345
+TEL;CELL;PREF:+919822054321
+TEL;WORK:
+EMAIL;HOME:rahul.verma@example.com
+NOTE;ENCODING=QUOTED-PRINTABLE:Met at the Baner site visit=0D=0AWants 3BHK, east facing
+END:VCARD
+BEGIN:VCARD
+VERSION:2.1
+N:;Anita;;;
+FN:Anita
+TEL;CELL:98765 43210
+END:VCARD
+`
+
+// crlf converts a fixture to the CRLF line endings phones write
+func crlf(s string) string {
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
+
+func TestParseVCardsIOSExport(t *testing.T) {
+	cards, err := ParseVCards(strings.NewReader(crlf(iosExport)))
+	if err != nil {
+		t.Fatalf("ParseVCards() error = %v", err)
+	}
+
+	want := []VCard{{
+		FormattedName: "Priya Sharma",
+		FamilyName:    "Sharma",
+		GivenName:     "Priya",
+		Phones:        []string{"+91 98200 12345", "022 2634 5678", "+91 22 4000 1234"},
+		Emails:        []string{"priya@example.com", "priya.home@example.com"},
+		Street:        "Flat 12, Sea Breeze;Wing B",
+		City:          "Mumbai",
+		Region:        "Maharashtra",
+		PostalCode:    "400053",
+		Note:          "Looking for a 2BHK near Andheri station, ready to move. Budget up to 1.5 crore\nPrefers evening calls",
+	}}
+	if !reflect.DeepEqual(cards, want) {
+		t.Errorf("ParseVCards() =\n%#v\nwant\n%#v", cards, want)
+	}
+}
+
+func TestParseVCardsAndroidExport(t *testing.T) {
+	cards, err := ParseVCards(strings.NewReader(crlf(androidExport)))
+	if err != nil {
+		t.Fatalf("ParseVCards() error = %v", err)
+	}
+
+	want := []VCard{
+		{
+			FormattedName: "राहुल वर्मा",
+			FamilyName:    "वर्मा",
+			GivenName:     "राहुल",
+			Phones:        []string{"+919822012345", "+919822054321", "020-2567-8901"},
+			Emails:        []string{"rahul.verma@example.com"},
+			Note:          "Met at the Baner site visit\r\nWants 3BHK, east facing",
+		},
+		{
+			FormattedName: "Anita",
+			GivenName:     "Anita",
+			Phones:        []string{"98765 43210"},
+		},
+	}
+	if !reflect.DeepEqual(cards, want) {
+		t.Errorf("ParseVCards() =\n%#v\nwant\n%#v", cards, want)
+	}
+}
+
+func TestParseVCardsTelAndEmailParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		lines      string
+		wantPhones []string
+		wantEmails []string
+	}{
+		{
+			name:       "file order without preferences",
+			lines:      "TEL;TYPE=HOME:1111\nTEL;TYPE=WORK:2222\nEMAIL:a@example.com\nEMAIL:b@example.com",
+			wantPhones: []string{"1111", "2222"},
+			wantEmails: []string{"a@example.com", "b@example.com"},
+		},
+		{
+			name:       "mobile numbers first, in file order",
+			lines:      "TEL;TYPE=HOME:1111\nTEL;TYPE=CELL:2222\nTEL;TYPE=WORK:3333\nTEL;TYPE=CELL:4444",
+			wantPhones: []string{"2222", "4444", "1111", "3333"},
+		},
+		{
+			name:       "comma separated types",
+			lines:      "TEL;TYPE=WORK,VOICE:1111\nTEL;TYPE=\"cell,voice\":2222",
+			wantPhones: []string{"2222", "1111"},
+		},
+		{
+			name:       "vCard 4.0 PREF parameter and tel URIs",
+			lines:      "TEL;VALUE=uri;TYPE=home:tel:+91-22-2634-5678\nTEL;VALUE=uri;PREF=1;TYPE=work:tel:+91-22-4000-1234\nEMAIL;TYPE=work:w@example.com\nEMAIL;PREF=1:p@example.com",
+			wantPhones: []string{"+91-22-4000-1234", "+91-22-2634-5678"},
+			wantEmails: []string{"p@example.com", "w@example.com"},
+		},
+		{
+			name:       "blank values are skipped",
+			lines:      "TEL;TYPE=CELL:\nTEL:1111\nEMAIL;TYPE=INTERNET: \nEMAIL:a@example.com",
+			wantPhones: []string{"1111"},
+			wantEmails: []string{"a@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := "BEGIN:VCARD\nVERSION:3.0\nFN:Test\n" + tt.lines + "\nEND:VCARD\n"
+			cards, err := ParseVCards(strings.NewReader(crlf(file)))
+			if err != nil {
+				t.Fatalf("ParseVCards() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(cards[0].Phones, tt.wantPhones) {
+				t.Errorf("phones = %q, want %q", cards[0].Phones, tt.wantPhones)
+			}
+			if !reflect.DeepEqual(cards[0].Emails, tt.wantEmails) {
+				t.Errorf("emails = %q, want %q", cards[0].Emails, tt.wantEmails)
+			}
+		})
+	}
+}
+
+func TestParseVCardsWithoutContacts(t *testing.T) {
+	for _, file := range []string{"", "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", "FN:Orphan\r\n"} {
+		if _, err := ParseVCards(strings.NewReader(file)); err == nil {
+			t.Errorf("ParseVCards(%q) succeeded, want an error", file)
+		}
+	}
+}
+
+func TestVCardEscaping(t *testing.T) {
+	tests := []struct {
+		value   string
+		escaped string
+	}{
+		{"Plain text", "Plain text"},
+		{"Flat 12, Sea Breeze", `Flat 12\, Sea Breeze`},
+		{"Wing A; Wing B", `Wing A\; Wing B`},
+		{`C:\Users\priya`, `C:\\Users\\priya`},
+		{"First line\nSecond line", `First line\nSecond line`},
+		{"Windows\r\nline break", `Windows\nline break`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := escapeVCardValue(tt.value); got != tt.escaped {
+				t.Errorf("escapeVCardValue(%q) = %q, want %q", tt.value, got, tt.escaped)
+			}
+
+			want := strings.ReplaceAll(tt.value, "\r\n", "\n")
+			if got := unescapeVCardValue(tt.escaped); got != want {
+				t.Errorf("unescapeVCardValue(%q) = %q, want %q", tt.escaped, got, want)
+			}
+		})
+	}
+}
+
+func TestFoldVCardLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		wantLines int
+	}{
+		{"short line", "FN:Priya Sharma", 1},
+		{"exactly 75 octets", "NOTE:" + strings.Repeat("a", 70), 1},
+		{"76 octets", "NOTE:" + strings.Repeat("a", 71), 2},
+		{"long ASCII note", "NOTE:" + strings.Repeat("Looking for a 2BHK near the station. ", 8), 5},
+		{"multi-byte characters", "FN:" + strings.Repeat("राहुल वर्मा ", 10), 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := foldVCardLine(tt.line)
+			lines := strings.Split(folded, "\r\n")
+
+			if len(lines) != tt.wantLines {
+				t.Errorf("folded into %d lines, want %d", len(lines), tt.wantLines)
+			}
+			for i, line := range lines {
+				if len(line) > vCardMaxLineOctets {
+					t.Errorf("line %d is %d octets, want at most %d", i+1, len(line), vCardMaxLineOctets)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a character: %q", i+1, line)
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d does not start with a space: %q", i+1, line)
+				}
+			}
+
+			unfolded, err := vCardLines(strings.NewReader(folded + "\r\n"))
+			if err != nil {
+				t.Fatalf("vCardLines() error = %v", err)
+			}
+			if len(unfolded) != 1 || unfolded[0] != tt.line {
+				t.Errorf("unfolded = %q, want %q", unfolded, tt.line)
+			}
+		})
+	}
+}
+
+func TestWriteVCard(t *testing.T) {
+	card := VCard{
+		FormattedName: "Priya Sharma",
+		FamilyName:    "Sharma",
+		GivenName:     "Priya",
+		Phones:        []string{"+919820012345", "+912226345678"},
+		Emails:        []string{"priya@example.com"},
+		Street:        "Flat 12, Sea Breeze",
+		City:          "Mumbai",
+		Region:        "Maharashtra",
+		PostalCode:    "400053",
+		Note:          "Budget; 1.5 crore",
+	}
+
+	var buf bytes.Buffer
+	if err := WriteVCard(&buf, card); err != nil {
+		t.Fatalf("WriteVCard() error = %v", err)
+	}
+
+	want := crlf(`BEGIN:VCARD
+VERSION:3.0
+N:Sharma;Priya;;;
+FN:Priya Sharma
+TEL;TYPE=CELL,PREF:+919820012345
+TEL;TYPE=CELL:+912226345678
+EMAIL;TYPE=INTERNET:priya@example.com
+ADR;TYPE=HOME:;;Flat 12\, Sea Breeze;Mumbai;Maharashtra;400053;
+NOTE:Budget\; 1.5 crore
+END:VCARD
+`)
+	if buf.String() != want {
+		t.Errorf("WriteVCard() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestVCardRoundTrip(t *testing.T) {
+	cards := []VCard{
+		{
+			FormattedName: "Priya Sharma",
+			FamilyName:    "Sharma",
+			GivenName:     "Priya",
+			Phones:        []string{"+919820012345", "+912226345678", "+912240001234"},
+			Emails:        []string{"priya@example.com", "priya.home@example.com"},
+			Street:        "Flat 12, Sea Breeze; Wing B",
+			City:          "Mumbai",
+			Region:        "Maharashtra",
+			PostalCode:    "400053",
+			Note:          strings.Repeat("Wants a 2BHK near Andheri station, ready to move; budget up to 1.5 crore. ", 3) + "\nPrefers evening calls",
+		},
+		{
+			FormattedName: "राहुल वर्मा",
+			FamilyName:    "वर्मा",
+			GivenName:     "राहुल",
+			Phones:        []string{"+919822012345"},
+			Note:          strings.Repeat("पुणे में 3BHK फ्लैट चाहिए ", 6) + `C:\notes`,
+		},
+		{
+			FormattedName: "Anita",
+			GivenName:     "Anita",
+			Emails:        []string{"anita@example.com"},
+		},
+	}
+
+	var buf bytes.Buffer
+	for _, card := range cards {
+		if err := WriteVCard(&buf, card); err != nil {
+			t.Fatalf("WriteVCard() error = %v", err)
+		}
+	}
+
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > vCardMaxLineOctets {
+			t.Errorf("written line %d is %d octets, want at most %d", i+1, len(line), vCardMaxLineOctets)
+		}
+	}
+
+	parsed, err := ParseVCards(&buf)
+	if err != nil {
+		t.Fatalf("ParseVCards() error = %v", err)
+	}
+
+	// Trailing spaces are trimmed from every value when read back
+	for i := range cards {
+		cards[i].Note = strings.TrimSpace(cards[i].Note)
+	}
+	if !reflect.DeepEqual(parsed, cards) {
+		t.Errorf("round trip =\n%#v\nwant\n%#v", parsed, cards)
+	}
+}
@@ -0,0 +1,43 @@
+-- Create property_matches table linking clients to properties that fit their requirements
+CREATE TABLE IF NOT EXISTS property_matches (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Relationships
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+
+    -- Match Quality
+    score INTEGER NOT NULL CHECK (score BETWEEN 0 AND 100),
+    reasons TEXT[] DEFAULT '{}',
+
+    -- Timestamps (created_at marks when the match first surfaced)
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (client_id, property_id)
+);
+
+-- Performance Indexes
+
+-- Best matches for a client
+CREATE INDEX IF NOT EXISTS idx_property_matches_client_score
+    ON property_matches(client_id, score DESC);
+
+-- Best matches for a property
+CREATE INDEX IF NOT EXISTS idx_property_matches_property_score
+    ON property_matches(property_id, score DESC);
+
+-- Candidate lookups used by the matching engine
+CREATE INDEX IF NOT EXISTS idx_properties_match_candidates
+    ON properties(LOWER(city), listing_type, status);
+
+CREATE INDEX IF NOT EXISTS idx_clients_match_candidates
+    ON clients(LOWER(city), type, status);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_property_matches_updated_at ON property_matches;
+CREATE TRIGGER update_property_matches_updated_at
+    BEFORE UPDATE ON property_matches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,46 @@
+-- Trigram index on address for duplicate listing detection (pg_trgm enabled in migration 002)
+CREATE INDEX IF NOT EXISTS idx_properties_address_trgm
+    ON properties USING gin(address gin_trgm_ops);
+
+-- Create property_duplicates table recording likely duplicate listing pairs
+CREATE TABLE IF NOT EXISTS property_duplicates (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Pair of properties, stored in canonical order so each pair appears once
+    property_a_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    property_b_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+
+    -- Detection details
+    address_similarity REAL NOT NULL,
+
+    -- Admin Review
+    status VARCHAR(50) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'dismissed')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (property_a_id < property_b_id),
+    UNIQUE (property_a_id, property_b_id)
+);
+
+-- Performance Indexes
+
+-- Admin review queue
+CREATE INDEX IF NOT EXISTS idx_property_duplicates_status
+    ON property_duplicates(status, created_at DESC);
+
+-- Lookups from either side of a pair
+CREATE INDEX IF NOT EXISTS idx_property_duplicates_property_b
+    ON property_duplicates(property_b_id);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_property_duplicates_updated_at ON property_duplicates;
+CREATE TRIGGER update_property_duplicates_updated_at
+    BEFORE UPDATE ON property_duplicates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,81 @@
+-- Create saved_searches table holding stored property search definitions
+CREATE TABLE IF NOT EXISTS saved_searches (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Ownership (a broker's own search, optionally on behalf of one of their clients)
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id UUID REFERENCES clients(id) ON DELETE CASCADE,
+
+    -- Search Definition (same vocabulary as property search filters)
+    name VARCHAR(100) NOT NULL,
+    filters JSONB NOT NULL DEFAULT '{}',
+
+    -- Delivery Preferences
+    notify_in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_email BOOLEAN NOT NULL DEFAULT TRUE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_notified_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user_created
+    ON saved_searches(user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_client
+    ON saved_searches(client_id) WHERE client_id IS NOT NULL;
+
+-- Evaluator prefilter on the most selective filter keys
+CREATE INDEX IF NOT EXISTS idx_saved_searches_active_city
+    ON saved_searches(LOWER(filters->>'city')) WHERE is_active = TRUE;
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_saved_searches_updated_at ON saved_searches;
+CREATE TRIGGER update_saved_searches_updated_at
+    BEFORE UPDATE ON saved_searches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Create saved_search_alerts table so each listing is alerted at most once per search
+CREATE TABLE IF NOT EXISTS saved_search_alerts (
+    saved_search_id UUID NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (saved_search_id, property_id)
+);
+
+-- Create notifications table for in-app notifications
+CREATE TABLE IF NOT EXISTS notifications (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Recipient
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Content
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+
+    -- Related entity (e.g. the property a saved search matched)
+    entity_type VARCHAR(50),
+    entity_id UUID,
+
+    -- Read State
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    read_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created
+    ON notifications(user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread
+    ON notifications(user_id) WHERE is_read = FALSE;
@@ -0,0 +1,101 @@
+-- Create projects table for channel partner (builder) projects
+CREATE TABLE IF NOT EXISTS projects (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Basic Project Information
+    name VARCHAR(255) NOT NULL,
+    builder_name VARCHAR(255) NOT NULL,
+    project_type VARCHAR(50) NOT NULL CHECK (project_type IN ('residential', 'commercial', 'mixed')),
+
+    -- Location Information
+    location VARCHAR(255) NOT NULL,
+    city VARCHAR(100) NOT NULL,
+    state VARCHAR(100) NOT NULL,
+
+    -- Pricing
+    price_range_min DECIMAL(15, 2) NOT NULL,
+    price_range_max DECIMAL(15, 2) NOT NULL,
+
+    -- Description and Media
+    description TEXT NOT NULL,
+    amenities TEXT[] DEFAULT '{}',
+    images TEXT[] DEFAULT '{}',
+    brochure_url VARCHAR(500),
+
+    -- Timeline and Status
+    launch_date DATE,
+    possession_date DATE,
+    status VARCHAR(50) NOT NULL DEFAULT 'upcoming'
+        CHECK (status IN ('upcoming', 'launched', 'under_construction', 'ready')),
+
+    -- Ownership
+    channel_partner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (price_range_min <= price_range_max)
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_projects_partner_created
+    ON projects(channel_partner_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_projects_city_status
+    ON projects(LOWER(city), status);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_projects_updated_at ON projects;
+CREATE TRIGGER update_projects_updated_at
+    BEFORE UPDATE ON projects
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Create project_units table for unit-level inventory
+CREATE TABLE IF NOT EXISTS project_units (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+
+    -- Unit Identification
+    tower VARCHAR(50) NOT NULL,
+    floor INTEGER NOT NULL,
+    unit_number VARCHAR(50) NOT NULL,
+
+    -- Unit Details
+    configuration VARCHAR(50) NOT NULL, -- e.g. 1BHK, 2BHK, Shop, Office
+    carpet_area DECIMAL(10, 2),
+    price DECIMAL(15, 2) NOT NULL,
+
+    -- Inventory Status
+    status VARCHAR(50) NOT NULL DEFAULT 'available'
+        CHECK (status IN ('available', 'blocked', 'booked')),
+
+    -- Block held by a broker for one of their clients
+    blocked_by_broker_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    blocked_for_client_id UUID REFERENCES clients(id) ON DELETE SET NULL,
+    blocked_at TIMESTAMP WITH TIME ZONE,
+    block_expires_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (project_id, tower, unit_number)
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_project_units_project_status
+    ON project_units(project_id, status);
+
+CREATE INDEX IF NOT EXISTS idx_project_units_blocked_by
+    ON project_units(blocked_by_broker_id) WHERE blocked_by_broker_id IS NOT NULL;
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_project_units_updated_at ON project_units;
+CREATE TRIGGER update_project_units_updated_at
+    BEFORE UPDATE ON project_units
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,42 @@
+-- Create property_share_links table for public, tokenized listing pages
+CREATE TABLE IF NOT EXISTS property_share_links (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Shared listing and the broker who shared it
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Unguessable token used in the public URL
+    token VARCHAR(64) NOT NULL UNIQUE,
+
+    -- Presentation options
+    show_broker_contact BOOLEAN NOT NULL DEFAULT TRUE,
+    hide_address BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- Lifetime
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+
+    -- View tracking
+    view_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_property_share_links_property
+    ON property_share_links(property_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_property_share_links_broker
+    ON property_share_links(broker_id);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_property_share_links_updated_at ON property_share_links;
+CREATE TRIGGER update_property_share_links_updated_at
+    BEFORE UPDATE ON property_share_links
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,42 @@
+-- Add listing images used by share pages and portal feeds
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS images TEXT[] DEFAULT '{}';
+
+-- Create syndication_feeds table for exporting listings to external property portals
+CREATE TABLE IF NOT EXISTS syndication_feeds (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owner
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Feed definition
+    name VARCHAR(255) NOT NULL,
+    portal VARCHAR(100) NOT NULL,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('xml', 'json')),
+    root_element VARCHAR(100) NOT NULL DEFAULT 'listings',
+    item_element VARCHAR(100) NOT NULL DEFAULT 'listing',
+    fields JSONB NOT NULL DEFAULT '[]', -- ordered [{source, target}] field mapping
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- Scheduled generation state
+    last_generated_at TIMESTAMP WITH TIME ZONE,
+    last_file_path VARCHAR(500),
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_syndication_feeds_broker
+    ON syndication_feeds(broker_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_properties_broker_updated
+    ON properties(broker_id, updated_at);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_syndication_feeds_updated_at ON syndication_feeds;
+CREATE TRIGGER update_syndication_feeds_updated_at
+    BEFORE UPDATE ON syndication_feeds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,43 @@
+-- Create documents table for the property and client documents vault
+CREATE TABLE IF NOT EXISTS documents (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owning broker and the records the document is filed under
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    property_id UUID REFERENCES properties(id) ON DELETE CASCADE,
+    client_id UUID REFERENCES clients(id) ON DELETE CASCADE,
+
+    -- Classification
+    document_type VARCHAR(30) NOT NULL CHECK (document_type IN (
+        'sale_deed', 'noc', 'rera_certificate', 'rent_agreement',
+        'id_proof', 'floor_plan', 'tax_receipt', 'other'
+    )),
+    title VARCHAR(255) NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private' CHECK (visibility IN ('private', 'shareable')),
+
+    -- Stored file (kept outside the public uploads directory)
+    file_name VARCHAR(255) NOT NULL UNIQUE,
+    original_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    -- Every document is filed under a property, a client, or both
+    CONSTRAINT documents_owner_check CHECK (property_id IS NOT NULL OR client_id IS NOT NULL)
+);
+
+-- Performance Indexes
+CREATE INDEX IF NOT EXISTS idx_documents_broker ON documents(broker_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_documents_property ON documents(property_id) WHERE property_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_documents_client ON documents(client_id) WHERE client_id IS NOT NULL;
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_documents_updated_at ON documents;
+CREATE TRIGGER update_documents_updated_at
+    BEFORE UPDATE ON documents
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
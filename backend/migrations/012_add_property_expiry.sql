@@ -0,0 +1,18 @@
+-- Add listing expiry, renewal and the archived status to properties
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS expiry_notified_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+
+-- Allow the archived status
+ALTER TABLE properties DROP CONSTRAINT IF EXISTS properties_status_check;
+ALTER TABLE properties ADD CONSTRAINT properties_status_check
+    CHECK (status IN ('available', 'sold', 'rented', 'under_negotiation', 'archived'));
+
+-- Give existing listings an expiry, with at least two weeks' notice before anything is archived
+UPDATE properties
+SET expires_at = GREATEST(updated_at + INTERVAL '90 days', NOW() + INTERVAL '14 days')
+WHERE expires_at IS NULL;
+
+-- Supports the scheduled expiry scan
+CREATE INDEX IF NOT EXISTS idx_properties_status_expires
+    ON properties(status, expires_at);
@@ -0,0 +1,17 @@
+-- Add soft deletion to properties, clients and appointments
+-- Deleted rows stay in the trash until the purge job removes them after the retention window
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Trash listing and purge scans only touch deleted rows
+CREATE INDEX IF NOT EXISTS idx_properties_deleted
+    ON properties(broker_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_clients_deleted
+    ON clients(broker_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_appointments_deleted
+    ON appointments(broker_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Cascade restore finds a client's appointments trashed together with it
+CREATE INDEX IF NOT EXISTS idx_appointments_client_deleted
+    ON appointments(client_id, deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,29 @@
+-- Create property_daily_stats table for listing engagement analytics
+-- One row per property and day; counters are incremented as events happen
+CREATE TABLE IF NOT EXISTS property_daily_stats (
+    -- Listing and the broker who owns it
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+
+    -- Engagement counters
+    share_views INTEGER NOT NULL DEFAULT 0,
+    app_views INTEGER NOT NULL DEFAULT 0,
+    inquiries INTEGER NOT NULL DEFAULT 0,
+    appointments INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (property_id, day)
+);
+
+-- Per-broker reports scan a date range across all of the broker's listings
+CREATE INDEX IF NOT EXISTS idx_property_daily_stats_broker_day
+    ON property_daily_stats(broker_id, day);
+
+-- Backfill appointment counts from existing appointments
+INSERT INTO property_daily_stats (property_id, broker_id, day, appointments)
+SELECT a.property_id, p.broker_id, a.created_at::date, COUNT(*)
+FROM appointments a
+JOIN properties p ON p.id = a.property_id
+WHERE a.property_id IS NOT NULL
+GROUP BY a.property_id, p.broker_id, a.created_at::date
+ON CONFLICT (property_id, day) DO NOTHING;
@@ -0,0 +1,32 @@
+-- Record when a listing was sold or rented so days-on-market can be measured
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP WITH TIME ZONE;
+
+-- Listings closed before this migration use their last update as the closing time
+UPDATE properties
+SET closed_at = updated_at
+WHERE status IN ('sold', 'rented') AND closed_at IS NULL;
+
+-- Keep closed_at in step with the status: set when a listing closes, cleared if it is reopened
+CREATE OR REPLACE FUNCTION set_property_closed_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status IN ('sold', 'rented') THEN
+        IF TG_OP = 'INSERT' OR OLD.status NOT IN ('sold', 'rented') OR NEW.closed_at IS NULL THEN
+            NEW.closed_at = NOW();
+        END IF;
+    ELSE
+        NEW.closed_at = NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS set_properties_closed_at ON properties;
+CREATE TRIGGER set_properties_closed_at
+    BEFORE INSERT OR UPDATE OF status ON properties
+    FOR EACH ROW
+    EXECUTE FUNCTION set_property_closed_at();
+
+-- Market analytics group listings by city regardless of capitalisation
+CREATE INDEX IF NOT EXISTS idx_properties_city_lower
+    ON properties(LOWER(TRIM(city)), listing_type);
@@ -0,0 +1,37 @@
+-- Add structured requirement fields to clients; the free-text requirements column is kept for notes
+DO $$
+BEGIN
+    -- Columns are added and backfilled together, once, so later edits are never overwritten
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'clients' AND column_name = 'preferred_localities'
+    ) THEN
+        ALTER TABLE clients
+            ADD COLUMN property_types TEXT[] NOT NULL DEFAULT '{}',
+            ADD COLUMN listing_type VARCHAR(50) CHECK (listing_type IN ('sale', 'rent')),
+            ADD COLUMN bedrooms_min INTEGER,
+            ADD COLUMN bedrooms_max INTEGER,
+            ADD COLUMN area_min DECIMAL(10, 2),
+            ADD COLUMN area_max DECIMAL(10, 2),
+            ADD COLUMN preferred_localities TEXT[] NOT NULL DEFAULT '{}',
+            ADD COLUMN required_amenities TEXT[] NOT NULL DEFAULT '{}';
+
+        -- Seed the structured fields from what existing clients already tell us
+        UPDATE clients SET
+            listing_type = CASE type WHEN 'buyer' THEN 'sale' WHEN 'tenant' THEN 'rent' END,
+            preferred_localities = CASE
+                WHEN TRIM(preferred_location) <> '' THEN ARRAY[TRIM(preferred_location)]
+                ELSE '{}'
+            END,
+            bedrooms_min = substring(requirements FROM '(?i)(\d+)\s*-?\s*bhk')::INTEGER,
+            bedrooms_max = substring(requirements FROM '(?i)(\d+)\s*-?\s*bhk')::INTEGER;
+    END IF;
+END
+$$;
+
+-- Requirement filters on the client list
+CREATE INDEX IF NOT EXISTS idx_clients_property_types
+    ON clients USING gin(property_types);
+
+CREATE INDEX IF NOT EXISTS idx_clients_required_amenities
+    ON clients USING gin(required_amenities);
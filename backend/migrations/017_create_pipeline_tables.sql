@@ -0,0 +1,62 @@
+-- Create pipeline_stages table: each broker's configurable sales stages, in board order
+CREATE TABLE IF NOT EXISTS pipeline_stages (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owner
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Stage definition; won and lost stages close the lead
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (kind IN ('open', 'won', 'lost')),
+    position INTEGER NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pipeline_stages_broker_name
+    ON pipeline_stages(broker_id, LOWER(name));
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_stages_broker_position
+    ON pipeline_stages(broker_id, position);
+
+DROP TRIGGER IF EXISTS update_pipeline_stages_updated_at ON pipeline_stages;
+CREATE TRIGGER update_pipeline_stages_updated_at
+    BEFORE UPDATE ON pipeline_stages
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Current stage of each client; stages with clients in them cannot be deleted
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS pipeline_stage_id UUID REFERENCES pipeline_stages(id) ON DELETE RESTRICT;
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS stage_changed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS lost_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_clients_pipeline_stage
+    ON clients(pipeline_stage_id, stage_changed_at DESC);
+
+-- Create client_stage_transitions table: history of every stage change
+CREATE TABLE IF NOT EXISTS client_stage_transitions (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Client and the broker who moved it
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    -- Stages; names are copied so history survives renames and deletions
+    from_stage_id UUID REFERENCES pipeline_stages(id) ON DELETE SET NULL,
+    from_stage_name VARCHAR(100),
+    to_stage_id UUID REFERENCES pipeline_stages(id) ON DELETE SET NULL,
+    to_stage_name VARCHAR(100) NOT NULL,
+
+    -- Why the lead was lost, for moves into a lost stage
+    lost_reason TEXT,
+
+    -- Timestamp
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_client_stage_transitions_client
+    ON client_stage_transitions(client_id, created_at DESC);
@@ -0,0 +1,61 @@
+-- Create client_activities table: the interaction log behind each client's timeline
+DO $$
+BEGIN
+    -- The table is created and backfilled together, once, so deleted entries never come back
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.tables
+        WHERE table_name = 'client_activities'
+    ) THEN
+        CREATE TABLE client_activities (
+            -- Primary Key
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+            -- Relationships; author is empty when no user made the change
+            client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+            broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+            appointment_id UUID REFERENCES appointments(id) ON DELETE SET NULL,
+
+            -- Entry
+            type VARCHAR(50) NOT NULL CHECK (type IN (
+                'call', 'whatsapp', 'email', 'note', 'site_visit', 'meeting',
+                'status_change', 'appointment_scheduled', 'appointment_rescheduled',
+                'appointment_completed', 'appointment_cancelled'
+            )),
+            body TEXT NOT NULL,
+
+            -- Timestamps; occurred_at is when the interaction happened, which may predate logging it
+            occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+        );
+
+        -- Existing notes become the first entry of each client's timeline
+        INSERT INTO client_activities (client_id, broker_id, author_id, type, body, occurred_at)
+        SELECT id, broker_id, broker_id, 'note', TRIM(notes), updated_at
+        FROM clients
+        WHERE TRIM(COALESCE(notes, '')) <> '';
+
+        -- Existing appointments are listed when they were booked and, once closed, when that happened
+        INSERT INTO client_activities (client_id, broker_id, appointment_id, type, body, occurred_at)
+        SELECT client_id, broker_id, id, 'appointment_scheduled',
+            INITCAP(REPLACE(type, '_', ' ')) || ' scheduled for ' || TO_CHAR(date, 'YYYY-MM-DD')
+                || ' at ' || TO_CHAR(time, 'HH24:MI') || ': ' || title,
+            created_at
+        FROM appointments;
+
+        INSERT INTO client_activities (client_id, broker_id, appointment_id, type, body, occurred_at)
+        SELECT client_id, broker_id, id, 'appointment_' || status,
+            INITCAP(REPLACE(type, '_', ' ')) || ' ' || status || ': ' || title,
+            updated_at
+        FROM appointments
+        WHERE status IN ('completed', 'cancelled');
+    END IF;
+END
+$$;
+
+-- Timeline query pattern: a client's entries, newest first
+CREATE INDEX IF NOT EXISTS idx_client_activities_client_occurred
+    ON client_activities(client_id, occurred_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_client_activities_appointment
+    ON client_activities(appointment_id);
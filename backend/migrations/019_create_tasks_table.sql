@@ -0,0 +1,53 @@
+-- Create tasks table: follow-ups attached to clients or properties, with reminders
+CREATE TABLE IF NOT EXISTS tasks (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owner and the user who has to do the task
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assignee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- What the task is about; both are optional
+    client_id UUID REFERENCES clients(id) ON DELETE CASCADE,
+    property_id UUID REFERENCES properties(id) ON DELETE CASCADE,
+
+    -- Task Details
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    priority VARCHAR(20) NOT NULL DEFAULT 'medium' CHECK (priority IN ('low', 'medium', 'high')),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'done')),
+
+    -- Scheduling; the reminder goes out at remind_at and reminded_at records that it did
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    remind_at TIMESTAMP WITH TIME ZONE,
+    reminded_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Task list query patterns: a user's open tasks by due date
+CREATE INDEX IF NOT EXISTS idx_tasks_assignee_due
+    ON tasks(assignee_id, status, due_at);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_broker_due
+    ON tasks(broker_id, status, due_at);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_client
+    ON tasks(client_id);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_property
+    ON tasks(property_id);
+
+-- Reminder scan: open tasks whose reminder has not gone out yet
+CREATE INDEX IF NOT EXISTS idx_tasks_pending_reminders
+    ON tasks(remind_at)
+    WHERE status = 'open' AND reminded_at IS NULL;
+
+DROP TRIGGER IF EXISTS update_tasks_updated_at ON tasks;
+CREATE TRIGGER update_tasks_updated_at
+    BEFORE UPDATE ON tasks
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,56 @@
+-- Normalized contact indexes for duplicate client detection within a broker's book
+-- Phones are compared on their last 10 digits so "+91 98200-12345" matches "9820012345"
+CREATE INDEX IF NOT EXISTS idx_clients_broker_phone_digits
+    ON clients(broker_id, RIGHT(regexp_replace(phone, '\D', '', 'g'), 10))
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_clients_broker_email_lower
+    ON clients(broker_id, LOWER(TRIM(email)))
+    WHERE deleted_at IS NULL;
+
+-- Create client_duplicates table recording likely duplicate client pairs
+CREATE TABLE IF NOT EXISTS client_duplicates (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owner of both clients
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Pair of clients, stored in canonical order so each pair appears once
+    client_a_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    client_b_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+
+    -- Detection details
+    phone_match BOOLEAN NOT NULL DEFAULT FALSE,
+    email_match BOOLEAN NOT NULL DEFAULT FALSE,
+    name_similarity REAL NOT NULL DEFAULT 0,
+
+    -- Broker Review; merging removes the pair along with the merged client
+    status VARCHAR(50) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'dismissed')),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (client_a_id < client_b_id),
+    UNIQUE (client_a_id, client_b_id)
+);
+
+-- Performance Indexes
+
+-- Broker review queue
+CREATE INDEX IF NOT EXISTS idx_client_duplicates_broker_status
+    ON client_duplicates(broker_id, status, created_at DESC);
+
+-- Lookups from either side of a pair
+CREATE INDEX IF NOT EXISTS idx_client_duplicates_client_b
+    ON client_duplicates(client_b_id);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_client_duplicates_updated_at ON client_duplicates;
+CREATE TRIGGER update_client_duplicates_updated_at
+    BEFORE UPDATE ON client_duplicates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
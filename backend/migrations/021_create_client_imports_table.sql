@@ -0,0 +1,47 @@
+-- Create client_imports table tracking contact file imports and their per-row report
+CREATE TABLE IF NOT EXISTS client_imports (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Ownership
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Source file and options
+    file_name VARCHAR(255) NOT NULL,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('csv', 'vcard')),
+    duplicate_policy VARCHAR(20) NOT NULL DEFAULT 'skip'
+        CHECK (duplicate_policy IN ('skip', 'merge', 'create')),
+    default_type VARCHAR(50) NOT NULL DEFAULT 'buyer'
+        CHECK (default_type IN ('buyer', 'seller', 'tenant', 'owner')),
+    mapping JSONB,
+
+    -- Progress
+    status VARCHAR(20) NOT NULL DEFAULT 'processing'
+        CHECK (status IN ('processing', 'completed', 'failed')),
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    created_rows INTEGER NOT NULL DEFAULT 0,
+    merged_rows INTEGER NOT NULL DEFAULT 0,
+    skipped_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+
+    -- Rows that were merged, skipped or failed, in file order
+    report JSONB NOT NULL DEFAULT '[]',
+
+    -- Timestamps
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- A broker's imports, newest first
+CREATE INDEX IF NOT EXISTS idx_client_imports_broker
+    ON client_imports(broker_id, created_at DESC);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_client_imports_updated_at ON client_imports;
+CREATE TRIGGER update_client_imports_updated_at
+    BEFORE UPDATE ON client_imports
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
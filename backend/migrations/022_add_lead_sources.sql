@@ -0,0 +1,42 @@
+-- Add lead source and campaign attribution to clients, and per-broker public lead capture forms
+ALTER TABLE clients
+    ADD COLUMN IF NOT EXISTS source VARCHAR(20)
+        CHECK (source IN ('website', 'portal', 'referral', 'walk_in', 'other')),
+    ADD COLUMN IF NOT EXISTS campaign VARCHAR(100);
+
+-- Lead source filters and the per-source summary
+CREATE INDEX IF NOT EXISTS idx_clients_broker_source
+    ON clients(broker_id, source, campaign)
+    WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS lead_forms (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Each broker has one form
+    broker_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Unguessable key used in the public submission URL
+    form_key VARCHAR(64) NOT NULL UNIQUE,
+
+    -- Options
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    default_type VARCHAR(50) NOT NULL DEFAULT 'buyer'
+        CHECK (default_type IN ('buyer', 'seller', 'tenant', 'owner')),
+
+    -- Submission tracking; spam covers submissions rejected by the honeypot or timing checks
+    submission_count INTEGER NOT NULL DEFAULT 0,
+    spam_count INTEGER NOT NULL DEFAULT 0,
+    last_submission_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_lead_forms_updated_at ON lead_forms;
+CREATE TRIGGER update_lead_forms_updated_at
+    BEFORE UPDATE ON lead_forms
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,33 @@
+-- Add a stored lead score to clients so brokers can work through their book best leads first
+ALTER TABLE clients
+    ADD COLUMN IF NOT EXISTS lead_score SMALLINT NOT NULL DEFAULT 0
+        CHECK (lead_score BETWEEN 0 AND 100),
+    ADD COLUMN IF NOT EXISTS lead_score_factors JSONB,
+    ADD COLUMN IF NOT EXISTS lead_score_updated_at TIMESTAMP WITH TIME ZONE;
+
+-- Client list sorted by lead score
+CREATE INDEX IF NOT EXISTS idx_clients_broker_lead_score
+    ON clients(broker_id, lead_score DESC, id DESC)
+    WHERE deleted_at IS NULL;
+
+-- Refreshing a lead score is bookkeeping rather than an edit, so an update that only changes the
+-- score keeps the client's updated_at (which the score itself reads for recency)
+CREATE OR REPLACE FUNCTION update_clients_updated_at_column()
+RETURNS TRIGGER AS $clients_updated_at$
+BEGIN
+    IF (NEW.lead_score, NEW.lead_score_factors) IS DISTINCT FROM (OLD.lead_score, OLD.lead_score_factors)
+        AND to_jsonb(NEW) - 'lead_score' - 'lead_score_factors' - 'lead_score_updated_at'
+            = to_jsonb(OLD) - 'lead_score' - 'lead_score_factors' - 'lead_score_updated_at' THEN
+        NEW.updated_at = OLD.updated_at;
+    ELSE
+        NEW.updated_at = NOW();
+    END IF;
+    RETURN NEW;
+END;
+$clients_updated_at$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS update_clients_updated_at ON clients;
+CREATE TRIGGER update_clients_updated_at
+    BEFORE UPDATE ON clients
+    FOR EACH ROW
+    EXECUTE FUNCTION update_clients_updated_at_column();
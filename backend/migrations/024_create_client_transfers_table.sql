@@ -0,0 +1,84 @@
+-- Create client_transfers table recording clients handed from one broker to another
+CREATE TABLE IF NOT EXISTS client_transfers (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Giving and receiving brokers, and who proposed the transfer (the giving broker or an admin)
+    from_broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    -- Clients offered; clients merged or deleted in the meantime are skipped on acceptance
+    client_ids UUID[] NOT NULL,
+    message TEXT,
+
+    -- The receiving broker accepts or declines; the proposer may cancel while it is pending
+    status VARCHAR(50) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    responded_at TIMESTAMP WITH TIME ZONE,
+
+    -- What acceptance moved: client count and related record counts by table
+    transferred_count INTEGER NOT NULL DEFAULT 0,
+    moved JSONB,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (from_broker_id <> to_broker_id),
+    CHECK (cardinality(client_ids) > 0)
+);
+
+-- Incoming and outgoing transfer lists
+CREATE INDEX IF NOT EXISTS idx_client_transfers_to_broker
+    ON client_transfers(to_broker_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_client_transfers_from_broker
+    ON client_transfers(from_broker_id, created_at DESC);
+
+-- Finding pending transfers that already offer a client
+CREATE INDEX IF NOT EXISTS idx_client_transfers_pending_clients
+    ON client_transfers USING gin(client_ids)
+    WHERE status = 'pending';
+
+DROP TRIGGER IF EXISTS update_client_transfers_updated_at ON client_transfers;
+CREATE TRIGGER update_client_transfers_updated_at
+    BEFORE UPDATE ON client_transfers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Create client_ownership_history table recording every change of a client's broker
+-- Broker names are copied so the history still reads after a broker's account is removed
+CREATE TABLE IF NOT EXISTS client_ownership_history (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    transfer_id UUID REFERENCES client_transfers(id) ON DELETE SET NULL,
+
+    from_broker_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    from_broker_name VARCHAR(200),
+    to_broker_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    to_broker_name VARCHAR(200),
+
+    transferred_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_client_ownership_history_client
+    ON client_ownership_history(client_id, transferred_at DESC);
+
+-- Moving a client or appointment to another broker refreshes its denormalized broker_name and
+-- broker_city with the same functions that fill them in on insert
+DROP TRIGGER IF EXISTS populate_client_broker_info_on_transfer ON clients;
+CREATE TRIGGER populate_client_broker_info_on_transfer
+    BEFORE UPDATE OF broker_id ON clients
+    FOR EACH ROW
+    WHEN (OLD.broker_id IS DISTINCT FROM NEW.broker_id)
+    EXECUTE FUNCTION populate_client_broker_info();
+
+DROP TRIGGER IF EXISTS populate_appointment_broker_info_on_transfer ON appointments;
+CREATE TRIGGER populate_appointment_broker_info_on_transfer
+    BEFORE UPDATE OF broker_id ON appointments
+    FOR EACH ROW
+    WHEN (OLD.broker_id IS DISTINCT FROM NEW.broker_id)
+    EXECUTE FUNCTION populate_appointment_broker_info();
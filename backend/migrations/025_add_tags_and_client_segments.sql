@@ -0,0 +1,45 @@
+-- Add per-broker tags to clients and properties
+-- A tag exists for as long as some client or property of the broker carries it
+ALTER TABLE clients
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE properties
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- GIN indexes support tag containment filters
+CREATE INDEX IF NOT EXISTS idx_clients_tags
+    ON clients USING gin(tags)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_properties_tags
+    ON properties USING gin(tags)
+    WHERE deleted_at IS NULL;
+
+-- Create client_segments table holding stored client list filters
+CREATE TABLE IF NOT EXISTS client_segments (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Ownership
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Segment Definition (same vocabulary as the client list filters)
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    filters JSONB NOT NULL DEFAULT '{}',
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Segment names are unique per broker regardless of case
+CREATE UNIQUE INDEX IF NOT EXISTS idx_client_segments_broker_name
+    ON client_segments(broker_id, LOWER(name));
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_client_segments_updated_at ON client_segments;
+CREATE TRIGGER update_client_segments_updated_at
+    BEFORE UPDATE ON client_segments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,32 @@
+-- Create contact_consents table: an append-only log of clients' consent to be contacted per channel
+-- A contact's current consent on a channel is its latest record; contacts with no record have not consented
+-- Records are keyed by the contact itself so an opt-out keeps applying after the client is deleted or re-added
+CREATE TABLE IF NOT EXISTS contact_consents (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Ownership
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Contact: the last 10 phone digits for whatsapp, sms and call, the lowercased address for email
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('whatsapp', 'sms', 'email', 'call')),
+    contact VARCHAR(255) NOT NULL,
+    client_id UUID REFERENCES clients(id) ON DELETE SET NULL,
+
+    -- Consent
+    status VARCHAR(20) NOT NULL CHECK (status IN ('granted', 'withdrawn')),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('verbal', 'written', 'lead_form', 'opt_out_link', 'client_request', 'transfer', 'other')),
+    note TEXT,
+
+    -- Audit
+    recorded_by UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL when the client recorded it
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Index for looking up a contact's latest consent
+CREATE INDEX IF NOT EXISTS idx_contact_consents_contact
+    ON contact_consents(broker_id, channel, contact, recorded_at DESC);
+
+-- Index for a client's consent history
+CREATE INDEX IF NOT EXISTS idx_contact_consents_client
+    ON contact_consents(client_id, recorded_at DESC);
@@ -0,0 +1,77 @@
+-- Create deals table recording the transactions brokers close for their clients
+CREATE TABLE IF NOT EXISTS deals (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Ownership
+    broker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Parties; records that are purged from the trash leave the deal in place
+    client_id UUID REFERENCES clients(id) ON DELETE SET NULL,
+    property_id UUID REFERENCES properties(id) ON DELETE SET NULL,
+
+    -- Co-broker or channel partner sharing the brokerage, and their share of it
+    partner_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    partner_share_percent DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (partner_share_percent BETWEEN 0 AND 100),
+
+    -- Terms
+    agreed_price DECIMAL(15,2) NOT NULL CHECK (agreed_price > 0),
+    token_amount DECIMAL(15,2) CHECK (token_amount >= 0),
+    token_date DATE,
+    brokerage_percent DECIMAL(5,2) CHECK (brokerage_percent BETWEEN 0 AND 100),
+    brokerage_amount DECIMAL(15,2) NOT NULL CHECK (brokerage_amount >= 0),
+
+    -- Progress
+    status VARCHAR(20) NOT NULL DEFAULT 'token_paid'
+        CHECK (status IN ('token_paid', 'agreement_signed', 'completed', 'cancelled')),
+    expected_close_date DATE,
+    closed_on DATE,
+    notes TEXT,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Indexes for the deal lists
+CREATE INDEX IF NOT EXISTS idx_deals_broker ON deals(broker_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_deals_partner ON deals(partner_id, created_at DESC) WHERE partner_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_deals_client ON deals(client_id);
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_deals_updated_at ON deals;
+CREATE TRIGGER update_deals_updated_at
+    BEFORE UPDATE ON deals
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Create deal_milestones table: the installments the brokerage is paid in
+CREATE TABLE IF NOT EXISTS deal_milestones (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    deal_id UUID NOT NULL REFERENCES deals(id) ON DELETE CASCADE,
+
+    -- Installment
+    name VARCHAR(100) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    due_date DATE,
+
+    -- Payment
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'received')),
+    received_on DATE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Indexes for a deal's milestones and the monthly commission report
+CREATE INDEX IF NOT EXISTS idx_deal_milestones_deal ON deal_milestones(deal_id, due_date);
+CREATE INDEX IF NOT EXISTS idx_deal_milestones_received ON deal_milestones(received_on) WHERE status = 'received';
+
+-- Trigger to automatically update updated_at timestamp
+DROP TRIGGER IF EXISTS update_deal_milestones_updated_at ON deal_milestones;
+CREATE TRIGGER update_deal_milestones_updated_at
+    BEFORE UPDATE ON deal_milestones
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,50 @@
+-- Create client_portal_tokens table for the magic links clients sign in to the portal with
+-- Only a hash of each token is stored; a token signs in once and expires soon after it is sent
+CREATE TABLE IF NOT EXISTS client_portal_tokens (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- The client row the link signs in to
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+
+    -- SHA-256 of the token in the link
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+
+    -- Lifetime
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Index for clearing a client's spent links
+CREATE INDEX IF NOT EXISTS idx_client_portal_tokens_client ON client_portal_tokens(client_id);
+
+-- Create client_shared_properties table for the listings a broker shares with a client on the portal
+CREATE TABLE IF NOT EXISTS client_shared_properties (
+    -- Primary Key
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Client and the listing shared with them
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    property_id UUID NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+
+    -- Broker's note to the client about the listing
+    note TEXT,
+
+    -- Audit
+    shared_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    shared_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    UNIQUE (client_id, property_id)
+);
+
+-- Index for a client's shared listings, newest first
+CREATE INDEX IF NOT EXISTS idx_client_shared_properties_client
+    ON client_shared_properties(client_id, shared_at DESC);
+
+-- Client's answer to an appointment from the portal; cleared when the appointment is rescheduled
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS client_response VARCHAR(20)
+    CHECK (client_response IN ('confirmed', 'cancelled'));
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS client_responded_at TIMESTAMP WITH TIME ZONE;